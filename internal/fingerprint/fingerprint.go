@@ -0,0 +1,188 @@
+/*
+Package fingerprint describes what was under test during a run: the OCP version and its history, the network type and
+platform, the node inventory, the installed operators, and the state of the machine config pools. Fingerprints are
+saved as JSON alongside the reports and their key fields are embedded as properties in the JUnit and reportxml reports
+so failures can be compared across labs.
+*/
+package fingerprint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// FileName is the name of the fingerprint saved in the reports directory.
+const FileName = "cluster_fingerprint.json"
+
+// PropertyPrefix starts the name of every report property created from a fingerprint.
+const PropertyPrefix = "cluster-"
+
+// Fingerprint is a snapshot of the cluster under test. Sections that could not be captured are left empty and the
+// reason is recorded in Errors, so a partial fingerprint is still saved.
+type Fingerprint struct {
+	Captured             time.Time           `json:"captured"`
+	ClusterName          string              `json:"clusterName,omitempty"`
+	OCPVersion           string              `json:"ocpVersion,omitempty"`
+	VersionHistory       []VersionHistory    `json:"versionHistory,omitempty"`
+	NetworkType          string              `json:"networkType,omitempty"`
+	Platform             string              `json:"platform,omitempty"`
+	ControlPlaneTopology string              `json:"controlPlaneTopology,omitempty"`
+	Proxied              bool                `json:"proxied"`
+	Nodes                []Node              `json:"nodes,omitempty"`
+	Operators            []Operator          `json:"operators,omitempty"`
+	MachineConfigPools   []MachineConfigPool `json:"machineConfigPools,omitempty"`
+	Errors               []string            `json:"errors,omitempty"`
+}
+
+// VersionHistory is a single entry of the ClusterVersion update history, most recent first.
+type VersionHistory struct {
+	Version   string     `json:"version"`
+	State     string     `json:"state"`
+	Image     string     `json:"image,omitempty"`
+	Started   time.Time  `json:"started"`
+	Completed *time.Time `json:"completed,omitempty"`
+}
+
+// Node is the inventory of a single node.
+type Node struct {
+	Name             string   `json:"name"`
+	Roles            []string `json:"roles,omitempty"`
+	Ready            bool     `json:"ready"`
+	KernelVersion    string   `json:"kernelVersion"`
+	OSImage          string   `json:"osImage"`
+	KubeletVersion   string   `json:"kubeletVersion"`
+	ContainerRuntime string   `json:"containerRuntime"`
+	Architecture     string   `json:"architecture"`
+}
+
+// Operator is a single installed ClusterServiceVersion. CSVs copied into other namespaces are not included.
+type Operator struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Version   string `json:"version"`
+	Phase     string `json:"phase"`
+}
+
+// MachineConfigPool is the state of a single MachineConfigPool.
+type MachineConfigPool struct {
+	Name                 string `json:"name"`
+	Configuration        string `json:"configuration"`
+	MachineCount         int32  `json:"machineCount"`
+	ReadyMachineCount    int32  `json:"readyMachineCount"`
+	UpdatedMachineCount  int32  `json:"updatedMachineCount"`
+	DegradedMachineCount int32  `json:"degradedMachineCount"`
+	Updated              bool   `json:"updated"`
+	Updating             bool   `json:"updating"`
+	Degraded             bool   `json:"degraded"`
+}
+
+// Property is a single key field of a fingerprint, named the way it appears in reports.
+type Property struct {
+	Name  string
+	Value string
+}
+
+// AddError records that part of the fingerprint could not be captured.
+func (fingerprint *Fingerprint) AddError(section string, err error) {
+	glog.V(100).Infof("Failed to capture %s for the cluster fingerprint: %v", section, err)
+
+	fingerprint.Errors = append(fingerprint.Errors, fmt.Sprintf("%s: %v", section, err))
+}
+
+// Sort orders the nodes, operators, and machine config pools by name so fingerprints can be compared.
+func (fingerprint *Fingerprint) Sort() {
+	slices.SortFunc(fingerprint.Nodes, func(a, b Node) int { return strings.Compare(a.Name, b.Name) })
+	slices.SortFunc(fingerprint.Operators, func(a, b Operator) int {
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+	slices.SortFunc(fingerprint.MachineConfigPools, func(a, b MachineConfigPool) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+// Properties returns the key fields of the fingerprint for embedding in reports. Fields with multiple values, such as
+// the kernel versions of the nodes, are deduplicated and joined with commas.
+func (fingerprint *Fingerprint) Properties() []Property {
+	var kernels, osImages, operators, degradedPools []string
+
+	for _, node := range fingerprint.Nodes {
+		kernels = appendUnique(kernels, node.KernelVersion)
+		osImages = appendUnique(osImages, node.OSImage)
+	}
+
+	for _, operator := range fingerprint.Operators {
+		operators = appendUnique(operators, fmt.Sprintf("%s:%s", operator.Name, operator.Version))
+	}
+
+	for _, pool := range fingerprint.MachineConfigPools {
+		if pool.Degraded {
+			degradedPools = append(degradedPools, pool.Name)
+		}
+	}
+
+	properties := []Property{
+		{Name: "cluster-name", Value: fingerprint.ClusterName},
+		{Name: "ocp-version", Value: fingerprint.OCPVersion},
+		{Name: "network-type", Value: fingerprint.NetworkType},
+		{Name: "platform", Value: fingerprint.Platform},
+		{Name: "topology", Value: fingerprint.ControlPlaneTopology},
+		{Name: "proxied", Value: fmt.Sprintf("%t", fingerprint.Proxied)},
+		{Name: "node-count", Value: fmt.Sprintf("%d", len(fingerprint.Nodes))},
+		{Name: "kernel-versions", Value: strings.Join(kernels, ",")},
+		{Name: "os-images", Value: strings.Join(osImages, ",")},
+		{Name: "operators", Value: strings.Join(operators, ",")},
+		{Name: "degraded-mcps", Value: strings.Join(degradedPools, ",")},
+		{Name: "captured", Value: fingerprint.Captured.UTC().Format(time.RFC3339)},
+	}
+
+	for index := range properties {
+		properties[index].Name = PropertyPrefix + properties[index].Name
+	}
+
+	return properties
+}
+
+// Save writes the fingerprint as indented JSON to path.
+func (fingerprint *Fingerprint) Save(path string) error {
+	glog.V(100).Infof("Saving cluster fingerprint to %s", path)
+
+	contents, err := json.MarshalIndent(fingerprint, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, contents, 0644)
+}
+
+// Load reads a fingerprint saved by Save.
+func Load(path string) (*Fingerprint, error) {
+	glog.V(100).Infof("Loading cluster fingerprint from %s", path)
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := &Fingerprint{}
+
+	err = json.Unmarshal(contents, fingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cluster fingerprint %s: %w", path, err)
+	}
+
+	return fingerprint, nil
+}
+
+func appendUnique(values []string, value string) []string {
+	if value == "" || slices.Contains(values, value) {
+		return values
+	}
+
+	return append(values, value)
+}
@@ -0,0 +1,129 @@
+package fingerprint
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProperties(t *testing.T) {
+	fingerprint := &Fingerprint{
+		Captured:   time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		OCPVersion: "4.18.3",
+		Nodes: []Node{
+			{Name: "master-0", KernelVersion: "5.14.0-427", OSImage: "RHCOS 418"},
+			{Name: "master-1", KernelVersion: "5.14.0-427", OSImage: "RHCOS 418"},
+			{Name: "worker-0", KernelVersion: "5.14.0-427.rt", OSImage: "RHCOS 418"},
+		},
+		Operators: []Operator{
+			{Name: "sriov-network-operator.v4.18.0", Version: "4.18.0"},
+		},
+		MachineConfigPools: []MachineConfigPool{
+			{Name: "master"},
+			{Name: "worker", Degraded: true},
+		},
+	}
+
+	properties := make(map[string]string)
+	for _, property := range fingerprint.Properties() {
+		properties[property.Name] = property.Value
+	}
+
+	assert.Equal(t, "4.18.3", properties["cluster-ocp-version"])
+	assert.Equal(t, "3", properties["cluster-node-count"])
+	assert.Equal(t, "5.14.0-427,5.14.0-427.rt", properties["cluster-kernel-versions"])
+	assert.Equal(t, "RHCOS 418", properties["cluster-os-images"])
+	assert.Equal(t, "sriov-network-operator.v4.18.0:4.18.0", properties["cluster-operators"])
+	assert.Equal(t, "worker", properties["cluster-degraded-mcps"])
+	assert.Equal(t, "2025-01-02T03:04:05Z", properties["cluster-captured"])
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	fingerprint := &Fingerprint{
+		Captured:   time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		OCPVersion: "4.18.3",
+		Nodes:      []Node{{Name: "worker-1"}, {Name: "worker-0"}},
+	}
+	fingerprint.Sort()
+
+	err := fingerprint.Save(path)
+	assert.Nil(t, err)
+
+	loaded, err := Load(path)
+	assert.Nil(t, err)
+	assert.Equal(t, fingerprint, loaded)
+	assert.Equal(t, "worker-0", loaded.Nodes[0].Name)
+
+	_, err = Load(filepath.Join(t.TempDir(), FileName))
+	assert.NotNil(t, err)
+}
+
+func TestAddReportProperties(t *testing.T) {
+	reportsDir := t.TempDir()
+	properties := []Property{{Name: "cluster-ocp-version", Value: "4.18.3"}, {Name: "RandomSeed", Value: "42"}}
+
+	junitPath := filepath.Join(reportsDir, "sriov_junit.xml")
+	err := reporters.GenerateJUnitReport(types.Report{
+		SuiteDescription: "sriov",
+		SpecReports:      types.SpecReports{{LeafNodeType: types.NodeTypeIt, LeafNodeText: "spec"}},
+	}, junitPath)
+	assert.Nil(t, err)
+
+	err = AddReportProperties(junitPath, properties)
+	assert.Nil(t, err)
+
+	var junitReport reporters.JUnitTestSuites
+
+	err = unmarshalReport(junitPath, &junitReport)
+	assert.Nil(t, err)
+	assert.Len(t, junitReport.TestSuites, 1)
+	assert.Equal(t, "4.18.3", junitReport.TestSuites[0].Properties.WithName("cluster-ocp-version"))
+	assert.Equal(t, "42", junitReport.TestSuites[0].Properties.WithName("RandomSeed"))
+	assert.Len(t, junitReport.TestSuites[0].TestCases, 1)
+
+	reportXMLPath := filepath.Join(reportsDir, "report_testrun.xml")
+	reportxml.Create(types.Report{
+		SuiteDescription: "sriov",
+		SpecReports:      types.SpecReports{{LeafNodeType: types.NodeTypeIt, LeafNodeText: "spec"}},
+	}, reportXMLPath, "")
+
+	err = AddReportProperties(reportXMLPath, properties)
+	assert.Nil(t, err)
+
+	// Creating the report again aggregates the new suite into the existing file and keeps its properties.
+	reportxml.Create(types.Report{
+		SuiteDescription: "metallb",
+		SpecReports:      types.SpecReports{{LeafNodeType: types.NodeTypeIt, LeafNodeText: "other"}},
+	}, reportXMLPath, "")
+
+	var testSuite reportxml.TestSuite
+
+	err = unmarshalReport(reportXMLPath, &testSuite)
+	assert.Nil(t, err)
+	assert.Len(t, testSuite.TestCases, 2)
+	assert.Equal(t, []reportxml.Property{
+		{Name: "cluster-ocp-version", Value: "4.18.3"}, {Name: "RandomSeed", Value: "42"}}, testSuite.Properties.Property)
+
+	err = os.WriteFile(junitPath, []byte("<html></html>"), 0644)
+	assert.Nil(t, err)
+
+	err = AddReportProperties(junitPath, properties)
+	assert.NotNil(t, err)
+}
+
+func unmarshalReport(path string, report any) error {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return xml.Unmarshal(contents, report)
+}
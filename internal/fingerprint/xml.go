@@ -0,0 +1,119 @@
+package fingerprint
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+
+	"github.com/golang/glog"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+)
+
+// AddReportProperties adds properties to every test suite in the JUnit or reportxml report at path, replacing any
+// existing properties with the same names. The format is detected from the root element: testsuites for JUnit reports
+// written by ginkgo and testsuite for reports written by reportxml.
+func AddReportProperties(path string, properties []Property) error {
+	glog.V(100).Infof("Adding %d properties to report %s", len(properties), path)
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	root, err := rootElement(contents)
+	if err != nil {
+		return fmt.Errorf("failed to parse report %s: %w", path, err)
+	}
+
+	var updated any
+
+	switch root {
+	case "testsuites":
+		var junitReport reporters.JUnitTestSuites
+
+		err = xml.Unmarshal(contents, &junitReport)
+		if err != nil {
+			return fmt.Errorf("failed to parse JUnit report %s: %w", path, err)
+		}
+
+		for index := range junitReport.TestSuites {
+			suiteProperties := &junitReport.TestSuites[index].Properties.Properties
+			for _, property := range properties {
+				*suiteProperties = setJUnitProperty(*suiteProperties, property)
+			}
+		}
+
+		updated = junitReport
+	case "testsuite":
+		var testSuite reportxml.TestSuite
+
+		err = xml.Unmarshal(contents, &testSuite)
+		if err != nil {
+			return fmt.Errorf("failed to parse reportxml report %s: %w", path, err)
+		}
+
+		for _, property := range properties {
+			testSuite.Properties.Property = setReportXMLProperty(testSuite.Properties.Property, property)
+		}
+
+		updated = testSuite
+	default:
+		return fmt.Errorf("report %s has unknown root element %s", path, root)
+	}
+
+	var buffer bytes.Buffer
+
+	buffer.WriteString(xml.Header)
+
+	// Both ginkgo and reportxml use the same indentation.
+	encoder := xml.NewEncoder(&buffer)
+	encoder.Indent("  ", "    ")
+
+	err = encoder.Encode(updated)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, buffer.Bytes(), 0644)
+}
+
+func rootElement(contents []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(contents))
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+func setJUnitProperty(properties []reporters.JUnitProperty, property Property) []reporters.JUnitProperty {
+	for index := range properties {
+		if properties[index].Name == property.Name {
+			properties[index].Value = property.Value
+
+			return properties
+		}
+	}
+
+	return append(properties, reporters.JUnitProperty{Name: property.Name, Value: property.Value})
+}
+
+func setReportXMLProperty(properties []reportxml.Property, property Property) []reportxml.Property {
+	for index := range properties {
+		if properties[index].Name == property.Name {
+			properties[index].Value = property.Value
+
+			return properties
+		}
+	}
+
+	return append(properties, reportxml.Property{Name: property.Name, Value: property.Value})
+}
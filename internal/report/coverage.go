@@ -0,0 +1,276 @@
+package main
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/results"
+)
+
+// CoverageEntry is a single row of the coverage matrix. There is one entry per It spec.
+type CoverageEntry struct {
+	// ID is the reportxml ID of the spec. It is empty if the spec has no ID.
+	ID string `json:"id"`
+	// Suite is the description of the suite containing the spec.
+	Suite string `json:"suite"`
+	// SuitePath is the path of the suite relative to the repo root.
+	SuitePath string `json:"suitePath"`
+	// File and Line are the location of the It node, relative to the repo root.
+	File string `json:"file"`
+	Line int    `json:"line"`
+	// ContainerPath is the text of every container of the spec, from outermost to innermost.
+	ContainerPath []string `json:"containerPath"`
+	// Spec is the text of the It node.
+	Spec string `json:"spec"`
+	// Labels contains the labels of the spec, including those inherited from its containers and suite.
+	Labels []string `json:"labels"`
+	// Duplicate is true if another spec has the same ID.
+	Duplicate bool `json:"duplicate"`
+}
+
+// CoverageMatrix maps every reportxml ID onto the specs that use it. It is ordered by ID, with specs missing an ID
+// last.
+type CoverageMatrix struct {
+	Entries []CoverageEntry `json:"entries"`
+	// DuplicateIDs contains every ID used by more than one spec, sorted.
+	DuplicateIDs []string `json:"duplicateIDs"`
+	// MissingID is the number of specs without an ID.
+	MissingID int `json:"missingID"`
+	// Labels maps each label onto the number of specs with that label. Suite labels are included even if the suite
+	// has no specs, in which case the count is 0.
+	Labels map[string]int `json:"labels"`
+	// Unlabeled is the number of specs without any labels.
+	Unlabeled int `json:"unlabeled"`
+}
+
+// NewCoverageMatrix creates a CoverageMatrix from every spec in tree.
+func NewCoverageMatrix(tree *SuiteTree) *CoverageMatrix {
+	glog.V(100).Infof("Creating CoverageMatrix from tree with path %s", tree.Path)
+
+	matrix := &CoverageMatrix{Labels: make(map[string]int)}
+	matrix.addEntries(tree, nil)
+
+	idCounts := make(map[string]int)
+
+	for _, entry := range matrix.Entries {
+		if len(entry.Labels) == 0 {
+			matrix.Unlabeled++
+		}
+
+		for _, label := range entry.Labels {
+			matrix.Labels[label]++
+		}
+
+		if entry.ID == "" {
+			matrix.MissingID++
+
+			continue
+		}
+
+		idCounts[entry.ID]++
+	}
+
+	for index := range matrix.Entries {
+		entry := &matrix.Entries[index]
+		if entry.ID != "" && idCounts[entry.ID] > 1 {
+			entry.Duplicate = true
+		}
+	}
+
+	for id, count := range idCounts {
+		if count > 1 {
+			matrix.DuplicateIDs = append(matrix.DuplicateIDs, id)
+		}
+	}
+
+	slices.Sort(matrix.DuplicateIDs)
+	slices.SortStableFunc(matrix.Entries, compareCoverageEntries)
+
+	return matrix
+}
+
+// IDs returns the number of distinct IDs in the matrix.
+func (matrix *CoverageMatrix) IDs() int {
+	ids := make(map[string]bool)
+
+	for _, entry := range matrix.Entries {
+		if entry.ID != "" {
+			ids[entry.ID] = true
+		}
+	}
+
+	return len(ids)
+}
+
+// UnusedLabels returns every label in the matrix without any specs, sorted.
+func (matrix *CoverageMatrix) UnusedLabels() []string {
+	var unusedLabels []string
+
+	for label, count := range matrix.Labels {
+		if count == 0 {
+			unusedLabels = append(unusedLabels, label)
+		}
+	}
+
+	slices.Sort(unusedLabels)
+
+	return unusedLabels
+}
+
+// WriteCSV writes the entries of the matrix as CSV, including a header. Labels and containers are separated by
+// semicolons and " / " respectively.
+func (matrix *CoverageMatrix) WriteCSV(writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+
+	err := csvWriter.Write([]string{"id", "suite", "suite_path", "file", "container_path", "spec", "labels", "duplicate"})
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range matrix.Entries {
+		err = csvWriter.Write([]string{
+			entry.ID,
+			entry.Suite,
+			entry.SuitePath,
+			entry.File + ":" + strconv.Itoa(entry.Line),
+			strings.Join(entry.ContainerPath, " / "),
+			entry.Spec,
+			strings.Join(entry.Labels, ";"),
+			strconv.FormatBool(entry.Duplicate),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+
+	return csvWriter.Error()
+}
+
+// WriteJSON writes the entire matrix as indented JSON.
+func (matrix *CoverageMatrix) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(matrix)
+}
+
+// SaveCoverageMatrix saves matrix to outputFileName, truncating it if it exists. The matrix is saved as JSON if the
+// file name ends with .json and as CSV otherwise.
+func SaveCoverageMatrix(matrix *CoverageMatrix, outputFileName string) error {
+	outputFile, err := os.Create(outputFileName)
+	if err != nil {
+		return err
+	}
+
+	defer outputFile.Close()
+
+	if strings.EqualFold(filepath.Ext(outputFileName), ".json") {
+		return matrix.WriteJSON(outputFile)
+	}
+
+	return matrix.WriteCSV(outputFile)
+}
+
+// CoverageMatrixFileName returns the name of the file to save the matrix for branch to, adding the branch name before
+// the extension of matrixFileName. Slashes in the branch name, such as in release/4.18, are replaced with dashes so the
+// file is saved next to matrixFileName.
+func CoverageMatrixFileName(matrixFileName, branch string) string {
+	extension := filepath.Ext(matrixFileName)
+	branch = strings.NewReplacer("/", "-", "\\", "-", " ", "_").Replace(branch)
+
+	return fmt.Sprintf("%s_%s%s", strings.TrimSuffix(matrixFileName, extension), branch, extension)
+}
+
+// addEntries recursively adds an entry for every leaf node under tree. The suite is the closest ancestor with a
+// description. Suite labels are added to the matrix labels even if the suite has no specs.
+func (matrix *CoverageMatrix) addEntries(tree, suite *SuiteTree) {
+	if tree.Description != "" {
+		suite = tree
+
+		for _, label := range tree.Labels {
+			if _, ok := matrix.Labels[label]; !ok {
+				matrix.Labels[label] = 0
+			}
+		}
+	}
+
+	if tree.SpecReport == nil {
+		for _, child := range tree.Children {
+			matrix.addEntries(child, suite)
+		}
+
+		return
+	}
+
+	labels := []string{}
+
+	if suite != nil {
+		labels = append(labels, suite.Labels...)
+	}
+
+	for _, label := range tree.SpecReport.Labels() {
+		if !slices.Contains(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+
+	entry := CoverageEntry{
+		ID:            results.IDFromLabels(labels),
+		File:          cleanPath(tree.SpecReport.LeafNodeLocation.FileName),
+		Line:          tree.SpecReport.LeafNodeLocation.LineNumber,
+		ContainerPath: tree.SpecReport.ContainerHierarchyTexts,
+		Spec:          tree.SpecReport.LeafNodeText,
+		Labels:        labels,
+	}
+
+	if suite != nil {
+		entry.Suite = suite.Description
+		entry.SuitePath = cleanPath(suite.Path)
+	}
+
+	matrix.Entries = append(matrix.Entries, entry)
+}
+
+// compareCoverageEntries orders entries by ID, with entries missing an ID last, then by file and line.
+func compareCoverageEntries(entryA, entryB CoverageEntry) int {
+	if (entryA.ID == "") != (entryB.ID == "") {
+		if entryA.ID == "" {
+			return 1
+		}
+
+		return -1
+	}
+
+	if n := compareIDs(entryA.ID, entryB.ID); n != 0 {
+		return n
+	}
+
+	if n := strings.Compare(entryA.File, entryB.File); n != 0 {
+		return n
+	}
+
+	return entryA.Line - entryB.Line
+}
+
+// compareIDs compares IDs numerically if both are numbers and lexicographically otherwise.
+func compareIDs(idA, idB string) int {
+	numberA, errA := strconv.Atoi(idA)
+	numberB, errB := strconv.Atoi(idB)
+
+	if errA == nil && errB == nil {
+		return cmp.Compare(numberA, numberB)
+	}
+
+	return strings.Compare(idA, idB)
+}
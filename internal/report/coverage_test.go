@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSpecReport(text string, line int, labels ...string) types.SpecReport {
+	return types.SpecReport{
+		LeafNodeType:            types.NodeTypeIt,
+		LeafNodeText:            text,
+		LeafNodeLabels:          labels,
+		ContainerHierarchyTexts: []string{"SR-IOV"},
+		LeafNodeLocation: types.CodeLocation{
+			FileName:   "/src/eco-gotests/tests/cnf/core/network/sriov/tests/basic.go",
+			LineNumber: line,
+		},
+	}
+}
+
+func TestNewCoverageMatrix(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		suiteLabels          []string
+		specs                types.SpecReports
+		expectedIDs          []string
+		expectedDuplicates   []bool
+		expectedDuplicateIDs []string
+		expectedMissingID    int
+		expectedLabels       map[string]int
+		expectedUnlabeled    int
+		expectedUnusedLabels []string
+	}{
+		{
+			name:        "sorted by id with duplicates",
+			suiteLabels: []string{"sriov"},
+			specs: types.SpecReports{
+				newTestSpecReport("first", 10, "test_id:10"),
+				newTestSpecReport("second", 20, "test_id:2"),
+				newTestSpecReport("third", 30, "test_id:2", "sriov"),
+			},
+			expectedIDs:          []string{"2", "2", "10"},
+			expectedDuplicates:   []bool{true, true, false},
+			expectedDuplicateIDs: []string{"2"},
+			expectedLabels:       map[string]int{"sriov": 3, "test_id:10": 1, "test_id:2": 2},
+		},
+		{
+			name:        "missing ids listed last",
+			suiteLabels: []string{"sriov"},
+			specs: types.SpecReports{
+				newTestSpecReport("without id", 10),
+				newTestSpecReport("with id", 20, "test_id:1"),
+				newTestSpecReport("also without id", 5),
+			},
+			expectedIDs:        []string{"1", "", ""},
+			expectedDuplicates: []bool{false, false, false},
+			expectedMissingID:  2,
+			expectedLabels:     map[string]int{"sriov": 3, "test_id:1": 1},
+		},
+		{
+			name: "specs without labels",
+			specs: types.SpecReports{
+				newTestSpecReport("unlabeled", 10),
+				newTestSpecReport("labeled", 20, "sriov"),
+			},
+			expectedIDs:        []string{"", ""},
+			expectedDuplicates: []bool{false, false},
+			expectedMissingID:  2,
+			expectedLabels:     map[string]int{"sriov": 1},
+			expectedUnlabeled:  1,
+		},
+		{
+			name:                 "suite labels without specs",
+			suiteLabels:          []string{"ptp", "sriov"},
+			expectedLabels:       map[string]int{"ptp": 0, "sriov": 0},
+			expectedUnusedLabels: []string{"ptp", "sriov"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tree := NewFromReports([]types.Report{{
+				SuitePath:        "/src/eco-gotests/tests/cnf/core/network/sriov",
+				SuiteDescription: "SR-IOV",
+				SuiteLabels:      testCase.suiteLabels,
+				SpecReports:      testCase.specs,
+			}})
+
+			matrix := NewCoverageMatrix(tree)
+
+			var (
+				ids        []string
+				duplicates []bool
+			)
+
+			for _, entry := range matrix.Entries {
+				ids = append(ids, entry.ID)
+				duplicates = append(duplicates, entry.Duplicate)
+
+				assert.Equal(t, "SR-IOV", entry.Suite)
+				assert.Equal(t, "eco-gotests/tests/cnf/core/network/sriov", entry.SuitePath)
+			}
+
+			assert.Equal(t, testCase.expectedIDs, ids)
+			assert.Equal(t, testCase.expectedDuplicates, duplicates)
+			assert.Equal(t, testCase.expectedDuplicateIDs, matrix.DuplicateIDs)
+			assert.Equal(t, testCase.expectedMissingID, matrix.MissingID)
+			assert.Equal(t, testCase.expectedLabels, matrix.Labels)
+			assert.Equal(t, testCase.expectedUnlabeled, matrix.Unlabeled)
+			assert.Equal(t, testCase.expectedUnusedLabels, matrix.UnusedLabels())
+		})
+	}
+}
+
+func TestCoverageMatrixWriteCSV(t *testing.T) {
+	tree := NewFromReports([]types.Report{{
+		SuitePath:        "/src/eco-gotests/tests/cnf/core/network/sriov",
+		SuiteDescription: "SR-IOV",
+		SpecReports: types.SpecReports{
+			newTestSpecReport("without labels", 20),
+			newTestSpecReport("with id", 10, "sriov", "test_id:1"),
+		},
+	}})
+
+	var output bytes.Buffer
+
+	err := NewCoverageMatrix(tree).WriteCSV(&output)
+	assert.Nil(t, err)
+
+	suitePath := "eco-gotests/tests/cnf/core/network/sriov"
+	assert.Equal(t, "id,suite,suite_path,file,container_path,spec,labels,duplicate\n"+
+		"1,SR-IOV,"+suitePath+","+suitePath+"/tests/basic.go:10,SR-IOV,with id,sriov;test_id:1,false\n"+
+		",SR-IOV,"+suitePath+","+suitePath+"/tests/basic.go:20,SR-IOV,without labels,,false\n",
+		output.String())
+}
+
+func TestCoverageMatrixFileName(t *testing.T) {
+	testCases := []struct {
+		name           string
+		matrixFileName string
+		branch         string
+		expected       string
+	}{
+		{name: "simple branch", matrixFileName: "coverage.csv", branch: "main", expected: "coverage_main.csv"},
+		{
+			name:           "branch with slash",
+			matrixFileName: "out/coverage.json",
+			branch:         "release/4.18",
+			expected:       "out/coverage_release-4.18.json",
+		},
+		{name: "no extension", matrixFileName: "coverage", branch: "release-4.18", expected: "coverage_release-4.18"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, CoverageMatrixFileName(testCase.matrixFileName, testCase.branch))
+		})
+	}
+}
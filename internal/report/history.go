@@ -0,0 +1,275 @@
+package main
+
+import (
+	"cmp"
+	"io/fs"
+	"math"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/results"
+)
+
+// SpecHistory contains the outcomes of a single spec over multiple past runs. Each run counts once, even if the spec
+// was attempted multiple times during the run.
+type SpecHistory struct {
+	// Name is the full text of the spec the last time it was seen.
+	Name string
+	// ID is the reportxml ID of the spec, if it has one.
+	ID string
+	// Runs is the number of runs the spec was included in, regardless of the outcome.
+	Runs int
+	// Passed is the number of runs where the spec passed on the first attempt.
+	Passed int
+	// Failed is the number of runs where the last attempt of the spec failed.
+	Failed int
+	// Skipped is the number of runs where the spec was skipped.
+	Skipped int
+	// Flaked is the number of runs where the spec failed, then passed on a retry.
+	Flaked int
+	// Durations contains the duration in seconds of every attempt that was not skipped.
+	Durations []float64
+}
+
+// PassRate returns the fraction of runs where the spec ran and eventually passed, including flakes. It is 0 if the spec
+// never ran.
+func (history *SpecHistory) PassRate() float64 {
+	executed := history.Runs - history.Skipped
+	if executed == 0 {
+		return 0
+	}
+
+	return float64(history.Passed+history.Flaked) / float64(executed)
+}
+
+// FlakeRate returns the fraction of runs where the spec ran and passed only after failing. It is 0 if the spec never
+// ran.
+func (history *SpecHistory) FlakeRate() float64 {
+	executed := history.Runs - history.Skipped
+	if executed == 0 {
+		return 0
+	}
+
+	return float64(history.Flaked) / float64(executed)
+}
+
+// DurationPercentile returns the pth percentile, from 0 to 100, of the spec durations in seconds using the nearest-rank
+// method. It is 0 if there are no durations.
+func (history *SpecHistory) DurationPercentile(p float64) float64 {
+	if len(history.Durations) == 0 {
+		return 0
+	}
+
+	sorted := slices.Clone(history.Durations)
+	slices.Sort(sorted)
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	rank = max(1, min(rank, len(sorted)))
+
+	return sorted[rank-1]
+}
+
+// record adds the attempts of the spec from a single run to the history.
+func (history *SpecHistory) record(attempts []results.SpecResult) {
+	history.Runs++
+
+	sawFailure := false
+	lastStatus := results.StatusSkipped
+
+	for _, attempt := range attempts {
+		history.Name = attempt.Name
+
+		if attempt.ID != "" {
+			history.ID = attempt.ID
+		}
+
+		if attempt.Status == results.StatusSkipped {
+			continue
+		}
+
+		history.Durations = append(history.Durations, attempt.DurationSeconds)
+		sawFailure = sawFailure || attempt.Status == results.StatusFailed || attempt.Flaked
+		lastStatus = attempt.Status
+	}
+
+	switch {
+	case lastStatus == results.StatusSkipped:
+		history.Skipped++
+	case lastStatus == results.StatusFailed:
+		history.Failed++
+	case sawFailure:
+		history.Flaked++
+	default:
+		history.Passed++
+	}
+}
+
+// History contains the SpecHistory of every spec found in a directory of past runs. Specs are keyed by their full text
+// and by their reportxml ID, so renamed specs keep their history as long as their ID does not change.
+type History struct {
+	// Runs is the number of runs in the history.
+	Runs   int
+	byName map[string]*SpecHistory
+	byID   map[string]*SpecHistory
+}
+
+// NewHistoryFromDir creates a History from every run in dir. Each directory under dir, including dir itself, that
+// directly contains JUnit reports is treated as a single run. Within a run, a spec that appears in more than one report
+// is considered retried, and the reports are ordered by their attempt number and then by their timestamp.
+func NewHistoryFromDir(dir string) (*History, error) {
+	glog.V(100).Infof("Creating History from runs in %s", dir)
+
+	history := &History{
+		byName: make(map[string]*SpecHistory),
+		byID:   make(map[string]*SpecHistory),
+	}
+
+	err := filepath.WalkDir(dir, func(path string, dirEntry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !dirEntry.IsDir() || !hasJUnitReports(path) {
+			return nil
+		}
+
+		summary, err := results.Load(path)
+		if err != nil {
+			glog.V(100).Infof("Skipping run in %s: %v", path, err)
+
+			return nil
+		}
+
+		history.addRun(summary)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Created History with %d runs and %d specs", history.Runs, len(history.byName))
+
+	return history, nil
+}
+
+// Lookup returns the history for spec, first by its reportxml ID and then by its full text. It returns nil if the spec
+// has no history or the receiver is nil.
+func (history *History) Lookup(spec *types.SpecReport) *SpecHistory {
+	if history == nil || spec == nil {
+		return nil
+	}
+
+	if id := results.IDFromLabels(spec.Labels()); id != "" {
+		if specHistory, ok := history.byID[id]; ok {
+			return specHistory
+		}
+	}
+
+	return history.byName[spec.FullText()]
+}
+
+// Flaky returns the history of every spec that flaked at least once, sorted by descending flake rate and then by name.
+func (history *History) Flaky() []*SpecHistory {
+	var flaky []*SpecHistory
+
+	// A SpecHistory may be stored under multiple names if the spec was renamed, so deduplicate them.
+	for _, specHistory := range history.byName {
+		if specHistory.Flaked > 0 && !slices.Contains(flaky, specHistory) {
+			flaky = append(flaky, specHistory)
+		}
+	}
+
+	slices.SortFunc(flaky, func(a, b *SpecHistory) int {
+		if n := cmp.Compare(b.FlakeRate(), a.FlakeRate()); n != 0 {
+			return n
+		}
+
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return flaky
+}
+
+// addRun records the outcome of every It spec in every attempt of the suites in summary. Specs with an ID share a
+// SpecHistory with any previous spec with the same ID, regardless of their full text.
+func (history *History) addRun(summary *results.RunSummary) {
+	history.Runs++
+
+	suites := summary.Attempts()
+	slices.SortStableFunc(suites, func(a, b results.SuiteResult) int {
+		if n := cmp.Compare(a.Attempt, b.Attempt); n != 0 {
+			return n
+		}
+
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	var (
+		order    []string
+		attempts = make(map[string][]results.SpecResult)
+	)
+
+	for _, suite := range suites {
+		for _, spec := range suite.Specs {
+			if spec.NodeType != types.NodeTypeIt.String() {
+				continue
+			}
+
+			if _, ok := attempts[spec.Name]; !ok {
+				order = append(order, spec.Name)
+			}
+
+			attempts[spec.Name] = append(attempts[spec.Name], spec)
+		}
+	}
+
+	for _, name := range order {
+		history.specHistory(attempts[name]).record(attempts[name])
+	}
+}
+
+// specHistory returns the SpecHistory for a spec with the provided attempts, creating it if necessary.
+func (history *History) specHistory(attempts []results.SpecResult) *SpecHistory {
+	name := attempts[0].Name
+	id := attempts[0].ID
+
+	specHistory, ok := history.byID[id]
+	if id == "" || !ok {
+		specHistory, ok = history.byName[name]
+		if !ok {
+			specHistory = &SpecHistory{}
+		}
+	}
+
+	history.byName[name] = specHistory
+
+	if id != "" {
+		history.byID[id] = specHistory
+	}
+
+	return specHistory
+}
+
+// AttachHistory sets the History of every leaf node in the tree that has one.
+func (tree *SuiteTree) AttachHistory(history *History) {
+	if tree.SpecReport != nil {
+		tree.History = history.Lookup(tree.SpecReport)
+
+		return
+	}
+
+	for _, child := range tree.Children {
+		child.AttachHistory(history)
+	}
+}
+
+// hasJUnitReports returns whether dir directly contains at least one JUnit report.
+func hasJUnitReports(dir string) bool {
+	matches, err := filepath.Glob(filepath.Join(dir, "*"+results.JUnitSuffix))
+
+	return err == nil && len(matches) > 0
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/results"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testFailedRunJUnitReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" disabled="0" errors="0" failures="1" time="11">
+  <testsuite name="sriov" package="/eco-gotests/tests/cnf/core/network/sriov" tests="3" failures="1"
+      timestamp="2024-05-01T12:00:00" time="11">
+    <testcase name="[BeforeSuite]" classname="sriov" status="passed" time="1"></testcase>
+    <testcase name="[It] SriovBasic creates a VF [sriov, test_id:1234]" classname="sriov" status="passed" time="2">
+    </testcase>
+    <testcase name="[It] SriovBasic deletes a VF [sriov]" classname="sriov" status="failed" time="8">
+      <failure message="Expected true" type="failed">full failure</failure>
+    </testcase>
+  </testsuite>
+</testsuites>`
+
+	testRetryRunJUnitReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" disabled="0" errors="0" failures="0" time="7">
+  <testsuite name="sriov" package="/eco-gotests/tests/cnf/core/network/sriov" tests="3" failures="0"
+      timestamp="2024-05-01T13:00:00" time="7">
+    <testcase name="[BeforeSuite]" classname="sriov" status="passed" time="1"></testcase>
+    <testcase name="[It] SriovBasic creates a VF [sriov, test_id:1234]" classname="sriov" status="skipped" time="0">
+      <skipped message="skipped"></skipped>
+    </testcase>
+    <testcase name="[It] SriovBasic deletes a VF [sriov]" classname="sriov" status="passed" time="6">
+    </testcase>
+  </testsuite>
+</testsuites>`
+)
+
+func TestSpecHistoryRecord(t *testing.T) {
+	passed := results.SpecResult{Name: "spec", Status: results.StatusPassed, DurationSeconds: 2}
+	failed := results.SpecResult{Name: "spec", Status: results.StatusFailed, DurationSeconds: 3}
+	skipped := results.SpecResult{Name: "spec", Status: results.StatusSkipped}
+
+	testCases := []struct {
+		name     string
+		runs     [][]results.SpecResult
+		expected SpecHistory
+	}{
+		{
+			name: "zero runs",
+		},
+		{
+			name:     "single passed run",
+			runs:     [][]results.SpecResult{{passed}},
+			expected: SpecHistory{Name: "spec", Runs: 1, Passed: 1, Durations: []float64{2}},
+		},
+		{
+			name:     "single failed run",
+			runs:     [][]results.SpecResult{{failed}},
+			expected: SpecHistory{Name: "spec", Runs: 1, Failed: 1, Durations: []float64{3}},
+		},
+		{
+			name:     "single skipped run",
+			runs:     [][]results.SpecResult{{skipped}},
+			expected: SpecHistory{Name: "spec", Runs: 1, Skipped: 1},
+		},
+		{
+			name:     "flaked with ginkgo flake attempts",
+			runs:     [][]results.SpecResult{{{Name: "spec", Status: results.StatusPassed, DurationSeconds: 4, Flaked: true}}},
+			expected: SpecHistory{Name: "spec", Runs: 1, Flaked: 1, Durations: []float64{4}},
+		},
+		{
+			name:     "skipped retry keeps the earlier result",
+			runs:     [][]results.SpecResult{{passed, skipped}, {failed, skipped}},
+			expected: SpecHistory{Name: "spec", Runs: 2, Passed: 1, Failed: 1, Durations: []float64{2, 3}},
+		},
+		{
+			name:     "every run retried",
+			runs:     [][]results.SpecResult{{failed, passed}, {failed, failed}, {failed, failed, passed}},
+			expected: SpecHistory{Name: "spec", Runs: 3, Failed: 1, Flaked: 2, Durations: []float64{3, 2, 3, 3, 3, 3, 2}},
+		},
+		{
+			name: "renamed spec keeps its id",
+			runs: [][]results.SpecResult{
+				{{Name: "old", ID: "1234", Status: results.StatusPassed, DurationSeconds: 1}},
+				{{Name: "new", Status: results.StatusPassed, DurationSeconds: 1}},
+			},
+			expected: SpecHistory{Name: "new", ID: "1234", Runs: 2, Passed: 2, Durations: []float64{1, 1}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var history SpecHistory
+
+			for _, attempts := range testCase.runs {
+				history.record(attempts)
+			}
+
+			assert.Equal(t, testCase.expected, history)
+		})
+	}
+}
+
+func TestSpecHistoryRates(t *testing.T) {
+	testCases := []struct {
+		name              string
+		history           SpecHistory
+		expectedPassRate  float64
+		expectedFlakeRate float64
+	}{
+		{
+			name: "zero runs",
+		},
+		{
+			name:    "every run skipped",
+			history: SpecHistory{Runs: 2, Skipped: 2},
+		},
+		{
+			name:             "single passed run",
+			history:          SpecHistory{Runs: 1, Passed: 1},
+			expectedPassRate: 1,
+		},
+		{
+			name:              "every run retried",
+			history:           SpecHistory{Runs: 2, Flaked: 2},
+			expectedPassRate:  1,
+			expectedFlakeRate: 1,
+		},
+		{
+			name:              "mixed",
+			history:           SpecHistory{Runs: 5, Passed: 1, Failed: 1, Skipped: 1, Flaked: 2},
+			expectedPassRate:  0.75,
+			expectedFlakeRate: 0.5,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedPassRate, testCase.history.PassRate())
+			assert.Equal(t, testCase.expectedFlakeRate, testCase.history.FlakeRate())
+		})
+	}
+}
+
+func TestDurationPercentile(t *testing.T) {
+	testCases := []struct {
+		name       string
+		durations  []float64
+		percentile float64
+		expected   float64
+	}{
+		{name: "no durations", percentile: 50, expected: 0},
+		{name: "single duration minimum", durations: []float64{3}, percentile: 0, expected: 3},
+		{name: "single duration maximum", durations: []float64{3}, percentile: 100, expected: 3},
+		{name: "median", durations: []float64{4, 1, 3, 2}, percentile: 50, expected: 2},
+		{name: "p90", durations: []float64{4, 1, 3, 2}, percentile: 90, expected: 4},
+		{name: "p0", durations: []float64{4, 1, 3, 2}, percentile: 0, expected: 1},
+		{name: "above 100", durations: []float64{4, 1, 3, 2}, percentile: 150, expected: 4},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			history := SpecHistory{Durations: testCase.durations}
+
+			assert.Equal(t, testCase.expected, history.DurationPercentile(testCase.percentile))
+		})
+	}
+}
+
+func TestNewHistoryFromDir(t *testing.T) {
+	testCases := []struct {
+		name string
+		// runs maps the name of each run directory onto the JUnit reports it contains, by file name.
+		runs           map[string]map[string]string
+		expectedRuns   int
+		expectedFlaky  []string
+		expectedCreate SpecHistory
+		expectedDelete SpecHistory
+	}{
+		{
+			name: "zero runs",
+			runs: map[string]map[string]string{"empty": {}},
+		},
+		{
+			name:         "single run",
+			runs:         map[string]map[string]string{"run1": {"sriov_suite_test_junit.xml": testFailedRunJUnitReport}},
+			expectedRuns: 1,
+			expectedCreate: SpecHistory{
+				Name: "SriovBasic creates a VF", ID: "1234", Runs: 1, Passed: 1, Durations: []float64{2}},
+			expectedDelete: SpecHistory{Name: "SriovBasic deletes a VF", Runs: 1, Failed: 1, Durations: []float64{8}},
+		},
+		{
+			name: "every run retried",
+			runs: map[string]map[string]string{
+				"run1": {
+					"sriov_suite_test_junit.xml":          testFailedRunJUnitReport,
+					"sriov_suite_test_attempt1_junit.xml": testRetryRunJUnitReport,
+				},
+				"run2": {
+					"sriov_suite_test_junit.xml":          testFailedRunJUnitReport,
+					"sriov_suite_test_attempt1_junit.xml": testRetryRunJUnitReport,
+				},
+			},
+			expectedRuns:  2,
+			expectedFlaky: []string{"SriovBasic deletes a VF"},
+			expectedCreate: SpecHistory{
+				Name: "SriovBasic creates a VF", ID: "1234", Runs: 2, Passed: 2, Durations: []float64{2, 2}},
+			expectedDelete: SpecHistory{
+				Name: "SriovBasic deletes a VF", Runs: 2, Flaked: 2, Durations: []float64{8, 6, 8, 6}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			historyDir := t.TempDir()
+
+			for runName, reports := range testCase.runs {
+				runDir := filepath.Join(historyDir, runName)
+
+				err := os.MkdirAll(runDir, 0755)
+				assert.Nil(t, err)
+
+				for fileName, report := range reports {
+					err = os.WriteFile(filepath.Join(runDir, fileName), []byte(report), 0644)
+					assert.Nil(t, err)
+				}
+			}
+
+			history, err := NewHistoryFromDir(historyDir)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedRuns, history.Runs)
+
+			var flaky []string
+			for _, specHistory := range history.Flaky() {
+				flaky = append(flaky, specHistory.Name)
+			}
+
+			assert.Equal(t, testCase.expectedFlaky, flaky)
+
+			if testCase.expectedRuns == 0 {
+				assert.Empty(t, history.byName)
+
+				return
+			}
+
+			assert.Equal(t, testCase.expectedCreate, *history.byID["1234"])
+			assert.Equal(t, testCase.expectedDelete, *history.byName["SriovBasic deletes a VF"])
+		})
+	}
+}
+
+func TestNewHistoryFromMissingDir(t *testing.T) {
+	_, err := NewHistoryFromDir(filepath.Join(t.TempDir(), "missing"))
+	assert.NotNil(t, err)
+}
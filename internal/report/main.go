@@ -24,9 +24,18 @@ The flags are:
 	-c, -clean
 		Delete the test suite cache and exit without running
 
+	-m, -matrix string
+		File to save the reportxml ID coverage matrix to. Saved as JSON if it ends in .json or CSV otherwise. When there
+		are multiple branches, the branch name, with slashes replaced by dashes, is added before the extension. Will not
+		be generated if left blank
+
 	-o, -output string
 		Directory to output static site to. Will not be generated if left blank
 
+	-r, -results string
+		Directory of past runs to add pass rate, flake rate, and durations to each spec from. Every directory containing
+		JUnit reports is one run. Leave blank to skip
+
 	-v int
 		Log level verbosity for glog. Use 100 for logging all messages or leave blank for none
 */
@@ -47,11 +56,13 @@ import (
 )
 
 var (
-	help      bool
-	actionURL string
-	branch    string
-	clean     bool
-	output    string
+	help       bool
+	actionURL  string
+	branch     string
+	clean      bool
+	matrix     string
+	output     string
+	resultsDir string
 )
 
 //nolint:gochecknoinits // This is a main package so init is fine.
@@ -61,13 +72,18 @@ func init() {
 		actionURLUsage = "URL to the action generating this report. Only necessary with -o. Uses \"/\" if left blank"
 		branchUsage    = "Space-separated list of globs to match branches. Leave blank to use the local directory"
 		cleanUsage     = "Delete the test suite cache and exit without running"
+		matrixUsage    = "File to save the ID coverage matrix to. Saved as JSON if it ends in .json or CSV otherwise"
 		outputUsage    = "Directory to output static site to. Will not be generated if left blank"
+		resultsUsage   = "Directory of past runs to add pass rate, flake rate, and durations to each spec from. " +
+			"Every directory containing JUnit reports is one run. Leave blank to skip"
 
 		defaultHelp      = false
 		defaultActionURL = "/"
 		defaultBranch    = ""
 		defaultClean     = false
+		defaultMatrix    = ""
 		defaultOutput    = ""
+		defaultResults   = ""
 
 		shorthand = " (shorthand)"
 	)
@@ -84,8 +100,14 @@ func init() {
 	flag.BoolVar(&clean, "clean", defaultClean, cleanUsage)
 	flag.BoolVar(&clean, "c", defaultClean, cleanUsage+shorthand)
 
+	flag.StringVar(&matrix, "matrix", defaultMatrix, matrixUsage)
+	flag.StringVar(&matrix, "m", defaultMatrix, matrixUsage+shorthand)
+
 	flag.StringVar(&output, "output", defaultOutput, outputUsage)
 	flag.StringVar(&output, "o", defaultOutput, outputUsage+shorthand)
+
+	flag.StringVar(&resultsDir, "results", defaultResults, resultsUsage)
+	flag.StringVar(&resultsDir, "r", defaultResults, resultsUsage+shorthand)
 }
 
 func main() {
@@ -120,6 +142,30 @@ func main() {
 
 	printTreeMap(treeMap)
 
+	if resultsDir != "" {
+		history, err := NewHistoryFromDir(resultsDir)
+		if err != nil {
+			glog.Errorf("Failed to load history from %s: %v", resultsDir, err)
+
+			os.Exit(1)
+		}
+
+		for _, tree := range treeMap {
+			tree.AttachHistory(history)
+		}
+
+		printFlaky(history)
+	}
+
+	if matrix != "" {
+		err := saveCoverageMatrices(treeMap, matrix)
+		if err != nil {
+			glog.Errorf("Failed to save coverage matrix to %s: %v", matrix, err)
+
+			os.Exit(1)
+		}
+	}
+
 	if output != "" {
 		err := templateTreeMap(treeMap, output)
 		if err != nil {
@@ -173,6 +219,51 @@ func printTreeMap(treeMap map[CacheKey]*SuiteTree) {
 	}
 }
 
+func printFlaky(history *History) {
+	flaky := history.Flaky()
+
+	fmt.Println("---")
+	fmt.Printf("Flaky specs over %d runs: %d\n", history.Runs, len(flaky))
+
+	for _, specHistory := range flaky {
+		fmt.Printf("%5.1f%% flaked, %5.1f%% passed: %s\n",
+			specHistory.FlakeRate()*100, specHistory.PassRate()*100, specHistory.Name)
+	}
+}
+
+func saveCoverageMatrices(treeMap map[CacheKey]*SuiteTree, matrixFileName string) error {
+	for key, tree := range treeMap {
+		coverageMatrix := NewCoverageMatrix(tree)
+
+		outputFileName := matrixFileName
+		if len(treeMap) > 1 {
+			outputFileName = CoverageMatrixFileName(matrixFileName, key.Branch)
+		}
+
+		err := SaveCoverageMatrix(coverageMatrix, outputFileName)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("---")
+		fmt.Printf("Coverage matrix for branch %s saved to %s\n", key.Branch, outputFileName)
+		fmt.Printf("%d specs, %d IDs, %d duplicated IDs, %d specs missing an ID\n",
+			len(coverageMatrix.Entries), coverageMatrix.IDs(), len(coverageMatrix.DuplicateIDs), coverageMatrix.MissingID)
+
+		if len(coverageMatrix.DuplicateIDs) > 0 {
+			fmt.Printf("Duplicated IDs: %s\n", strings.Join(coverageMatrix.DuplicateIDs, ", "))
+		}
+
+		fmt.Printf("%d specs without labels\n", coverageMatrix.Unlabeled)
+
+		if unusedLabels := coverageMatrix.UnusedLabels(); len(unusedLabels) > 0 {
+			fmt.Printf("Labels without specs: %s\n", strings.Join(unusedLabels, ", "))
+		}
+	}
+
+	return nil
+}
+
 func templateTreeMap(treeMap map[CacheKey]*SuiteTree, output string) error {
 	err := os.MkdirAll(output, 0755)
 	if err != nil {
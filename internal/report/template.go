@@ -2,6 +2,7 @@ package main
 
 import (
 	_ "embed"
+	"fmt"
 	"html/template"
 	"os"
 	"path/filepath"
@@ -21,6 +22,8 @@ var (
 var (
 	funcMap = template.FuncMap{
 		"cleanPath": cleanPath,
+		"percent":   percent,
+		"seconds":   seconds,
 	}
 	treeTemplate   = template.Must(template.New("tree_template.html").Funcs(funcMap).Parse(treeTemplateFile))
 	reportTemplate = template.Must(template.New("report_template.html").Parse(reportTemplateFile))
@@ -98,3 +101,13 @@ func cleanPath(path string) string {
 
 	return path
 }
+
+// percent formats a fraction between 0 and 1 as a percentage with one decimal place.
+func percent(fraction float64) string {
+	return fmt.Sprintf("%.1f%%", fraction*100)
+}
+
+// seconds formats a number of seconds as a duration rounded to milliseconds.
+func seconds(seconds float64) string {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Millisecond).String()
+}
@@ -22,6 +22,8 @@ type SuiteTree struct {
 	Name string
 	// Description is the description of the test suite. It is taken from the report.
 	Description string
+	// Labels are the labels passed to RunSpecs for the test suite. They are taken from the report.
+	Labels []string
 	// Specs is the sum of specs from all child suites, recursively.
 	Specs int
 	// Children is a list of child suites. It can be sorted by [SuiteTree.Sort].
@@ -29,6 +31,9 @@ type SuiteTree struct {
 	// SpecReport should only be set when Children is empty, meaning this is a leaf node representing a single spec.
 	// It should only have It specs.
 	SpecReport *types.SpecReport
+	// History contains the results of past runs for this spec. It is only set on leaf nodes by
+	// [SuiteTree.AttachHistory] and is not cached.
+	History *SpecHistory `json:"-"`
 }
 
 // NewFromReports creates a new SuiteTree from a list of reports. The root of the tree will be `/`.
@@ -42,6 +47,7 @@ func NewFromReports(reports []types.Report) *SuiteTree {
 
 	for _, report := range reports {
 		leaf := root.Insert(report.SuitePath, report.SuiteDescription, report.PreRunStats.TotalSpecs)
+		leaf.Labels = report.SuiteLabels
 		leaf.InsertSpecs(report.SpecReports)
 	}
 
@@ -0,0 +1,43 @@
+package results
+
+import (
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// SuiteFromGinkgoReport converts an in-memory Ginkgo report into a SuiteResult with the provided key. It produces the
+// same result as parsing the JUnit report Ginkgo writes for the suite, so it can be used from ReportAfterSuite before
+// that report exists.
+func SuiteFromGinkgoReport(key string, report types.Report) SuiteResult {
+	suite := SuiteResult{
+		Key:       key,
+		Name:      report.SuiteDescription,
+		Path:      report.SuitePath,
+		Timestamp: report.StartTime,
+	}
+
+	for _, specReport := range report.SpecReports {
+		suite.Specs = append(suite.Specs, newSpecFromGinkgo(specReport))
+	}
+
+	return suite
+}
+
+func newSpecFromGinkgo(specReport types.SpecReport) SpecResult {
+	labels := specReport.Labels()
+
+	spec := SpecResult{
+		Name:            specReport.FullText(),
+		NodeType:        specReport.LeafNodeType.String(),
+		Labels:          labels,
+		ID:              IDFromLabels(labels),
+		Status:          StatusFromGinkgoState(specReport.State.String()),
+		DurationSeconds: specReport.RunTime.Seconds(),
+		Flaked:          specReport.State == types.SpecStatePassed && specReport.NumAttempts > 1,
+	}
+
+	if specReport.State.Is(types.SpecStateFailureStates) {
+		spec.Message = specReport.Failure.Message
+	}
+
+	return spec
+}
@@ -0,0 +1,220 @@
+package results
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+)
+
+const (
+	// JUnitSuffix is the suffix of JUnit reports written by Ginkgo using GeneralConfig.GetJunitReportPath.
+	JUnitSuffix = "_junit.xml"
+	// ReportXMLSuffix is the suffix of reportxml reports written using GeneralConfig.GetReportPath.
+	ReportXMLSuffix = "_testrun.xml"
+	// FailedDumpPrefix is the prefix of the directories containing the dumps of failed specs for a suite.
+	FailedDumpPrefix = "failed_"
+
+	// reportXMLCaseTag is the property reportxml uses for the test case ID, including the project prefix.
+	reportXMLCaseTag = "testcase-id"
+	// reportXMLIDLabelPrefix is the prefix of the label added by reportxml.ID.
+	reportXMLIDLabelPrefix = "test_id:"
+)
+
+var (
+	// Matches the leaf node type Ginkgo prepends to JUnit test case names, such as [It] or [BeforeSuite].
+	leafNodeTypePrefix = regexp.MustCompile(`^\[([A-Za-z]+)\]( |$)`)
+
+	// Matches the labels Ginkgo appends to JUnit test case names.
+	labelsSuffix = regexp.MustCompile(` \[([^\[\]]*)\]$`)
+
+	// Matches the timeline entry Ginkgo writes to the JUnit system-err when an attempt fails and the spec is retried.
+	failedAttempt = regexp.MustCompile(`Attempt #\d+ Failed\.\s+Retrying`)
+
+	// Matches the attempt number GeneralConfig.GetJunitReportPath adds to the report name when the test runner reruns
+	// failed specs.
+	attemptSuffix = regexp.MustCompile(`_attempt(\d+)$`)
+)
+
+// ParseJUnitFile reads a Ginkgo JUnit report and returns a SuiteResult for every suite in it. The key of each suite
+// is derived from the file name, so that it matches the failed dump directory for the suite, and the attempt number
+// of a retry is taken from the file name as well.
+func ParseJUnitFile(path string) ([]SuiteResult, error) {
+	glog.V(100).Infof("Parsing JUnit report at %s", path)
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var junitSuites reporters.JUnitTestSuites
+
+	err = xml.Unmarshal(contents, &junitSuites)
+	if err != nil {
+		return nil, err
+	}
+
+	key, attempt := SplitAttempt(strings.TrimSuffix(filepath.Base(path), JUnitSuffix))
+
+	var suites []SuiteResult
+
+	for _, junitSuite := range junitSuites.TestSuites {
+		suite := newSuiteFromJUnit(key, junitSuite)
+		suite.Attempt = attempt
+		suites = append(suites, suite)
+	}
+
+	return suites, nil
+}
+
+// ParseReportXMLFile reads a reportxml report and returns a map of spec names to reportxml IDs. reportxml includes
+// the project prefix in the ID, so it is kept as is.
+func ParseReportXMLFile(path string) (map[string]string, error) {
+	glog.V(100).Infof("Parsing reportxml report at %s", path)
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var testSuite reportxml.TestSuite
+
+	err = xml.Unmarshal(contents, &testSuite)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]string)
+
+	for _, testCase := range testSuite.TestCases {
+		for _, property := range testCase.Properties.Property {
+			if property.Name == reportXMLCaseTag {
+				ids[testCase.Name] = property.Value
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+func newSuiteFromJUnit(key string, junitSuite reporters.JUnitTestSuite) SuiteResult {
+	suite := SuiteResult{
+		Key:  key,
+		Name: junitSuite.Name,
+		Path: junitSuite.Package,
+	}
+
+	if timestamp, err := time.Parse("2006-01-02T15:04:05", junitSuite.Timestamp); err == nil {
+		suite.Timestamp = timestamp
+	}
+
+	for _, testCase := range junitSuite.TestCases {
+		suite.Specs = append(suite.Specs, newSpecFromJUnit(testCase))
+	}
+
+	return suite
+}
+
+func newSpecFromJUnit(testCase reporters.JUnitTestCase) SpecResult {
+	name, nodeType, labels := SplitJUnitName(testCase.Name)
+
+	spec := SpecResult{
+		Name:            name,
+		NodeType:        nodeType,
+		Labels:          labels,
+		ID:              IDFromLabels(labels),
+		Status:          StatusFromGinkgoState(testCase.Status),
+		DurationSeconds: testCase.Time,
+	}
+
+	spec.Flaked = spec.Status == StatusPassed && failedAttempt.MatchString(testCase.SystemErr)
+
+	switch {
+	case testCase.Failure != nil:
+		spec.Message = testCase.Failure.Message
+	case testCase.Error != nil:
+		spec.Message = testCase.Error.Message
+	case testCase.Skipped != nil:
+		spec.Message = testCase.Skipped.Message
+	}
+
+	return spec
+}
+
+// SplitAttempt splits the name of a JUnit report, without the JUnit suffix, into the suite key and the attempt number.
+// The attempt is 0 if the name has no attempt suffix.
+func SplitAttempt(reportName string) (string, int) {
+	match := attemptSuffix.FindStringSubmatch(reportName)
+	if match == nil {
+		return reportName, 0
+	}
+
+	attempt, err := strconv.Atoi(match[1])
+	if err != nil {
+		return reportName, 0
+	}
+
+	return strings.TrimSuffix(reportName, match[0]), attempt
+}
+
+// SplitJUnitName splits the name of a Ginkgo JUnit test case into the spec full text, leaf node type, and labels.
+// The node type is empty if the name has no leaf node type prefix.
+func SplitJUnitName(junitName string) (string, string, []string) {
+	name := junitName
+	nodeType := ""
+
+	if match := leafNodeTypePrefix.FindStringSubmatch(name); match != nil {
+		nodeType = match[1]
+		name = strings.TrimPrefix(name, match[0])
+	}
+
+	var labels []string
+
+	if match := labelsSuffix.FindStringSubmatch(name); match != nil {
+		name = strings.TrimSuffix(name, match[0])
+
+		for _, label := range strings.Split(match[1], ",") {
+			if label = strings.TrimSpace(label); label != "" {
+				labels = append(labels, label)
+			}
+		}
+	}
+
+	return name, nodeType, labels
+}
+
+// IDFromLabels returns the reportxml ID from the labels of a spec or an empty string if it has none.
+func IDFromLabels(labels []string) string {
+	for _, label := range labels {
+		if id, found := strings.CutPrefix(label, reportXMLIDLabelPrefix); found {
+			return id
+		}
+	}
+
+	return ""
+}
+
+// StatusFromGinkgoState maps the string representation of a Ginkgo spec state onto a Status.
+func StatusFromGinkgoState(state string) Status {
+	switch state {
+	case "passed":
+		return StatusPassed
+	case "skipped", "pending":
+		return StatusSkipped
+	default:
+		return StatusFailed
+	}
+}
+
+// SpecDirName returns the name of the directory the reporter uses to dump the artifacts of a failed spec. It must be
+// kept in sync with reporter.SpecDirName in tests/internal/reporter.
+func SpecDirName(fullText string) string {
+	return strings.NewReplacer(" ", "_", "/", "-").Replace(fullText)
+}
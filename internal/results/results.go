@@ -0,0 +1,336 @@
+/*
+Package results aggregates the JUnit and reportxml reports produced by the test suites in a single reports directory
+into a run summary. The summary counts passed, failed, and skipped specs per suite, per label, and per reportxml ID and
+links failed specs to the directories the reporter dumped for them.
+*/
+package results
+
+import (
+	"cmp"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// Status is the simplified outcome of a spec.
+type Status string
+
+const (
+	// StatusPassed is used for specs that passed.
+	StatusPassed Status = "passed"
+	// StatusFailed is used for specs that failed, panicked, were interrupted, aborted, or timed out.
+	StatusFailed Status = "failed"
+	// StatusSkipped is used for specs that were skipped or pending.
+	StatusSkipped Status = "skipped"
+)
+
+// Counts contains the number of specs with each status and their total duration.
+type Counts struct {
+	Total           int     `json:"total"`
+	Passed          int     `json:"passed"`
+	Failed          int     `json:"failed"`
+	Skipped         int     `json:"skipped"`
+	DurationSeconds float64 `json:"durationSeconds"`
+}
+
+// Add increments the counts for a single spec.
+func (counts *Counts) Add(spec SpecResult) {
+	counts.Total++
+	counts.DurationSeconds += spec.DurationSeconds
+
+	switch spec.Status {
+	case StatusPassed:
+		counts.Passed++
+	case StatusFailed:
+		counts.Failed++
+	case StatusSkipped:
+		counts.Skipped++
+	}
+}
+
+// SpecResult is the result of a single spec.
+type SpecResult struct {
+	// Name is the full text of the spec.
+	Name string `json:"name"`
+	// NodeType is the Ginkgo leaf node type, such as It or BeforeSuite.
+	NodeType        string   `json:"nodeType,omitempty"`
+	ID              string   `json:"id,omitempty"`
+	Labels          []string `json:"labels,omitempty"`
+	Status          Status   `json:"status"`
+	DurationSeconds float64  `json:"durationSeconds"`
+	Message         string   `json:"message,omitempty"`
+	// Flaked is true when the spec passed only after failing one or more attempts with Ginkgo's --flake-attempts.
+	Flaked bool `json:"flaked,omitempty"`
+	// DumpDir is the path of the failed spec dump directory, relative to the reports directory. It is only set when
+	// the directory exists.
+	DumpDir string `json:"dumpDir,omitempty"`
+}
+
+// SuiteResult is the result of a single suite.
+type SuiteResult struct {
+	// Key identifies the suite by the name of its suite test file, without extension.
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	// Attempt is the number of the test runner attempt that produced the suite result. It is 0 for the first run of
+	// the suite and increases each time the failed specs are rerun.
+	Attempt   int          `json:"attempt,omitempty"`
+	Path      string       `json:"path,omitempty"`
+	Timestamp time.Time    `json:"timestamp"`
+	Counts    Counts       `json:"counts"`
+	Specs     []SpecResult `json:"specs"`
+}
+
+// GroupSummary contains the counts for a group of specs sharing a label or reportxml ID.
+type GroupSummary struct {
+	Name   string `json:"name"`
+	Counts Counts `json:"counts"`
+	// Suites are the keys of the suites containing specs in this group.
+	Suites []string `json:"suites"`
+}
+
+// RunSummary is the aggregated result of all the suites in a reports directory.
+type RunSummary struct {
+	Generated  time.Time      `json:"generated"`
+	ReportsDir string         `json:"reportsDir"`
+	Totals     Counts         `json:"totals"`
+	Suites     []SuiteResult  `json:"suites"`
+	Labels     []GroupSummary `json:"labels"`
+	IDs        []GroupSummary `json:"ids"`
+
+	// ids are the reportxml IDs keyed by spec name that the summary was created with, kept so ReplaceSuite can
+	// resolve the IDs of the replaced suite.
+	ids map[string]string
+	// attempts are the suites the summary was created with, before merging the attempts of each suite.
+	attempts []SuiteResult
+}
+
+// Load reads every JUnit and reportxml report in reportsDir and returns the aggregated RunSummary. Reports that
+// cannot be parsed are logged and skipped so a single corrupt report does not hide the rest of the run.
+func Load(reportsDir string) (*RunSummary, error) {
+	glog.V(100).Infof("Loading results from %s", reportsDir)
+
+	dirEntries, err := os.ReadDir(reportsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		suites []SuiteResult
+		ids    = make(map[string]string)
+	)
+
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(reportsDir, dirEntry.Name())
+
+		switch {
+		case strings.HasSuffix(dirEntry.Name(), JUnitSuffix):
+			fileSuites, err := ParseJUnitFile(path)
+			if err != nil {
+				glog.Errorf("Failed to parse JUnit report %s: %v", path, err)
+
+				continue
+			}
+
+			suites = append(suites, fileSuites...)
+		case strings.HasSuffix(dirEntry.Name(), ReportXMLSuffix):
+			fileIDs, err := ParseReportXMLFile(path)
+			if err != nil {
+				glog.Errorf("Failed to parse reportxml report %s: %v", path, err)
+
+				continue
+			}
+
+			for name, id := range fileIDs {
+				ids[name] = id
+			}
+		}
+	}
+
+	if len(suites) == 0 {
+		return nil, errors.New("no JUnit reports found in " + reportsDir)
+	}
+
+	return NewRunSummary(reportsDir, suites, ids), nil
+}
+
+// NewRunSummary aggregates suites into a RunSummary. Suites with the same key are different attempts of the same
+// suite and are merged using MergeAttempts. Specs without an ID in their labels use the ID from ids, keyed by spec
+// name, if present. Failed specs are linked to their dump directory if it exists under reportsDir.
+func NewRunSummary(reportsDir string, suites []SuiteResult, ids map[string]string) *RunSummary {
+	summary := &RunSummary{
+		Generated:  time.Now(),
+		ReportsDir: reportsDir,
+		Suites:     MergeAttempts(suites),
+		ids:        ids,
+		attempts:   suites,
+	}
+
+	labelGroups := make(map[string]*GroupSummary)
+	idGroups := make(map[string]*GroupSummary)
+
+	for suiteIndex := range summary.Suites {
+		suite := &summary.Suites[suiteIndex]
+		suite.Counts = Counts{}
+
+		for specIndex := range suite.Specs {
+			spec := &suite.Specs[specIndex]
+
+			if spec.ID == "" {
+				spec.ID = ids[spec.Name]
+			}
+
+			if spec.Status == StatusFailed {
+				spec.DumpDir = findDumpDir(reportsDir, suite.Key, spec.Name)
+			}
+
+			suite.Counts.Add(*spec)
+			summary.Totals.Add(*spec)
+
+			for _, label := range spec.Labels {
+				addToGroup(labelGroups, label, suite.Key, *spec)
+			}
+
+			if spec.ID != "" {
+				addToGroup(idGroups, spec.ID, suite.Key, *spec)
+			}
+		}
+	}
+
+	slices.SortFunc(summary.Suites, func(a, b SuiteResult) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	summary.Labels = sortedGroups(labelGroups)
+	summary.IDs = sortedGroups(idGroups)
+
+	return summary
+}
+
+// ReplaceSuite replaces the attempt of the suite with the same key and attempt number as suite, or adds it if none
+// exists, and recomputes the summary. It is used to include a suite whose JUnit report has not been written yet.
+func (summary *RunSummary) ReplaceSuite(suite SuiteResult) *RunSummary {
+	suites := slices.DeleteFunc(slices.Clone(summary.attempts), func(existing SuiteResult) bool {
+		return existing.Key == suite.Key && existing.Attempt == suite.Attempt
+	})
+
+	return NewRunSummary(summary.ReportsDir, append(suites, suite), summary.ids)
+}
+
+// Attempts returns every attempt of every suite the summary was created with, before they were merged.
+func (summary *RunSummary) Attempts() []SuiteResult {
+	return slices.Clone(summary.attempts)
+}
+
+// MergeAttempts merges the attempts of each suite, identified by their key, into a single suite result. Attempts are
+// applied in order, and a spec from a later attempt replaces the same spec from an earlier one unless it was skipped,
+// which is how specs that were not rerun appear in the report of a retry. A spec that passed after failing an earlier
+// attempt is marked as flaked. Suites are returned in the order their key first appears in suites.
+func MergeAttempts(suites []SuiteResult) []SuiteResult {
+	var (
+		keys     []string
+		attempts = make(map[string][]SuiteResult)
+	)
+
+	for _, suite := range suites {
+		if _, ok := attempts[suite.Key]; !ok {
+			keys = append(keys, suite.Key)
+		}
+
+		attempts[suite.Key] = append(attempts[suite.Key], suite)
+	}
+
+	merged := make([]SuiteResult, 0, len(keys))
+
+	for _, key := range keys {
+		suiteAttempts := attempts[key]
+		slices.SortStableFunc(suiteAttempts, func(a, b SuiteResult) int {
+			return cmp.Compare(a.Attempt, b.Attempt)
+		})
+
+		suite := suiteAttempts[0]
+		suite.Specs = slices.Clone(suite.Specs)
+
+		for _, retry := range suiteAttempts[1:] {
+			suite.Specs = mergeSpecs(suite.Specs, retry.Specs)
+		}
+
+		merged = append(merged, suite)
+	}
+
+	return merged
+}
+
+// mergeSpecs applies the specs of a retry on top of specs and returns the result.
+func mergeSpecs(specs, retrySpecs []SpecResult) []SpecResult {
+	for _, retrySpec := range retrySpecs {
+		index := slices.IndexFunc(specs, func(spec SpecResult) bool {
+			return spec.Name == retrySpec.Name && spec.NodeType == retrySpec.NodeType
+		})
+
+		if index < 0 {
+			specs = append(specs, retrySpec)
+
+			continue
+		}
+
+		if retrySpec.Status == StatusSkipped {
+			continue
+		}
+
+		retrySpec.Flaked = retrySpec.Flaked ||
+			(retrySpec.Status == StatusPassed && (specs[index].Status == StatusFailed || specs[index].Flaked))
+		specs[index] = retrySpec
+	}
+
+	return specs
+}
+
+func addToGroup(groups map[string]*GroupSummary, name, suiteKey string, spec SpecResult) {
+	group, ok := groups[name]
+	if !ok {
+		group = &GroupSummary{Name: name}
+		groups[name] = group
+	}
+
+	group.Counts.Add(spec)
+
+	if !slices.Contains(group.Suites, suiteKey) {
+		group.Suites = append(group.Suites, suiteKey)
+	}
+}
+
+func sortedGroups(groups map[string]*GroupSummary) []GroupSummary {
+	sorted := make([]GroupSummary, 0, len(groups))
+
+	for _, group := range groups {
+		slices.Sort(group.Suites)
+		sorted = append(sorted, *group)
+	}
+
+	slices.SortFunc(sorted, func(a, b GroupSummary) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return sorted
+}
+
+// findDumpDir returns the path of the dump directory for a failed spec relative to reportsDir, or an empty string if
+// it does not exist.
+func findDumpDir(reportsDir, suiteKey, specName string) string {
+	dumpDir := filepath.Join(FailedDumpPrefix+suiteKey, SpecDirName(specName))
+
+	if _, err := os.Stat(filepath.Join(reportsDir, dumpDir)); err != nil {
+		return ""
+	}
+
+	return dumpDir
+}
@@ -0,0 +1,300 @@
+package results
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testJUnitReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="4" disabled="0" errors="0" failures="1" time="12.5">
+  <testsuite name="sriov" package="/eco-gotests/tests/cnf/core/network/sriov" tests="4" failures="1"
+      timestamp="2024-05-01T12:00:00" time="12.5">
+    <testcase name="[BeforeSuite]" classname="sriov" status="passed" time="1"></testcase>
+    <testcase name="[It] SriovBasic creates a VF [sriov, test_id:1234]" classname="sriov" status="passed"
+        time="2.5">
+      <system-err>Attempt #1 Failed.  Retrying ↺ @ 05/01/24 12:00:01</system-err>
+    </testcase>
+    <testcase name="[It] SriovBasic deletes/recreates a VF [sriov]" classname="sriov" status="failed" time="8">
+      <failure message="Expected true" type="failed">full failure</failure>
+    </testcase>
+    <testcase name="[It] SriovBasic skips [sriov]" classname="sriov" status="skipped" time="1">
+      <skipped message="skipped - not supported"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>`
+
+	testRetryJUnitReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="4" disabled="0" errors="0" failures="0" time="9">
+  <testsuite name="sriov" package="/eco-gotests/tests/cnf/core/network/sriov" tests="4" failures="0"
+      timestamp="2024-05-01T12:10:00" time="9">
+    <testcase name="[BeforeSuite]" classname="sriov" status="passed" time="1"></testcase>
+    <testcase name="[It] SriovBasic creates a VF [sriov, test_id:1234]" classname="sriov" status="skipped" time="0">
+      <skipped message="skipped"></skipped>
+    </testcase>
+    <testcase name="[It] SriovBasic deletes/recreates a VF [sriov]" classname="sriov" status="passed" time="7">
+    </testcase>
+    <testcase name="[It] SriovBasic skips [sriov]" classname="sriov" status="skipped" time="0">
+      <skipped message="skipped"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>`
+
+	testReportXML = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="sriov" tests="1">
+  <testcase name="SriovBasic deletes/recreates a VF" classname="sriov">
+    <properties>
+      <property name="testcase-id" value="OCP-5678"></property>
+    </properties>
+  </testcase>
+</testsuite>`
+)
+
+func TestSplitJUnitName(t *testing.T) {
+	testCases := []struct {
+		junitName        string
+		expectedName     string
+		expectedNodeType string
+		expectedLabels   []string
+	}{
+		{
+			junitName:        "[It] Suite container spec [label1, test_id:1234]",
+			expectedName:     "Suite container spec",
+			expectedNodeType: "It",
+			expectedLabels:   []string{"label1", "test_id:1234"},
+		},
+		{
+			junitName:        "[It] Suite spec without labels",
+			expectedName:     "Suite spec without labels",
+			expectedNodeType: "It",
+		},
+		{
+			junitName:        "[BeforeSuite]",
+			expectedName:     "",
+			expectedNodeType: "BeforeSuite",
+		},
+		{
+			junitName:    "spec with [brackets] inside",
+			expectedName: "spec with [brackets] inside",
+		},
+	}
+
+	for _, testCase := range testCases {
+		name, nodeType, labels := SplitJUnitName(testCase.junitName)
+		assert.Equal(t, testCase.expectedName, name)
+		assert.Equal(t, testCase.expectedNodeType, nodeType)
+		assert.Equal(t, testCase.expectedLabels, labels)
+	}
+}
+
+func TestStatusFromGinkgoState(t *testing.T) {
+	testCases := []struct {
+		state          string
+		expectedStatus Status
+	}{
+		{state: "passed", expectedStatus: StatusPassed},
+		{state: "skipped", expectedStatus: StatusSkipped},
+		{state: "pending", expectedStatus: StatusSkipped},
+		{state: "failed", expectedStatus: StatusFailed},
+		{state: "panicked", expectedStatus: StatusFailed},
+		{state: "timedout", expectedStatus: StatusFailed},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expectedStatus, StatusFromGinkgoState(testCase.state))
+	}
+}
+
+func TestLoad(t *testing.T) {
+	reportsDir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(reportsDir, "sriov_suite_test"+JUnitSuffix), []byte(testJUnitReport), 0644)
+	assert.Nil(t, err)
+
+	err = os.WriteFile(filepath.Join(reportsDir, "report"+ReportXMLSuffix), []byte(testReportXML), 0644)
+	assert.Nil(t, err)
+
+	dumpDir := filepath.Join(FailedDumpPrefix+"sriov_suite_test", "SriovBasic_deletes-recreates_a_VF")
+	err = os.MkdirAll(filepath.Join(reportsDir, dumpDir), 0755)
+	assert.Nil(t, err)
+
+	summary, err := Load(reportsDir)
+	assert.Nil(t, err)
+
+	if !assert.Len(t, summary.Suites, 1) {
+		return
+	}
+
+	suite := summary.Suites[0]
+	assert.Equal(t, "sriov_suite_test", suite.Key)
+	assert.Equal(t, "sriov", suite.Name)
+	assert.Equal(t, Counts{Total: 4, Passed: 2, Failed: 1, Skipped: 1, DurationSeconds: 12.5}, suite.Counts)
+	assert.Equal(t, suite.Counts, summary.Totals)
+
+	assert.Equal(t, "1234", suite.Specs[1].ID)
+	assert.True(t, suite.Specs[1].Flaked)
+	assert.False(t, suite.Specs[2].Flaked)
+	assert.Equal(t, "OCP-5678", suite.Specs[2].ID)
+	assert.Equal(t, dumpDir, suite.Specs[2].DumpDir)
+	assert.Equal(t, "Expected true", suite.Specs[2].Message)
+	assert.Equal(t, "", suite.Specs[3].DumpDir)
+
+	assert.Equal(t, []GroupSummary{
+		{Name: "sriov", Counts: Counts{Total: 3, Passed: 1, Failed: 1, Skipped: 1, DurationSeconds: 11.5},
+			Suites: []string{"sriov_suite_test"}},
+		{Name: "test_id:1234", Counts: Counts{Total: 1, Passed: 1, DurationSeconds: 2.5},
+			Suites: []string{"sriov_suite_test"}},
+	}, summary.Labels)
+
+	assert.Equal(t, []string{"1234", "OCP-5678"}, []string{summary.IDs[0].Name, summary.IDs[1].Name})
+
+	err = summary.WriteFiles(filepath.Join(reportsDir, "summary"))
+	assert.Nil(t, err)
+	assert.FileExists(t, filepath.Join(reportsDir, "summary", SummaryJSONFileName))
+	assert.FileExists(t, filepath.Join(reportsDir, "summary", SummaryHTMLFileName))
+}
+
+func TestLoadRetries(t *testing.T) {
+	reportsDir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(reportsDir, "sriov_suite_test"+JUnitSuffix), []byte(testJUnitReport), 0644)
+	assert.Nil(t, err)
+
+	err = os.WriteFile(
+		filepath.Join(reportsDir, "sriov_suite_test_attempt1"+JUnitSuffix), []byte(testRetryJUnitReport), 0644)
+	assert.Nil(t, err)
+
+	summary, err := Load(reportsDir)
+	assert.Nil(t, err)
+
+	if !assert.Len(t, summary.Suites, 1) {
+		return
+	}
+
+	suite := summary.Suites[0]
+	assert.Equal(t, "sriov_suite_test", suite.Key)
+	assert.Equal(t, Counts{Total: 4, Passed: 3, Skipped: 1, DurationSeconds: 11.5}, suite.Counts)
+	assert.Equal(t, StatusPassed, suite.Specs[1].Status)
+	assert.True(t, suite.Specs[2].Flaked)
+	assert.Equal(t, "skipped - not supported", suite.Specs[3].Message)
+
+	attempts := summary.Attempts()
+	assert.Len(t, attempts, 2)
+	assert.ElementsMatch(t, []int{0, 1}, []int{attempts[0].Attempt, attempts[1].Attempt})
+}
+
+func TestSplitAttempt(t *testing.T) {
+	testCases := []struct {
+		name            string
+		reportName      string
+		expectedKey     string
+		expectedAttempt int
+	}{
+		{name: "first attempt", reportName: "sriov_suite_test", expectedKey: "sriov_suite_test"},
+		{name: "retry", reportName: "sriov_suite_test_attempt2", expectedKey: "sriov_suite_test", expectedAttempt: 2},
+		{name: "no number", reportName: "sriov_suite_test_attempt", expectedKey: "sriov_suite_test_attempt"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			key, attempt := SplitAttempt(testCase.reportName)
+
+			assert.Equal(t, testCase.expectedKey, key)
+			assert.Equal(t, testCase.expectedAttempt, attempt)
+		})
+	}
+}
+
+func TestMergeAttempts(t *testing.T) {
+	testCases := []struct {
+		name           string
+		attempts       []SuiteResult
+		expectedSpecs  []SpecResult
+		expectedSuites int
+	}{
+		{
+			name: "single attempt",
+			attempts: []SuiteResult{
+				{Key: "a", Specs: []SpecResult{{Name: "a1", Status: StatusFailed}}},
+			},
+			expectedSpecs:  []SpecResult{{Name: "a1", Status: StatusFailed}},
+			expectedSuites: 1,
+		},
+		{
+			name: "retry passes failed spec and skips the rest",
+			attempts: []SuiteResult{
+				{Key: "a", Attempt: 1, Specs: []SpecResult{
+					{Name: "a1", Status: StatusSkipped}, {Name: "a2", Status: StatusPassed, DurationSeconds: 3},
+				}},
+				{Key: "a", Specs: []SpecResult{
+					{Name: "a1", Status: StatusPassed}, {Name: "a2", Status: StatusFailed, DurationSeconds: 5},
+				}},
+			},
+			expectedSpecs: []SpecResult{
+				{Name: "a1", Status: StatusPassed}, {Name: "a2", Status: StatusPassed, DurationSeconds: 3, Flaked: true},
+			},
+			expectedSuites: 1,
+		},
+		{
+			name: "every retry fails",
+			attempts: []SuiteResult{
+				{Key: "a", Specs: []SpecResult{{Name: "a1", Status: StatusFailed, Message: "first"}}},
+				{Key: "a", Attempt: 1, Specs: []SpecResult{{Name: "a1", Status: StatusFailed, Message: "second"}}},
+				{Key: "a", Attempt: 2, Specs: []SpecResult{{Name: "a1", Status: StatusFailed, Message: "third"}}},
+			},
+			expectedSpecs:  []SpecResult{{Name: "a1", Status: StatusFailed, Message: "third"}},
+			expectedSuites: 1,
+		},
+		{
+			name: "different suites are not merged",
+			attempts: []SuiteResult{
+				{Key: "a", Specs: []SpecResult{{Name: "a1", Status: StatusFailed}}},
+				{Key: "b", Attempt: 1, Specs: []SpecResult{{Name: "a1", Status: StatusPassed}}},
+			},
+			expectedSpecs:  []SpecResult{{Name: "a1", Status: StatusFailed}},
+			expectedSuites: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			merged := MergeAttempts(testCase.attempts)
+
+			assert.Len(t, merged, testCase.expectedSuites)
+			assert.Equal(t, testCase.expectedSpecs, merged[0].Specs)
+		})
+	}
+}
+
+func TestLoadNoReports(t *testing.T) {
+	_, err := Load(t.TempDir())
+	assert.NotNil(t, err)
+}
+
+func TestReplaceSuite(t *testing.T) {
+	summary := NewRunSummary("", []SuiteResult{
+		{Key: "a", Specs: []SpecResult{{Name: "a1", Status: StatusPassed}}},
+		{Key: "b", Specs: []SpecResult{{Name: "b1", Status: StatusFailed}}},
+	}, nil)
+
+	replaced := summary.ReplaceSuite(SuiteResult{Key: "b", Specs: []SpecResult{{Name: "b1", Status: StatusPassed}}})
+	assert.Equal(t, Counts{Total: 2, Passed: 2}, replaced.Totals)
+	assert.Equal(t, 1, summary.Totals.Failed)
+
+	added := summary.ReplaceSuite(SuiteResult{Key: "c", Specs: []SpecResult{{Name: "c1", Status: StatusSkipped}}})
+	assert.Equal(t, 3, added.Totals.Total)
+	assert.Equal(t, []string{"a", "b", "c"}, []string{added.Suites[0].Key, added.Suites[1].Key, added.Suites[2].Key})
+}
+
+func TestReplaceSuiteKeepsIDs(t *testing.T) {
+	summary := NewRunSummary("", []SuiteResult{
+		{Key: "a", Specs: []SpecResult{{Name: "a1", Status: StatusPassed}}},
+	}, map[string]string{"a1": "1234", "b1": "5678"})
+
+	replaced := summary.ReplaceSuite(SuiteResult{Key: "b", Specs: []SpecResult{{Name: "b1", Status: StatusPassed}}})
+	assert.Equal(t, "5678", replaced.Suites[1].Specs[0].ID)
+	assert.Equal(t, []string{"1234", "5678"}, []string{replaced.IDs[0].Name, replaced.IDs[1].Name})
+}
@@ -0,0 +1,83 @@
+package results
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// SummaryJSONFileName is the name of the JSON run summary written by WriteFiles.
+	SummaryJSONFileName = "summary.json"
+	// SummaryHTMLFileName is the name of the HTML run summary written by WriteFiles.
+	SummaryHTMLFileName = "summary.html"
+)
+
+var (
+	//go:embed summary_template.html
+	summaryTemplateFile string
+
+	summaryFuncMap = template.FuncMap{
+		"duration": formatDuration,
+	}
+	summaryTemplate = template.Must(
+		template.New("summary_template.html").Funcs(summaryFuncMap).Parse(summaryTemplateFile))
+)
+
+// WriteJSON saves summary as indented JSON to fileName, truncating it if it exists.
+func (summary *RunSummary) WriteJSON(fileName string) error {
+	glog.V(100).Infof("Writing JSON run summary to %s", fileName)
+
+	contents, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(fileName, contents, 0644)
+}
+
+// WriteHTML renders summary as a static HTML page and saves it to fileName, truncating it if it exists. Links to
+// failed spec dumps are relative to the reports directory, so the page should be saved there for them to work.
+func (summary *RunSummary) WriteHTML(fileName string) error {
+	glog.V(100).Infof("Writing HTML run summary to %s", fileName)
+
+	outputFile, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+
+	defer outputFile.Close()
+
+	return summaryTemplate.Execute(outputFile, summary)
+}
+
+// WriteFiles saves both the JSON and HTML run summaries to outputDir, creating it if it does not exist.
+func (summary *RunSummary) WriteFiles(outputDir string) error {
+	err := os.MkdirAll(outputDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	err = summary.WriteJSON(filepath.Join(outputDir, SummaryJSONFileName))
+	if err != nil {
+		return fmt.Errorf("failed to write JSON run summary: %w", err)
+	}
+
+	err = summary.WriteHTML(filepath.Join(outputDir, SummaryHTMLFileName))
+	if err != nil {
+		return fmt.Errorf("failed to write HTML run summary: %w", err)
+	}
+
+	return nil
+}
+
+// formatDuration formats a number of seconds as a duration rounded to milliseconds.
+func formatDuration(seconds float64) string {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Millisecond).String()
+}
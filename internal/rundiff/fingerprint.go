@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/fingerprint"
+)
+
+const (
+	// ChangeAdded is used for items only in the newer fingerprint.
+	ChangeAdded = "added"
+	// ChangeRemoved is used for items only in the older fingerprint.
+	ChangeRemoved = "removed"
+	// ChangeModified is used for items in both fingerprints with different values.
+	ChangeModified = "changed"
+)
+
+// FingerprintDiff lists the differences between two cluster fingerprints.
+type FingerprintDiff struct {
+	From    string   `json:"from"`
+	To      string   `json:"to"`
+	Changes []Change `json:"changes,omitempty"`
+}
+
+// Change is a single difference between two fingerprints.
+type Change struct {
+	// Kind is the part of the fingerprint that changed, such as "operator" or "node".
+	Kind string `json:"kind"`
+	// Name identifies the item that changed within its kind.
+	Name   string `json:"name"`
+	Change string `json:"change"`
+	Old    string `json:"old,omitempty"`
+	New    string `json:"new,omitempty"`
+}
+
+// DiffFingerprints returns the differences between the older fingerprint from and the newer fingerprint to.
+func DiffFingerprints(
+	fromName string, from *fingerprint.Fingerprint, toName string, to *fingerprint.Fingerprint) FingerprintDiff {
+	diff := FingerprintDiff{From: fromName, To: toName}
+
+	diff.compareValue("cluster", "name", from.ClusterName, to.ClusterName)
+	diff.compareValue("cluster", "ocp-version", from.OCPVersion, to.OCPVersion)
+	diff.compareValue("cluster", "network-type", from.NetworkType, to.NetworkType)
+	diff.compareValue("cluster", "platform", from.Platform, to.Platform)
+	diff.compareValue("cluster", "topology", from.ControlPlaneTopology, to.ControlPlaneTopology)
+	diff.compareValue("cluster", "proxied", fmt.Sprintf("%t", from.Proxied), fmt.Sprintf("%t", to.Proxied))
+
+	diff.compareMaps("operator", operatorVersions(from), operatorVersions(to))
+	diff.compareMaps("node", nodeDescriptions(from), nodeDescriptions(to))
+	diff.compareMaps("machineconfigpool", poolDescriptions(from), poolDescriptions(to))
+
+	return diff
+}
+
+func (diff *FingerprintDiff) compareValue(kind, name, oldValue, newValue string) {
+	if oldValue != newValue {
+		diff.Changes = append(diff.Changes, Change{
+			Kind: kind, Name: name, Change: ChangeModified, Old: oldValue, New: newValue})
+	}
+}
+
+func (diff *FingerprintDiff) compareMaps(kind string, oldItems, newItems map[string]string) {
+	for _, name := range sortedKeys(oldItems) {
+		newValue, found := newItems[name]
+
+		switch {
+		case !found:
+			diff.Changes = append(diff.Changes, Change{Kind: kind, Name: name, Change: ChangeRemoved, Old: oldItems[name]})
+		case newValue != oldItems[name]:
+			diff.compareValue(kind, name, oldItems[name], newValue)
+		}
+	}
+
+	for _, name := range sortedKeys(newItems) {
+		if _, found := oldItems[name]; !found {
+			diff.Changes = append(diff.Changes, Change{Kind: kind, Name: name, Change: ChangeAdded, New: newItems[name]})
+		}
+	}
+}
+
+// operatorVersions maps the namespace and name of each operator, without the version suffix of its CSV, onto its
+// version so upgrades are reported as changes rather than an operator being removed and another added.
+func operatorVersions(clusterFingerprint *fingerprint.Fingerprint) map[string]string {
+	versions := make(map[string]string)
+
+	for _, operator := range clusterFingerprint.Operators {
+		name, _, _ := strings.Cut(operator.Name, ".v")
+		versions[operator.Namespace+"/"+name] = fmt.Sprintf("%s (%s)", operator.Version, operator.Phase)
+	}
+
+	return versions
+}
+
+func nodeDescriptions(clusterFingerprint *fingerprint.Fingerprint) map[string]string {
+	descriptions := make(map[string]string)
+
+	for _, node := range clusterFingerprint.Nodes {
+		roles := slices.Clone(node.Roles)
+		slices.Sort(roles)
+
+		descriptions[node.Name] = fmt.Sprintf("roles=%s kernel=%s os=%s kubelet=%s ready=%t",
+			strings.Join(roles, ","), node.KernelVersion, node.OSImage, node.KubeletVersion, node.Ready)
+	}
+
+	return descriptions
+}
+
+func poolDescriptions(clusterFingerprint *fingerprint.Fingerprint) map[string]string {
+	descriptions := make(map[string]string)
+
+	for _, pool := range clusterFingerprint.MachineConfigPools {
+		descriptions[pool.Name] = fmt.Sprintf("config=%s machines=%d updated=%t updating=%t degraded=%t",
+			pool.Configuration, pool.MachineCount, pool.Updated, pool.Updating, pool.Degraded)
+	}
+
+	return descriptions
+}
@@ -0,0 +1,187 @@
+/*
+Rundiff is a tool to report structured differences in the outputs of long running tests. Each argument is a file or a
+directory and, for directories, the cluster fingerprint and every *.log and *.csv file directly inside are loaded.
+
+Cluster fingerprints (*.json) are compared in the order they are provided, reporting changes to the OCP version,
+network type, and platform as well as added, removed, and changed operators, nodes, and machine config pools.
+
+Stability outputs, as saved by the stability package, are analyzed individually:
+
+  - pod and tuned restart counters report which keys restarted and when
+  - policy statuses report which policies were not Compliant and for how long
+  - PTP statuses report every Unsync interval and the total downtime
+
+Keys that appear or disappear between samples, such as recreated pods, are reported for every output except PTP.
+
+Like diff, the exit code is 0 if no differences are found, 1 if there are differences, and 2 if any error occurs.
+
+Usage:
+
+	rundiff [flags] path...
+
+The flags are:
+
+	-h, -help
+		Print this help message
+
+	-j, -json
+		Print the differences as JSON rather than text
+
+	-v int
+		Log level verbosity for glog. Use 100 for logging all messages or leave blank for none
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/fingerprint"
+)
+
+var (
+	help       bool
+	jsonOutput bool
+)
+
+//nolint:gochecknoinits // This is a main package so init is fine.
+func init() {
+	const (
+		helpUsage = "Print this help message"
+		jsonUsage = "Print the differences as JSON rather than text"
+
+		defaultHelp = false
+		defaultJSON = false
+
+		shorthand = " (shorthand)"
+	)
+
+	flag.BoolVar(&help, "help", defaultHelp, helpUsage)
+	flag.BoolVar(&help, "h", defaultHelp, helpUsage+shorthand)
+
+	flag.BoolVar(&jsonOutput, "json", defaultJSON, jsonUsage)
+	flag.BoolVar(&jsonOutput, "j", defaultJSON, jsonUsage+shorthand)
+}
+
+func main() {
+	// Also send glog messages to stderr
+	_ = flag.Lookup("logtostderr").Value.Set("true")
+
+	flag.Parse()
+
+	if help {
+		flag.Usage()
+
+		return
+	}
+
+	if flag.NArg() == 0 {
+		glog.Errorf("At least one file or directory must be provided")
+
+		os.Exit(2)
+	}
+
+	report, err := NewReport(flag.Args())
+	if err != nil {
+		glog.Errorf("Failed to compare outputs: %v", err)
+
+		os.Exit(2)
+	}
+
+	if jsonOutput {
+		err = report.WriteJSON(os.Stdout)
+	} else {
+		err = report.WriteText(os.Stdout)
+	}
+
+	if err != nil {
+		glog.Errorf("Failed to print differences: %v", err)
+
+		os.Exit(2)
+	}
+
+	if report.Changed() {
+		os.Exit(1)
+	}
+}
+
+// NewReport loads every fingerprint and stability output in paths and reports their differences.
+func NewReport(paths []string) (*Report, error) {
+	fingerprintPaths, stabilityPaths, err := expandPaths(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fingerprintPaths) == 0 && len(stabilityPaths) == 0 {
+		return nil, fmt.Errorf("no fingerprints or stability outputs found in %s", strings.Join(paths, ", "))
+	}
+
+	report := &Report{}
+
+	var previous *fingerprint.Fingerprint
+
+	for index, path := range fingerprintPaths {
+		current, err := fingerprint.Load(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if previous != nil {
+			report.Fingerprints = append(report.Fingerprints,
+				DiffFingerprints(fingerprintPaths[index-1], previous, path, current))
+		}
+
+		previous = current
+	}
+
+	for _, path := range stabilityPaths {
+		stabilityReport, err := LoadStabilityFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Stability = append(report.Stability, *stabilityReport)
+	}
+
+	return report, nil
+}
+
+// expandPaths splits paths into fingerprints and stability outputs, replacing directories with the matching files
+// directly inside them.
+func expandPaths(paths []string) ([]string, []string, error) {
+	var fingerprintPaths, stabilityPaths []string
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !info.IsDir() {
+			if filepath.Ext(path) == ".json" {
+				fingerprintPaths = append(fingerprintPaths, path)
+			} else {
+				stabilityPaths = append(stabilityPaths, path)
+			}
+
+			continue
+		}
+
+		fingerprintPath := filepath.Join(path, fingerprint.FileName)
+		if _, err := os.Stat(fingerprintPath); err == nil {
+			fingerprintPaths = append(fingerprintPaths, fingerprintPath)
+		}
+
+		for _, pattern := range []string{"*.log", "*.csv"} {
+			// The only possible error is a malformed pattern.
+			matches, _ := filepath.Glob(filepath.Join(path, pattern))
+			stabilityPaths = append(stabilityPaths, matches...)
+		}
+	}
+
+	return fingerprintPaths, stabilityPaths, nil
+}
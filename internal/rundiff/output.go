@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Report is every difference found in the inputs.
+type Report struct {
+	Fingerprints []FingerprintDiff `json:"fingerprints,omitempty"`
+	Stability    []StabilityReport `json:"stability,omitempty"`
+}
+
+// Changed returns whether any difference was found.
+func (report *Report) Changed() bool {
+	for _, diff := range report.Fingerprints {
+		if len(diff.Changes) > 0 {
+			return true
+		}
+	}
+
+	for _, stabilityReport := range report.Stability {
+		if stabilityReport.Changed() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WriteJSON writes the report as indented JSON.
+func (report *Report) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
+
+// WriteText writes the report in a human readable form, one section per fingerprint diff and stability file.
+func (report *Report) WriteText(writer io.Writer) error {
+	var builder strings.Builder
+
+	for _, diff := range report.Fingerprints {
+		fmt.Fprintf(&builder, "== fingerprint %s -> %s: %d changes\n", diff.From, diff.To, len(diff.Changes))
+
+		for _, change := range diff.Changes {
+			switch change.Change {
+			case ChangeAdded:
+				fmt.Fprintf(&builder, "  + %s %s: %s\n", change.Kind, change.Name, change.New)
+			case ChangeRemoved:
+				fmt.Fprintf(&builder, "  - %s %s: %s\n", change.Kind, change.Name, change.Old)
+			default:
+				fmt.Fprintf(&builder, "  ~ %s %s: %s -> %s\n", change.Kind, change.Name, change.Old, change.New)
+			}
+		}
+	}
+
+	for _, stabilityReport := range report.Stability {
+		fmt.Fprintf(&builder, "== %s (%s, %d samples, %s to %s)\n", stabilityReport.File, stabilityReport.Kind,
+			stabilityReport.Samples, formatTime(stabilityReport.Start), formatTime(stabilityReport.End))
+
+		if !stabilityReport.Changed() {
+			builder.WriteString("  no changes\n")
+
+			continue
+		}
+
+		for _, increase := range stabilityReport.Increases {
+			fmt.Fprintf(&builder, "  %s %s: %d -> %d\n",
+				formatTime(increase.Time), increase.Key, increase.Previous, increase.Current)
+		}
+
+		for _, event := range stabilityReport.Added {
+			fmt.Fprintf(&builder, "  %s + %s\n", formatTime(event.Time), event.Key)
+		}
+
+		for _, event := range stabilityReport.Removed {
+			fmt.Fprintf(&builder, "  %s - %s\n", formatTime(event.Time), event.Key)
+		}
+
+		for _, interval := range stabilityReport.Intervals {
+			end := formatTime(interval.End)
+			if interval.Ongoing {
+				end += " (ongoing)"
+			}
+
+			fmt.Fprintf(&builder, "  %s %s from %s to %s for %s", interval.Key, interval.State,
+				formatTime(interval.Start), end, formatSeconds(interval.DurationSeconds))
+
+			if interval.Reason != "" {
+				fmt.Fprintf(&builder, ": %s", interval.Reason)
+			}
+
+			builder.WriteString("\n")
+		}
+
+		if len(stabilityReport.Intervals) > 0 {
+			fmt.Fprintf(&builder, "  total downtime: %s\n", formatSeconds(stabilityReport.DowntimeSeconds))
+		}
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+
+	return err
+}
+
+func formatTime(timestamp time.Time) string {
+	return timestamp.Format(time.RFC3339)
+}
+
+func formatSeconds(seconds float64) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
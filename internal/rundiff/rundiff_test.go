@@ -0,0 +1,164 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/fingerprint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStabilityReport(t *testing.T) {
+	testCases := []struct {
+		output            string
+		expectedKind      SeriesKind
+		expectedIncreases []CounterIncrease
+		expectedAdded     []KeyEvent
+		expectedRemoved   []KeyEvent
+		expectedIntervals []Interval
+		expectedDowntime  float64
+		expectedError     bool
+	}{
+		{
+			output: "2025-01-01T00:00:00Z,etcd-0,0,etcd-1,2\n" +
+				"2025-01-01T00:05:00Z,etcd-0,1,etcd-1,2\n" +
+				"2025-01-01T00:10:00Z,etcd-0,1,etcd-2,0\n",
+			expectedKind: SeriesCounters,
+			expectedIncreases: []CounterIncrease{
+				{Key: "etcd-0", Time: at(5), Previous: 0, Current: 1},
+			},
+			expectedAdded:   []KeyEvent{{Key: "etcd-2", Time: at(10)}},
+			expectedRemoved: []KeyEvent{{Key: "etcd-1", Time: at(10)}},
+		},
+		{
+			output: "2025-01-01T00:00:00Z,policy-a,Compliant,policy-b,NonCompliant\n" +
+				"2025-01-01T00:05:00Z,policy-a,NonCompliant,policy-b,NonCompliant\n" +
+				"2025-01-01T00:10:00Z,policy-a,Compliant,policy-b,Compliant\n" +
+				"2025-01-01T00:15:00Z,policy-a,NonCompliant,policy-b,Compliant\n",
+			expectedKind: SeriesStates,
+			expectedIntervals: []Interval{
+				{Key: "policy-b", State: "NonCompliant", Start: at(0), End: at(10), DurationSeconds: 600},
+				{Key: "policy-a", State: "NonCompliant", Start: at(5), End: at(10), DurationSeconds: 300},
+				{Key: "policy-a", State: "NonCompliant", Start: at(15), End: at(15), Ongoing: true},
+			},
+			expectedDowntime: 900,
+		},
+		{
+			output: "2025-01-01T00:00:00Z,Sync,\"<nil>\"\n" +
+				"2025-01-01T00:05:00Z,Unsync,\"clock state is FREERUN\"\n" +
+				"2025-01-01T00:10:00Z,Unsync,\"clock state is HOLDOVER\"\n" +
+				"2025-01-01T00:15:00Z,Sync,\"<nil>\"\n" +
+				"2025-01-01T00:20:00Z,Unsync,\"clock state is FREERUN\"\n" +
+				"2025-01-01T00:30:00Z,Unsync,\"clock state is FREERUN\"\n",
+			expectedKind: SeriesPTP,
+			expectedIntervals: []Interval{
+				{Key: "ptp", State: "Unsync", Start: at(5), End: at(15), DurationSeconds: 600,
+					Reason: "clock state is FREERUN"},
+				{Key: "ptp", State: "Unsync", Start: at(20), End: at(30), Ongoing: true, DurationSeconds: 600,
+					Reason: "clock state is FREERUN"},
+			},
+			expectedDowntime: 1200,
+		},
+		{
+			output:       "2025-01-01T00:00:00Z,\n2025-01-01T00:05:00Z,tuned_restarts,1\n",
+			expectedKind: SeriesCounters,
+			expectedAdded: []KeyEvent{
+				{Key: "tuned_restarts", Time: at(5)},
+			},
+		},
+		{
+			output:        "yesterday,etcd-0,0\n",
+			expectedError: true,
+		},
+		{
+			output:        "",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		report, err := NewStabilityReport("test.log", strings.NewReader(testCase.output))
+		assert.Equal(t, testCase.expectedError, err != nil)
+
+		if err != nil {
+			continue
+		}
+
+		assert.Equal(t, testCase.expectedKind, report.Kind)
+		assert.Equal(t, testCase.expectedIncreases, report.Increases)
+		assert.Equal(t, testCase.expectedAdded, report.Added)
+		assert.Equal(t, testCase.expectedRemoved, report.Removed)
+		assert.Equal(t, testCase.expectedIntervals, report.Intervals)
+		assert.Equal(t, testCase.expectedDowntime, report.DowntimeSeconds)
+	}
+}
+
+func TestDiffFingerprints(t *testing.T) {
+	from := &fingerprint.Fingerprint{
+		OCPVersion: "4.17.10",
+		Nodes:      []fingerprint.Node{{Name: "master-0", KernelVersion: "5.14.0-427"}},
+		Operators: []fingerprint.Operator{
+			{Name: "sriov-network-operator.v4.17.0", Namespace: "openshift-sriov", Version: "4.17.0"},
+			{Name: "ptp-operator.v4.17.0", Namespace: "openshift-ptp", Version: "4.17.0"},
+		},
+	}
+	to := &fingerprint.Fingerprint{
+		OCPVersion: "4.18.3",
+		Nodes:      []fingerprint.Node{{Name: "master-0", KernelVersion: "5.14.0-427"}},
+		Operators: []fingerprint.Operator{
+			{Name: "sriov-network-operator.v4.18.0", Namespace: "openshift-sriov", Version: "4.18.0"},
+			{Name: "metallb-operator.v4.18.0", Namespace: "metallb-system", Version: "4.18.0"},
+		},
+	}
+
+	diff := DiffFingerprints("a", from, "b", to)
+	assert.Equal(t, []Change{
+		{Kind: "cluster", Name: "ocp-version", Change: ChangeModified, Old: "4.17.10", New: "4.18.3"},
+		{Kind: "operator", Name: "openshift-ptp/ptp-operator", Change: ChangeRemoved, Old: "4.17.0 ()"},
+		{Kind: "operator", Name: "openshift-sriov/sriov-network-operator", Change: ChangeModified,
+			Old: "4.17.0 ()", New: "4.18.0 ()"},
+		{Kind: "operator", Name: "metallb-system/metallb-operator", Change: ChangeAdded, New: "4.18.0 ()"},
+	}, diff.Changes)
+
+	assert.Empty(t, DiffFingerprints("a", from, "b", from).Changes)
+}
+
+func TestNewReport(t *testing.T) {
+	runDirs := []string{t.TempDir(), t.TempDir()}
+
+	for index, ocpVersion := range []string{"4.17.10", "4.18.3"} {
+		err := (&fingerprint.Fingerprint{OCPVersion: ocpVersion}).Save(filepath.Join(runDirs[index], fingerprint.FileName))
+		assert.Nil(t, err)
+	}
+
+	err := os.WriteFile(
+		filepath.Join(runDirs[1], "stability_ptp.log"), []byte("2025-01-01T00:00:00Z,Sync,\"<nil>\"\n"), 0644)
+	assert.Nil(t, err)
+
+	report, err := NewReport(runDirs)
+	assert.Nil(t, err)
+	assert.Len(t, report.Fingerprints, 1)
+	assert.Len(t, report.Stability, 1)
+	assert.True(t, report.Changed())
+
+	var text strings.Builder
+
+	err = report.WriteText(&text)
+	assert.Nil(t, err)
+	assert.Contains(t, text.String(), "~ cluster ocp-version: 4.17.10 -> 4.18.3")
+	assert.Contains(t, text.String(), "no changes")
+
+	report, err = NewReport(runDirs[1:])
+	assert.Nil(t, err)
+	assert.False(t, report.Changed())
+
+	_, err = NewReport([]string{t.TempDir()})
+	assert.NotNil(t, err)
+}
+
+func at(minutes int) time.Time {
+	return time.Date(2025, 1, 1, 0, minutes, 0, 0, time.UTC)
+}
@@ -0,0 +1,361 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// SeriesKind is the format of a stability output file, detected from its contents.
+type SeriesKind string
+
+const (
+	// SeriesPTP is the output of stability.SavePTPStatus: a timestamp, Sync or Unsync, and the quoted error.
+	SeriesPTP SeriesKind = "ptp"
+	// SeriesCounters is a key/value output whose values are all integers, such as SavePodsRestartsInNamespace and
+	// SaveTunedRestarts.
+	SeriesCounters SeriesKind = "counters"
+	// SeriesStates is a key/value output with non-integer values, such as SavePolicyStatus.
+	SeriesStates SeriesKind = "states"
+)
+
+const (
+	ptpSync   = "Sync"
+	ptpUnsync = "Unsync"
+	// healthyState is the value of a state series that is not reported as an interval.
+	healthyState = "Compliant"
+	// ptpNoError is how SavePTPStatus formats a nil error.
+	ptpNoError = "<nil>"
+)
+
+// Sample is a single line of a stability output file.
+type Sample struct {
+	Time   time.Time
+	Values map[string]string
+}
+
+// StabilityReport describes the changes within a single stability output file.
+type StabilityReport struct {
+	File    string     `json:"file"`
+	Kind    SeriesKind `json:"kind"`
+	Samples int        `json:"samples"`
+	Start   time.Time  `json:"start"`
+	End     time.Time  `json:"end"`
+	// Increases lists every time a counter, such as the restarts of a pod, went up between samples.
+	Increases []CounterIncrease `json:"increases,omitempty"`
+	// Added and Removed list keys, such as pod or policy names, that appeared or disappeared after the first sample.
+	Added   []KeyEvent `json:"added,omitempty"`
+	Removed []KeyEvent `json:"removed,omitempty"`
+	// Intervals lists periods where a state was not healthy: policies that were not Compliant or PTP being Unsync.
+	Intervals []Interval `json:"intervals,omitempty"`
+	// DowntimeSeconds is the sum of the durations of all the intervals.
+	DowntimeSeconds float64 `json:"downtimeSeconds"`
+}
+
+// CounterIncrease is a counter going up between two consecutive samples.
+type CounterIncrease struct {
+	Key      string    `json:"key"`
+	Time     time.Time `json:"time"`
+	Previous int       `json:"previous"`
+	Current  int       `json:"current"`
+}
+
+// KeyEvent is a key appearing in or disappearing from a sample.
+type KeyEvent struct {
+	Key  string    `json:"key"`
+	Time time.Time `json:"time"`
+}
+
+// Interval is a period in which key had an unhealthy state. It starts at the first sample with the state and ends at
+// the first sample without it. Ongoing intervals end at the last sample.
+type Interval struct {
+	Key             string    `json:"key"`
+	State           string    `json:"state"`
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	Ongoing         bool      `json:"ongoing"`
+	DurationSeconds float64   `json:"durationSeconds"`
+	Reason          string    `json:"reason,omitempty"`
+}
+
+// Changed returns whether anything changed within the file.
+func (report *StabilityReport) Changed() bool {
+	return len(report.Increases) > 0 || len(report.Added) > 0 || len(report.Removed) > 0 || len(report.Intervals) > 0
+}
+
+// LoadStabilityFile parses a stability output file and reports every change within it.
+func LoadStabilityFile(path string) (*StabilityReport, error) {
+	glog.V(100).Infof("Loading stability output %s", path)
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	report, err := NewStabilityReport(path, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stability output %s: %w", path, err)
+	}
+
+	return report, nil
+}
+
+// NewStabilityReport parses stability output from reader and reports every change within it. The name is only used to
+// identify the report.
+func NewStabilityReport(name string, reader io.Reader) (*StabilityReport, error) {
+	records, err := readRecords(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no samples found")
+	}
+
+	kind := detectKind(records)
+
+	samples, err := parseSamples(kind, records)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(samples, func(a, b Sample) int { return a.Time.Compare(b.Time) })
+
+	report := &StabilityReport{
+		File:    name,
+		Kind:    kind,
+		Samples: len(samples),
+		Start:   samples[0].Time,
+		End:     samples[len(samples)-1].Time,
+	}
+
+	report.addKeyEvents(samples)
+
+	switch kind {
+	case SeriesPTP:
+		report.addIntervals(samples, func(state string) bool { return state == ptpSync })
+	case SeriesStates:
+		report.addIntervals(samples, func(state string) bool { return state == healthyState })
+	case SeriesCounters:
+		report.addIncreases(samples)
+	}
+
+	for _, interval := range report.Intervals {
+		report.DowntimeSeconds += interval.DurationSeconds
+	}
+
+	return report, nil
+}
+
+func readRecords(reader io.Reader) ([][]string, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+
+	var records [][]string
+
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		// Lines for an empty map are written as the timestamp followed by an empty column.
+		if len(record) == 2 && record[1] == "" {
+			record = record[:1]
+		}
+
+		records = append(records, record)
+	}
+}
+
+func detectKind(records [][]string) SeriesKind {
+	isPTP := true
+	isCounters := true
+
+	for _, record := range records {
+		if len(record) != 3 || (record[1] != ptpSync && record[1] != ptpUnsync) {
+			isPTP = false
+		}
+
+		for index := 2; index < len(record); index += 2 {
+			if _, err := strconv.Atoi(record[index]); err != nil {
+				isCounters = false
+			}
+		}
+	}
+
+	switch {
+	case isPTP:
+		return SeriesPTP
+	case isCounters:
+		return SeriesCounters
+	default:
+		return SeriesStates
+	}
+}
+
+func parseSamples(kind SeriesKind, records [][]string) ([]Sample, error) {
+	var samples []Sample
+
+	for lineNumber, record := range records {
+		sampleTime, err := time.Parse(time.RFC3339, record[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid timestamp %q: %w", lineNumber+1, record[0], err)
+		}
+
+		sample := Sample{Time: sampleTime, Values: make(map[string]string)}
+
+		if kind == SeriesPTP {
+			sample.Values[string(SeriesPTP)] = record[1]
+			sample.Values[ptpReasonKey] = record[2]
+			samples = append(samples, sample)
+
+			continue
+		}
+
+		if len(record)%2 != 1 {
+			return nil, fmt.Errorf("line %d: expected key and value pairs after the timestamp", lineNumber+1)
+		}
+
+		for index := 1; index < len(record); index += 2 {
+			sample.Values[record[index]] = record[index+1]
+		}
+
+		samples = append(samples, sample)
+	}
+
+	return samples, nil
+}
+
+// ptpReasonKey holds the error of a PTP sample. It cannot collide with keys since PTP samples have a single key.
+const ptpReasonKey = "reason"
+
+// addKeyEvents records keys that appear or disappear between consecutive samples.
+func (report *StabilityReport) addKeyEvents(samples []Sample) {
+	if report.Kind == SeriesPTP {
+		return
+	}
+
+	for index := 1; index < len(samples); index++ {
+		previous, current := samples[index-1], samples[index]
+
+		for _, key := range sortedKeys(current.Values) {
+			if _, ok := previous.Values[key]; !ok {
+				report.Added = append(report.Added, KeyEvent{Key: key, Time: current.Time})
+			}
+		}
+
+		for _, key := range sortedKeys(previous.Values) {
+			if _, ok := current.Values[key]; !ok {
+				report.Removed = append(report.Removed, KeyEvent{Key: key, Time: current.Time})
+			}
+		}
+	}
+}
+
+// addIncreases records counters that go up between consecutive samples.
+func (report *StabilityReport) addIncreases(samples []Sample) {
+	for index := 1; index < len(samples); index++ {
+		previous, current := samples[index-1], samples[index]
+
+		for _, key := range sortedKeys(current.Values) {
+			previousValue, ok := previous.Values[key]
+			if !ok {
+				continue
+			}
+
+			// Values were validated to be integers when detecting the kind.
+			previousCount, _ := strconv.Atoi(previousValue)
+			currentCount, _ := strconv.Atoi(current.Values[key])
+
+			if currentCount > previousCount {
+				report.Increases = append(report.Increases, CounterIncrease{
+					Key: key, Time: current.Time, Previous: previousCount, Current: currentCount,
+				})
+			}
+		}
+	}
+}
+
+// addIntervals records the periods in which each key had a state for which healthy returns false.
+func (report *StabilityReport) addIntervals(samples []Sample, healthy func(state string) bool) {
+	openIntervals := make(map[string]*Interval)
+
+	closeInterval := func(key string, end time.Time, ongoing bool) {
+		interval := openIntervals[key]
+		interval.End = end
+		interval.Ongoing = ongoing
+		interval.DurationSeconds = end.Sub(interval.Start).Seconds()
+		report.Intervals = append(report.Intervals, *interval)
+
+		delete(openIntervals, key)
+	}
+
+	for _, sample := range samples {
+		for _, key := range sortedKeys(sample.Values) {
+			if key == ptpReasonKey && report.Kind == SeriesPTP {
+				continue
+			}
+
+			state := sample.Values[key]
+			interval, open := openIntervals[key]
+
+			if open && interval.State != state {
+				closeInterval(key, sample.Time, false)
+			}
+
+			if _, open = openIntervals[key]; !open && !healthy(state) {
+				openIntervals[key] = &Interval{Key: key, State: state, Start: sample.Time}
+
+				if reason := sample.Values[ptpReasonKey]; report.Kind == SeriesPTP && reason != ptpNoError {
+					openIntervals[key].Reason = reason
+				}
+			}
+		}
+
+		// Keys that disappeared end their intervals.
+		for _, key := range sortedKeys(openIntervals) {
+			if _, ok := sample.Values[key]; !ok {
+				closeInterval(key, sample.Time, false)
+			}
+		}
+	}
+
+	for _, key := range sortedKeys(openIntervals) {
+		closeInterval(key, report.End, true)
+	}
+
+	slices.SortStableFunc(report.Intervals, func(a, b Interval) int {
+		if compared := a.Start.Compare(b.Start); compared != 0 {
+			return compared
+		}
+
+		return strings.Compare(a.Key, b.Key)
+	})
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,111 @@
+/*
+Summary is a tool to aggregate the reports of every test suite run into a single run summary. It reads all the JUnit
+(*_junit.xml) and reportxml (*_testrun.xml) reports in the input directory and saves summary.json and summary.html to
+the output directory. The summary contains pass, fail, and skip counts and durations per suite, per label, and per
+reportxml ID, along with links to the failed_* dump directories of failed specs.
+
+Upon successful generation of the summary the exit code is 0. If any error occurs it will be logged to stderr and the
+exit code will be 1. Failed specs do not affect the exit code.
+
+Usage:
+
+	summary [flags]
+
+The flags are:
+
+	-h, -help
+		Print this help message
+
+	-i, -input string
+		Directory containing the reports to summarize. Uses ECO_REPORTS_DUMP_DIR or /tmp/reports if left blank
+
+	-o, -output string
+		Directory to save the summary to. Uses the input directory if left blank
+
+	-v int
+		Log level verbosity for glog. Use 100 for logging all messages or leave blank for none
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/results"
+)
+
+const defaultReportsDir = "/tmp/reports"
+
+var (
+	help   bool
+	input  string
+	output string
+)
+
+//nolint:gochecknoinits // This is a main package so init is fine.
+func init() {
+	const (
+		helpUsage   = "Print this help message"
+		inputUsage  = "Directory containing the reports to summarize. Uses ECO_REPORTS_DUMP_DIR or /tmp/reports if left blank"
+		outputUsage = "Directory to save the summary to. Uses the input directory if left blank"
+
+		defaultHelp   = false
+		defaultInput  = ""
+		defaultOutput = ""
+
+		shorthand = " (shorthand)"
+	)
+
+	flag.BoolVar(&help, "help", defaultHelp, helpUsage)
+	flag.BoolVar(&help, "h", defaultHelp, helpUsage+shorthand)
+
+	flag.StringVar(&input, "input", defaultInput, inputUsage)
+	flag.StringVar(&input, "i", defaultInput, inputUsage+shorthand)
+
+	flag.StringVar(&output, "output", defaultOutput, outputUsage)
+	flag.StringVar(&output, "o", defaultOutput, outputUsage+shorthand)
+}
+
+func main() {
+	// Also send glog messages to stderr
+	_ = flag.Lookup("logtostderr").Value.Set("true")
+
+	flag.Parse()
+
+	if help {
+		flag.Usage()
+
+		return
+	}
+
+	if input == "" {
+		input = os.Getenv("ECO_REPORTS_DUMP_DIR")
+	}
+
+	if input == "" {
+		input = defaultReportsDir
+	}
+
+	if output == "" {
+		output = input
+	}
+
+	summary, err := results.Load(input)
+	if err != nil {
+		glog.Errorf("Failed to load reports from %s: %v", input, err)
+
+		os.Exit(1)
+	}
+
+	err = summary.WriteFiles(output)
+	if err != nil {
+		glog.Errorf("Failed to save summary to %s: %v", output, err)
+
+		os.Exit(1)
+	}
+
+	fmt.Printf("%d specs: %d passed, %d failed, %d skipped\n",
+		summary.Totals.Total, summary.Totals.Passed, summary.Totals.Failed, summary.Totals.Skipped)
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// AllFeatures is the feature name that selects every suite under the tests directory.
+	AllFeatures = "all"
+
+	suiteFileSuffix = "_suite_test.go"
+)
+
+// Suite is a single Ginkgo suite package selected to run.
+type Suite struct {
+	// Path is the directory of the suite package.
+	Path string `json:"path"`
+	// Feature is the feature through which the suite was selected.
+	Feature string `json:"feature"`
+	// Group is the directory of the feature containing the suite. Suites in the same group are run sequentially since
+	// they are likely to share cluster resources.
+	Group string `json:"group"`
+}
+
+// ResolveSuites returns every suite under testsDir that belongs to one of features and none of excluded, sorted by
+// path. Features are matched against directory names anywhere under testsDir, except in internal directories. It is an
+// error for any feature, included or excluded, to match no directories so typos do not go unnoticed.
+func ResolveSuites(testsDir string, features, excluded []string) ([]Suite, error) {
+	glog.V(100).Infof("Resolving features %v excluding %v under %s", features, excluded, testsDir)
+
+	if len(features) == 0 {
+		return nil, fmt.Errorf("no features provided")
+	}
+
+	featureDirs, err := findFeatureDirs(testsDir, features)
+	if err != nil {
+		return nil, err
+	}
+
+	excludedDirs, err := findFeatureDirs(testsDir, excluded)
+	if err != nil {
+		return nil, err
+	}
+
+	var suites []Suite
+
+	for _, featureDir := range featureDirs {
+		suiteDirs, err := findSuiteDirs(featureDir.path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, suiteDir := range suiteDirs {
+			if isUnderAny(suiteDir, excludedDirs) || slices.ContainsFunc(suites, func(suite Suite) bool {
+				return suite.Path == suiteDir
+			}) {
+				continue
+			}
+
+			suites = append(suites, Suite{
+				Path:    suiteDir,
+				Feature: featureDir.feature,
+				Group:   suiteGroup(testsDir, featureDir, suiteDir),
+			})
+		}
+	}
+
+	if len(suites) == 0 {
+		return nil, fmt.Errorf("no suites found for features %v excluding %v", features, excluded)
+	}
+
+	slices.SortFunc(suites, func(a, b Suite) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return suites, nil
+}
+
+type featureDir struct {
+	feature string
+	path    string
+}
+
+// findFeatureDirs returns the directories matching each feature. The AllFeatures feature matches testsDir itself.
+func findFeatureDirs(testsDir string, features []string) ([]featureDir, error) {
+	var (
+		featureDirs []featureDir
+		missing     []string
+	)
+
+	for _, feature := range features {
+		if feature == AllFeatures {
+			featureDirs = append(featureDirs, featureDir{feature: feature, path: testsDir})
+
+			continue
+		}
+
+		matches, err := findDirsNamed(testsDir, feature)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(matches) == 0 {
+			missing = append(missing, feature)
+
+			continue
+		}
+
+		for _, match := range matches {
+			featureDirs = append(featureDirs, featureDir{feature: feature, path: match})
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("could not find any directories for features %v", missing)
+	}
+
+	return featureDirs, nil
+}
+
+// findDirsNamed returns every directory under root named name, skipping internal directories.
+func findDirsNamed(root, name string) ([]string, error) {
+	var matches []string
+
+	err := filepath.WalkDir(root, func(path string, dirEntry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !dirEntry.IsDir() {
+			return nil
+		}
+
+		if dirEntry.Name() == "internal" {
+			return filepath.SkipDir
+		}
+
+		if dirEntry.Name() == name && path != root {
+			matches = append(matches, path)
+		}
+
+		return nil
+	})
+
+	return matches, err
+}
+
+// findSuiteDirs returns every directory under root, including root, containing a Ginkgo suite file.
+func findSuiteDirs(root string) ([]string, error) {
+	var suiteDirs []string
+
+	err := filepath.WalkDir(root, func(path string, dirEntry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if dirEntry.IsDir() && dirEntry.Name() == "internal" {
+			return filepath.SkipDir
+		}
+
+		if !dirEntry.IsDir() && strings.HasSuffix(dirEntry.Name(), suiteFileSuffix) {
+			suiteDir := filepath.Dir(path)
+			if !slices.Contains(suiteDirs, suiteDir) {
+				suiteDirs = append(suiteDirs, suiteDir)
+			}
+		}
+
+		return nil
+	})
+
+	return suiteDirs, err
+}
+
+// suiteGroup returns the group of a suite. For the AllFeatures feature, suites are grouped by the first two directories
+// under testsDir, such as cnf/ran, since there is no narrower feature directory.
+func suiteGroup(testsDir string, feature featureDir, suiteDir string) string {
+	if feature.feature != AllFeatures {
+		return feature.path
+	}
+
+	relativePath, err := filepath.Rel(testsDir, suiteDir)
+	if err != nil {
+		return suiteDir
+	}
+
+	elements := strings.Split(relativePath, string(filepath.Separator))
+	if len(elements) > 2 {
+		elements = elements[:2]
+	}
+
+	return filepath.Join(append([]string{testsDir}, elements...)...)
+}
+
+func isUnderAny(path string, dirs []featureDir) bool {
+	for _, dir := range dirs {
+		if path == dir.path || strings.HasPrefix(path, dir.path+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
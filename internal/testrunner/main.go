@@ -0,0 +1,356 @@
+/*
+Testrunner is a tool to run the Ginkgo test suites selected by feature. It resolves features to suite packages, runs
+each suite with its own timeout, optionally runs independent suites in parallel and retries only the failed specs, then
+saves a manifest of everything that was executed.
+
+Features are matched against directory names anywhere under the tests directory, excluding internal directories. The
+feature "all" selects every suite. If any included or excluded feature matches no directories, the tool exits without
+running anything. Suites selected through the same feature directory are considered dependent and always run
+sequentially. With "all", suites are grouped by the first two directories under the tests directory instead.
+
+Every flag defaults to the value of the environment variable listed with it, so the variables used by the previous
+test-runner script keep working. Arguments after the flags are passed to ginkgo as is.
+
+Upon success the exit code is 0. If any suite fails after all retries, the exit code is 1. If the suites cannot be
+resolved or any other error occurs it will be logged to stderr and the exit code will be 2.
+
+Usage:
+
+	testrunner [flags] [-- ginkgo flags]
+
+The flags are:
+
+	-h, -help
+		Print this help message
+
+	-f, -features string
+		Space-separated list of features to run. Use "all" to run every suite. Env: ECO_TEST_FEATURES
+
+	-x, -exclude string
+		Space-separated list of features to skip. Env: ECO_TEST_EXCLUDE_FEATURES
+
+	-l, -labels string
+		Ginkgo label filter query. Env: ECO_TEST_LABELS
+
+	-t, -timeout duration
+		Ginkgo timeout for each suite. Env: ECO_TEST_TIMEOUT (default 24h)
+
+	-suite-timeouts string
+		Comma-separated list of suite directory or feature names and timeouts overriding -t, for example
+		"ptp=6h,sriov=2h". Env: ECO_TEST_SUITE_TIMEOUTS
+
+	-p, -parallel int
+		Number of independent suite groups to run at the same time. Env: ECO_TEST_PARALLEL (default 1)
+
+	-r, -retries int
+		Number of times to rerun only the failed specs of a suite. Env: ECO_TEST_RETRIES (default 0)
+
+	-m, -manifest string
+		File to save the run manifest to. Env: ECO_TEST_MANIFEST (default $ECO_REPORTS_DUMP_DIR/run_manifest.json)
+
+	-n, -dry-run
+		Print the ginkgo commands without running them
+
+	-ginkgo string
+		Path to the ginkgo binary. Env: ECO_TEST_GINKGO (default "ginkgo", searched in PATH and $GOPATH/bin)
+
+	-tests-dir string
+		Directory containing the test suites (default "./tests")
+
+	-vv
+		Run ginkgo with verbose output. Env: ECO_TEST_VERBOSE
+
+	-trace
+		Include full stack traces in ginkgo failures. Env: ECO_TEST_TRACE
+
+	-v int
+		Log level verbosity for glog. Use 100 for logging all messages or leave blank for none. Setting
+		ECO_VERBOSE_SCRIPT to true prints the resolved suites
+*/
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+const (
+	exitFailed = 1
+	exitError  = 2
+)
+
+var (
+	help          bool
+	features      string
+	exclude       string
+	labels        string
+	timeout       time.Duration
+	suiteTimeouts string
+	parallel      int
+	retries       int
+	manifestFile  string
+	dryRun        bool
+	ginkgoPath    string
+	testsDir      string
+	verbose       bool
+	trace         bool
+)
+
+//nolint:gochecknoinits // This is a main package so init is fine.
+func init() {
+	const (
+		helpUsage          = "Print this help message"
+		featuresUsage      = "Space-separated list of features to run. Use \"all\" to run every suite. Env: ECO_TEST_FEATURES"
+		excludeUsage       = "Space-separated list of features to skip. Env: ECO_TEST_EXCLUDE_FEATURES"
+		labelsUsage        = "Ginkgo label filter query. Env: ECO_TEST_LABELS"
+		timeoutUsage       = "Ginkgo timeout for each suite. Env: ECO_TEST_TIMEOUT"
+		suiteTimeoutsUsage = "Comma-separated list of suite or feature names and timeouts overriding -t, for example " +
+			"\"ptp=6h,sriov=2h\". Env: ECO_TEST_SUITE_TIMEOUTS"
+		parallelUsage = "Number of independent suite groups to run at the same time. Env: ECO_TEST_PARALLEL"
+		retriesUsage  = "Number of times to rerun only the failed specs of a suite. Env: ECO_TEST_RETRIES"
+		manifestUsage = "File to save the run manifest to. Env: ECO_TEST_MANIFEST"
+		dryRunUsage   = "Print the ginkgo commands without running them"
+		ginkgoUsage   = "Path to the ginkgo binary. Env: ECO_TEST_GINKGO"
+		testsDirUsage = "Directory containing the test suites"
+		verboseUsage  = "Run ginkgo with verbose output. Env: ECO_TEST_VERBOSE"
+		traceUsage    = "Include full stack traces in ginkgo failures. Env: ECO_TEST_TRACE"
+
+		defaultHelp     = false
+		defaultDryRun   = false
+		defaultTestsDir = "./tests"
+
+		shorthand = " (shorthand)"
+	)
+
+	defaultFeatures := os.Getenv("ECO_TEST_FEATURES")
+	defaultExclude := os.Getenv("ECO_TEST_EXCLUDE_FEATURES")
+	defaultLabels := os.Getenv("ECO_TEST_LABELS")
+	defaultTimeout := envDuration("ECO_TEST_TIMEOUT", 24*time.Hour)
+	defaultSuiteTimeouts := os.Getenv("ECO_TEST_SUITE_TIMEOUTS")
+	defaultParallel := envInt("ECO_TEST_PARALLEL", 1)
+	defaultRetries := envInt("ECO_TEST_RETRIES", 0)
+	defaultManifest := envString("ECO_TEST_MANIFEST", filepath.Join(reportsDir(), "run_manifest.json"))
+	defaultGinkgo := envString("ECO_TEST_GINKGO", "ginkgo")
+	defaultVerbose := os.Getenv("ECO_TEST_VERBOSE") == "true"
+	defaultTrace := os.Getenv("ECO_TEST_TRACE") == "true"
+
+	flag.BoolVar(&help, "help", defaultHelp, helpUsage)
+	flag.BoolVar(&help, "h", defaultHelp, helpUsage+shorthand)
+
+	flag.StringVar(&features, "features", defaultFeatures, featuresUsage)
+	flag.StringVar(&features, "f", defaultFeatures, featuresUsage+shorthand)
+
+	flag.StringVar(&exclude, "exclude", defaultExclude, excludeUsage)
+	flag.StringVar(&exclude, "x", defaultExclude, excludeUsage+shorthand)
+
+	flag.StringVar(&labels, "labels", defaultLabels, labelsUsage)
+	flag.StringVar(&labels, "l", defaultLabels, labelsUsage+shorthand)
+
+	flag.DurationVar(&timeout, "timeout", defaultTimeout, timeoutUsage)
+	flag.DurationVar(&timeout, "t", defaultTimeout, timeoutUsage+shorthand)
+
+	flag.StringVar(&suiteTimeouts, "suite-timeouts", defaultSuiteTimeouts, suiteTimeoutsUsage)
+
+	flag.IntVar(&parallel, "parallel", defaultParallel, parallelUsage)
+	flag.IntVar(&parallel, "p", defaultParallel, parallelUsage+shorthand)
+
+	flag.IntVar(&retries, "retries", defaultRetries, retriesUsage)
+	flag.IntVar(&retries, "r", defaultRetries, retriesUsage+shorthand)
+
+	flag.StringVar(&manifestFile, "manifest", defaultManifest, manifestUsage)
+	flag.StringVar(&manifestFile, "m", defaultManifest, manifestUsage+shorthand)
+
+	flag.BoolVar(&dryRun, "dry-run", defaultDryRun, dryRunUsage)
+	flag.BoolVar(&dryRun, "n", defaultDryRun, dryRunUsage+shorthand)
+
+	flag.StringVar(&ginkgoPath, "ginkgo", defaultGinkgo, ginkgoUsage)
+	flag.StringVar(&testsDir, "tests-dir", defaultTestsDir, testsDirUsage)
+	flag.BoolVar(&verbose, "vv", defaultVerbose, verboseUsage)
+	flag.BoolVar(&trace, "trace", defaultTrace, traceUsage)
+}
+
+func main() {
+	// Also send glog messages to stderr
+	_ = flag.Lookup("logtostderr").Value.Set("true")
+
+	flag.Parse()
+
+	if help {
+		flag.Usage()
+
+		return
+	}
+
+	os.Exit(run())
+}
+
+func run() int {
+	parsedSuiteTimeouts, err := ParseSuiteTimeouts(suiteTimeouts)
+	if err != nil {
+		glog.Errorf("Failed to parse suite timeouts %q: %v", suiteTimeouts, err)
+
+		return exitError
+	}
+
+	suites, err := ResolveSuites(testsDir, strings.Fields(features), strings.Fields(exclude))
+	if err != nil {
+		glog.Errorf("Failed to resolve suites: %v", err)
+
+		return exitError
+	}
+
+	if os.Getenv("ECO_VERBOSE_SCRIPT") == "true" {
+		fmt.Println("Found suites:")
+
+		for _, suite := range suites {
+			fmt.Printf("%s (feature %s)\n", suite.Path, suite.Feature)
+		}
+	}
+
+	workDir, err := os.MkdirTemp("", "eco-testrunner-")
+	if err != nil {
+		glog.Errorf("Failed to create directory for ginkgo reports: %v", err)
+
+		return exitError
+	}
+
+	defer os.RemoveAll(workDir)
+
+	runner := &Runner{
+		Ginkgo:        findGinkgo(ginkgoPath),
+		LabelFilter:   labels,
+		Timeout:       timeout,
+		SuiteTimeouts: parsedSuiteTimeouts,
+		Retries:       retries,
+		Parallel:      parallel,
+		Verbose:       verbose,
+		Trace:         trace,
+		ExtraArgs:     flag.Args(),
+		DryRun:        dryRun,
+		WorkDir:       workDir,
+		Output:        os.Stdout,
+	}
+
+	manifest := &RunManifest{
+		Started:         time.Now(),
+		Features:        strings.Fields(features),
+		ExcludeFeatures: strings.Fields(exclude),
+		LabelFilter:     labels,
+		Parallel:        parallel,
+		Retries:         retries,
+		Suites:          make([]SuiteRun, len(suites)),
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	manifest.Passed = runner.Run(ctx, suites, manifest)
+	manifest.Finished = time.Now()
+
+	if !dryRun {
+		err = manifest.Save(manifestFile)
+		if err != nil {
+			glog.Errorf("Failed to save run manifest to %s: %v", manifestFile, err)
+
+			return exitError
+		}
+
+		fmt.Printf("Run manifest saved to %s\n", manifestFile)
+	}
+
+	if !manifest.Passed && !dryRun {
+		return exitFailed
+	}
+
+	return 0
+}
+
+// ParseSuiteTimeouts parses a comma-separated list of name=duration pairs into a map.
+func ParseSuiteTimeouts(list string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+
+	for _, pair := range strings.Split(list, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, value, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("missing = in %q", pair)
+		}
+
+		duration, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, err
+		}
+
+		timeouts[strings.TrimSpace(name)] = duration
+	}
+
+	return timeouts, nil
+}
+
+// findGinkgo returns the ginkgo binary to use. If name is not in PATH, the ginkgo in $GOPATH/bin is used if it exists.
+func findGinkgo(name string) string {
+	if _, err := exec.LookPath(name); err == nil || strings.ContainsRune(name, filepath.Separator) {
+		return name
+	}
+
+	goPath := os.Getenv("GOPATH")
+	if goPath == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return name
+		}
+
+		goPath = filepath.Join(home, "go")
+	}
+
+	goPathGinkgo := filepath.Join(goPath, "bin", name)
+	if _, err := os.Stat(goPathGinkgo); err == nil {
+		return goPathGinkgo
+	}
+
+	return name
+}
+
+func reportsDir() string {
+	return envString("ECO_REPORTS_DUMP_DIR", "/tmp/reports")
+}
+
+func envString(name, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+
+	return defaultValue
+}
+
+func envInt(name string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func envDuration(name string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(name))
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
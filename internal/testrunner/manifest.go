@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Attempt is a single execution of ginkgo for a suite.
+type Attempt struct {
+	Command  []string  `json:"command"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	ExitCode int       `json:"exitCode"`
+	// Focus contains the full text of the specs this attempt was limited to. It is empty for the first attempt.
+	Focus []string `json:"focus,omitempty"`
+	// FailedSpecs contains the full text of every It spec that failed in this attempt.
+	FailedSpecs []string `json:"failedSpecs,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// SuiteRun contains every attempt to run a suite.
+type SuiteRun struct {
+	Suite
+	Timeout  string    `json:"timeout"`
+	Attempts []Attempt `json:"attempts"`
+	Passed   bool      `json:"passed"`
+}
+
+// RunManifest records what was executed by the test runner and the outcome of each suite.
+type RunManifest struct {
+	Started         time.Time  `json:"started"`
+	Finished        time.Time  `json:"finished"`
+	Features        []string   `json:"features"`
+	ExcludeFeatures []string   `json:"excludeFeatures,omitempty"`
+	LabelFilter     string     `json:"labelFilter,omitempty"`
+	Parallel        int        `json:"parallel"`
+	Retries         int        `json:"retries"`
+	Suites          []SuiteRun `json:"suites"`
+	Passed          bool       `json:"passed"`
+
+	mutex sync.Mutex
+}
+
+// SetSuiteRun stores suiteRun at index in a way that is safe to call from multiple goroutines.
+func (manifest *RunManifest) SetSuiteRun(index int, suiteRun SuiteRun) {
+	manifest.mutex.Lock()
+	defer manifest.mutex.Unlock()
+
+	manifest.Suites[index] = suiteRun
+}
+
+// Save writes the manifest as indented JSON to fileName, creating its directory if it does not exist.
+func (manifest *RunManifest) Save(fileName string) error {
+	manifest.mutex.Lock()
+	defer manifest.mutex.Unlock()
+
+	err := os.MkdirAll(filepath.Dir(fileName), 0755)
+	if err != nil {
+		return err
+	}
+
+	contents, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(fileName, contents, 0644)
+}
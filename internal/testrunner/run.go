@@ -0,0 +1,373 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// timeoutGrace is how long after the ginkgo timeout the runner waits before killing ginkgo. Ginkgo needs some time
+// after its own timeout to run cleanup nodes and write reports.
+const timeoutGrace = 5 * time.Minute
+
+// reportAttemptEnv is the environment variable that tells the suites which attempt they are running as, so each attempt
+// writes its own JUnit report. It must be kept in sync with config.EnvReportAttempt in tests/internal/config.
+const reportAttemptEnv = "ECO_REPORT_ATTEMPT"
+
+// Runner executes ginkgo for each suite and retries failed specs.
+type Runner struct {
+	// Ginkgo is the path to the ginkgo binary.
+	Ginkgo string
+	// LabelFilter is passed to ginkgo as --label-filter if not empty.
+	LabelFilter string
+	// Timeout is the ginkgo timeout for each suite unless overridden by SuiteTimeouts.
+	Timeout time.Duration
+	// SuiteTimeouts maps the name of a suite directory or feature onto its timeout.
+	SuiteTimeouts map[string]time.Duration
+	// Retries is the number of times to rerun the failed specs of a suite.
+	Retries int
+	// Parallel is the number of suite groups to run at the same time.
+	Parallel int
+	Verbose  bool
+	Trace    bool
+	// ExtraArgs are passed to ginkgo before the suite path.
+	ExtraArgs []string
+	// DryRun prints the ginkgo commands without running them.
+	DryRun bool
+	// WorkDir is the directory where ginkgo JSON reports are saved to find failed specs.
+	WorkDir string
+	// Output receives the output of ginkgo.
+	Output io.Writer
+
+	outputMutex sync.Mutex
+}
+
+// Run executes every suite and records the results in manifest, which must have one entry in Suites per suite. Suites
+// in the same group are run sequentially, while up to Parallel groups run at the same time. It returns whether all the
+// suites passed.
+func (runner *Runner) Run(ctx context.Context, suites []Suite, manifest *RunManifest) bool {
+	var (
+		groupOrder []string
+		groups     = make(map[string][]int)
+	)
+
+	for index, suite := range suites {
+		if _, ok := groups[suite.Group]; !ok {
+			groupOrder = append(groupOrder, suite.Group)
+		}
+
+		groups[suite.Group] = append(groups[suite.Group], index)
+	}
+
+	groupChannel := make(chan []int)
+	waitGroup := sync.WaitGroup{}
+
+	for range max(1, runner.Parallel) {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			for indices := range groupChannel {
+				for _, index := range indices {
+					manifest.SetSuiteRun(index, runner.runSuite(ctx, suites[index]))
+				}
+			}
+		}()
+	}
+
+	for _, group := range groupOrder {
+		groupChannel <- groups[group]
+	}
+
+	close(groupChannel)
+	waitGroup.Wait()
+
+	for _, suiteRun := range manifest.Suites {
+		if !suiteRun.Passed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// runSuite runs a single suite, then reruns only the specs that failed up to Retries times.
+func (runner *Runner) runSuite(ctx context.Context, suite Suite) SuiteRun {
+	timeout := runner.suiteTimeout(suite)
+	suiteRun := SuiteRun{Suite: suite, Timeout: timeout.String()}
+
+	var focus []string
+
+	for attemptNumber := 0; attemptNumber <= runner.Retries; attemptNumber++ {
+		attempt := runner.runAttempt(ctx, suite, timeout, attemptNumber, focus)
+		suiteRun.Attempts = append(suiteRun.Attempts, attempt)
+
+		if attempt.ExitCode == 0 && attempt.Error == "" {
+			suiteRun.Passed = true
+
+			break
+		}
+
+		if len(attempt.FailedSpecs) == 0 || ctx.Err() != nil {
+			glog.V(100).Infof("Not retrying suite %s since no failed specs were found", suite.Path)
+
+			break
+		}
+
+		focus = attempt.FailedSpecs
+	}
+
+	return suiteRun
+}
+
+// runAttempt runs ginkgo once for suite. If focus is not empty, only the specs with those full texts are run.
+func (runner *Runner) runAttempt(
+	ctx context.Context, suite Suite, timeout time.Duration, attemptNumber int, focus []string) Attempt {
+	var focusArgs []string
+
+	if len(focus) > 0 {
+		// The first attempt always runs the whole suite, so its report has the suite description.
+		suiteDescription := readSuiteDescription(filepath.Join(runner.WorkDir, jsonReportName(suite, 0)))
+		focusArgs = FocusArgs(suiteDescription, focus)
+	}
+
+	reportName := jsonReportName(suite, attemptNumber)
+	args := runner.ginkgoArgs(timeout, runner.WorkDir, reportName, focusArgs)
+	args = append(args, packagePath(suite.Path))
+
+	attempt := Attempt{
+		Command: append([]string{runner.Ginkgo}, args...),
+		Started: time.Now(),
+		Focus:   focus,
+	}
+
+	runner.printf("%s\n", strings.Join(attempt.Command, " "))
+
+	if runner.DryRun {
+		attempt.Finished = attempt.Started
+
+		return attempt
+	}
+
+	cmdCtx, cancel := context.WithTimeout(ctx, timeout+timeoutGrace)
+	defer cancel()
+
+	output := runner.suiteOutput(suite)
+	defer output.Flush()
+
+	cmd := exec.CommandContext(cmdCtx, runner.Ginkgo, args...)
+	cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%d", reportAttemptEnv, attemptNumber))
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	err := cmd.Run()
+	attempt.Finished = time.Now()
+	attempt.ExitCode = -1
+
+	if cmd.ProcessState != nil {
+		attempt.ExitCode = cmd.ProcessState.ExitCode()
+	}
+
+	var exitError *exec.ExitError
+	if err != nil && !errors.As(err, &exitError) {
+		attempt.Error = err.Error()
+	}
+
+	if cmdCtx.Err() != nil {
+		attempt.Error = fmt.Sprintf("ginkgo did not exit within %s: %v", timeout+timeoutGrace, cmdCtx.Err())
+	}
+
+	reportPath := filepath.Join(runner.WorkDir, reportName)
+
+	attempt.FailedSpecs, err = ReadFailedSpecs(reportPath)
+	if err != nil {
+		glog.V(100).Infof("Failed to read failed specs from %s: %v", reportPath, err)
+	}
+
+	return attempt
+}
+
+// ginkgoArgs returns the arguments for ginkgo, excluding the suite path.
+func (runner *Runner) ginkgoArgs(timeout time.Duration, outputDir, reportName string, focusArgs []string) []string {
+	args := []string{
+		"--timeout=" + timeout.String(),
+		"--keep-going",
+		"--require-suite",
+		"--output-dir=" + outputDir,
+		"--json-report=" + reportName,
+	}
+
+	if runner.Verbose {
+		args = append(args, "-vv")
+	}
+
+	if runner.Trace {
+		args = append(args, "--trace")
+	}
+
+	if runner.LabelFilter != "" {
+		args = append(args, "--label-filter="+runner.LabelFilter)
+	}
+
+	args = append(args, focusArgs...)
+
+	return append(args, runner.ExtraArgs...)
+}
+
+// suiteTimeout returns the timeout for suite, preferring a timeout for the suite directory name over one for its
+// feature.
+func (runner *Runner) suiteTimeout(suite Suite) time.Duration {
+	if timeout, ok := runner.SuiteTimeouts[filepath.Base(suite.Path)]; ok {
+		return timeout
+	}
+
+	if timeout, ok := runner.SuiteTimeouts[suite.Feature]; ok {
+		return timeout
+	}
+
+	return runner.Timeout
+}
+
+func (runner *Runner) printf(format string, args ...any) {
+	runner.outputMutex.Lock()
+	defer runner.outputMutex.Unlock()
+
+	fmt.Fprintf(runner.Output, format, args...)
+}
+
+// suiteOutput returns the writer for the output of suite. When suites run in parallel, each line is prefixed with the
+// suite directory name so interleaved output can be told apart.
+func (runner *Runner) suiteOutput(suite Suite) *prefixWriter {
+	prefix := ""
+	if runner.Parallel > 1 {
+		prefix = fmt.Sprintf("[%s] ", filepath.Base(suite.Path))
+	}
+
+	return &prefixWriter{runner: runner, prefix: prefix}
+}
+
+// prefixWriter writes complete lines to the runner output with a prefix.
+type prefixWriter struct {
+	runner *Runner
+	prefix string
+	buffer bytes.Buffer
+}
+
+// Write buffers p and writes every complete line in the buffer to the runner output.
+func (writer *prefixWriter) Write(p []byte) (int, error) {
+	writer.buffer.Write(p)
+
+	for {
+		line, err := writer.buffer.ReadString('\n')
+		if err != nil {
+			writer.buffer.WriteString(line)
+
+			break
+		}
+
+		writer.runner.printf("%s%s", writer.prefix, line)
+	}
+
+	return len(p), nil
+}
+
+// Flush writes any remaining partial line to the runner output.
+func (writer *prefixWriter) Flush() {
+	if writer.buffer.Len() > 0 {
+		writer.runner.printf("%s%s\n", writer.prefix, writer.buffer.String())
+		writer.buffer.Reset()
+	}
+}
+
+// packagePath returns path in a form ginkgo treats as a directory rather than an import path.
+func packagePath(path string) string {
+	if filepath.IsAbs(path) || strings.HasPrefix(path, ".") {
+		return path
+	}
+
+	return "." + string(filepath.Separator) + path
+}
+
+// jsonReportName returns the name of the ginkgo JSON report for an attempt to run suite.
+func jsonReportName(suite Suite, attemptNumber int) string {
+	return fmt.Sprintf("%s_attempt%d.json", strings.ReplaceAll(filepath.Clean(suite.Path), "/", "_"), attemptNumber)
+}
+
+// FocusArgs returns the ginkgo --focus arguments that select exactly the specs with the provided full texts. Ginkgo
+// matches focus regexes against the suite description and spec full text separated by a space.
+func FocusArgs(suiteDescription string, fullTexts []string) []string {
+	var args []string
+
+	for _, fullText := range fullTexts {
+		// Without the suite description, the start of the regex cannot be anchored.
+		if suiteDescription == "" {
+			args = append(args, "--focus="+regexp.QuoteMeta(fullText)+"$")
+
+			continue
+		}
+
+		args = append(args, "--focus=^"+regexp.QuoteMeta(suiteDescription+" "+fullText)+"$")
+	}
+
+	return args
+}
+
+// ReadFailedSpecs returns the full text of every It spec that failed in the ginkgo JSON report at reportPath.
+func ReadFailedSpecs(reportPath string) ([]string, error) {
+	reports, err := readReports(reportPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var failedSpecs []string
+
+	for _, report := range reports {
+		specs := report.SpecReports.WithLeafNodeType(types.NodeTypeIt).WithState(types.SpecStateFailureStates)
+		for _, spec := range specs {
+			failedSpecs = append(failedSpecs, spec.FullText())
+		}
+	}
+
+	return failedSpecs, nil
+}
+
+// readSuiteDescription returns the description of the first suite in the ginkgo JSON report at reportPath, or an
+// empty string if it cannot be read.
+func readSuiteDescription(reportPath string) string {
+	reports, err := readReports(reportPath)
+	if err != nil || len(reports) == 0 {
+		return ""
+	}
+
+	return reports[0].SuiteDescription
+}
+
+func readReports(reportPath string) ([]types.Report, error) {
+	contents, err := os.ReadFile(reportPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []types.Report
+
+	err = json.Unmarshal(contents, &reports)
+	if err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
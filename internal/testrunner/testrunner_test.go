@@ -0,0 +1,142 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveSuites(t *testing.T) {
+	testsDir := t.TempDir()
+
+	for _, suiteDir := range []string{
+		"cnf/ran/ptp", "cnf/ran/powermanagement", "cnf/core/network/sriov", "cnf/core/network/metallb",
+		"cnf/ran/internal/fake",
+	} {
+		err := os.MkdirAll(filepath.Join(testsDir, suiteDir), 0755)
+		assert.Nil(t, err)
+
+		err = os.WriteFile(
+			filepath.Join(testsDir, suiteDir, filepath.Base(suiteDir)+suiteFileSuffix), []byte("package x"), 0644)
+		assert.Nil(t, err)
+	}
+
+	testCases := []struct {
+		features       []string
+		excluded       []string
+		expectedPaths  []string
+		expectedGroups []string
+		expectedError  bool
+	}{
+		{
+			features:       []string{"ran"},
+			expectedPaths:  []string{"cnf/ran/powermanagement", "cnf/ran/ptp"},
+			expectedGroups: []string{"cnf/ran", "cnf/ran"},
+		},
+		{
+			features:       []string{"network"},
+			excluded:       []string{"metallb"},
+			expectedPaths:  []string{"cnf/core/network/sriov"},
+			expectedGroups: []string{"cnf/core/network"},
+		},
+		{
+			features: []string{"all"},
+			expectedPaths: []string{
+				"cnf/core/network/metallb", "cnf/core/network/sriov", "cnf/ran/powermanagement", "cnf/ran/ptp"},
+			expectedGroups: []string{"cnf/core", "cnf/core", "cnf/ran", "cnf/ran"},
+		},
+		{
+			features:      []string{"ran", "tpyo"},
+			expectedError: true,
+		},
+		{
+			features:      []string{"ran"},
+			excluded:      []string{"tpyo"},
+			expectedError: true,
+		},
+		{
+			features:      []string{"fake"},
+			expectedError: true,
+		},
+		{
+			features:      []string{"ptp"},
+			excluded:      []string{"ptp"},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		suites, err := ResolveSuites(testsDir, testCase.features, testCase.excluded)
+		assert.Equal(t, testCase.expectedError, err != nil)
+
+		var paths, groups []string
+
+		for _, suite := range suites {
+			paths = append(paths, filepath.ToSlash(must(filepath.Rel(testsDir, suite.Path))))
+			groups = append(groups, filepath.ToSlash(must(filepath.Rel(testsDir, suite.Group))))
+		}
+
+		assert.Equal(t, testCase.expectedPaths, paths)
+		assert.Equal(t, testCase.expectedGroups, groups)
+	}
+}
+
+func TestFocusArgs(t *testing.T) {
+	args := FocusArgs("sriov", []string{"SriovBasic creates a VF (IPv4)", "SriovBasic deletes a VF"})
+	assert.Equal(t, []string{
+		`--focus=^sriov SriovBasic creates a VF \(IPv4\)$`,
+		`--focus=^sriov SriovBasic deletes a VF$`,
+	}, args)
+
+	focus := regexp.MustCompile(args[0][len("--focus="):])
+	assert.True(t, focus.MatchString("sriov SriovBasic creates a VF (IPv4)"))
+	assert.False(t, focus.MatchString("sriov SriovBasic creates a VF (IPv4) twice"))
+
+	assert.Equal(t, []string{`--focus=spec$`}, FocusArgs("", []string{"spec"}))
+}
+
+func TestParseSuiteTimeouts(t *testing.T) {
+	testCases := []struct {
+		list             string
+		expectedTimeouts map[string]time.Duration
+		expectedError    bool
+	}{
+		{
+			list:             "",
+			expectedTimeouts: map[string]time.Duration{},
+		},
+		{
+			list:             "ptp=6h, sriov = 90m",
+			expectedTimeouts: map[string]time.Duration{"ptp": 6 * time.Hour, "sriov": 90 * time.Minute},
+		},
+		{
+			list:          "ptp",
+			expectedError: true,
+		},
+		{
+			list:          "ptp=forever",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		timeouts, err := ParseSuiteTimeouts(testCase.list)
+		assert.Equal(t, testCase.expectedError, err != nil)
+
+		if err == nil {
+			assert.Equal(t, testCase.expectedTimeouts, timeouts)
+		}
+	}
+}
+
+func must(path string, err error) string {
+	if err != nil {
+		panic(err)
+	}
+
+	return path
+}
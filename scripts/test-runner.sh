@@ -1,59 +1,8 @@
 #!/usr/bin/env bash
 
-GOPATH="${GOPATH:-~/go}"
-PATH=$PATH:$GOPATH/bin
-TEST_DIR="./tests"
+# The test-runner is implemented in internal/testrunner. This script is kept so existing jobs calling it keep working.
+# All ECO_TEST_* environment variables are read by the test-runner itself and arguments are passed through to ginkgo.
 
-# Check that ECO_TEST_FEATURES environment variable has been set
-if [[ -z "${ECO_TEST_FEATURES}" ]]; then
-    echo "ECO_TEST_FEATURES environment variable is undefined"
-    exit 1
-fi
+cd "$(dirname "$0")/.." || exit 1
 
-# Set feature_dirs to top-level test directory when "all" feature provided
-if [[ "${ECO_TEST_FEATURES}" == "all" ]]; then
-    feature_dirs=${TEST_DIR}
-else
-    # Find all test directories matching provided features
-    for feature in ${ECO_TEST_FEATURES}; do
-        discovered_features=$(find $TEST_DIR -depth -name "${feature}" -not -path '*/internal/*' 2> /dev/null)
-        if [[ ! -z $discovered_features ]]; then
-            feature_dirs+=" "$discovered_features
-        else
-            if [[ "${ECO_VERBOSE_SCRIPT}" == "true" ]]; then
-                echo "Could not find any feature directories matching ${feature}"
-            fi
-        fi
-    done
-
-    if [[ -z "${feature_dirs}" ]]; then
-        echo "Could not find any feature directories for provided features: ${ECO_TEST_FEATURES}"
-        exit 1
-    fi
-
-    if [[ "${ECO_VERBOSE_SCRIPT}" == "true" ]]; then
-        echo "Found feature directories:"
-        for directory in $feature_dirs; do printf "$directory\n"; done
-    fi
-fi
-
-
-# Build ginkgo command
-cmd="ginkgo -timeout=24h --keep-going --require-suite -r"
-
-if [[ "${ECO_TEST_VERBOSE}" == "true" ]]; then
-    cmd+=" -vv"
-fi
-
-if [[ "${ECO_TEST_TRACE}" == "true" ]]; then
-    cmd+=" --trace"
-fi
-
-if [[ ! -z "${ECO_TEST_LABELS}" ]]; then
-    cmd+=" --label-filter=\"${ECO_TEST_LABELS}\""
-fi
-cmd+=" "$feature_dirs" $@"   # + user args --xxx=yyy...
-
-# Execute ginkgo command
-echo $cmd
-eval $cmd
+exec go run ./internal/testrunner -- "$@"
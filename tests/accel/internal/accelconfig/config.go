@@ -2,15 +2,12 @@ package accelconfig
 
 import (
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 
 	"github.com/golang/glog"
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -42,14 +39,7 @@ func NewAccelConfig() *AccelConfig {
 	baseDir := filepath.Dir(filename)
 	configFile := filepath.Join(baseDir, PathToDefaultAccelParamsFile)
 
-	err := readFile(&accelConfig, configFile)
-	if err != nil {
-		glog.V(90).Infof("Error reading config file %s", configFile)
-
-		return nil
-	}
-
-	err = envconfig.Process("eco_accel_", &accelConfig)
+	err := config.LoadWithEnvPrefix(&accelConfig, configFile, "eco_accel_")
 	if err != nil {
 		log.Printf("failed to instantiate AccelConfig: %v", err)
 
@@ -69,20 +59,3 @@ func NewAccelConfig() *AccelConfig {
 
 	return &accelConfig
 }
-
-func readFile(accelConfig *AccelConfig, configFile string) error {
-	openedConfigFile, err := os.Open(configFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedConfigFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedConfigFile)
-
-	err = decoder.Decode(&accelConfig)
-
-	return err
-}
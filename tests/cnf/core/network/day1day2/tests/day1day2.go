@@ -15,12 +15,12 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
 
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/day1day2/internal/day1day2env"
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/day1day2/internal/juniper"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/day1day2/internal/tsparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/cmd"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netnmstate"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netparam"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netswitch"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 )
@@ -30,7 +30,8 @@ var _ = Describe("Day1Day2", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 		workerNodeList   []*nodes.Builder
 		bondName         string
 		bondSlaves       []string
-		juniperSession   *juniper.JunosSession
+		labSwitch        netswitch.Switch
+		switchSnapshot   netswitch.Snapshot
 		switchInterfaces []string
 		switchLagNames   []string
 	)
@@ -54,13 +55,8 @@ var _ = Describe("Day1Day2", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 			Skip(fmt.Sprintf("Day1Day2 tests skipped. Cluster is not suitable due to: %s", err.Error()))
 		}
 
-		By("Getting switch credentials")
-		switchCredentials, err := juniper.NewSwitchCredentials()
-		Expect(err).ToNot(HaveOccurred(), "Failed to get switch credentials")
-
 		By("Opening management connection to switch")
-		juniperSession, err = juniper.NewSession(
-			switchCredentials.SwitchIP, switchCredentials.User, switchCredentials.Password)
+		labSwitch, err = netswitch.New(NetConfig)
 		Expect(err).ToNot(HaveOccurred(), "Failed to open a switch session")
 
 		By("Collecting switch interfaces")
@@ -73,9 +69,11 @@ var _ = Describe("Day1Day2", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 	})
 
 	AfterEach(func() {
-		if len(juniper.InterfaceConfigs) > 0 {
+		if len(switchSnapshot) > 0 {
 			By("Reverting initial switch interface configurations")
-			recoverSwitchConfiguration(juniperSession, switchInterfaces, switchLagNames)
+			recoverSwitchConfiguration(labSwitch, switchSnapshot, switchLagNames)
+
+			switchSnapshot = nil
 
 			By("Verifying workers are still available over the bond interface")
 			err := day1day2env.CheckConnectivityBetweenMasterAndWorkers()
@@ -92,13 +90,22 @@ var _ = Describe("Day1Day2", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 		Expect(err).ToNot(HaveOccurred(), "Failed to remove all NMState policies")
 	})
 
+	AfterAll(func() {
+		if labSwitch != nil {
+			By("Closing management connection to switch")
+			labSwitch.Close()
+		}
+	})
+
 	It("Day1: Validate cluster deployed via bond interface with 2 VFs enslaved and fail-over",
 		reportxml.ID("63928"), func() {
-			err := juniper.DumpInterfaceConfigs(juniperSession, switchInterfaces)
+			var err error
+
+			switchSnapshot, err = labSwitch.Snapshot(switchInterfaces...)
 			Expect(err).ToNot(HaveOccurred(), "Failed to save initial switch interfaces configs")
 
 			By("Testing Bond fail over scenario")
-			testBondFailOver(juniperSession, switchInterfaces)
+			testBondFailOver(labSwitch, switchInterfaces)
 		})
 
 	It("VF: change QOS configuration", reportxml.ID("63926"), func() {
@@ -195,24 +202,24 @@ var _ = Describe("Day1Day2", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 	})
 })
 
-func recoverSwitchConfiguration(juniperSession *juniper.JunosSession, switchInterfaces, lagInterfaces []string) {
-	err := juniper.RestoreSwitchInterfacesConfiguration(juniperSession, switchInterfaces)
+func recoverSwitchConfiguration(labSwitch netswitch.Switch, snapshot netswitch.Snapshot, lagInterfaces []string) {
+	err := labSwitch.Restore(snapshot)
 	Expect(err).ToNot(HaveOccurred(), "Failed to restore initial switch interfaces configurations")
 
-	err = juniper.DeleteInterfaces(juniperSession, lagInterfaces)
+	err = labSwitch.DeleteInterfaces(lagInterfaces...)
 	Expect(err).ToNot(HaveOccurred(), "Failed to delete switch LAG interfaces")
 }
 
-func waitForSwitchInterfaceUp(juniperSession *juniper.JunosSession, switchLagName string) {
+func waitForSwitchInterfaceUp(labSwitch netswitch.Switch, switchLagName string) {
 	Eventually(func() bool {
-		isBondInterfaceUp, err := juniper.IsSwitchInterfaceUp(juniperSession, switchLagName)
+		linkState, err := labSwitch.GetLinkState(switchLagName)
 		Expect(err).ToNot(HaveOccurred(), fmt.Sprintf("Failed to get status of switch LAG interface %s", switchLagName))
 
-		return isBondInterfaceUp
+		return linkState.OperUp
 	}, 1*time.Minute, 5*time.Second).Should(BeTrue(), "Bond interface is not Up on the switch")
 }
 
-func testBondFailOver(juniperSession *juniper.JunosSession, switchInterfaces []string) {
+func testBondFailOver(labSwitch netswitch.Switch, switchInterfaces []string) {
 	By("Verifying workers are still available over the bond interface")
 
 	err := day1day2env.CheckConnectivityBetweenMasterAndWorkers()
@@ -220,7 +227,7 @@ func testBondFailOver(juniperSession *juniper.JunosSession, switchInterfaces []s
 
 	By("Disabling one bond slave interface on the switch and check the traffic again via secondary bond interface")
 
-	err = juniper.DisableSwitchInterface(juniperSession, switchInterfaces[0])
+	err = labSwitch.DisableInterface(switchInterfaces[0])
 	Expect(err).ToNot(HaveOccurred(), fmt.Sprintf("Failed to shutdown switch interface %s", switchInterfaces[0]))
 
 	err = day1day2env.CheckConnectivityBetweenMasterAndWorkers()
@@ -229,14 +236,14 @@ func testBondFailOver(juniperSession *juniper.JunosSession, switchInterfaces []s
 	By(fmt.Sprintf("Disabling secondary LAG slave interface %s, bring first LAG slave interface %s back"+
 		" and check the traffic again", switchInterfaces[1], switchInterfaces[0]))
 
-	err = juniper.EnableSwitchInterface(juniperSession, switchInterfaces[0])
+	err = labSwitch.EnableInterface(switchInterfaces[0])
 	Expect(err).ToNot(HaveOccurred(),
 		fmt.Sprintf("Failed to turn on the switch interface %s", switchInterfaces[0]))
 
-	err = juniper.DisableSwitchInterface(juniperSession, switchInterfaces[1])
+	err = labSwitch.DisableInterface(switchInterfaces[1])
 	Expect(err).ToNot(HaveOccurred(), fmt.Sprintf("Failed to shutdown switch interface %s", switchInterfaces[1]))
 
-	waitForSwitchInterfaceUp(juniperSession, switchInterfaces[0])
+	waitForSwitchInterfaceUp(labSwitch, switchInterfaces[0])
 
 	By("Verifying workers are still available over the bond interface")
 
@@ -20,11 +20,11 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/sriov"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/dpdk/internal/link"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/dpdk/internal/tsparams"
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/cmd"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/define"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netenv"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netparam"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/testpmd"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
 	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/types"
 	corev1 "k8s.io/api/core/v1"
@@ -253,8 +253,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 				sleepCMD,
 			)
 
-			err = cmd.RxTrafficOnClientPod(clientPod, defineTestPmdCmd(tapOneInterfaceName,
-				"${PCIDEVICE_OPENSHIFT_IO_DPDKPOLICYONE}"))
+			err = rxTrafficOnClientPod(clientPod, tapOneInterfaceName, "${PCIDEVICE_OPENSHIFT_IO_DPDKPOLICYONE}")
 			Expect(err).ToNot(HaveOccurred(), "The Receive traffic test on the the client pod failed")
 
 			checkRxOutputRateForInterfaces(
@@ -266,8 +265,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 					secondInterfaceBasedOnTapOne: maxMulticastNoiseRate},
 			)
 
-			err = cmd.RxTrafficOnClientPod(clientPod, defineTestPmdCmd(tapTwoInterfaceName,
-				"${PCIDEVICE_OPENSHIFT_IO_DPDKPOLICYONE}"))
+			err = rxTrafficOnClientPod(clientPod, tapTwoInterfaceName, "${PCIDEVICE_OPENSHIFT_IO_DPDKPOLICYONE}")
 			Expect(err).ToNot(HaveOccurred(), "The Receive traffic test on the the client pod failed")
 			checkRxOutputRateForInterfaces(
 				clientPod,
@@ -338,7 +336,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 				Expect(err).ToNot(HaveOccurred(), "Fail to collect PCI addresses")
 
 				By("Running client dpdk-testpmd")
-				err = cmd.RxTrafficOnClientPod(clientPod, defineTestPmdCmd(tapOneInterfaceName, pciAddressList[0]))
+				err = rxTrafficOnClientPod(clientPod, tapOneInterfaceName, pciAddressList[0])
 				Expect(err).ToNot(HaveOccurred(),
 					"The Receive traffic test on the the client pod failed")
 
@@ -347,7 +345,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 					clientPod, map[string]int{
 						tapOneInterfaceName:         minimumExpectedDPDKRate,
 						firstInterfaceBasedOnTapOne: minimumExpectedDPDKRate})
-				err = cmd.RxTrafficOnClientPod(clientPod, defineTestPmdCmd(tapTwoInterfaceName, pciAddressList[1]))
+				err = rxTrafficOnClientPod(clientPod, tapTwoInterfaceName, pciAddressList[1])
 				Expect(err).ToNot(HaveOccurred(),
 					"The Receive traffic test on the the client pod failed")
 
@@ -404,7 +402,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 				clientPod.Object.Annotations["k8s.v1.cni.cncf.io/network-status"])
 			Expect(err).ToNot(HaveOccurred(), "Fail to collect PCI addresses")
 
-			err = cmd.RxTrafficOnClientPod(clientPod, defineTestPmdCmd(tapOneInterfaceName, pciAddressList[0]))
+			err = rxTrafficOnClientPod(clientPod, tapOneInterfaceName, pciAddressList[0])
 			Expect(err).ToNot(HaveOccurred(), "The Receive traffic test on the the client pod failed")
 
 			checkRxOutputRateForInterfaces(
@@ -413,7 +411,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 					firstInterfaceBasedOnTapOne:  minimumExpectedDPDKRate,
 					secondInterfaceBasedOnTapOne: maxMulticastNoiseRate,
 				})
-			err = cmd.RxTrafficOnClientPod(clientPod, defineTestPmdCmd(tapTwoInterfaceName, pciAddressList[1]))
+			err = rxTrafficOnClientPod(clientPod, tapTwoInterfaceName, pciAddressList[1])
 			Expect(err).ToNot(HaveOccurred(), "The Receive traffic test on the the client pod failed")
 
 			checkRxOutputRateForInterfaces(
@@ -510,7 +508,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 				deploymentPod.Object.Annotations["k8s.v1.cni.cncf.io/network-status"])
 			Expect(err).ToNot(HaveOccurred(), "Fail to collect PCI addresses")
 
-			err = cmd.RxTrafficOnClientPod(deploymentPod, defineTestPmdCmd(tapOneInterfaceName, pciAddressList[0]))
+			err = rxTrafficOnClientPod(deploymentPod, tapOneInterfaceName, pciAddressList[0])
 			Expect(err).ToNot(HaveOccurred(), "The Receive traffic test on the the client pod failed")
 
 			checkRxOutputRateForInterfaces(
@@ -519,7 +517,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 					firstVlanInterfaceBasedOnTapOne: minimumExpectedDPDKRate,
 				})
 
-			err = cmd.RxTrafficOnClientPod(deploymentPod, defineTestPmdCmd(tapTwoInterfaceName, pciAddressList[1]))
+			err = rxTrafficOnClientPod(deploymentPod, tapTwoInterfaceName, pciAddressList[1])
 			Expect(err).ToNot(HaveOccurred(), "The Receive traffic test on the the client pod failed")
 
 			checkRxOutputRateForInterfaces(
@@ -545,7 +543,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 				deploymentPod.Object.Annotations["k8s.v1.cni.cncf.io/network-status"])
 			Expect(err).ToNot(HaveOccurred(), "Fail to collect PCI addresses")
 
-			err = cmd.RxTrafficOnClientPod(deploymentPod, defineTestPmdCmd(tapOneInterfaceName, pciAddressList[0]))
+			err = rxTrafficOnClientPod(deploymentPod, tapOneInterfaceName, pciAddressList[0])
 			Expect(err).ToNot(HaveOccurred(),
 				"The Receive traffic test on the the client pod failed %s")
 
@@ -554,7 +552,7 @@ var _ = Describe("rootless", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 					tapOneInterfaceName:             minimumExpectedDPDKRate,
 					firstVlanInterfaceBasedOnTapOne: minimumExpectedDPDKRate,
 				})
-			err = cmd.RxTrafficOnClientPod(deploymentPod, defineTestPmdCmd(tapTwoInterfaceName, pciAddressList[1]))
+			err = rxTrafficOnClientPod(deploymentPod, tapTwoInterfaceName, pciAddressList[1])
 			Expect(err).ToNot(HaveOccurred(), "The Receive traffic test on the the client pod failed")
 
 			checkRxOutputRateForInterfaces(
@@ -701,14 +699,13 @@ func defineAndCreateDPDKPod(
 }
 
 func defineTestServerPmdCmd(ethPeer, pciAddress, txIPs string) []string {
-	baseCmd := fmt.Sprintf("dpdk-testpmd -a %s -- --forward-mode txonly --eth-peer=0,%s", pciAddress, ethPeer)
-	if txIPs != "" {
-		baseCmd += fmt.Sprintf(" --tx-ip=%s", txIPs)
-	}
-
-	baseCmd += " --stats-period 5"
-
-	return []string{"/bin/bash", "-c", baseCmd}
+	return testpmd.Options{
+		PCIAddresses: []string{pciAddress},
+		ForwardMode:  testpmd.ForwardModeTxOnly,
+		EthPeers:     []testpmd.EthPeer{{Port: 0, MAC: ethPeer}},
+		TxIPs:        txIPs,
+		StatsPeriod:  5,
+	}.ContainerCommand()
 }
 
 func definePodNetwork(podNetMapList []map[string]string) []*types.NetworkSelectionElement {
@@ -748,10 +745,20 @@ func definePodNetwork(podNetMapList []map[string]string) []*types.NetworkSelecti
 	return clientPodNetConfig
 }
 
-func defineTestPmdCmd(interfaceName string, pciAddress string) string {
-	return fmt.Sprintf("timeout -s SIGKILL 20 dpdk-testpmd "+
-		"--vdev=virtio_user0,path=/dev/vhost-net,queues=2,queue_size=1024,iface=%s "+
-		"-a %s -- --stats-period 5", interfaceName, pciAddress)
+// rxTrafficOnClientPod runs testpmd on the tap interface and the VF with pciAddress in clientPod and verifies that
+// the VF received packets.
+func rxTrafficOnClientPod(clientPod *pod.Builder, interfaceName, pciAddress string) error {
+	result, err := testpmd.Run(clientPod, testpmd.Options{
+		PCIAddresses: []string{pciAddress},
+		VDevs: []string{
+			fmt.Sprintf("virtio_user0,path=/dev/vhost-net,queues=2,queue_size=1024,iface=%s", interfaceName)},
+		StatsPeriod: 5,
+	}, 20*time.Second, false)
+	if err != nil {
+		return err
+	}
+
+	return result.Check(0, testpmd.ReceivedPackets())
 }
 
 func checkRxOutputRateForInterfaces(clientPod *pod.Builder, interfaceTrafficRateMap map[string]int) {
@@ -4,15 +4,13 @@ import (
 	"fmt"
 	"log"
 	"net"
-	"os"
 	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
 
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/internal/coreconfig"
-	"gopkg.in/yaml.v2"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 )
 
 const (
@@ -29,6 +27,7 @@ type NetworkConfig struct {
 	Frrk8sNamespace         string `yaml:"frr-k8s_namespace" envconfig:"ECO_CNF_CORE_NET_FRR-K8S_NAMESPACE"`
 	CnfMcpLabel             string `yaml:"cnf_mcp_label" envconfig:"ECO_CNF_CORE_NET_CNF_MCP_LABEL"`
 	MultusNamesapce         string `yaml:"multus_namespace" envconfig:"ECO_CNF_CORE_NET_MULTUS_NAMESPACE"`
+	SwitchType              string `envconfig:"ECO_CNF_CORE_NET_SWITCH_TYPE"`
 	SwitchUser              string `envconfig:"ECO_CNF_CORE_NET_SWITCH_USER"`
 	SwitchPass              string `envconfig:"ECO_CNF_CORE_NET_SWITCH_PASS"`
 	SwitchIP                string `envconfig:"ECO_CNF_CORE_NET_SWITCH_IP"`
@@ -57,18 +56,9 @@ func NewNetConfig() *NetworkConfig {
 	_, filename, _, _ := runtime.Caller(0)
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultCnfCoreNetParamsFile)
-	err := readFile(&netConf, confFile)
-
+	err := config.Load(&netConf, confFile)
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
-
-		return nil
-	}
-
-	err = readEnv(&netConf)
-
-	if err != nil {
-		log.Print("Error to read environment variables")
+		log.Printf("Error to load config file %s: %v", confFile, err)
 
 		return nil
 	}
@@ -229,32 +219,3 @@ func (netConfig *NetworkConfig) GetClusterVlan() (string, error) {
 
 	return netConfig.ClusterVlan, nil
 }
-
-func readFile(netConfig *NetworkConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-	err = decoder.Decode(&netConfig)
-
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(netConfig *NetworkConfig) error {
-	err := envconfig.Process("", netConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
@@ -0,0 +1,391 @@
+package netswitch
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Juniper/go-netconf/netconf"
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var (
+	rpcConfigStringSet = "<load-configuration action=\"set\"" +
+		" format=\"text\"><configuration-set>%s</configuration-set></load-configuration>"
+	rpcGetInterfaceConfig = "<get-configuration><configuration><interfaces><interface><name>%s</name></interface>" +
+		"</interfaces></configuration></get-configuration>"
+	rpcApplyConfig         = "<load-configuration format=\"xml\" action=\"replace\">%s</load-configuration>"
+	rpcCommit              = "<commit-configuration/>"
+	rpcCommandJSON         = "<command format=\"json\">%s</command>"
+	rpcGetChassisInventory = "<get-chassis-inventory/>"
+)
+
+type (
+	// Junos is a Juniper switch managed over NETCONF. Besides implementing Switch, it allows sending Junos specific
+	// configuration and commands.
+	Junos struct {
+		session  *netconf.Session
+		host     string
+		user     string
+		password string
+	}
+
+	// interfaceStatus is the output of the show interfaces command.
+	interfaceStatus struct {
+		InterfaceInformation []struct {
+			PhysicalInterface []struct {
+				AdminStatus []struct {
+					Data string `json:"data"`
+				} `json:"admin-status"`
+				OperStatus []struct {
+					Data string `json:"data"`
+				} `json:"oper-status"`
+			} `json:"physical-interface"`
+		} `json:"interface-information"`
+	}
+
+	commitError struct {
+		Path    string `xml:"error-path"`
+		Element string `xml:"error-info>bad-element"`
+		Message string `xml:"error-message"`
+	}
+
+	commitResults struct {
+		XMLName xml.Name      `xml:"commit-results"`
+		Errors  []commitError `xml:"rpc-error"`
+	}
+)
+
+var _ Switch = (*Junos)(nil)
+
+// NewJunos establishes a new connection to a Junos device that we will use to run our commands against.
+func NewJunos(host, user, password string) (*Junos, error) {
+	glog.V(90).Infof("Creating a new session for host: %s", host)
+
+	junos := &Junos{host: host, user: user, password: password}
+
+	err := junos.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	return junos, nil
+}
+
+// Close disconnects the session to the device.
+func (junos *Junos) Close() {
+	glog.V(90).Info("Closing session with switch")
+
+	if junos.session != nil {
+		junos.session.Transport.Close()
+	}
+}
+
+// EnableInterface enables the interface by deleting its disable statement.
+func (junos *Junos) EnableInterface(name string) error {
+	glog.V(90).Infof("Enabling interface: %s", name)
+
+	return junos.Config([]string{fmt.Sprintf("delete interfaces %s disable", name)})
+}
+
+// DisableInterface disables the interface.
+func (junos *Junos) DisableInterface(name string) error {
+	glog.V(90).Infof("Disabling interface: %s", name)
+
+	return junos.Config([]string{fmt.Sprintf("set interfaces %s disable", name)})
+}
+
+// GetLinkState returns the state of the interface from the show interfaces command.
+func (junos *Junos) GetLinkState(name string) (LinkState, error) {
+	glog.V(90).Infof("Getting link state of interface %s", name)
+
+	output, err := junos.RunCommand(fmt.Sprintf("show interfaces %s", name))
+	if err != nil {
+		return LinkState{}, err
+	}
+
+	return parseLinkState(name, output)
+}
+
+// SetLAG creates the aggregated ethernet interface name with members as its ports. The aggregated ethernet device
+// count of the chassis must already allow name.
+func (junos *Junos) SetLAG(name string, members []string, lacp bool) error {
+	glog.V(90).Infof("Creating Link Aggregated switch interface %s with enslaved ports %v and LACP %t",
+		name, members, lacp)
+
+	return junos.Config(lagCommands(name, members, lacp))
+}
+
+// DeleteInterfaces deletes the interfaces.
+func (junos *Junos) DeleteInterfaces(names ...string) error {
+	glog.V(90).Infof("Deleting the interfaces: %v", names)
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	return junos.Config(deleteCommands(names))
+}
+
+// SetTrunkVLANs configures the interface as a trunk port and adds vlans to its members. VLANs are referenced by the
+// name vlan<id> which is how they are named in the lab switches.
+func (junos *Junos) SetTrunkVLANs(name string, vlans ...uint16) error {
+	glog.V(90).Infof("Configuring vlans %v on the trunk interface %s", vlans, name)
+
+	return junos.Config(vlanCommands(name, "trunk", vlans))
+}
+
+// SetAccessVLAN configures the interface as an access port in vlan, which is referenced by the name vlan<id>.
+func (junos *Junos) SetAccessVLAN(name string, vlan uint16) error {
+	glog.V(90).Infof("Configuring vlan %d on the access interface %s", vlan, name)
+
+	return junos.Config(vlanCommands(name, "access", []uint16{vlan}))
+}
+
+// Snapshot returns the XML configuration of each of the interfaces.
+func (junos *Junos) Snapshot(names ...string) (Snapshot, error) {
+	glog.V(90).Infof("Saving configuration of the switch interfaces: %v", names)
+
+	snapshot := Snapshot{}
+
+	for _, name := range names {
+		config, err := junos.GetInterfaceConfig(name)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot[name] = config
+	}
+
+	return snapshot, nil
+}
+
+// Restore deletes all configuration of the interfaces in snapshot and then applies their saved configuration.
+func (junos *Junos) Restore(snapshot Snapshot) error {
+	names := slices.Sorted(maps.Keys(snapshot))
+
+	glog.V(90).Infof("Restoring configuration of the switch interfaces: %v", names)
+
+	err := junos.DeleteInterfaces(names...)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		err = junos.ApplyInterfaceConfig(snapshot[name])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Config loads commands, which are in the set format, and commits them.
+func (junos *Junos) Config(commands []string) error {
+	glog.V(90).Infof("Sending configuration commands to a switch: %v", commands)
+
+	return junos.load(fmt.Sprintf(rpcConfigStringSet, strings.Join(commands, "\n")))
+}
+
+// ApplyInterfaceConfig loads config, which is XML configuration as returned by GetInterfaceConfig, replacing the
+// existing configuration and commits it.
+func (junos *Junos) ApplyInterfaceConfig(config string) error {
+	glog.V(90).Info("Applying switch interface configuration")
+
+	return junos.load(fmt.Sprintf(rpcApplyConfig, config))
+}
+
+// RunCommand executes any operational mode command, such as "show" or "request", and returns its JSON output.
+func (junos *Junos) RunCommand(command string) (string, error) {
+	glog.V(90).Infof("Running command on a switch: %s", command)
+
+	err := junos.openSessionIfNotExists()
+	if err != nil {
+		return "", err
+	}
+
+	return junos.exec(fmt.Sprintf(rpcCommandJSON, command))
+}
+
+// GetInterfaceConfig returns the XML configuration of the interface.
+func (junos *Junos) GetInterfaceConfig(name string) (string, error) {
+	glog.V(90).Infof("Getting configuration for switch interface: %s", name)
+
+	err := junos.openSessionIfNotExists()
+	if err != nil {
+		return "", err
+	}
+
+	return junos.exec(fmt.Sprintf(rpcGetInterfaceConfig, name))
+}
+
+// dial opens a new session to the switch, retrying for up to 2 minutes.
+func (junos *Junos) dial() error {
+	return wait.PollUntilContextTimeout(
+		context.TODO(), 30*time.Second, 120*time.Second, true, func(ctx context.Context) (bool, error) {
+			session, err := netconf.DialSSH(junos.host, netconf.SSHConfigPassword(junos.user, junos.password))
+			if err != nil {
+				glog.V(90).Infof("Failed to open SSH: %s", err)
+
+				return false, nil
+			}
+
+			junos.session = session
+
+			return true, nil
+		})
+}
+
+// load executes the load-configuration rpc and commits the loaded configuration.
+func (junos *Junos) load(rpc string) error {
+	err := junos.openSessionIfNotExists()
+	if err != nil {
+		return err
+	}
+
+	reply, err := junos.session.Exec(netconf.RawMethod(rpc))
+	if err != nil {
+		return err
+	}
+
+	err = junos.commit()
+	if err != nil {
+		return err
+	}
+
+	for _, replyError := range reply.Errors {
+		return errors.New(replyError.Message)
+	}
+
+	return nil
+}
+
+// commit commits the configuration.
+func (junos *Junos) commit() error {
+	glog.V(90).Info("Committing switch configuration")
+
+	var results commitResults
+
+	reply, err := junos.session.Exec(netconf.RawMethod(rpcCommit))
+	if err != nil {
+		return err
+	}
+
+	for _, replyError := range reply.Errors {
+		return errors.New(replyError.Message)
+	}
+
+	err = xml.Unmarshal([]byte(reply.Data), &results)
+	if err != nil {
+		return err
+	}
+
+	for _, commitErr := range results.Errors {
+		return fmt.Errorf("[%s]\n    %s\nError: %s", strings.Trim(commitErr.Path, "[\r\n]"),
+			strings.Trim(commitErr.Element, "[\r\n]"), strings.Trim(commitErr.Message, "[\r\n]"))
+	}
+
+	return nil
+}
+
+// openSessionIfNotExists opens a new session if the current session was closed by the switch.
+func (junos *Junos) openSessionIfNotExists() error {
+	if junos.session != nil {
+		reply, err := junos.exec(rpcGetChassisInventory)
+		if err == nil && reply != "" {
+			return nil
+		}
+	}
+
+	glog.V(90).Infof("Current session doesn't exist, opening a new one")
+
+	return junos.dial()
+}
+
+// exec executes the rpc and returns the data of its reply.
+func (junos *Junos) exec(rpc string) (string, error) {
+	reply, err := junos.session.Exec(netconf.RawMethod(rpc))
+	if err != nil {
+		return "", err
+	}
+
+	for _, replyError := range reply.Errors {
+		return "", errors.New(replyError.Message)
+	}
+
+	if reply.Data == "" {
+		return "", errors.New("no output available, please check the syntax of your command")
+	}
+
+	return reply.Data, nil
+}
+
+// lagCommands returns the commands to create the aggregated ethernet interface name with members as its ports.
+func lagCommands(name string, members []string, lacp bool) []string {
+	var commands []string
+
+	for _, member := range members {
+		commands = append(commands, fmt.Sprintf("set interfaces %s ether-options 802.3ad %s", member, name))
+	}
+
+	if lacp {
+		commands = append(commands, fmt.Sprintf("set interfaces %s aggregated-ether-options lacp active", name))
+	}
+
+	return append(commands, fmt.Sprintf("set interfaces %s unit 0 family ethernet-switching", name))
+}
+
+// deleteCommands returns the commands to delete the interfaces.
+func deleteCommands(names []string) []string {
+	var commands []string
+
+	for _, name := range names {
+		commands = append(commands, fmt.Sprintf("delete interfaces %s", name))
+	}
+
+	return commands
+}
+
+// vlanCommands returns the commands to set the interface mode of the interface and add vlans to its members.
+func vlanCommands(name, mode string, vlans []uint16) []string {
+	prefix := fmt.Sprintf("set interfaces %s unit 0 family ethernet-switching", name)
+	commands := []string{fmt.Sprintf("%s interface-mode %s", prefix, mode)}
+
+	for _, vlan := range vlans {
+		commands = append(commands, fmt.Sprintf("%s vlan members vlan%d", prefix, vlan))
+	}
+
+	return commands
+}
+
+// parseLinkState parses the JSON output of show interfaces for the interface.
+func parseLinkState(name, output string) (LinkState, error) {
+	var status interfaceStatus
+
+	err := json.Unmarshal([]byte(output), &status)
+	if err != nil {
+		return LinkState{}, fmt.Errorf("failed to parse status of interface %s: %w", name, err)
+	}
+
+	if len(status.InterfaceInformation) == 0 || len(status.InterfaceInformation[0].PhysicalInterface) == 0 {
+		return LinkState{}, fmt.Errorf("no status found for interface %s", name)
+	}
+
+	physicalInterface := status.InterfaceInformation[0].PhysicalInterface[0]
+	if len(physicalInterface.AdminStatus) == 0 || len(physicalInterface.OperStatus) == 0 {
+		return LinkState{}, fmt.Errorf("no admin or oper status found for interface %s", name)
+	}
+
+	return LinkState{
+		AdminUp: physicalInterface.AdminStatus[0].Data == "up",
+		OperUp:  physicalInterface.OperStatus[0].Data == "up",
+	}, nil
+}
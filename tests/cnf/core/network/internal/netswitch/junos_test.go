@@ -0,0 +1,97 @@
+package netswitch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLAGCommands(t *testing.T) {
+	testCases := []struct {
+		name     string
+		lacp     bool
+		expected []string
+	}{
+		{
+			name: "without lacp",
+			lacp: false,
+			expected: []string{
+				"set interfaces et-0/0/1 ether-options 802.3ad ae0",
+				"set interfaces et-0/0/2 ether-options 802.3ad ae0",
+				"set interfaces ae0 unit 0 family ethernet-switching",
+			},
+		},
+		{
+			name: "with lacp",
+			lacp: true,
+			expected: []string{
+				"set interfaces et-0/0/1 ether-options 802.3ad ae0",
+				"set interfaces et-0/0/2 ether-options 802.3ad ae0",
+				"set interfaces ae0 aggregated-ether-options lacp active",
+				"set interfaces ae0 unit 0 family ethernet-switching",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, lagCommands("ae0", []string{"et-0/0/1", "et-0/0/2"}, testCase.lacp))
+		})
+	}
+}
+
+func TestVLANCommands(t *testing.T) {
+	assert.Equal(t, []string{
+		"set interfaces ae0 unit 0 family ethernet-switching interface-mode trunk",
+		"set interfaces ae0 unit 0 family ethernet-switching vlan members vlan100",
+		"set interfaces ae0 unit 0 family ethernet-switching vlan members vlan200",
+	}, vlanCommands("ae0", "trunk", []uint16{100, 200}))
+}
+
+func TestParseLinkState(t *testing.T) {
+	testCases := []struct {
+		name          string
+		output        string
+		expected      LinkState
+		expectedError string
+	}{
+		{
+			name: "up",
+			output: `{"interface-information": [{"physical-interface": [{"name": [{"data": "et-0/0/1"}],
+				"admin-status": [{"data": "up", "attributes": {"junos:format": "Enabled"}}],
+				"oper-status": [{"data": "up"}]}]}]}`,
+			expected: LinkState{AdminUp: true, OperUp: true},
+		},
+		{
+			name: "disabled",
+			output: `{"interface-information": [{"physical-interface": [{"name": [{"data": "et-0/0/1"}],
+				"admin-status": [{"data": "down"}], "oper-status": [{"data": "down"}]}]}]}`,
+			expected: LinkState{AdminUp: false, OperUp: false},
+		},
+		{
+			name:          "no interface",
+			output:        `{"interface-information": []}`,
+			expectedError: "no status found for interface et-0/0/1",
+		},
+		{
+			name:          "no oper status",
+			output:        `{"interface-information": [{"physical-interface": [{"admin-status": [{"data": "up"}]}]}]}`,
+			expectedError: "no admin or oper status found for interface et-0/0/1",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			linkState, err := parseLinkState("et-0/0/1", testCase.output)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, linkState)
+		})
+	}
+}
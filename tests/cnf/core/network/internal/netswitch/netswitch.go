@@ -0,0 +1,94 @@
+package netswitch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netconfig"
+)
+
+const (
+	// TypeJunos is the switch type of Juniper switches managed over NETCONF. It is used when no type is configured.
+	TypeJunos = "junos"
+	// TypeSimulated is the switch type of the in-memory simulated switch, used for unit tests and dry runs.
+	TypeSimulated = "simulated"
+)
+
+type (
+	// Switch is a top-of-rack switch connected to the cluster nodes. Implementations are vendor specific, so tests
+	// should only depend on this interface unless they need vendor specific configuration.
+	Switch interface {
+		// EnableInterface administratively enables the interface.
+		EnableInterface(name string) error
+		// DisableInterface administratively disables the interface, which brings its link down.
+		DisableInterface(name string) error
+		// GetLinkState returns the administrative and operational state of the interface.
+		GetLinkState(name string) (LinkState, error)
+		// SetLAG creates the link aggregation interface name with members as its ports, using LACP if lacp is true.
+		SetLAG(name string, members []string, lacp bool) error
+		// DeleteInterfaces deletes all configuration of the interfaces, and the interfaces themselves if they are
+		// logical interfaces such as LAGs.
+		DeleteInterfaces(names ...string) error
+		// SetTrunkVLANs configures the interface as a trunk port and adds vlans to its allowed VLANs.
+		SetTrunkVLANs(name string, vlans ...uint16) error
+		// SetAccessVLAN configures the interface as an access port in vlan.
+		SetAccessVLAN(name string, vlan uint16) error
+		// Snapshot returns the current configuration of the interfaces so it can be restored with Restore.
+		Snapshot(names ...string) (Snapshot, error)
+		// Restore replaces the configuration of each interface in snapshot with its configuration in snapshot.
+		Restore(snapshot Snapshot) error
+		// Close releases the connection to the switch.
+		Close()
+	}
+
+	// LinkState is the state of a switch interface.
+	LinkState struct {
+		// AdminUp is whether the interface is administratively enabled.
+		AdminUp bool
+		// OperUp is whether the link of the interface is up.
+		OperUp bool
+	}
+
+	// Snapshot is the saved configuration of switch interfaces keyed by interface name. The configuration is in the
+	// format of the switch that took the snapshot, so it can only be restored to the same type of switch.
+	Snapshot map[string]string
+)
+
+// New returns the switch configured in netConfig. The switch type is set by ECO_CNF_CORE_NET_SWITCH_TYPE and is junos
+// if left blank. The simulated switch is created with the interfaces in ECO_CNF_CORE_NET_SWITCH_INTERFACES and
+// ECO_CNF_CORE_NET_PRIMARY_SWITCH_INTERFACES.
+func New(netConfig *netconfig.NetworkConfig) (Switch, error) {
+	switch netConfig.SwitchType {
+	case "", TypeJunos:
+		user, err := netConfig.GetSwitchUser()
+		if err != nil {
+			return nil, err
+		}
+
+		pass, err := netConfig.GetSwitchPass()
+		if err != nil {
+			return nil, err
+		}
+
+		ipAddress, err := netConfig.GetSwitchIP()
+		if err != nil {
+			return nil, err
+		}
+
+		return NewJunos(ipAddress, user, pass)
+	case TypeSimulated:
+		var interfaces []string
+
+		for _, configured := range []string{netConfig.SwitchInterfaces, netConfig.PrimarySwitchInterfaces} {
+			for _, name := range strings.Split(configured, ",") {
+				if name != "" {
+					interfaces = append(interfaces, name)
+				}
+			}
+		}
+
+		return NewSimulated(interfaces...), nil
+	default:
+		return nil, fmt.Errorf("unknown switch type %s, check ECO_CNF_CORE_NET_SWITCH_TYPE env var", netConfig.SwitchType)
+	}
+}
@@ -0,0 +1,24 @@
+package netswitch
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	labSwitch, err := New(&netconfig.NetworkConfig{
+		SwitchType:              TypeSimulated,
+		SwitchInterfaces:        "et-0/0/1,et-0/0/2",
+		PrimarySwitchInterfaces: "",
+	})
+	assert.Nil(t, err)
+	assert.IsType(t, &Simulated{}, labSwitch)
+
+	_, err = labSwitch.GetLinkState("et-0/0/2")
+	assert.Nil(t, err)
+
+	_, err = New(&netconfig.NetworkConfig{SwitchType: "unknown"})
+	assert.EqualError(t, err, "unknown switch type unknown, check ECO_CNF_CORE_NET_SWITCH_TYPE env var")
+}
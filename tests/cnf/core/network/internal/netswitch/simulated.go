@@ -0,0 +1,286 @@
+package netswitch
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/golang/glog"
+)
+
+type (
+	// Simulated is an in-memory switch for unit tests and dry runs. It tracks the configuration and link state of its
+	// interfaces without connecting to a device. Physical interfaces exist from creation while LAGs are created by
+	// SetLAG, and using any other interface is an error.
+	Simulated struct {
+		mutex      sync.Mutex
+		interfaces map[string]*SimulatedInterface
+	}
+
+	// SimulatedInterface is the state of an interface of the simulated switch.
+	SimulatedInterface struct {
+		// LAG is whether this is a link aggregation interface rather than a physical interface.
+		LAG bool `json:"lag,omitempty"`
+		// Disabled is whether the interface is administratively disabled.
+		Disabled bool `json:"disabled,omitempty"`
+		// NoCarrier is whether the cable of a physical interface is disconnected. It is not part of the configuration.
+		NoCarrier bool `json:"-"`
+		// Parent is the LAG a physical interface is a member of.
+		Parent string `json:"parent,omitempty"`
+		// LACP is whether a LAG uses LACP.
+		LACP bool `json:"lacp,omitempty"`
+		// Mode is the VLAN mode of the interface, trunk or access, or empty if it is not a switching interface.
+		Mode  string   `json:"mode,omitempty"`
+		VLANs []uint16 `json:"vlans,omitempty"`
+	}
+)
+
+var _ Switch = (*Simulated)(nil)
+
+// NewSimulated creates a simulated switch with the physical interfaces, which are enabled and connected.
+func NewSimulated(interfaces ...string) *Simulated {
+	simulated := &Simulated{interfaces: make(map[string]*SimulatedInterface, len(interfaces))}
+
+	for _, name := range interfaces {
+		simulated.interfaces[name] = &SimulatedInterface{}
+	}
+
+	return simulated
+}
+
+// Close does nothing since the simulated switch has no connection.
+func (simulated *Simulated) Close() {}
+
+// EnableInterface enables the interface.
+func (simulated *Simulated) EnableInterface(name string) error {
+	return simulated.update(name, func(iface *SimulatedInterface) error {
+		iface.Disabled = false
+
+		return nil
+	})
+}
+
+// DisableInterface disables the interface.
+func (simulated *Simulated) DisableInterface(name string) error {
+	return simulated.update(name, func(iface *SimulatedInterface) error {
+		iface.Disabled = true
+
+		return nil
+	})
+}
+
+// SetCarrier connects or disconnects the cable of the physical interface, simulating a link failure that is not caused
+// by the switch configuration.
+func (simulated *Simulated) SetCarrier(name string, connected bool) error {
+	return simulated.update(name, func(iface *SimulatedInterface) error {
+		if iface.LAG {
+			return fmt.Errorf("interface %s is a LAG and has no carrier", name)
+		}
+
+		iface.NoCarrier = !connected
+
+		return nil
+	})
+}
+
+// GetLinkState returns the state of the interface. A physical interface is up if it is enabled and connected, while a
+// LAG is up if it is enabled and any of its members are up.
+func (simulated *Simulated) GetLinkState(name string) (LinkState, error) {
+	simulated.mutex.Lock()
+	defer simulated.mutex.Unlock()
+
+	iface, ok := simulated.interfaces[name]
+	if !ok {
+		return LinkState{}, fmt.Errorf("interface %s does not exist", name)
+	}
+
+	return LinkState{AdminUp: !iface.Disabled, OperUp: simulated.isUp(name)}, nil
+}
+
+// GetInterface returns a copy of the state of the interface.
+func (simulated *Simulated) GetInterface(name string) (SimulatedInterface, error) {
+	simulated.mutex.Lock()
+	defer simulated.mutex.Unlock()
+
+	iface, ok := simulated.interfaces[name]
+	if !ok {
+		return SimulatedInterface{}, fmt.Errorf("interface %s does not exist", name)
+	}
+
+	copied := *iface
+	copied.VLANs = slices.Clone(iface.VLANs)
+
+	return copied, nil
+}
+
+// SetLAG creates the LAG name, or updates it if it exists, and adds members to it.
+func (simulated *Simulated) SetLAG(name string, members []string, lacp bool) error {
+	glog.V(90).Infof("Creating simulated LAG %s with members %v and LACP %t", name, members, lacp)
+
+	simulated.mutex.Lock()
+	defer simulated.mutex.Unlock()
+
+	lag, ok := simulated.interfaces[name]
+
+	switch {
+	case !ok:
+		lag = &SimulatedInterface{LAG: true}
+	case !lag.LAG:
+		return fmt.Errorf("interface %s is not a LAG", name)
+	}
+
+	for _, member := range members {
+		iface, ok := simulated.interfaces[member]
+		if !ok || iface.LAG {
+			return fmt.Errorf("LAG member %s is not a physical interface", member)
+		}
+	}
+
+	for _, member := range members {
+		simulated.interfaces[member].Parent = name
+	}
+
+	lag.LACP = lag.LACP || lacp
+	simulated.interfaces[name] = lag
+
+	return nil
+}
+
+// DeleteInterfaces removes the configuration of the interfaces. LAGs are removed and their members are released.
+func (simulated *Simulated) DeleteInterfaces(names ...string) error {
+	glog.V(90).Infof("Deleting simulated interfaces %v", names)
+
+	simulated.mutex.Lock()
+	defer simulated.mutex.Unlock()
+
+	for _, name := range names {
+		iface, ok := simulated.interfaces[name]
+		if !ok {
+			return fmt.Errorf("interface %s does not exist", name)
+		}
+
+		if !iface.LAG {
+			simulated.interfaces[name] = &SimulatedInterface{NoCarrier: iface.NoCarrier}
+
+			continue
+		}
+
+		delete(simulated.interfaces, name)
+
+		for _, member := range simulated.interfaces {
+			if member.Parent == name {
+				member.Parent = ""
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetTrunkVLANs sets the interface to trunk mode and adds vlans to its VLANs.
+func (simulated *Simulated) SetTrunkVLANs(name string, vlans ...uint16) error {
+	return simulated.update(name, func(iface *SimulatedInterface) error {
+		iface.Mode = "trunk"
+
+		for _, vlan := range vlans {
+			if !slices.Contains(iface.VLANs, vlan) {
+				iface.VLANs = append(iface.VLANs, vlan)
+			}
+		}
+
+		return nil
+	})
+}
+
+// SetAccessVLAN sets the interface to access mode in vlan.
+func (simulated *Simulated) SetAccessVLAN(name string, vlan uint16) error {
+	return simulated.update(name, func(iface *SimulatedInterface) error {
+		iface.Mode = "access"
+		iface.VLANs = []uint16{vlan}
+
+		return nil
+	})
+}
+
+// Snapshot returns the JSON configuration of each of the interfaces.
+func (simulated *Simulated) Snapshot(names ...string) (Snapshot, error) {
+	simulated.mutex.Lock()
+	defer simulated.mutex.Unlock()
+
+	snapshot := Snapshot{}
+
+	for _, name := range names {
+		iface, ok := simulated.interfaces[name]
+		if !ok {
+			return nil, fmt.Errorf("interface %s does not exist", name)
+		}
+
+		config, err := json.Marshal(iface)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot[name] = string(config)
+	}
+
+	return snapshot, nil
+}
+
+// Restore replaces the configuration of each interface in snapshot, recreating LAGs that were deleted.
+func (simulated *Simulated) Restore(snapshot Snapshot) error {
+	glog.V(90).Infof("Restoring %d simulated interfaces", len(snapshot))
+
+	simulated.mutex.Lock()
+	defer simulated.mutex.Unlock()
+
+	for name, config := range snapshot {
+		restored := &SimulatedInterface{}
+
+		err := json.Unmarshal([]byte(config), restored)
+		if err != nil {
+			return fmt.Errorf("failed to restore interface %s: %w", name, err)
+		}
+
+		if current, ok := simulated.interfaces[name]; ok {
+			restored.NoCarrier = current.NoCarrier
+		}
+
+		simulated.interfaces[name] = restored
+	}
+
+	return nil
+}
+
+// update calls change on the interface while holding the lock.
+func (simulated *Simulated) update(name string, change func(iface *SimulatedInterface) error) error {
+	simulated.mutex.Lock()
+	defer simulated.mutex.Unlock()
+
+	iface, ok := simulated.interfaces[name]
+	if !ok {
+		return fmt.Errorf("interface %s does not exist", name)
+	}
+
+	return change(iface)
+}
+
+// isUp returns whether the link of the interface is up. The lock must be held.
+func (simulated *Simulated) isUp(name string) bool {
+	iface := simulated.interfaces[name]
+	if iface.Disabled {
+		return false
+	}
+
+	if !iface.LAG {
+		return !iface.NoCarrier
+	}
+
+	for memberName, member := range simulated.interfaces {
+		if member.Parent == name && simulated.isUp(memberName) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,184 @@
+package netswitch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulatedLinkState(t *testing.T) {
+	testCases := []struct {
+		name      string
+		configure func(simulated *Simulated) error
+		iface     string
+		expected  LinkState
+	}{
+		{
+			name:      "physical interface up",
+			configure: func(*Simulated) error { return nil },
+			iface:     "et-0/0/1",
+			expected:  LinkState{AdminUp: true, OperUp: true},
+		},
+		{
+			name:      "physical interface disabled",
+			configure: func(simulated *Simulated) error { return simulated.DisableInterface("et-0/0/1") },
+			iface:     "et-0/0/1",
+			expected:  LinkState{AdminUp: false, OperUp: false},
+		},
+		{
+			name:      "physical interface without carrier",
+			configure: func(simulated *Simulated) error { return simulated.SetCarrier("et-0/0/1", false) },
+			iface:     "et-0/0/1",
+			expected:  LinkState{AdminUp: true, OperUp: false},
+		},
+		{
+			name: "lag with one member down",
+			configure: func(simulated *Simulated) error {
+				err := simulated.SetLAG("ae0", []string{"et-0/0/1", "et-0/0/2"}, true)
+				if err != nil {
+					return err
+				}
+
+				return simulated.DisableInterface("et-0/0/1")
+			},
+			iface:    "ae0",
+			expected: LinkState{AdminUp: true, OperUp: true},
+		},
+		{
+			name: "lag with all members down",
+			configure: func(simulated *Simulated) error {
+				err := simulated.SetLAG("ae0", []string{"et-0/0/1", "et-0/0/2"}, false)
+				if err != nil {
+					return err
+				}
+
+				err = simulated.DisableInterface("et-0/0/1")
+				if err != nil {
+					return err
+				}
+
+				return simulated.SetCarrier("et-0/0/2", false)
+			},
+			iface:    "ae0",
+			expected: LinkState{AdminUp: true, OperUp: false},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			simulated := NewSimulated("et-0/0/1", "et-0/0/2")
+
+			err := testCase.configure(simulated)
+			assert.Nil(t, err)
+
+			linkState, err := simulated.GetLinkState(testCase.iface)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, linkState)
+		})
+	}
+}
+
+func TestSimulatedErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configure     func(simulated *Simulated) error
+		expectedError string
+	}{
+		{
+			name:          "unknown interface",
+			configure:     func(simulated *Simulated) error { return simulated.EnableInterface("et-0/0/9") },
+			expectedError: "interface et-0/0/9 does not exist",
+		},
+		{
+			name: "lag member is not physical",
+			configure: func(simulated *Simulated) error {
+				return simulated.SetLAG("ae0", []string{"et-0/0/9"}, false)
+			},
+			expectedError: "LAG member et-0/0/9 is not a physical interface",
+		},
+		{
+			name: "lag is a physical interface",
+			configure: func(simulated *Simulated) error {
+				return simulated.SetLAG("et-0/0/1", []string{"et-0/0/2"}, false)
+			},
+			expectedError: "interface et-0/0/1 is not a LAG",
+		},
+		{
+			name: "carrier of a lag",
+			configure: func(simulated *Simulated) error {
+				err := simulated.SetLAG("ae0", []string{"et-0/0/1"}, false)
+				if err != nil {
+					return err
+				}
+
+				return simulated.SetCarrier("ae0", false)
+			},
+			expectedError: "interface ae0 is a LAG and has no carrier",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.configure(NewSimulated("et-0/0/1", "et-0/0/2"))
+			assert.EqualError(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestSimulatedVLANs(t *testing.T) {
+	simulated := NewSimulated("et-0/0/1")
+
+	err := simulated.SetTrunkVLANs("et-0/0/1", 100, 200)
+	assert.Nil(t, err)
+
+	err = simulated.SetTrunkVLANs("et-0/0/1", 200, 300)
+	assert.Nil(t, err)
+
+	iface, err := simulated.GetInterface("et-0/0/1")
+	assert.Nil(t, err)
+	assert.Equal(t, SimulatedInterface{Mode: "trunk", VLANs: []uint16{100, 200, 300}}, iface)
+
+	err = simulated.SetAccessVLAN("et-0/0/1", 400)
+	assert.Nil(t, err)
+
+	iface, err = simulated.GetInterface("et-0/0/1")
+	assert.Nil(t, err)
+	assert.Equal(t, SimulatedInterface{Mode: "access", VLANs: []uint16{400}}, iface)
+}
+
+func TestSimulatedSnapshotRestore(t *testing.T) {
+	simulated := NewSimulated("et-0/0/1", "et-0/0/2")
+
+	err := simulated.SetTrunkVLANs("et-0/0/1", 100)
+	assert.Nil(t, err)
+
+	snapshot, err := simulated.Snapshot("et-0/0/1", "et-0/0/2")
+	assert.Nil(t, err)
+
+	err = simulated.SetLAG("ae0", []string{"et-0/0/1", "et-0/0/2"}, true)
+	assert.Nil(t, err)
+
+	err = simulated.DisableInterface("et-0/0/1")
+	assert.Nil(t, err)
+
+	err = simulated.SetCarrier("et-0/0/2", false)
+	assert.Nil(t, err)
+
+	err = simulated.Restore(snapshot)
+	assert.Nil(t, err)
+
+	err = simulated.DeleteInterfaces("ae0")
+	assert.Nil(t, err)
+
+	iface, err := simulated.GetInterface("et-0/0/1")
+	assert.Nil(t, err)
+	assert.Equal(t, SimulatedInterface{Mode: "trunk", VLANs: []uint16{100}}, iface)
+
+	// The carrier is not part of the configuration so restoring does not reconnect the cable.
+	iface, err = simulated.GetInterface("et-0/0/2")
+	assert.Nil(t, err)
+	assert.Equal(t, SimulatedInterface{NoCarrier: true}, iface)
+
+	_, err = simulated.GetInterface("ae0")
+	assert.EqualError(t, err, "interface ae0 does not exist")
+}
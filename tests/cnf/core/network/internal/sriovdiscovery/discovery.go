@@ -0,0 +1,191 @@
+package sriovdiscovery
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	sriovV1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/sriov"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netparam"
+)
+
+// PF is an SR-IOV physical function reported in the SriovNetworkNodeState of a node.
+type PF struct {
+	// Node is the name of the node the PF is on.
+	Node string
+	// Name is the kernel name of the PF, such as ens1f0.
+	Name string
+	// PCIAddress is the PCI address of the PF.
+	PCIAddress string
+	// Vendor is the PCI vendor ID, such as 15b3 for Mellanox.
+	Vendor string
+	// DeviceID is the PCI device ID, which together with Vendor identifies the NIC model.
+	DeviceID string
+	// Driver is the kernel driver bound to the PF, such as mlx5_core or ice.
+	Driver string
+	// LinkSpeed is the speed of the link in Mb/s. It is zero if the link is down or its speed is unknown.
+	LinkSpeed int
+	// LinkAdminState is the administrative state of the link, up or down. It is empty on older operators.
+	LinkAdminState string
+	// LinkType is the link layer type, such as ETH.
+	LinkType string
+	// TotalVFs is the maximum number of VFs the PF supports.
+	TotalVFs int
+	// ExternallyManaged is whether the VFs of the PF are created outside the SR-IOV operator.
+	ExternallyManaged bool
+}
+
+// Filter selects the PFs returned by Discover. Empty fields match any PF and list fields match a PF that has any of
+// the listed values.
+type Filter struct {
+	// Names are the PF names to select, such as the interfaces cabled to the switch.
+	Names []string
+	// Vendors are the PCI vendor IDs to select.
+	Vendors []string
+	// DeviceIDs are the PCI device IDs to select.
+	DeviceIDs []string
+	// Drivers are the kernel drivers to select.
+	Drivers []string
+	// LinkUp selects only PFs whose link is up.
+	LinkUp bool
+	// MinLinkSpeed is the minimum link speed in Mb/s.
+	MinLinkSpeed int
+	// MinTotalVFs is the minimum number of VFs the PF must support.
+	MinTotalVFs int
+	// RDMA selects only PFs that support RDMA.
+	RDMA bool
+}
+
+// String returns the PF as node/name along with its model and driver.
+func (pf PF) String() string {
+	return fmt.Sprintf("%s/%s (%s:%s %s)", pf.Node, pf.Name, pf.Vendor, pf.DeviceID, pf.Driver)
+}
+
+// Model returns the vendor and device ID of the PF, which identifies its NIC model.
+func (pf PF) Model() string {
+	return pf.Vendor + ":" + pf.DeviceID
+}
+
+// LinkUp returns whether the link of the PF is up. A PF with a link speed is up unless it is administratively down.
+func (pf PF) LinkUp() bool {
+	return pf.LinkSpeed > 0 && pf.LinkAdminState != "down"
+}
+
+// RDMACapable returns whether the PF supports RDMA. The SR-IOV operator only supports RDMA on Mellanox NICs.
+func (pf PF) RDMACapable() bool {
+	return pf.Vendor == netparam.MlxVendorID
+}
+
+// Match returns whether pf is selected by filter.
+func (filter Filter) Match(pf PF) bool {
+	if len(filter.Names) > 0 && !slices.Contains(filter.Names, pf.Name) {
+		return false
+	}
+
+	if len(filter.Vendors) > 0 && !slices.Contains(filter.Vendors, pf.Vendor) {
+		return false
+	}
+
+	if len(filter.DeviceIDs) > 0 && !slices.Contains(filter.DeviceIDs, pf.DeviceID) {
+		return false
+	}
+
+	if len(filter.Drivers) > 0 && !slices.Contains(filter.Drivers, pf.Driver) {
+		return false
+	}
+
+	if filter.LinkUp && !pf.LinkUp() {
+		return false
+	}
+
+	if filter.RDMA && !pf.RDMACapable() {
+		return false
+	}
+
+	return pf.LinkSpeed >= filter.MinLinkSpeed && pf.TotalVFs >= filter.MinTotalVFs
+}
+
+// Discover reads the SriovNetworkNodeState of each node in sriovOperatorNamespace and returns the PFs selected by
+// filter, sorted by node and name.
+func Discover(
+	apiClient *clients.Settings, sriovOperatorNamespace string, nodeList []*nodes.Builder, filter Filter) ([]PF, error) {
+	var pfs []PF
+
+	for _, node := range nodeList {
+		nodeState := sriov.NewNetworkNodeStateBuilder(apiClient, node.Definition.Name, sriovOperatorNamespace)
+
+		err := nodeState.Discover()
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover SR-IOV interfaces on node %s: %w", node.Definition.Name, err)
+		}
+
+		pfs = append(pfs, FromInterfaces(node.Definition.Name, nodeState.Objects.Status.Interfaces)...)
+	}
+
+	selected := Select(pfs, filter)
+
+	glog.V(90).Infof("Discovered SR-IOV PFs %v matching filter %+v", selected, filter)
+
+	return selected, nil
+}
+
+// FromInterfaces returns the PFs of the interfaces in the SriovNetworkNodeState status of node.
+func FromInterfaces(node string, interfaces sriovV1.InterfaceExts) []PF {
+	pfs := make([]PF, 0, len(interfaces))
+
+	for _, nic := range interfaces {
+		pfs = append(pfs, PF{
+			Node:              node,
+			Name:              nic.Name,
+			PCIAddress:        nic.PciAddress,
+			Vendor:            nic.Vendor,
+			DeviceID:          nic.DeviceID,
+			Driver:            nic.Driver,
+			LinkSpeed:         parseLinkSpeed(nic.LinkSpeed),
+			LinkAdminState:    nic.LinkAdminState,
+			LinkType:          nic.LinkType,
+			TotalVFs:          nic.TotalVfs,
+			ExternallyManaged: nic.ExternallyManaged,
+		})
+	}
+
+	return pfs
+}
+
+// Select returns the PFs matching filter, sorted by node and name.
+func Select(pfs []PF, filter Filter) []PF {
+	var selected []PF
+
+	for _, pf := range pfs {
+		if filter.Match(pf) {
+			selected = append(selected, pf)
+		}
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		if selected[i].Node != selected[j].Node {
+			return selected[i].Node < selected[j].Node
+		}
+
+		return selected[i].Name < selected[j].Name
+	})
+
+	return selected
+}
+
+// parseLinkSpeed returns the speed in Mb/s of a link speed such as 25000 Mb/s. Unknown and negative speeds, which the
+// operator reports for links that are down, are returned as zero.
+func parseLinkSpeed(linkSpeed string) int {
+	speed, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(linkSpeed), "Mb/s")))
+	if err != nil || speed < 0 {
+		return 0
+	}
+
+	return speed
+}
@@ -0,0 +1,147 @@
+package sriovdiscovery
+
+import (
+	"testing"
+
+	sriovV1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+var testInterfaces = sriovV1.InterfaceExts{
+	{
+		Name:       "ens2f1",
+		PciAddress: "0000:3b:00.1",
+		Vendor:     "8086",
+		DeviceID:   "159b",
+		Driver:     "ice",
+		LinkSpeed:  "25000 Mb/s",
+		LinkType:   "ETH",
+		TotalVfs:   64,
+	},
+	{
+		Name:           "ens1f0",
+		PciAddress:     "0000:d8:00.0",
+		Vendor:         "15b3",
+		DeviceID:       "1017",
+		Driver:         "mlx5_core",
+		LinkSpeed:      "100000 Mb/s",
+		LinkAdminState: "up",
+		LinkType:       "ETH",
+		TotalVfs:       8,
+	},
+	{
+		Name:      "ens1f1",
+		Vendor:    "15b3",
+		DeviceID:  "1017",
+		Driver:    "mlx5_core",
+		LinkSpeed: "-1 Mb/s",
+		TotalVfs:  8,
+	},
+	{
+		Name:           "ens2f0",
+		Vendor:         "8086",
+		DeviceID:       "159b",
+		Driver:         "ice",
+		LinkSpeed:      "25000 Mb/s",
+		LinkAdminState: "down",
+		TotalVfs:       64,
+	},
+}
+
+func TestFromInterfaces(t *testing.T) {
+	pfs := FromInterfaces("worker-0", testInterfaces)
+
+	assert.Len(t, pfs, 4)
+	assert.Equal(t, PF{
+		Node:       "worker-0",
+		Name:       "ens2f1",
+		PCIAddress: "0000:3b:00.1",
+		Vendor:     "8086",
+		DeviceID:   "159b",
+		Driver:     "ice",
+		LinkSpeed:  25000,
+		LinkType:   "ETH",
+		TotalVFs:   64,
+	}, pfs[0])
+	assert.Equal(t, "worker-0/ens2f1 (8086:159b ice)", pfs[0].String())
+	assert.Equal(t, 0, pfs[2].LinkSpeed)
+}
+
+func TestSelect(t *testing.T) {
+	pfs := append(FromInterfaces("worker-1", testInterfaces), FromInterfaces("worker-0", testInterfaces)...)
+
+	testCases := []struct {
+		name          string
+		filter        Filter
+		expectedNames []string
+	}{
+		{
+			name: "no filter",
+			expectedNames: []string{
+				"worker-0/ens1f0", "worker-0/ens1f1", "worker-0/ens2f0", "worker-0/ens2f1",
+				"worker-1/ens1f0", "worker-1/ens1f1", "worker-1/ens2f0", "worker-1/ens2f1",
+			},
+		},
+		{
+			name:          "link up",
+			filter:        Filter{LinkUp: true},
+			expectedNames: []string{"worker-0/ens1f0", "worker-0/ens2f1", "worker-1/ens1f0", "worker-1/ens2f1"},
+		},
+		{
+			name:          "names and vendor",
+			filter:        Filter{Names: []string{"ens1f1", "ens2f1"}, Vendors: []string{"8086"}},
+			expectedNames: []string{"worker-0/ens2f1", "worker-1/ens2f1"},
+		},
+		{
+			name:          "device id and driver",
+			filter:        Filter{DeviceIDs: []string{"1017"}, Drivers: []string{"mlx5_core", "ice"}},
+			expectedNames: []string{"worker-0/ens1f0", "worker-0/ens1f1", "worker-1/ens1f0", "worker-1/ens1f1"},
+		},
+		{
+			name:          "speed and total vfs",
+			filter:        Filter{MinLinkSpeed: 25000, MinTotalVFs: 16},
+			expectedNames: []string{"worker-0/ens2f0", "worker-0/ens2f1", "worker-1/ens2f0", "worker-1/ens2f1"},
+		},
+		{
+			name:          "rdma with link up",
+			filter:        Filter{RDMA: true, LinkUp: true},
+			expectedNames: []string{"worker-0/ens1f0", "worker-1/ens1f0"},
+		},
+		{
+			name:   "nothing matches",
+			filter: Filter{Names: []string{"eno1"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var names []string
+
+			for _, pf := range Select(pfs, testCase.filter) {
+				names = append(names, pf.Node+"/"+pf.Name)
+			}
+
+			assert.Equal(t, testCase.expectedNames, names)
+		})
+	}
+}
+
+func TestParseLinkSpeed(t *testing.T) {
+	testCases := []struct {
+		name      string
+		linkSpeed string
+		expected  int
+	}{
+		{name: "with unit", linkSpeed: "25000 Mb/s", expected: 25000},
+		{name: "without unit", linkSpeed: "10000", expected: 10000},
+		{name: "down", linkSpeed: "-1 Mb/s", expected: 0},
+		{name: "unknown", linkSpeed: "Unknown!", expected: 0},
+		{name: "empty", linkSpeed: "", expected: 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, parseLinkSpeed(testCase.linkSpeed))
+		})
+	}
+}
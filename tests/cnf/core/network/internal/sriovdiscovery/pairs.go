@@ -0,0 +1,94 @@
+package sriovdiscovery
+
+import (
+	"fmt"
+)
+
+// Topology is how the two PFs of a pair are placed relative to each other.
+type Topology string
+
+const (
+	// TopologySamePF pairs a PF with itself, so traffic goes between VFs of the same PF.
+	TopologySamePF Topology = "the same PF on both sides"
+	// TopologyDifferentPFSameNode pairs two different PFs on the same node.
+	TopologyDifferentPFSameNode Topology = "different PFs on the same node"
+	// TopologyDifferentNodes pairs two PFs on different nodes.
+	TopologyDifferentNodes Topology = "PFs on different nodes"
+)
+
+// Constraints are the constraints the two PFs of a pair must satisfy.
+type Constraints struct {
+	// Topology is how the PFs are placed relative to each other.
+	Topology Topology
+	// SameModel requires both PFs to have the same vendor and device ID.
+	SameModel bool
+}
+
+// Pair is a pair of PFs to run traffic between, such as the PFs of a client and a server pod.
+type Pair struct {
+	Client PF
+	Server PF
+}
+
+// String returns the constraints as a phrase such as different PFs on the same node of the same model.
+func (constraints Constraints) String() string {
+	if constraints.SameModel {
+		return string(constraints.Topology) + " of the same model"
+	}
+
+	return string(constraints.Topology)
+}
+
+// Match returns whether pair satisfies constraints.
+func (constraints Constraints) Match(pair Pair) bool {
+	if constraints.SameModel && pair.Client.Model() != pair.Server.Model() {
+		return false
+	}
+
+	samePF := pair.Client.Node == pair.Server.Node && pair.Client.Name == pair.Server.Name
+
+	switch constraints.Topology {
+	case TopologySamePF:
+		return samePF
+	case TopologyDifferentPFSameNode:
+		return pair.Client.Node == pair.Server.Node && !samePF
+	case TopologyDifferentNodes:
+		return pair.Client.Node != pair.Server.Node
+	default:
+		return false
+	}
+}
+
+// Pairs returns all pairs of pfs satisfying constraints, in the order of pfs. Pairs of two different PFs are only
+// returned once, with the PF that comes first in pfs as the client.
+func Pairs(pfs []PF, constraints Constraints) []Pair {
+	var pairs []Pair
+
+	for clientIndex, client := range pfs {
+		for _, server := range pfs[clientIndex:] {
+			pair := Pair{Client: client, Server: server}
+
+			if constraints.Match(pair) {
+				pairs = append(pairs, pair)
+			}
+		}
+	}
+
+	return pairs
+}
+
+// FindPair returns the first pair of pfs satisfying constraints. The error explains which topology is missing, so it
+// can be used as the reason to skip a test.
+func FindPair(pfs []PF, constraints Constraints) (Pair, error) {
+	if constraints.Topology != TopologySamePF && constraints.Topology != TopologyDifferentPFSameNode &&
+		constraints.Topology != TopologyDifferentNodes {
+		return Pair{}, fmt.Errorf("unknown SR-IOV PF topology %q", constraints.Topology)
+	}
+
+	pairs := Pairs(pfs, constraints)
+	if len(pairs) == 0 {
+		return Pair{}, fmt.Errorf("no SR-IOV PF pair with %s found among discovered PFs %v", constraints, pfs)
+	}
+
+	return pairs[0], nil
+}
@@ -0,0 +1,93 @@
+package sriovdiscovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindPair(t *testing.T) {
+	worker0Mlx := PF{Node: "worker-0", Name: "ens1f0", Vendor: "15b3", DeviceID: "1017", Driver: "mlx5_core"}
+	worker0Intel := PF{Node: "worker-0", Name: "ens2f0", Vendor: "8086", DeviceID: "159b", Driver: "ice"}
+	worker1Intel := PF{Node: "worker-1", Name: "ens2f0", Vendor: "8086", DeviceID: "159b", Driver: "ice"}
+
+	testCases := []struct {
+		name          string
+		pfs           []PF
+		constraints   Constraints
+		expectedPair  Pair
+		expectedError string
+	}{
+		{
+			name:         "same pf",
+			pfs:          []PF{worker0Mlx, worker0Intel},
+			constraints:  Constraints{Topology: TopologySamePF},
+			expectedPair: Pair{Client: worker0Mlx, Server: worker0Mlx},
+		},
+		{
+			name:         "different pf on the same node",
+			pfs:          []PF{worker0Mlx, worker0Intel, worker1Intel},
+			constraints:  Constraints{Topology: TopologyDifferentPFSameNode},
+			expectedPair: Pair{Client: worker0Mlx, Server: worker0Intel},
+		},
+		{
+			name:        "different pf on the same node of the same model",
+			pfs:         []PF{worker0Mlx, worker0Intel, worker1Intel},
+			constraints: Constraints{Topology: TopologyDifferentPFSameNode, SameModel: true},
+			expectedError: "no SR-IOV PF pair with different PFs on the same node of the same model found among " +
+				"discovered PFs [worker-0/ens1f0 (15b3:1017 mlx5_core) worker-0/ens2f0 (8086:159b ice) " +
+				"worker-1/ens2f0 (8086:159b ice)]",
+		},
+		{
+			name:         "different nodes of the same model",
+			pfs:          []PF{worker0Mlx, worker0Intel, worker1Intel},
+			constraints:  Constraints{Topology: TopologyDifferentNodes, SameModel: true},
+			expectedPair: Pair{Client: worker0Intel, Server: worker1Intel},
+		},
+		{
+			name:        "single node",
+			pfs:         []PF{worker0Mlx, worker0Intel},
+			constraints: Constraints{Topology: TopologyDifferentNodes},
+			expectedError: "no SR-IOV PF pair with PFs on different nodes found among discovered PFs " +
+				"[worker-0/ens1f0 (15b3:1017 mlx5_core) worker-0/ens2f0 (8086:159b ice)]",
+		},
+		{
+			name:          "no pfs",
+			constraints:   Constraints{Topology: TopologySamePF},
+			expectedError: "no SR-IOV PF pair with the same PF on both sides found among discovered PFs []",
+		},
+		{
+			name:          "unknown topology",
+			pfs:           []PF{worker0Mlx},
+			constraints:   Constraints{Topology: "same switch"},
+			expectedError: `unknown SR-IOV PF topology "same switch"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			pair, err := FindPair(testCase.pfs, testCase.constraints)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedPair, pair)
+		})
+	}
+}
+
+func TestPairs(t *testing.T) {
+	pfs := []PF{
+		{Node: "worker-0", Name: "ens1f0"},
+		{Node: "worker-0", Name: "ens1f1"},
+		{Node: "worker-1", Name: "ens1f0"},
+	}
+
+	assert.Len(t, Pairs(pfs, Constraints{Topology: TopologySamePF}), 3)
+	assert.Len(t, Pairs(pfs, Constraints{Topology: TopologyDifferentPFSameNode}), 1)
+	assert.Len(t, Pairs(pfs, Constraints{Topology: TopologyDifferentNodes}), 2)
+}
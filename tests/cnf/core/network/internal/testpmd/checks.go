@@ -0,0 +1,121 @@
+package testpmd
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Check verifies the statistics of a port in the result of a testpmd run.
+type Check func(result Result, port int) error
+
+// Check runs checks against the statistics of port and returns the errors of all failed checks.
+func (result Result) Check(port int, checks ...Check) error {
+	if _, ok := result.Latest(port); !ok {
+		return fmt.Errorf("no statistics found for port %d in testpmd output", port)
+	}
+
+	var errs []error
+
+	for _, check := range checks {
+		if err := check(result, port); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ReceivedPackets checks that the port received packets.
+func ReceivedPackets() Check {
+	return func(result Result, port int) error {
+		latest, _ := result.Latest(port)
+		if latest.RxPackets == 0 {
+			return fmt.Errorf("port %d received no packets", port)
+		}
+
+		return nil
+	}
+}
+
+// SentPackets checks that the port sent packets.
+func SentPackets() Check {
+	return func(result Result, port int) error {
+		latest, _ := result.Latest(port)
+		if latest.TxPackets == 0 {
+			return fmt.Errorf("port %d sent no packets", port)
+		}
+
+		return nil
+	}
+}
+
+// MinRxPPS checks that the port received at least pps packets per second on average.
+func MinRxPPS(pps uint64) Check {
+	return func(result Result, port int) error {
+		average, err := averageRate(result, port, func(stats PortStats) uint64 { return stats.RxPPS })
+		if err != nil {
+			return err
+		}
+
+		if average < pps {
+			return fmt.Errorf("port %d received %d packets per second, expected at least %d", port, average, pps)
+		}
+
+		return nil
+	}
+}
+
+// MinTxPPS checks that the port sent at least pps packets per second on average.
+func MinTxPPS(pps uint64) Check {
+	return func(result Result, port int) error {
+		average, err := averageRate(result, port, func(stats PortStats) uint64 { return stats.TxPPS })
+		if err != nil {
+			return err
+		}
+
+		if average < pps {
+			return fmt.Errorf("port %d sent %d packets per second, expected at least %d", port, average, pps)
+		}
+
+		return nil
+	}
+}
+
+// NoDrops checks that the port neither missed nor dropped packets and had no errors.
+func NoDrops() Check {
+	return func(result Result, port int) error {
+		latest, _ := result.Latest(port)
+		if latest.RxMissed != 0 || latest.RxErrors != 0 || latest.RxNoMbuf != 0 || latest.TxErrors != 0 {
+			return fmt.Errorf("port %d has %d rx missed, %d rx errors, %d rx nombuf and %d tx errors packets",
+				port, latest.RxMissed, latest.RxErrors, latest.RxNoMbuf, latest.TxErrors)
+		}
+
+		forward, ok := result.ForwardStats(port)
+		if ok && (forward.RxDropped != 0 || forward.TxDropped != 0) {
+			return fmt.Errorf("port %d dropped %d rx and %d tx packets", port, forward.RxDropped, forward.TxDropped)
+		}
+
+		return nil
+	}
+}
+
+// averageRate returns the average of rate over the samples of port. The first sample is skipped when there are more
+// since its rate covers the time before forwarding started.
+func averageRate(result Result, port int, rate func(stats PortStats) uint64) (uint64, error) {
+	samples := result.PortSamples(port)
+	if len(samples) > 1 {
+		samples = samples[1:]
+	}
+
+	if len(samples) == 0 {
+		return 0, fmt.Errorf("no statistics found for port %d in testpmd output", port)
+	}
+
+	var total uint64
+
+	for _, sample := range samples {
+		total += rate(sample)
+	}
+
+	return total / uint64(len(samples)), nil
+}
@@ -0,0 +1,74 @@
+package testpmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultCheck(t *testing.T) {
+	result := ParseOutput(testOutput)
+
+	testCases := []struct {
+		name          string
+		port          int
+		checks        []Check
+		expectedError string
+	}{
+		{
+			name:   "received packets at rate",
+			port:   0,
+			checks: []Check{ReceivedPackets(), MinRxPPS(1000000)},
+		},
+		{
+			name:          "rate below minimum",
+			port:          0,
+			checks:        []Check{MinRxPPS(1000001)},
+			expectedError: "port 0 received 1000000 packets per second, expected at least 1000001",
+		},
+		{
+			name:          "nothing sent",
+			port:          0,
+			checks:        []Check{SentPackets(), MinTxPPS(1)},
+			expectedError: "port 0 sent no packets\nport 0 sent 0 packets per second, expected at least 1",
+		},
+		{
+			name:          "drops",
+			port:          0,
+			checks:        []Check{NoDrops()},
+			expectedError: "port 0 has 12 rx missed, 1 rx errors, 2 rx nombuf and 0 tx errors packets",
+		},
+		{
+			name:          "unknown port",
+			port:          1,
+			checks:        []Check{ReceivedPackets()},
+			expectedError: "no statistics found for port 1 in testpmd output",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := result.Check(testCase.port, testCase.checks...)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestNoDropsForwardStats(t *testing.T) {
+	result := Result{
+		Samples: []PortStats{{Port: 0, RxPackets: 10}},
+		Forward: []ForwardStats{{Port: 0, RxPackets: 10, TxDropped: 3}},
+	}
+
+	assert.EqualError(t, result.Check(0, NoDrops()), "port 0 dropped 0 rx and 3 tx packets")
+
+	result.Forward[0].TxDropped = 0
+	assert.Nil(t, result.Check(0, NoDrops()))
+}
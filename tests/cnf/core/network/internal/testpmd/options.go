@@ -0,0 +1,197 @@
+package testpmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// DefaultBinary is the testpmd binary in the DPDK test container.
+	DefaultBinary = "dpdk-testpmd"
+
+	// ForwardModeIO forwards packets received on one port to its peer port without changes.
+	ForwardModeIO = "io"
+	// ForwardModeMACSwap swaps the source and destination MAC addresses of received packets and sends them back.
+	ForwardModeMACSwap = "macswap"
+	// ForwardModeRxOnly receives and drops packets.
+	ForwardModeRxOnly = "rxonly"
+	// ForwardModeTxOnly generates and sends packets without receiving.
+	ForwardModeTxOnly = "txonly"
+)
+
+type (
+	// Options are the options of a testpmd run. Fields left as their zero value are omitted from the command so the
+	// testpmd default is used. Values are not quoted so they can reference environment variables of the container,
+	// such as the PCIDEVICE variables set by the SR-IOV device plugin.
+	Options struct {
+		// Binary is the testpmd binary. Defaults to DefaultBinary.
+		Binary string
+
+		// Cores is the EAL core list, such as 2-4.
+		Cores string
+		// PCIAddresses are the devices testpmd is allowed to use. The ports of the devices are numbered in this order.
+		PCIAddresses []string
+		// VDevs are the virtual devices to create, such as a virtio_user device backed by vhost-net. Their ports are
+		// numbered after the ports of PCIAddresses.
+		VDevs []string
+		// IOVAMode is the EAL IOVA mode, pa or va.
+		IOVAMode string
+
+		// ForwardMode is the packet forwarding mode, such as ForwardModeTxOnly.
+		ForwardMode string
+		// PortMask is the hexadecimal mask of ports used for forwarding, such as 0x1.
+		PortMask string
+		// PortTopology is how ports are paired for forwarding, such as paired, chained, or loop.
+		PortTopology string
+		// ForwardingCores is the number of cores used for forwarding.
+		ForwardingCores int
+		// RxQueues and TxQueues are the number of queues per port.
+		RxQueues int
+		TxQueues int
+		// RxDescriptors and TxDescriptors are the number of descriptors per queue.
+		RxDescriptors int
+		TxDescriptors int
+		// EthPeers are the destination MAC addresses of the packets sent by each port.
+		EthPeers []EthPeer
+		// TxIPs is the source and destination IP addresses of txonly packets, such as 198.18.0.1,198.18.0.2.
+		TxIPs string
+
+		// HWVLANStrip enables stripping VLAN tags from received packets in hardware.
+		HWVLANStrip bool
+		// HWQinQStrip enables stripping both QinQ tags from received packets in hardware.
+		HWQinQStrip bool
+		// TxVLANs are the VLAN tags inserted into packets sent by each port.
+		TxVLANs []TxVLAN
+
+		// NoMlockall disables locking all memory, which is needed without the IPC_LOCK capability.
+		NoMlockall bool
+		// CmdlineFile is the path of a file with commands to run before forwarding starts. Use StartupCommands for
+		// its contents.
+		CmdlineFile string
+		// StatsPeriod is the period in seconds between the port statistics printed when not interactive.
+		StatsPeriod int
+		// Commands are interactive commands run before forwarding starts, after the commands for TxVLANs.
+		Commands []string
+		// Extra are additional testpmd options added to the end of the command.
+		Extra []string
+	}
+
+	// EthPeer is the destination MAC address of the packets sent by Port.
+	EthPeer struct {
+		Port int
+		MAC  string
+	}
+
+	// TxVLAN is a VLAN tag inserted into packets sent by Port. If OuterVLAN is set, packets are double tagged with
+	// OuterVLAN as the service VLAN and VLAN as the customer VLAN.
+	TxVLAN struct {
+		Port      int
+		VLAN      uint16
+		OuterVLAN uint16
+	}
+)
+
+// String returns the testpmd command line, without the interactive flag.
+func (options Options) String() string {
+	return options.command(false)
+}
+
+// ContainerCommand returns the command of a container that runs testpmd until it is stopped.
+func (options Options) ContainerCommand() []string {
+	return []string{"/bin/bash", "-c", options.String()}
+}
+
+// StartupCommands returns the interactive commands run before forwarding starts, which configure TxVLANs and run
+// Commands, followed by the start command. It is the contents of CmdlineFile when testpmd is not run interactively.
+func (options Options) StartupCommands() []string {
+	var commands []string
+
+	for _, txVLAN := range options.TxVLANs {
+		setCommand := fmt.Sprintf("tx_vlan set %d %d", txVLAN.Port, txVLAN.VLAN)
+		if txVLAN.OuterVLAN != 0 {
+			setCommand += fmt.Sprintf(" %d", txVLAN.OuterVLAN)
+		}
+
+		commands = append(commands,
+			fmt.Sprintf("port stop %d", txVLAN.Port), setCommand, fmt.Sprintf("port start %d", txVLAN.Port))
+	}
+
+	commands = append(commands, options.Commands...)
+
+	return append(commands, "start")
+}
+
+// command returns the testpmd command line, adding the interactive flag if interactive is true.
+func (options Options) command(interactive bool) string {
+	binary := options.Binary
+	if binary == "" {
+		binary = DefaultBinary
+	}
+
+	args := []string{binary}
+	args = appendOption(args, "-l", options.Cores)
+
+	for _, pciAddress := range options.PCIAddresses {
+		args = append(args, "-a", pciAddress)
+	}
+
+	for _, vdev := range options.VDevs {
+		args = append(args, "--vdev="+vdev)
+	}
+
+	args = appendOption(args, "--iova-mode=", options.IOVAMode)
+	args = append(args, "--")
+
+	if interactive {
+		args = append(args, "-i")
+	}
+
+	args = appendOption(args, "--portmask=", options.PortMask)
+	args = appendOption(args, "--nb-cores=", options.ForwardingCores)
+	args = appendOption(args, "--forward-mode=", options.ForwardMode)
+	args = appendOption(args, "--port-topology=", options.PortTopology)
+	args = appendOption(args, "--rxq=", options.RxQueues)
+	args = appendOption(args, "--txq=", options.TxQueues)
+	args = appendOption(args, "--rxd=", options.RxDescriptors)
+	args = appendOption(args, "--txd=", options.TxDescriptors)
+
+	for _, peer := range options.EthPeers {
+		args = append(args, fmt.Sprintf("--eth-peer=%d,%s", peer.Port, peer.MAC))
+	}
+
+	args = appendOption(args, "--tx-ip=", options.TxIPs)
+	args = appendFlag(args, "--enable-hw-vlan-strip", options.HWVLANStrip)
+	args = appendFlag(args, "--enable-hw-qinq-strip", options.HWQinQStrip)
+	args = appendFlag(args, "--no-mlockall", options.NoMlockall)
+	args = appendOption(args, "--cmdline-file=", options.CmdlineFile)
+
+	if !interactive {
+		args = appendOption(args, "--stats-period=", options.StatsPeriod)
+	}
+
+	return strings.Join(append(args, options.Extra...), " ")
+}
+
+// appendOption appends the option with value to args if value is not the zero value. Options ending with = are
+// joined with their value while other options are separate arguments.
+func appendOption[T comparable](args []string, option string, value T) []string {
+	var zero T
+	if value == zero {
+		return args
+	}
+
+	if strings.HasSuffix(option, "=") {
+		return append(args, fmt.Sprintf("%s%v", option, value))
+	}
+
+	return append(args, option, fmt.Sprint(value))
+}
+
+// appendFlag appends flag to args if set is true.
+func appendFlag(args []string, flag string, set bool) []string {
+	if set {
+		return append(args, flag)
+	}
+
+	return args
+}
@@ -0,0 +1,123 @@
+package testpmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionsString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		options  Options
+		expected string
+	}{
+		{
+			name:     "defaults",
+			options:  Options{},
+			expected: "dpdk-testpmd --",
+		},
+		{
+			name: "txonly with peer and tx ip",
+			options: Options{
+				PCIAddresses: []string{"${PCIDEVICE_OPENSHIFT_IO_DPDKPOLICY}"},
+				ForwardMode:  ForwardModeTxOnly,
+				EthPeers:     []EthPeer{{Port: 0, MAC: "60:00:00:00:00:01"}},
+				TxIPs:        "198.18.0.1,198.18.0.2",
+				StatsPeriod:  5,
+			},
+			expected: "dpdk-testpmd -a ${PCIDEVICE_OPENSHIFT_IO_DPDKPOLICY} -- --forward-mode=txonly " +
+				"--eth-peer=0,60:00:00:00:00:01 --tx-ip=198.18.0.1,198.18.0.2 --stats-period=5",
+		},
+		{
+			name: "all options",
+			options: Options{
+				Binary:          "testpmd",
+				Cores:           "2-4",
+				PCIAddresses:    []string{"0000:3b:02.0", "0000:3b:02.1"},
+				VDevs:           []string{"virtio_user0,path=/dev/vhost-net,iface=tap0"},
+				IOVAMode:        "va",
+				ForwardMode:     ForwardModeMACSwap,
+				PortMask:        "0x3",
+				PortTopology:    "loop",
+				ForwardingCores: 2,
+				RxQueues:        2,
+				TxQueues:        2,
+				RxDescriptors:   1024,
+				TxDescriptors:   1024,
+				HWVLANStrip:     true,
+				HWQinQStrip:     true,
+				NoMlockall:      true,
+				CmdlineFile:     "/etc/cmd/cmd_file",
+				StatsPeriod:     5,
+				Extra:           []string{"--auto-start"},
+			},
+			expected: "testpmd -l 2-4 -a 0000:3b:02.0 -a 0000:3b:02.1 " +
+				"--vdev=virtio_user0,path=/dev/vhost-net,iface=tap0 --iova-mode=va -- --portmask=0x3 --nb-cores=2 " +
+				"--forward-mode=macswap --port-topology=loop --rxq=2 --txq=2 --rxd=1024 --txd=1024 " +
+				"--enable-hw-vlan-strip --enable-hw-qinq-strip --no-mlockall --cmdline-file=/etc/cmd/cmd_file " +
+				"--stats-period=5 --auto-start",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.options.String())
+		})
+	}
+}
+
+func TestOptionsStartupCommands(t *testing.T) {
+	options := Options{
+		TxVLANs:  []TxVLAN{{Port: 0, VLAN: 100}, {Port: 1, VLAN: 100, OuterVLAN: 200}},
+		Commands: []string{"set promisc all on"},
+	}
+
+	assert.Equal(t, []string{
+		"port stop 0",
+		"tx_vlan set 0 100",
+		"port start 0",
+		"port stop 1",
+		"tx_vlan set 1 100 200",
+		"port start 1",
+		"set promisc all on",
+		"start",
+	}, options.StartupCommands())
+}
+
+func TestOptionsCommand(t *testing.T) {
+	options := Options{
+		PCIAddresses: []string{"0000:3b:02.0"},
+		ForwardMode:  ForwardModeRxOnly,
+		Commands:     []string{"set fwd 'rxonly'"},
+		CmdlineFile:  "/etc/cmd/cmd_file",
+		StatsPeriod:  5,
+	}
+
+	testCases := []struct {
+		name        string
+		interactive bool
+		expected    string
+	}{
+		{
+			name:        "killed after duration",
+			interactive: false,
+			expected: "timeout -s SIGKILL 20 dpdk-testpmd -a 0000:3b:02.0 -- --forward-mode=rxonly " +
+				"--cmdline-file=/etc/cmd/cmd_file --stats-period=5",
+		},
+		{
+			name:        "interactive",
+			interactive: true,
+			expected: `{ printf '%s\n' 'set fwd '\''rxonly'\''' 'start' 'show port stats all'; sleep 20; ` +
+				`printf '%s\n' 'show port stats all' 'stop' 'quit'; } | ` +
+				"dpdk-testpmd -a 0000:3b:02.0 -- -i --forward-mode=rxonly",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, options.Command(19500*time.Millisecond, testCase.interactive))
+		})
+	}
+}
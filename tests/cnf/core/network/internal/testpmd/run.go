@@ -0,0 +1,67 @@
+package testpmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+)
+
+const killedError = "command terminated with exit code 137"
+
+// Command returns the shell command that runs testpmd for duration. If interactive is true, testpmd runs the
+// StartupCommands, prints the port statistics when forwarding starts and again after duration, then stops forwarding
+// and quits, which also prints the forward statistics. Otherwise testpmd is killed after duration and only prints
+// the periodic port statistics, so StatsPeriod should be set.
+func (options Options) Command(duration time.Duration, interactive bool) string {
+	seconds := int(math.Ceil(duration.Seconds()))
+
+	if !interactive {
+		return fmt.Sprintf("timeout -s SIGKILL %d %s", seconds, options.command(false))
+	}
+
+	options.CmdlineFile = ""
+	startCommands := append(options.StartupCommands(), "show port stats all")
+	stopCommands := []string{"show port stats all", "stop", "quit"}
+
+	return fmt.Sprintf("{ %s; sleep %d; %s; } | %s",
+		printfCommand(startCommands), seconds, printfCommand(stopCommands), options.command(true))
+}
+
+// Run runs testpmd with options in runningPod for duration and returns the statistics it printed. See Command for
+// the difference between interactive and non-interactive runs. The pod is expected to be running or about to be.
+func Run(runningPod *pod.Builder, options Options, duration time.Duration, interactive bool,
+	containerName ...string) (Result, error) {
+	command := options.Command(duration, interactive)
+
+	glog.V(90).Infof("Running testpmd command %s in pod %s", command, runningPod.Definition.Name)
+
+	err := runningPod.WaitUntilRunning(time.Minute)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to wait until pod %s is running: %w", runningPod.Definition.Name, err)
+	}
+
+	output, err := runningPod.ExecCommand([]string{"/bin/bash", "-c", command}, containerName...)
+	if err != nil && (interactive || err.Error() != killedError) {
+		return Result{}, fmt.Errorf("failed to run testpmd command %s in pod %s with output %s: %w",
+			command, runningPod.Definition.Name, output.String(), err)
+	}
+
+	glog.V(90).Infof("Processing testpmd output from pod %s\n%s", runningPod.Definition.Name, output.String())
+
+	return ParseOutput(output.String()), nil
+}
+
+// printfCommand returns a printf command that prints each line on its own line.
+func printfCommand(lines []string) string {
+	quoted := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		quoted = append(quoted, "'"+strings.ReplaceAll(line, "'", `'\''`)+"'")
+	}
+
+	return `printf '%s\n' ` + strings.Join(quoted, " ")
+}
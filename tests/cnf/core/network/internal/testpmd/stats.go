@@ -0,0 +1,182 @@
+package testpmd
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	nicStatsHeader     = regexp.MustCompile(`NIC statistics for port (\d+)`)
+	forwardStatsHeader = regexp.MustCompile(`Forward statistics for port (\d+)`)
+	statsCounter       = regexp.MustCompile(`([A-Za-z]+-[A-Za-z]+):\s*(\d+)`)
+)
+
+type (
+	// PortStats are the statistics of a port printed by testpmd periodically or by show port stats. Packet and byte
+	// counters are totals since the port was started while rates are averages since the previous statistics of the
+	// port.
+	PortStats struct {
+		Port      int
+		RxPackets uint64
+		RxMissed  uint64
+		RxBytes   uint64
+		RxErrors  uint64
+		RxNoMbuf  uint64
+		TxPackets uint64
+		TxErrors  uint64
+		TxBytes   uint64
+		RxPPS     uint64
+		RxBPS     uint64
+		TxPPS     uint64
+		TxBPS     uint64
+	}
+
+	// ForwardStats are the statistics of a port printed by testpmd when forwarding stops.
+	ForwardStats struct {
+		Port      int
+		RxPackets uint64
+		RxDropped uint64
+		TxPackets uint64
+		TxDropped uint64
+	}
+
+	// Result is the statistics parsed from the output of testpmd.
+	Result struct {
+		// Samples are the port statistics in the order they were printed.
+		Samples []PortStats
+		// Forward are the forward statistics printed when forwarding stopped, which are missing if testpmd was killed.
+		Forward []ForwardStats
+		// Output is the output of testpmd.
+		Output string
+	}
+)
+
+// ParseOutput parses the port and forward statistics in the output of testpmd.
+func ParseOutput(output string) Result {
+	result := Result{Output: output}
+
+	var (
+		portStats    *PortStats
+		forwardStats *ForwardStats
+	)
+
+	for _, line := range strings.Split(output, "\n") {
+		if match := nicStatsHeader.FindStringSubmatch(line); match != nil {
+			port, _ := strconv.Atoi(match[1])
+			result.Samples = append(result.Samples, PortStats{Port: port})
+			portStats, forwardStats = &result.Samples[len(result.Samples)-1], nil
+
+			continue
+		}
+
+		if match := forwardStatsHeader.FindStringSubmatch(line); match != nil {
+			port, _ := strconv.Atoi(match[1])
+			result.Forward = append(result.Forward, ForwardStats{Port: port})
+			portStats, forwardStats = nil, &result.Forward[len(result.Forward)-1]
+
+			continue
+		}
+
+		if isStatsFooter(line) {
+			portStats, forwardStats = nil, nil
+
+			continue
+		}
+
+		for _, match := range statsCounter.FindAllStringSubmatch(line, -1) {
+			value, err := strconv.ParseUint(match[2], 10, 64)
+			if err != nil {
+				continue
+			}
+
+			name := strings.ToLower(match[1])
+
+			switch {
+			case portStats != nil:
+				portStats.set(name, value)
+			case forwardStats != nil:
+				forwardStats.set(name, value)
+			}
+		}
+	}
+
+	return result
+}
+
+// PortSamples returns the port statistics of port in the order they were printed.
+func (result Result) PortSamples(port int) []PortStats {
+	var samples []PortStats
+
+	for _, sample := range result.Samples {
+		if sample.Port == port {
+			samples = append(samples, sample)
+		}
+	}
+
+	return samples
+}
+
+// Latest returns the last port statistics of port and whether there are any.
+func (result Result) Latest(port int) (PortStats, bool) {
+	samples := result.PortSamples(port)
+	if len(samples) == 0 {
+		return PortStats{}, false
+	}
+
+	return samples[len(samples)-1], true
+}
+
+// ForwardStats returns the forward statistics of port and whether there are any.
+func (result Result) ForwardStats(port int) (ForwardStats, bool) {
+	for _, stats := range result.Forward {
+		if stats.Port == port {
+			return stats, true
+		}
+	}
+
+	return ForwardStats{}, false
+}
+
+// set sets the counter with the lowercase name to value.
+func (stats *PortStats) set(name string, value uint64) {
+	counters := map[string]*uint64{
+		"rx-packets": &stats.RxPackets,
+		"rx-missed":  &stats.RxMissed,
+		"rx-bytes":   &stats.RxBytes,
+		"rx-errors":  &stats.RxErrors,
+		"rx-nombuf":  &stats.RxNoMbuf,
+		"tx-packets": &stats.TxPackets,
+		"tx-errors":  &stats.TxErrors,
+		"tx-bytes":   &stats.TxBytes,
+		"rx-pps":     &stats.RxPPS,
+		"rx-bps":     &stats.RxBPS,
+		"tx-pps":     &stats.TxPPS,
+		"tx-bps":     &stats.TxBPS,
+	}
+
+	if counter, ok := counters[name]; ok {
+		*counter = value
+	}
+}
+
+// set sets the counter with the lowercase name to value.
+func (stats *ForwardStats) set(name string, value uint64) {
+	counters := map[string]*uint64{
+		"rx-packets": &stats.RxPackets,
+		"rx-dropped": &stats.RxDropped,
+		"tx-packets": &stats.TxPackets,
+		"tx-dropped": &stats.TxDropped,
+	}
+
+	if counter, ok := counters[name]; ok {
+		*counter = value
+	}
+}
+
+// isStatsFooter returns whether line is the line of # or - that ends a block of statistics.
+func isStatsFooter(line string) bool {
+	trimmed := strings.TrimSpace(line)
+
+	return len(trimmed) > 0 && (strings.Trim(trimmed, "#") == "" || strings.Trim(trimmed, "-") == "")
+}
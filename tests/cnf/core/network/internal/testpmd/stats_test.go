@@ -0,0 +1,110 @@
+package testpmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testOutput = `EAL: Detected CPU lcores: 8
+Port 0: 60:00:00:00:00:02
+Checking link statuses...
+Done
+
+  ######################## NIC statistics for port 0  ########################
+  RX-packets: 0          RX-missed: 0          RX-bytes:  0
+  RX-errors: 0
+  RX-nombuf:  0
+  TX-packets: 0          TX-errors: 0          TX-bytes:  0
+
+  Throughput (since last show)
+  Rx-pps:            0          Rx-bps:            0
+  Tx-pps:            0          Tx-bps:            0
+  ############################################################################
+
+  ######################## NIC statistics for port 0  ########################
+  RX-packets: 5000000    RX-missed: 0          RX-bytes:  320000000
+  RX-errors: 0
+  RX-nombuf:  0
+  TX-packets: 0          TX-errors: 0          TX-bytes:  0
+
+  Throughput (since last show)
+  Rx-pps:       999000          Rx-bps:    511488000
+  Tx-pps:            0          Tx-bps:            0
+  ############################################################################
+
+  ######################## NIC statistics for port 0  ########################
+  RX-packets: 10000000   RX-missed: 12         RX-bytes:  640000000
+  RX-errors: 1
+  RX-nombuf:  2
+  TX-packets: 0          TX-errors: 0          TX-bytes:  0
+
+  Throughput (since last show)
+  Rx-pps:      1001000          Rx-bps:    512512000
+  Tx-pps:            0          Tx-bps:            0
+  ############################################################################
+Telling cores to stop...
+Waiting for lcores to finish...
+
+  ---------------------- Forward statistics for port 0  ----------------------
+  RX-packets: 10000000       RX-dropped: 12            RX-total: 10000012
+  TX-packets: 0              TX-dropped: 0             TX-total: 0
+  ----------------------------------------------------------------------------
+
+  +++++++++++++++ Accumulated forward statistics for all ports+++++++++++++++
+  RX-packets: 10000000       RX-dropped: 12            RX-total: 10000012
+  TX-packets: 0              TX-dropped: 0             TX-total: 0
+  ++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++++
+`
+
+func TestParseOutput(t *testing.T) {
+	result := ParseOutput(testOutput)
+
+	assert.Equal(t, []PortStats{
+		{Port: 0},
+		{Port: 0, RxPackets: 5000000, RxBytes: 320000000, RxPPS: 999000, RxBPS: 511488000},
+		{
+			Port:      0,
+			RxPackets: 10000000,
+			RxMissed:  12,
+			RxBytes:   640000000,
+			RxErrors:  1,
+			RxNoMbuf:  2,
+			RxPPS:     1001000,
+			RxBPS:     512512000,
+		},
+	}, result.Samples)
+	assert.Equal(t, []ForwardStats{{Port: 0, RxPackets: 10000000, RxDropped: 12}}, result.Forward)
+	assert.Equal(t, testOutput, result.Output)
+
+	latest, ok := result.Latest(0)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(10000000), latest.RxPackets)
+
+	_, ok = result.Latest(1)
+	assert.False(t, ok)
+
+	_, ok = result.ForwardStats(1)
+	assert.False(t, ok)
+}
+
+func TestParseOutputMultiplePorts(t *testing.T) {
+	result := ParseOutput(`
+  ######################## NIC statistics for port 0  ########################
+  RX-packets: 10         RX-missed: 0          RX-bytes:  640
+  TX-packets: 20         TX-errors: 0          TX-bytes:  1280
+  ############################################################################
+
+  ######################## NIC statistics for port 1  ########################
+  RX-packets: 30         RX-missed: 0          RX-bytes:  1920
+  TX-packets: 40         TX-errors: 0          TX-bytes:  2560
+  ############################################################################
+  RX-packets: 50
+`)
+
+	assert.Equal(t, []PortStats{
+		{Port: 0, RxPackets: 10, RxBytes: 640, TxPackets: 20, TxBytes: 1280},
+		{Port: 1, RxPackets: 30, RxBytes: 1920, TxPackets: 40, TxBytes: 2560},
+	}, result.Samples)
+	assert.Empty(t, result.Forward)
+}
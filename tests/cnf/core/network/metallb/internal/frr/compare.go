@@ -0,0 +1,109 @@
+package frr
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Missing returns a description of each part of expected that is not in actual, or nil if actual contains all of
+// expected. Fields left as their zero value in expected are not compared, list elements with a key, such as neighbors
+// and route maps, are matched by their key rather than their position, and lists of lines only need to be contained in
+// the actual list. This allows expected to only describe the config a test cares about.
+func Missing(expected, actual *Config) []string {
+	if expected == nil {
+		return nil
+	}
+
+	if actual == nil {
+		return []string{"config is missing"}
+	}
+
+	return missing("config", reflect.ValueOf(*expected), reflect.ValueOf(*actual))
+}
+
+// missing compares expected against actual, which are of the same type, and returns a description of each part of
+// expected missing from actual. Path describes where in the config the values are.
+func missing(path string, expected, actual reflect.Value) []string {
+	if expected.IsZero() {
+		return nil
+	}
+
+	switch expected.Kind() {
+	case reflect.Pointer:
+		if actual.IsNil() {
+			return []string{fmt.Sprintf("%s is missing", path)}
+		}
+
+		return missing(path, expected.Elem(), actual.Elem())
+	case reflect.Struct:
+		var differences []string
+
+		for index := range expected.NumField() {
+			fieldPath := path + "." + expected.Type().Field(index).Name
+			differences = append(differences, missing(fieldPath, expected.Field(index), actual.Field(index))...)
+		}
+
+		return differences
+	case reflect.Slice:
+		return missingElements(path, expected, actual)
+	default:
+		if !expected.Equal(actual) {
+			return []string{fmt.Sprintf("%s is %v but expected %v", path, actual.Interface(), expected.Interface())}
+		}
+
+		return nil
+	}
+}
+
+// missingElements returns a description of each element of the expected slice missing from the actual slice. Keyed
+// elements are compared with the actual element with the same key and other elements must be in the actual slice.
+func missingElements(path string, expected, actual reflect.Value) []string {
+	var differences []string
+
+	for index := range expected.Len() {
+		element := expected.Index(index)
+
+		if keyedElement, ok := element.Interface().(keyed); ok {
+			elementPath := fmt.Sprintf("%s[%s]", path, keyedElement.key())
+			actualIndex := indexOfKey(actual, keyedElement.key())
+
+			if actualIndex < 0 {
+				differences = append(differences, fmt.Sprintf("%s is missing", elementPath))
+
+				continue
+			}
+
+			differences = append(differences, missing(elementPath, element, actual.Index(actualIndex))...)
+
+			continue
+		}
+
+		if !containsValue(actual, element) {
+			differences = append(differences, fmt.Sprintf("%s is missing %v", path, element.Interface()))
+		}
+	}
+
+	return differences
+}
+
+// indexOfKey returns the index of the element of slice with key, or -1 if there is none.
+func indexOfKey(slice reflect.Value, key string) int {
+	for index := range slice.Len() {
+		if element, ok := slice.Index(index).Interface().(keyed); ok && element.key() == key {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// containsValue returns whether slice contains an element deeply equal to value.
+func containsValue(slice, value reflect.Value) bool {
+	for index := range slice.Len() {
+		if reflect.DeepEqual(slice.Index(index).Interface(), value.Interface()) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,94 @@
+package frr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissing(t *testing.T) {
+	actual := &Config{
+		Hostname: "frr-pod",
+		BFD:      &BFD{Profiles: []BFDProfile{{Name: "fast", ReceiveInterval: 100}}},
+		Routers: []Router{{
+			ASN: 64500,
+			Neighbors: []Neighbor{
+				{Address: "10.0.0.1", RemoteAS: "64501", BFD: true},
+				{Address: "10.0.0.2", RemoteAS: "64501"},
+			},
+			AddressFamilies: []AddressFamily{{
+				Family:   "ipv4 unicast",
+				Networks: []string{"192.168.0.0/24", "192.168.1.0/24"},
+			}},
+		}},
+	}
+
+	testCases := []struct {
+		name     string
+		expected *Config
+		missing  []string
+	}{
+		{
+			name:     "nil expected",
+			expected: nil,
+			missing:  nil,
+		},
+		{
+			name:     "empty expected",
+			expected: &Config{},
+			missing:  nil,
+		},
+		{
+			name: "subset in a different order",
+			expected: &Config{Routers: []Router{{
+				ASN:       64500,
+				Neighbors: []Neighbor{{Address: "10.0.0.2"}, {Address: "10.0.0.1", BFD: true}},
+				AddressFamilies: []AddressFamily{{
+					Family: "ipv4 unicast", Networks: []string{"192.168.1.0/24"},
+				}},
+			}}},
+			missing: nil,
+		},
+		{
+			name: "different values",
+			expected: &Config{
+				Hostname: "other",
+				BFD:      &BFD{Profiles: []BFDProfile{{Name: "fast", ReceiveInterval: 300}}},
+			},
+			missing: []string{
+				"config.Hostname is frr-pod but expected other",
+				"config.BFD.Profiles[fast].ReceiveInterval is 100 but expected 300",
+			},
+		},
+		{
+			name: "missing elements",
+			expected: &Config{
+				Routers: []Router{
+					{
+						ASN:       64500,
+						Neighbors: []Neighbor{{Address: "10.0.0.3"}},
+						AddressFamilies: []AddressFamily{{
+							Family: "ipv4 unicast", Networks: []string{"192.168.2.0/24"},
+						}},
+					},
+					{ASN: 64500, VRF: "red"},
+				},
+				RouteMaps: []RouteMap{{Name: "rm", Sequence: 10}},
+			},
+			missing: []string{
+				"config.Routers[64500 vrf ].Neighbors[10.0.0.3] is missing",
+				"config.Routers[64500 vrf ].AddressFamilies[ipv4 unicast].Networks is missing 192.168.2.0/24",
+				"config.Routers[64500 vrf red] is missing",
+				"config.RouteMaps[rm 10] is missing",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.missing, Missing(testCase.expected, actual))
+		})
+	}
+
+	assert.Equal(t, []string{"config is missing"}, Missing(&Config{}, nil))
+}
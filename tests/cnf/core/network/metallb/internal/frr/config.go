@@ -0,0 +1,256 @@
+package frr
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type (
+	// Config is an FRR configuration. It can be rendered as frr.conf using String and parsed from the output of
+	// show running-config using ParseRunningConfig. Lists are rendered in order, so the same Config always renders the
+	// same frr.conf.
+	Config struct {
+		// Defaults is the profile in the frr defaults line, such as traditional.
+		Defaults string
+		Hostname string
+		// Global contains top level lines without a field of their own, such as log and debug lines.
+		Global         []string
+		BFD            *BFD
+		StaticRoutes   []StaticRoute
+		VRFs           []VRF
+		Interfaces     []Interface
+		Routers        []Router
+		PrefixLists    []PrefixList
+		CommunityLists []CommunityList
+		RouteMaps      []RouteMap
+	}
+
+	// BFD is the bfd node. An empty BFD still renders the node, which enables bfdd.
+	BFD struct {
+		Profiles []BFDProfile
+	}
+
+	// BFDProfile is a BFD profile. Intervals are in milliseconds.
+	BFDProfile struct {
+		Name             string
+		ReceiveInterval  int
+		TransmitInterval int
+		DetectMultiplier int
+		EchoMode         bool
+		PassiveMode      bool
+		MinimumTTL       int
+		// Options contains profile lines without a field of their own.
+		Options []string
+	}
+
+	// StaticRoute is a static route to Prefix. NextHop is everything after the prefix, such as an address, an
+	// interface, or both.
+	StaticRoute struct {
+		Prefix  string
+		NextHop string
+	}
+
+	// VRF is a vrf node.
+	VRF struct {
+		Name         string
+		VNI          int
+		StaticRoutes []StaticRoute
+	}
+
+	// Interface is an interface node. Lines are rendered as they are.
+	Interface struct {
+		Name  string
+		VRF   string
+		Lines []string
+	}
+
+	// Router is a router bgp node.
+	Router struct {
+		ASN      int
+		VRF      string
+		RouterID string
+		// Options contains router lines without a field of their own, such as no bgp ebgp-requires-policy.
+		Options []string
+		// Neighbors contains both neighbors and peer groups. Peer groups must come before their members.
+		Neighbors       []Neighbor
+		AddressFamilies []AddressFamily
+	}
+
+	// Neighbor is a BGP neighbor or peer group. Address is the address of the neighbor, the interface for
+	// unnumbered neighbors, or the name of the peer group.
+	Neighbor struct {
+		Address string
+		// PeerGroup is whether this is a peer group rather than a neighbor.
+		PeerGroup bool
+		// Interface is whether Address is an interface used for unnumbered peering.
+		Interface bool
+		// MemberOf is the peer group the neighbor is a member of.
+		MemberOf string
+		// RemoteAS is an AS number, internal, or external.
+		RemoteAS        string
+		Port            int
+		Password        string
+		Description     string
+		UpdateSource    string
+		EBGPMultihop    int
+		KeepAlive       int
+		HoldTime        int
+		ConnectTime     int
+		BFD             bool
+		BFDProfile      string
+		GracefulRestart bool
+		// Options contains neighbor lines without a field of their own, without the leading neighbor <address>.
+		Options []string
+	}
+
+	// AddressFamily is an address-family node in a router. Family is the rest of the line, such as ipv4 unicast.
+	AddressFamily struct {
+		Family string
+		// Networks contains the rest of each network line, such as 192.168.0.0/24 or 192.168.0.0/24 route-map rm.
+		Networks []string
+		// Redistribute contains the rest of each redistribute line, such as connected.
+		Redistribute []string
+		Neighbors    []AddressFamilyNeighbor
+		// Options contains address family lines without a field of their own.
+		Options []string
+	}
+
+	// AddressFamilyNeighbor is the configuration of a neighbor in an address family.
+	AddressFamilyNeighbor struct {
+		Address       string
+		Activate      bool
+		RouteMapIn    string
+		RouteMapOut   string
+		PrefixListIn  string
+		PrefixListOut string
+		// Options contains neighbor lines without a field of their own, without the leading neighbor <address>.
+		Options []string
+	}
+
+	// PrefixList is an ip prefix-list, or ipv6 prefix-list if IPv6 is set.
+	PrefixList struct {
+		Name  string
+		IPv6  bool
+		Rules []PrefixListRule
+	}
+
+	// PrefixListRule is an entry in a prefix list. Action is permit or deny and Prefix may be any.
+	PrefixListRule struct {
+		Seq    int
+		Action string
+		Prefix string
+		GE     int
+		LE     int
+	}
+
+	// CommunityList is a bgp community-list. Standard lists are used unless Expanded is set.
+	CommunityList struct {
+		Name     string
+		Expanded bool
+		Rules    []CommunityListRule
+	}
+
+	// CommunityListRule is an entry in a community list. Action is permit or deny.
+	CommunityListRule struct {
+		Seq       int
+		Action    string
+		Community string
+	}
+
+	// RouteMap is a single entry of a route map, identified by its name and sequence number. Action is permit or deny.
+	RouteMap struct {
+		Name     string
+		Action   string
+		Sequence int
+		// Match contains the rest of each match line, such as ip address prefix-list pl.
+		Match []string
+		// Set contains the rest of each set line, such as community 500:500.
+		Set []string
+		// Options contains route map lines without a field of their own, such as on-match next.
+		Options []string
+	}
+)
+
+// keyed is implemented by list elements that are matched by key rather than position when comparing configs.
+type keyed interface {
+	key() string
+}
+
+func (profile BFDProfile) key() string { return profile.Name }
+
+func (route StaticRoute) key() string { return route.Prefix + " " + route.NextHop }
+
+func (vrf VRF) key() string { return vrf.Name }
+
+func (iface Interface) key() string { return iface.Name + " vrf " + iface.VRF }
+
+func (router Router) key() string { return strconv.Itoa(router.ASN) + " vrf " + router.VRF }
+
+func (neighbor Neighbor) key() string { return neighbor.Address }
+
+func (family AddressFamily) key() string { return family.Family }
+
+func (neighbor AddressFamilyNeighbor) key() string { return neighbor.Address }
+
+func (list PrefixList) key() string { return fmt.Sprintf("%s ipv6 %t", list.Name, list.IPv6) }
+
+func (rule PrefixListRule) key() string { return strconv.Itoa(rule.Seq) + " " + rule.Prefix }
+
+func (list CommunityList) key() string { return list.Name }
+
+func (rule CommunityListRule) key() string { return strconv.Itoa(rule.Seq) + " " + rule.Community }
+
+func (routeMap RouteMap) key() string { return routeMap.Name + " " + strconv.Itoa(routeMap.Sequence) }
+
+// Router returns the router with asn in vrf, adding it if it does not exist yet. Use an empty vrf for the default VRF.
+func (config *Config) Router(asn int, vrf string) *Router {
+	for index := range config.Routers {
+		if config.Routers[index].ASN == asn && config.Routers[index].VRF == vrf {
+			return &config.Routers[index]
+		}
+	}
+
+	config.Routers = append(config.Routers, Router{ASN: asn, VRF: vrf})
+
+	return &config.Routers[len(config.Routers)-1]
+}
+
+// Neighbor returns the neighbor or peer group with address, adding it if it does not exist yet.
+func (router *Router) Neighbor(address string) *Neighbor {
+	for index := range router.Neighbors {
+		if router.Neighbors[index].Address == address {
+			return &router.Neighbors[index]
+		}
+	}
+
+	router.Neighbors = append(router.Neighbors, Neighbor{Address: address})
+
+	return &router.Neighbors[len(router.Neighbors)-1]
+}
+
+// AddressFamily returns the address family with family, such as ipv4 unicast, adding it if it does not exist yet.
+func (router *Router) AddressFamily(family string) *AddressFamily {
+	for index := range router.AddressFamilies {
+		if router.AddressFamilies[index].Family == family {
+			return &router.AddressFamilies[index]
+		}
+	}
+
+	router.AddressFamilies = append(router.AddressFamilies, AddressFamily{Family: family})
+
+	return &router.AddressFamilies[len(router.AddressFamilies)-1]
+}
+
+// Neighbor returns the configuration of the neighbor with address in the address family, adding it if it does not
+// exist yet.
+func (family *AddressFamily) Neighbor(address string) *AddressFamilyNeighbor {
+	for index := range family.Neighbors {
+		if family.Neighbors[index].Address == address {
+			return &family.Neighbors[index]
+		}
+	}
+
+	family.Neighbors = append(family.Neighbors, AddressFamilyNeighbor{Address: address})
+
+	return &family.Neighbors[len(family.Neighbors)-1]
+}
@@ -3,6 +3,8 @@ package frr
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/golang/glog"
@@ -10,6 +12,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/metallb/internal/tsparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/metrics"
 )
 
 type (
@@ -142,127 +145,91 @@ func DefineBaseConfig(daemonsConfig, frrConfig, vtyShConfig string) map[string]s
 }
 
 // DefineBGPConfig returns string which represents BGP config file peering to all given IP addresses.
-//
-//nolint:goconst
 func DefineBGPConfig(localBGPASN, remoteBGPASN int, neighborsIPAddresses []string, multiHop, bfd bool) string {
-	bgpConfig := tsparams.FRRBaseConfig +
-		fmt.Sprintf("router bgp %d\n", localBGPASN) +
-		tsparams.FRRDefaultBGPPreConfig
-
-	for _, ipAddress := range neighborsIPAddresses {
-		bgpConfig += fmt.Sprintf("  neighbor %s remote-as %d\n  neighbor %s password %s\n",
-			ipAddress, remoteBGPASN, ipAddress, tsparams.BGPPassword)
-
-		if bfd {
-			bgpConfig += fmt.Sprintf("  neighbor %s bfd\n", ipAddress)
-		}
-
-		if multiHop {
-			bgpConfig += fmt.Sprintf("  neighbor %s ebgp-multihop 2\n", ipAddress)
-		}
-	}
-
-	bgpConfig += "!\naddress-family ipv4 unicast\n"
-	for _, ipAddress := range neighborsIPAddresses {
-		bgpConfig += fmt.Sprintf("  neighbor %s activate\n", ipAddress)
-	}
-
-	bgpConfig += "exit-address-family\n!\naddress-family ipv6 unicast\n"
-	for _, ipAddress := range neighborsIPAddresses {
-		bgpConfig += fmt.Sprintf("  neighbor %s activate\n", ipAddress)
-	}
-
-	bgpConfig += "exit-address-family\n!\nline vty\n!\nend\n"
-
-	return bgpConfig
+	return defineBGPConfig(localBGPASN, remoteBGPASN, neighborsIPAddresses, multiHop, bfd).String()
 }
 
 // DefineBGPConfigWithStaticRouteAndNetwork defines BGP config file with static route and network.
-//
-//nolint:goconst
 func DefineBGPConfigWithStaticRouteAndNetwork(localBGPASN, remoteBGPASN int, hubPodIPs,
 	advertisedIPv4Routes, advertisedIPv6Routes, neighborsIPAddresses []string,
 	multiHop, bfd bool) string {
-	bgpConfig := tsparams.FRRBaseConfig +
-		fmt.Sprintf("ip route %s/32 %s\n", neighborsIPAddresses[1], hubPodIPs[0]) +
-		fmt.Sprintf("ip route %s/32 %s\n!\n", neighborsIPAddresses[0], hubPodIPs[1]) +
-		fmt.Sprintf("router bgp %d\n", localBGPASN) +
-		tsparams.FRRDefaultBGPPreConfig
-
-	for _, ipAddress := range neighborsIPAddresses {
-		bgpConfig += fmt.Sprintf("  neighbor %s remote-as %d\n  neighbor %s password %s\n",
-			ipAddress, remoteBGPASN, ipAddress, tsparams.BGPPassword)
-
-		if bfd {
-			bgpConfig += fmt.Sprintf("  neighbor %s bfd\n", ipAddress)
-		}
-
-		if multiHop {
-			bgpConfig += fmt.Sprintf("  neighbor %s ebgp-multihop 2\n", ipAddress)
-		}
-	}
-
-	bgpConfig += "!\naddress-family ipv4 unicast\n"
-	for _, ipAddress := range neighborsIPAddresses {
-		bgpConfig += fmt.Sprintf("  neighbor %s activate\n", ipAddress)
-	}
-
-	bgpConfig += fmt.Sprintf("  network %s\n", advertisedIPv4Routes[0])
-	bgpConfig += fmt.Sprintf("  network %s\n", advertisedIPv4Routes[1])
-	bgpConfig += "exit-address-family\n"
-
-	// Add network commands only once for IPv6
-	bgpConfig += "!\naddress-family ipv6 unicast\n"
-	for _, ipAddress := range neighborsIPAddresses {
-		bgpConfig += fmt.Sprintf("  neighbor %s activate\n", ipAddress)
+	config := defineBGPConfig(localBGPASN, remoteBGPASN, neighborsIPAddresses, multiHop, bfd)
+	config.StaticRoutes = []StaticRoute{
+		{Prefix: neighborsIPAddresses[1] + "/32", NextHop: hubPodIPs[0]},
+		{Prefix: neighborsIPAddresses[0] + "/32", NextHop: hubPodIPs[1]},
 	}
 
-	bgpConfig += fmt.Sprintf("  network %s\n", advertisedIPv6Routes[0])
-	bgpConfig += fmt.Sprintf("  network %s\n", advertisedIPv6Routes[1])
-	bgpConfig += "exit-address-family\n"
-
-	bgpConfig += "!\nline vty\n!\nend\n"
+	router := config.Router(localBGPASN, "")
+	router.AddressFamily("ipv4 unicast").Networks = advertisedIPv4Routes
+	router.AddressFamily("ipv6 unicast").Networks = advertisedIPv6Routes
 
-	return bgpConfig
+	return config.String()
 }
 
 // DefineBGPConfigWithIPv4Network defines BGP config file with network advertising only ipv4.
 func DefineBGPConfigWithIPv4Network(localBGPASN, remoteBGPASN int,
 	advertisedIPv4Routes, neighborsIPAddresses []string,
 	multiHop, bfd bool) string {
-	bgpConfig := tsparams.FRRBaseConfig +
-		fmt.Sprintf("router bgp %d\n", localBGPASN) +
-		tsparams.FRRDefaultBGPPreConfig
+	config := defineBGPConfig(localBGPASN, remoteBGPASN, neighborsIPAddresses, multiHop, bfd)
+	config.Router(localBGPASN, "").AddressFamily("ipv4 unicast").Networks = advertisedIPv4Routes
 
-	for _, ipAddress := range neighborsIPAddresses {
-		bgpConfig += fmt.Sprintf("  neighbor %s remote-as %d\n  neighbor %s password %s\n",
-			ipAddress, remoteBGPASN, ipAddress, tsparams.BGPPassword)
+	return config.String()
+}
 
-		if bfd {
-			bgpConfig += fmt.Sprintf("  neighbor %s bfd\n", ipAddress)
-		}
+// NewBaseConfig returns the minimal FRR configuration used by the external FRR pods, with a router for localBGPASN
+// that has no neighbors. Tests can add to it and render it with String.
+func NewBaseConfig(localBGPASN int) *Config {
+	return &Config{
+		Defaults: "traditional",
+		Hostname: "frr-pod",
+		Global: []string{
+			"log file /tmp/frr.log",
+			"log timestamp precision 3",
+			"debug zebra nht",
+			"debug bgp neighbor-events",
+		},
+		BFD: &BFD{},
+		Routers: []Router{{
+			ASN:      localBGPASN,
+			RouterID: "10.10.10.11",
+			Options: []string{
+				"no bgp ebgp-requires-policy",
+				"no bgp default ipv4-unicast",
+				"no bgp network import-check",
+			},
+		}},
+	}
+}
 
-		if multiHop {
-			bgpConfig += fmt.Sprintf("  neighbor %s ebgp-multihop 2\n", ipAddress)
-		}
+// GetRunningConfig returns the running config of the FRR container in frrPod.
+func GetRunningConfig(frrPod *pod.Builder) (*Config, error) {
+	frrConf, err := runningConfig(frrPod)
+	if err != nil {
+		return nil, err
 	}
 
-	bgpConfig += "!\naddress-family ipv4 unicast\n"
-	for _, ipAddress := range neighborsIPAddresses {
-		bgpConfig += fmt.Sprintf("  neighbor %s activate\n", ipAddress)
+	config, err := ParseRunningConfig(frrConf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse frr running config from pod %s: %w", frrPod.Definition.Name, err)
 	}
 
-	bgpConfig += fmt.Sprintf("  network %s\n", advertisedIPv4Routes[0])
-	bgpConfig += fmt.Sprintf("  network %s\n", advertisedIPv4Routes[1])
+	return config, nil
+}
 
-	bgpConfig += "exit-address-family\n!\naddress-family ipv6 unicast\n"
-	for _, ipAddress := range neighborsIPAddresses {
-		bgpConfig += fmt.Sprintf("  neighbor %s activate\n", ipAddress)
+// CheckFRRConfig checks that the running config of frrPod contains expected. Only the fields set in expected are
+// compared, see Missing.
+func CheckFRRConfig(frrPod *pod.Builder, expected *Config) (bool, error) {
+	actual, err := GetRunningConfig(frrPod)
+	if err != nil {
+		return false, err
 	}
 
-	bgpConfig += "exit-address-family\n!\nline vty\n!\nend\n"
+	missing := Missing(expected, actual)
+	for _, difference := range missing {
+		glog.V(90).Infof("FRR config of pod %s: %s", frrPod.Definition.Name, difference)
+	}
 
-	return bgpConfig
+	return len(missing) == 0, nil
 }
 
 // BGPNeighborshipHasState verifies that BGP session on a pod has given state.
@@ -299,24 +266,18 @@ func IsProtocolConfigured(frrPod *pod.Builder, protocol string) (bool, error) {
 	return false, nil
 }
 
-// GetMetricsByPrefix pulls all metrics from frr pods and sort them in the list by given prefix.
+// GetMetricsByPrefix pulls all metrics from frr pods and returns the names of those with the given prefix.
 func GetMetricsByPrefix(frrPod *pod.Builder, metricPrefix string) ([]string, error) {
-	stdout, err := frrPod.ExecCommand([]string{"curl", "localhost:7573/metrics"}, "frr")
-
+	frrMetrics, err := metrics.ScrapePod(frrPod, "localhost:7573/metrics", "frr")
 	if err != nil {
 		return nil, err
 	}
 
-	if len(strings.Split(stdout.String(), "\n")) == 0 {
-		return nil, fmt.Errorf("failed to collect metrics due to empty response")
-	}
-
 	var collectedMetrics []string
 
-	for _, line := range strings.Split(stdout.String(), "\n") {
-		if strings.HasPrefix(line, metricPrefix) {
-			metricsKey := line[0:strings.Index(line, "{")]
-			collectedMetrics = append(collectedMetrics, metricsKey)
+	for _, name := range frrMetrics.Names() {
+		if strings.HasPrefix(name, metricPrefix) {
+			collectedMetrics = append(collectedMetrics, name)
 		}
 	}
 
@@ -477,6 +438,38 @@ func GetGracefulRestartStatus(frrPod *pod.Builder, neighborIP string) (GRStatus,
 	return bgpNeighborGRStatus[neighborIP], nil
 }
 
+// defineBGPConfig returns the base config with a neighbor for each of neighborsIPAddresses activated in both the ipv4
+// unicast and ipv6 unicast address families.
+func defineBGPConfig(localBGPASN, remoteBGPASN int, neighborsIPAddresses []string, multiHop, bfd bool) *Config {
+	config := NewBaseConfig(localBGPASN)
+	router := config.Router(localBGPASN, "")
+
+	var activated []AddressFamilyNeighbor
+
+	for _, ipAddress := range neighborsIPAddresses {
+		neighbor := Neighbor{
+			Address:  ipAddress,
+			RemoteAS: strconv.Itoa(remoteBGPASN),
+			Password: tsparams.BGPPassword,
+			BFD:      bfd,
+		}
+
+		if multiHop {
+			neighbor.EBGPMultihop = 2
+		}
+
+		router.Neighbors = append(router.Neighbors, neighbor)
+		activated = append(activated, AddressFamilyNeighbor{Address: ipAddress, Activate: true})
+	}
+
+	router.AddressFamilies = []AddressFamily{
+		{Family: "ipv4 unicast", Neighbors: activated},
+		{Family: "ipv6 unicast", Neighbors: slices.Clone(activated)},
+	}
+
+	return config
+}
+
 func runningConfig(frrPod *pod.Builder) (string, error) {
 	bgpStateOut, err := frrPod.ExecCommand(append(netparam.VtySh, "sh run"), tsparams.FRRContainerName)
 	if err != nil {
@@ -660,52 +653,42 @@ func CheckFRRConfigLine(frrPod *pod.Builder, config string) (bool, error) {
 // DefineBGPConfigWithUnnumbered defines BGP config file with static route and network.
 func DefineBGPConfigWithUnnumbered(localBGPASN, remoteBGPASN int, interfaceName string,
 	advertisedIPv4Routes, advertisedIPv6Routes []string, multiHop, bfd bool) string {
-	bgpConfig := tsparams.FRRBaseConfig +
-		fmt.Sprintf("interface %s", interfaceName) +
-		"\n ipv6 nd ra-interval 10" +
-		"\n no ipv6 nd suppress-ra" +
-		"\n exit\n!\n" +
-		fmt.Sprintf("router bgp %d\n", localBGPASN) +
-		tsparams.FRRDefaultBGPPreConfig
-	bgpConfig += "!\n  neighbor unnumbered peer-group\n"
-	bgpConfig += fmt.Sprintf("  neighbor %s interface peer-group unnumbered\n", interfaceName)
-
-	if bfd {
-		bgpConfig += "  neighbor unnumbered bfd\n"
+	config := NewBaseConfig(localBGPASN)
+	config.Global = append(config.Global, "ipv6 nht resolve-via-default")
+	config.Interfaces = []Interface{{
+		Name:  interfaceName,
+		Lines: []string{"ipv6 nd ra-interval 10", "no ipv6 nd suppress-ra"},
+	}}
+	config.RouteMaps = []RouteMap{{
+		Name: "RMAP", Action: "permit", Sequence: 10, Set: []string{"ipv6 next-hop prefer-global"},
+	}}
+
+	router := config.Router(localBGPASN, "")
+	router.Neighbors = []Neighbor{
+		{
+			Address:   "unnumbered",
+			PeerGroup: true,
+			RemoteAS:  strconv.Itoa(remoteBGPASN),
+			Password:  tsparams.BGPPassword,
+			KeepAlive: 30,
+			HoldTime:  90,
+			// BFD is always enabled for unnumbered peering, regardless of bfd.
+			BFD: true,
+		},
+		{Address: interfaceName, Interface: true, MemberOf: "unnumbered"},
 	}
 
 	if multiHop {
-		bgpConfig += "  neighbor unnumbered ebgp-multihop 2\n"
+		router.Neighbor("unnumbered").EBGPMultihop = 2
 	}
 
-	bgpConfig += fmt.Sprintf("  neighbor unnumbered remote-as %d\n", remoteBGPASN)
-	bgpConfig += fmt.Sprintf("  neighbor unnumbered password %s\n", tsparams.BGPPassword)
-	bgpConfig += "  neighbor unnumbered timers 30 90\n  neighbor unnumbered bfd\n"
-
-	bgpConfig += "!\naddress-family ipv4 unicast\n"
-	bgpConfig += "  neighbor unnumbered activate\n"
-
-	for _, route := range advertisedIPv4Routes {
-		bgpConfig += fmt.Sprintf("  network %s\n", route)
+	activateUnnumbered := []AddressFamilyNeighbor{{Address: "unnumbered", Activate: true}}
+	router.AddressFamilies = []AddressFamily{
+		{Family: "ipv4 unicast", Networks: advertisedIPv4Routes, Neighbors: activateUnnumbered},
+		{Family: "ipv6 unicast", Networks: advertisedIPv6Routes, Neighbors: slices.Clone(activateUnnumbered)},
 	}
 
-	bgpConfig += "exit-address-family\n"
-
-	// Add network commands only once for IPv6
-	bgpConfig += "!\naddress-family ipv6 unicast\n"
-	bgpConfig += "  neighbor unnumbered activate\n"
-
-	for _, route := range advertisedIPv6Routes {
-		bgpConfig += fmt.Sprintf("  network %s\n", route)
-	}
-
-	bgpConfig += "exit-address-family\n"
-	bgpConfig += "!\nroute-map RMAP permit 10"
-	bgpConfig += "\nset ipv6 next-hop prefer-global\n"
-	bgpConfig += "!\nipv6 nht resolve-via-default\n"
-	bgpConfig += "!\nline vty\n!\nend\n"
-
-	return bgpConfig
+	return config.String()
 }
 
 // GetInterfaceStatus returns BGP interface details from an FRR pod.
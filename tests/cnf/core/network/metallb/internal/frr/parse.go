@@ -0,0 +1,593 @@
+package frr
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// parseNode is a node of the config, such as router bgp or an address family, that lines are added to while it is the
+// current node.
+type parseNode interface {
+	// accepts returns whether the line with fields is a command of this node.
+	accepts(fields []string) bool
+	// handle adds the line with fields to the config. It may return a child node that becomes the current node.
+	handle(fields []string) (parseNode, error)
+}
+
+// exitCommands end the current node and return to its parent.
+var exitCommands = []string{"exit", "exit-vrf", "exit-address-family"}
+
+// ParseRunningConfig parses the output of show running-config, or the contents of frr.conf, into a Config. Like FRR,
+// lines that are not commands of the current node are tried against its parents, so nodes do not need to end with
+// exit. Comments, the header printed by vtysh, frr version, and line vty are ignored.
+func ParseRunningConfig(runningConfig string) (*Config, error) {
+	config := &Config{}
+	stack := []parseNode{&topNode{config: config}}
+
+	for lineNumber, line := range strings.Split(runningConfig, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "!") {
+			continue
+		}
+
+		if fields[0] == "end" {
+			stack = stack[:1]
+
+			continue
+		}
+
+		if slices.Contains(exitCommands, fields[0]) {
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+
+			continue
+		}
+
+		// The top node accepts every line, so this always finds a node.
+		for !stack[len(stack)-1].accepts(fields) {
+			stack = stack[:len(stack)-1]
+		}
+
+		child, err := stack[len(stack)-1].handle(fields)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse line %d %q: %w", lineNumber+1, strings.TrimSpace(line), err)
+		}
+
+		if child != nil {
+			stack = append(stack, child)
+		}
+	}
+
+	return config, nil
+}
+
+// topNode handles lines outside of any node.
+type topNode struct {
+	config *Config
+}
+
+func (node *topNode) accepts([]string) bool { return true }
+
+func (node *topNode) handle(fields []string) (parseNode, error) {
+	config := node.config
+
+	switch {
+	case hasPrefix(fields, "frr", "version"), hasPrefix(fields, "Building", "configuration..."),
+		hasPrefix(fields, "Current", "configuration:"):
+		return nil, nil
+	case hasPrefix(fields, "frr", "defaults") && len(fields) == 3:
+		config.Defaults = fields[2]
+	case fields[0] == "hostname" && len(fields) == 2:
+		config.Hostname = fields[1]
+	case isStaticRoute(fields):
+		route, err := parseStaticRoute(fields)
+		config.StaticRoutes = append(config.StaticRoutes, route)
+
+		return nil, err
+	case hasPrefix(fields, "ip", "prefix-list") || hasPrefix(fields, "ipv6", "prefix-list"):
+		return nil, parsePrefixList(config, fields)
+	case hasPrefix(fields, "bgp", "community-list"):
+		return nil, parseCommunityList(config, fields)
+	case fields[0] == "route-map":
+		return parseRouteMap(config, fields)
+	case fields[0] == "interface" && len(fields) >= 2:
+		return parseInterface(config, fields)
+	case fields[0] == "vrf" && len(fields) == 2:
+		config.VRFs = append(config.VRFs, VRF{Name: fields[1]})
+
+		return &vrfNode{vrf: &config.VRFs[len(config.VRFs)-1]}, nil
+	case len(fields) == 1 && fields[0] == "bfd":
+		if config.BFD == nil {
+			config.BFD = &BFD{}
+		}
+
+		return &bfdNode{bfd: config.BFD}, nil
+	case hasPrefix(fields, "router", "bgp"):
+		return parseRouter(config, fields)
+	case hasPrefix(fields, "line", "vty"):
+		return &ignoredNode{}, nil
+	default:
+		config.Global = append(config.Global, strings.Join(fields, " "))
+	}
+
+	return nil, nil
+}
+
+// ignoredNode is a node whose commands are not part of the Config, such as line vty.
+type ignoredNode struct{}
+
+func (node *ignoredNode) accepts(fields []string) bool { return !isTopLevelCommand(fields) }
+
+func (node *ignoredNode) handle([]string) (parseNode, error) { return nil, nil }
+
+// vrfNode handles the lines of a vrf node.
+type vrfNode struct {
+	vrf *VRF
+}
+
+func (node *vrfNode) accepts(fields []string) bool {
+	return isStaticRoute(fields) || fields[0] == "vni"
+}
+
+func (node *vrfNode) handle(fields []string) (parseNode, error) {
+	if fields[0] == "vni" {
+		vni, err := parseIntField(fields, 1)
+		node.vrf.VNI = vni
+
+		return nil, err
+	}
+
+	route, err := parseStaticRoute(fields)
+	node.vrf.StaticRoutes = append(node.vrf.StaticRoutes, route)
+
+	return nil, err
+}
+
+// interfaceNode handles the lines of an interface node.
+type interfaceNode struct {
+	iface *Interface
+}
+
+func (node *interfaceNode) accepts(fields []string) bool { return !isTopLevelCommand(fields) }
+
+func (node *interfaceNode) handle(fields []string) (parseNode, error) {
+	node.iface.Lines = append(node.iface.Lines, strings.Join(fields, " "))
+
+	return nil, nil
+}
+
+// bfdNode handles the lines of the bfd node.
+type bfdNode struct {
+	bfd *BFD
+}
+
+func (node *bfdNode) accepts(fields []string) bool {
+	return fields[0] == "profile" || fields[0] == "peer"
+}
+
+func (node *bfdNode) handle(fields []string) (parseNode, error) {
+	// Peers are created by bgpd for neighbors with BFD enabled, so they are not part of the Config.
+	if fields[0] == "peer" {
+		return &bfdProfileNode{profile: &BFDProfile{}}, nil
+	}
+
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("expected profile name")
+	}
+
+	node.bfd.Profiles = append(node.bfd.Profiles, BFDProfile{Name: fields[1]})
+
+	return &bfdProfileNode{profile: &node.bfd.Profiles[len(node.bfd.Profiles)-1]}, nil
+}
+
+// bfdProfileNode handles the lines of a bfd profile or peer node.
+type bfdProfileNode struct {
+	profile *BFDProfile
+}
+
+func (node *bfdProfileNode) accepts(fields []string) bool {
+	return !isTopLevelCommand(fields) && fields[0] != "profile" && fields[0] != "peer"
+}
+
+func (node *bfdProfileNode) handle(fields []string) (parseNode, error) {
+	var err error
+
+	profile := node.profile
+
+	switch {
+	case fields[0] == "receive-interval":
+		profile.ReceiveInterval, err = parseIntField(fields, 1)
+	case fields[0] == "transmit-interval":
+		profile.TransmitInterval, err = parseIntField(fields, 1)
+	case fields[0] == "detect-multiplier":
+		profile.DetectMultiplier, err = parseIntField(fields, 1)
+	case fields[0] == "minimum-ttl":
+		profile.MinimumTTL, err = parseIntField(fields, 1)
+	case len(fields) == 1 && fields[0] == "echo-mode":
+		profile.EchoMode = true
+	case len(fields) == 1 && fields[0] == "passive-mode":
+		profile.PassiveMode = true
+	default:
+		profile.Options = append(profile.Options, strings.Join(fields, " "))
+	}
+
+	return nil, err
+}
+
+// routerNode handles the lines of a router bgp node.
+type routerNode struct {
+	router *Router
+}
+
+func (node *routerNode) accepts(fields []string) bool {
+	switch fields[0] {
+	case "neighbor", "address-family", "timers", "maximum-paths", "network", "redistribute":
+		return true
+	case "bgp":
+		return !isTopLevelCommand(fields)
+	case "no":
+		return len(fields) > 1 && fields[1] == "bgp"
+	default:
+		return false
+	}
+}
+
+func (node *routerNode) handle(fields []string) (parseNode, error) {
+	router := node.router
+
+	switch {
+	case hasPrefix(fields, "bgp", "router-id") && len(fields) == 3:
+		router.RouterID = fields[2]
+	case fields[0] == "neighbor" && len(fields) >= 3:
+		return nil, parseNeighbor(router.Neighbor(fields[1]), fields[2:])
+	case fields[0] == "address-family" && len(fields) >= 2:
+		return &addressFamilyNode{family: router.AddressFamily(strings.Join(fields[1:], " "))}, nil
+	default:
+		router.Options = append(router.Options, strings.Join(fields, " "))
+	}
+
+	return nil, nil
+}
+
+// addressFamilyNode handles the lines of an address-family node.
+type addressFamilyNode struct {
+	family *AddressFamily
+}
+
+func (node *addressFamilyNode) accepts(fields []string) bool {
+	switch fields[0] {
+	case "neighbor", "network", "redistribute", "aggregate-address", "maximum-paths", "import", "export", "rd", "rt",
+		"label", "table-map", "distance", "advertise-all-vni", "advertise":
+		return true
+	default:
+		return false
+	}
+}
+
+func (node *addressFamilyNode) handle(fields []string) (parseNode, error) {
+	family := node.family
+	rest := strings.Join(fields[1:], " ")
+
+	switch {
+	case fields[0] == "network":
+		family.Networks = append(family.Networks, rest)
+	case fields[0] == "redistribute":
+		family.Redistribute = append(family.Redistribute, rest)
+	case fields[0] == "neighbor" && len(fields) >= 3:
+		parseAddressFamilyNeighbor(family.Neighbor(fields[1]), fields[2:])
+	default:
+		family.Options = append(family.Options, strings.Join(fields, " "))
+	}
+
+	return nil, nil
+}
+
+// routeMapNode handles the lines of a route-map node.
+type routeMapNode struct {
+	routeMap *RouteMap
+}
+
+func (node *routeMapNode) accepts(fields []string) bool {
+	switch fields[0] {
+	case "match", "set", "on-match", "call", "continue", "description":
+		return true
+	default:
+		return false
+	}
+}
+
+func (node *routeMapNode) handle(fields []string) (parseNode, error) {
+	routeMap := node.routeMap
+	rest := strings.Join(fields[1:], " ")
+
+	switch fields[0] {
+	case "match":
+		routeMap.Match = append(routeMap.Match, rest)
+	case "set":
+		routeMap.Set = append(routeMap.Set, rest)
+	default:
+		routeMap.Options = append(routeMap.Options, strings.Join(fields, " "))
+	}
+
+	return nil, nil
+}
+
+// parseRouter adds the router from a router bgp <asn> [vrf <name>] line and returns its node.
+func parseRouter(config *Config, fields []string) (parseNode, error) {
+	if len(fields) != 3 && (len(fields) != 5 || fields[3] != "vrf") {
+		return nil, fmt.Errorf("expected router bgp <asn> [vrf <name>]")
+	}
+
+	asn, err := parseIntField(fields, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	vrf := ""
+	if len(fields) == 5 {
+		vrf = fields[4]
+	}
+
+	return &routerNode{router: config.Router(asn, vrf)}, nil
+}
+
+// parseInterface adds the interface from an interface <name> [vrf <name>] line and returns its node.
+func parseInterface(config *Config, fields []string) (parseNode, error) {
+	iface := Interface{Name: fields[1]}
+
+	switch {
+	case len(fields) == 4 && fields[2] == "vrf":
+		iface.VRF = fields[3]
+	case len(fields) != 2:
+		return nil, fmt.Errorf("expected interface <name> [vrf <name>]")
+	}
+
+	config.Interfaces = append(config.Interfaces, iface)
+
+	return &interfaceNode{iface: &config.Interfaces[len(config.Interfaces)-1]}, nil
+}
+
+// parseRouteMap adds the entry from a route-map <name> <permit|deny> <sequence> line and returns its node.
+func parseRouteMap(config *Config, fields []string) (parseNode, error) {
+	if len(fields) != 4 {
+		return nil, fmt.Errorf("expected route-map <name> <permit|deny> <sequence>")
+	}
+
+	sequence, err := parseIntField(fields, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	config.RouteMaps = append(config.RouteMaps, RouteMap{Name: fields[1], Action: fields[2], Sequence: sequence})
+
+	return &routeMapNode{routeMap: &config.RouteMaps[len(config.RouteMaps)-1]}, nil
+}
+
+// parseNeighbor sets the option of neighbor in fields, which are the fields after neighbor <address>.
+func parseNeighbor(neighbor *Neighbor, fields []string) error {
+	var err error
+
+	rest := strings.Join(fields[1:], " ")
+
+	switch {
+	case len(fields) == 1 && fields[0] == "peer-group":
+		neighbor.PeerGroup = true
+	case len(fields) == 2 && fields[0] == "peer-group":
+		neighbor.MemberOf = fields[1]
+	case fields[0] == "interface":
+		neighbor.Interface = true
+
+		if len(fields) > 1 {
+			return parseNeighbor(neighbor, fields[1:])
+		}
+	case fields[0] == "remote-as":
+		neighbor.RemoteAS = rest
+	case fields[0] == "port":
+		neighbor.Port, err = parseIntField(fields, 1)
+	case fields[0] == "password":
+		neighbor.Password = rest
+	case fields[0] == "description":
+		neighbor.Description = rest
+	case fields[0] == "update-source":
+		neighbor.UpdateSource = rest
+	case fields[0] == "ebgp-multihop":
+		// Without a hop count, FRR allows the maximum of 255 hops.
+		neighbor.EBGPMultihop = 255
+		if len(fields) > 1 {
+			neighbor.EBGPMultihop, err = parseIntField(fields, 1)
+		}
+	case len(fields) == 3 && hasPrefix(fields, "timers", "connect"):
+		neighbor.ConnectTime, err = parseIntField(fields, 2)
+	case len(fields) == 3 && fields[0] == "timers":
+		neighbor.KeepAlive, err = parseIntField(fields, 1)
+		if err == nil {
+			neighbor.HoldTime, err = parseIntField(fields, 2)
+		}
+	case len(fields) == 1 && fields[0] == "bfd":
+		neighbor.BFD = true
+	case len(fields) == 3 && hasPrefix(fields, "bfd", "profile"):
+		neighbor.BFD = true
+		neighbor.BFDProfile = fields[2]
+	case len(fields) == 1 && fields[0] == "graceful-restart":
+		neighbor.GracefulRestart = true
+	default:
+		neighbor.Options = append(neighbor.Options, strings.Join(fields, " "))
+	}
+
+	return err
+}
+
+// parseAddressFamilyNeighbor sets the option of neighbor in fields, which are the fields after neighbor <address>.
+func parseAddressFamilyNeighbor(neighbor *AddressFamilyNeighbor, fields []string) {
+	switch {
+	case len(fields) == 1 && fields[0] == "activate":
+		neighbor.Activate = true
+	case len(fields) == 3 && fields[0] == "route-map" && fields[2] == "in":
+		neighbor.RouteMapIn = fields[1]
+	case len(fields) == 3 && fields[0] == "route-map" && fields[2] == "out":
+		neighbor.RouteMapOut = fields[1]
+	case len(fields) == 3 && fields[0] == "prefix-list" && fields[2] == "in":
+		neighbor.PrefixListIn = fields[1]
+	case len(fields) == 3 && fields[0] == "prefix-list" && fields[2] == "out":
+		neighbor.PrefixListOut = fields[1]
+	default:
+		neighbor.Options = append(neighbor.Options, strings.Join(fields, " "))
+	}
+}
+
+// parseStaticRoute parses an ip route or ipv6 route line.
+func parseStaticRoute(fields []string) (StaticRoute, error) {
+	if len(fields) < 4 {
+		return StaticRoute{}, fmt.Errorf("expected %s route <prefix> <nexthop>", fields[0])
+	}
+
+	return StaticRoute{Prefix: fields[2], NextHop: strings.Join(fields[3:], " ")}, nil
+}
+
+// parsePrefixList adds the rule from an ip prefix-list or ipv6 prefix-list line to the matching prefix list.
+func parsePrefixList(config *Config, fields []string) error {
+	if len(fields) < 5 {
+		return fmt.Errorf("expected %s prefix-list <name> [seq <seq>] <permit|deny> <prefix>", fields[0])
+	}
+
+	list := PrefixList{Name: fields[2], IPv6: fields[0] == "ipv6"}
+	rule := PrefixListRule{}
+	rest := fields[3:]
+
+	if rest[0] == "seq" {
+		seq, err := parseIntField(rest, 1)
+		if err != nil {
+			return err
+		}
+
+		rule.Seq = seq
+		rest = rest[2:]
+	}
+
+	if len(rest) < 2 || len(rest)%2 != 0 {
+		return fmt.Errorf("expected <permit|deny> <prefix> [ge <length>] [le <length>]")
+	}
+
+	rule.Action, rule.Prefix = rest[0], rest[1]
+
+	for index := 2; index < len(rest); index += 2 {
+		length, err := parseIntField(rest, index+1)
+		if err != nil {
+			return err
+		}
+
+		switch rest[index] {
+		case "ge":
+			rule.GE = length
+		case "le":
+			rule.LE = length
+		default:
+			return fmt.Errorf("unexpected %s", rest[index])
+		}
+	}
+
+	for index := range config.PrefixLists {
+		if config.PrefixLists[index].key() == list.key() {
+			config.PrefixLists[index].Rules = append(config.PrefixLists[index].Rules, rule)
+
+			return nil
+		}
+	}
+
+	list.Rules = []PrefixListRule{rule}
+	config.PrefixLists = append(config.PrefixLists, list)
+
+	return nil
+}
+
+// parseCommunityList adds the rule from a bgp community-list line to the matching community list.
+func parseCommunityList(config *Config, fields []string) error {
+	rest := fields[2:]
+	list := CommunityList{}
+
+	if len(rest) > 0 && (rest[0] == "standard" || rest[0] == "expanded") {
+		list.Expanded = rest[0] == "expanded"
+		rest = rest[1:]
+	}
+
+	if len(rest) < 3 {
+		return fmt.Errorf("expected bgp community-list [standard|expanded] <name> [seq <seq>] <permit|deny> <value>")
+	}
+
+	list.Name = rest[0]
+	rest = rest[1:]
+	rule := CommunityListRule{}
+
+	if rest[0] == "seq" {
+		seq, err := parseIntField(rest, 1)
+		if err != nil {
+			return err
+		}
+
+		rule.Seq = seq
+		rest = rest[2:]
+	}
+
+	if len(rest) < 2 {
+		return fmt.Errorf("expected <permit|deny> <value>")
+	}
+
+	rule.Action, rule.Community = rest[0], strings.Join(rest[1:], " ")
+
+	for index := range config.CommunityLists {
+		if config.CommunityLists[index].Name == list.Name {
+			config.CommunityLists[index].Rules = append(config.CommunityLists[index].Rules, rule)
+
+			return nil
+		}
+	}
+
+	list.Rules = []CommunityListRule{rule}
+	config.CommunityLists = append(config.CommunityLists, list)
+
+	return nil
+}
+
+// isStaticRoute returns whether fields are an ip route or ipv6 route line.
+func isStaticRoute(fields []string) bool {
+	return hasPrefix(fields, "ip", "route") || hasPrefix(fields, "ipv6", "route")
+}
+
+// isTopLevelCommand returns whether fields can only be a command outside of any node. Nodes whose commands cannot be
+// recognized use this to find where they end when they are not ended with exit.
+func isTopLevelCommand(fields []string) bool {
+	switch fields[0] {
+	case "frr", "hostname", "log", "debug", "route-map", "router", "interface", "vrf", "bfd", "line", "service",
+		"password", "agentx":
+		return true
+	case "ip", "ipv6":
+		return len(fields) > 1 && slices.Contains([]string{"route", "prefix-list", "nht", "forwarding"}, fields[1])
+	case "bgp":
+		return len(fields) > 1 && slices.Contains(
+			[]string{"community-list", "large-community-list", "extcommunity-list", "as-path"}, fields[1])
+	default:
+		return false
+	}
+}
+
+// hasPrefix returns whether fields start with prefix.
+func hasPrefix(fields []string, prefix ...string) bool {
+	return len(fields) >= len(prefix) && slices.Equal(fields[:len(prefix)], prefix)
+}
+
+// parseIntField parses the field at index as an integer.
+func parseIntField(fields []string, index int) (int, error) {
+	if index >= len(fields) {
+		return 0, fmt.Errorf("expected a number after %s", fields[index-1])
+	}
+
+	value, err := strconv.Atoi(fields[index])
+	if err != nil {
+		return 0, fmt.Errorf("expected a number but found %s", fields[index])
+	}
+
+	return value, nil
+}
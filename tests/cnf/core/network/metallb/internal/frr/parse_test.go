@@ -0,0 +1,248 @@
+package frr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testRunningConfig is show running-config output from an frr-k8s pod with a VRF, BFD profile, and route filtering.
+const testRunningConfig = `Building configuration...
+
+Current configuration:
+!
+frr version 9.1_git
+frr defaults traditional
+hostname worker-0
+log file /etc/frr/frr.log informational
+log timestamp precision 3
+service integrated-vtysh-config
+!
+ip route 192.168.10.0/24 10.46.81.1
+!
+vrf red
+ vni 100
+ ip route 10.10.0.0/16 blackhole
+exit-vrf
+!
+interface ens5f0 vrf red
+ ip address 10.0.0.2/24
+exit
+!
+router bgp 64500
+ bgp router-id 10.46.81.30
+ no bgp ebgp-requires-policy
+ no bgp default ipv4-unicast
+ no bgp network import-check
+ neighbor 10.46.81.1 remote-as 64501
+ neighbor 10.46.81.1 port 180
+ neighbor 10.46.81.1 bfd profile fast
+ neighbor 10.46.81.1 password bgp-test
+ neighbor 10.46.81.1 timers 30 90
+ neighbor 10.46.81.1 timers connect 10
+ neighbor 10.46.81.1 ebgp-multihop
+ neighbor 10.46.81.1 disable-connected-check
+ !
+ address-family ipv4 unicast
+  network 192.168.100.1/32
+  neighbor 10.46.81.1 activate
+  neighbor 10.46.81.1 route-map 10.46.81.1-in in
+  neighbor 10.46.81.1 route-map 10.46.81.1-out out
+ exit-address-family
+exit
+!
+router bgp 64500 vrf red
+ bgp router-id 10.0.0.2
+ neighbor 10.0.0.1 remote-as external
+ neighbor 10.0.0.1 graceful-restart
+ !
+ address-family ipv4 unicast
+  redistribute connected
+  neighbor 10.0.0.1 activate
+ exit-address-family
+exit
+!
+bfd
+ profile fast
+  receive-interval 100
+  transmit-interval 100
+  echo-mode
+ exit
+ !
+ peer 10.46.81.1
+ exit
+ !
+exit
+!
+ip prefix-list 10.46.81.1-pl-ipv4 seq 1 permit 192.168.100.1/32
+ip prefix-list 10.46.81.1-pl-ipv4 seq 2 deny any
+ipv6 prefix-list 10.46.81.1-pl-ipv6 seq 1 permit 2001:db8::/64 ge 96 le 128
+bgp community-list standard 10.46.81.1-community seq 5 permit 65535:65282
+!
+route-map 10.46.81.1-out permit 1
+ match ip address prefix-list 10.46.81.1-pl-ipv4
+ set community 65535:65282 additive
+ on-match next
+exit
+!
+route-map 10.46.81.1-in deny 20
+exit
+!
+end
+`
+
+func TestParseRunningConfig(t *testing.T) {
+	config, err := ParseRunningConfig(testRunningConfig)
+	assert.Nil(t, err)
+
+	expected := &Config{
+		Defaults: "traditional",
+		Hostname: "worker-0",
+		Global: []string{
+			"log file /etc/frr/frr.log informational",
+			"log timestamp precision 3",
+			"service integrated-vtysh-config",
+		},
+		BFD: &BFD{Profiles: []BFDProfile{{
+			Name: "fast", ReceiveInterval: 100, TransmitInterval: 100, EchoMode: true,
+		}}},
+		StaticRoutes: []StaticRoute{{Prefix: "192.168.10.0/24", NextHop: "10.46.81.1"}},
+		VRFs: []VRF{{
+			Name: "red", VNI: 100, StaticRoutes: []StaticRoute{{Prefix: "10.10.0.0/16", NextHop: "blackhole"}},
+		}},
+		Interfaces: []Interface{{Name: "ens5f0", VRF: "red", Lines: []string{"ip address 10.0.0.2/24"}}},
+		Routers: []Router{
+			{
+				ASN:      64500,
+				RouterID: "10.46.81.30",
+				Options: []string{
+					"no bgp ebgp-requires-policy", "no bgp default ipv4-unicast", "no bgp network import-check",
+				},
+				Neighbors: []Neighbor{{
+					Address:      "10.46.81.1",
+					RemoteAS:     "64501",
+					Port:         180,
+					Password:     "bgp-test",
+					EBGPMultihop: 255,
+					KeepAlive:    30,
+					HoldTime:     90,
+					ConnectTime:  10,
+					BFD:          true,
+					BFDProfile:   "fast",
+					Options:      []string{"disable-connected-check"},
+				}},
+				AddressFamilies: []AddressFamily{{
+					Family:   "ipv4 unicast",
+					Networks: []string{"192.168.100.1/32"},
+					Neighbors: []AddressFamilyNeighbor{{
+						Address: "10.46.81.1", Activate: true, RouteMapIn: "10.46.81.1-in", RouteMapOut: "10.46.81.1-out",
+					}},
+				}},
+			},
+			{
+				ASN:       64500,
+				VRF:       "red",
+				RouterID:  "10.0.0.2",
+				Neighbors: []Neighbor{{Address: "10.0.0.1", RemoteAS: "external", GracefulRestart: true}},
+				AddressFamilies: []AddressFamily{{
+					Family:       "ipv4 unicast",
+					Redistribute: []string{"connected"},
+					Neighbors:    []AddressFamilyNeighbor{{Address: "10.0.0.1", Activate: true}},
+				}},
+			},
+		},
+		PrefixLists: []PrefixList{
+			{Name: "10.46.81.1-pl-ipv4", Rules: []PrefixListRule{
+				{Seq: 1, Action: "permit", Prefix: "192.168.100.1/32"},
+				{Seq: 2, Action: "deny", Prefix: "any"},
+			}},
+			{Name: "10.46.81.1-pl-ipv6", IPv6: true, Rules: []PrefixListRule{
+				{Seq: 1, Action: "permit", Prefix: "2001:db8::/64", GE: 96, LE: 128},
+			}},
+		},
+		CommunityLists: []CommunityList{{
+			Name: "10.46.81.1-community", Rules: []CommunityListRule{{Seq: 5, Action: "permit", Community: "65535:65282"}},
+		}},
+		RouteMaps: []RouteMap{
+			{
+				Name:     "10.46.81.1-out",
+				Action:   "permit",
+				Sequence: 1,
+				Match:    []string{"ip address prefix-list 10.46.81.1-pl-ipv4"},
+				Set:      []string{"community 65535:65282 additive"},
+				Options:  []string{"on-match next"},
+			},
+			{Name: "10.46.81.1-in", Action: "deny", Sequence: 20},
+		},
+	}
+
+	assert.Equal(t, expected, config)
+}
+
+func TestParseRunningConfigWithoutExit(t *testing.T) {
+	config, err := ParseRunningConfig(`router bgp 64500
+ neighbor 10.0.0.1 remote-as 64501
+ address-family ipv4 unicast
+  neighbor 10.0.0.1 activate
+ip prefix-list pl seq 5 permit any
+route-map rm permit 10
+ match ip address prefix-list pl
+interface eth0
+ ip address 10.0.0.2/24
+`)
+	assert.Nil(t, err)
+
+	expected := &Config{
+		Interfaces: []Interface{{Name: "eth0", Lines: []string{"ip address 10.0.0.2/24"}}},
+		Routers: []Router{{
+			ASN:       64500,
+			Neighbors: []Neighbor{{Address: "10.0.0.1", RemoteAS: "64501"}},
+			AddressFamilies: []AddressFamily{{
+				Family: "ipv4 unicast", Neighbors: []AddressFamilyNeighbor{{Address: "10.0.0.1", Activate: true}},
+			}},
+		}},
+		PrefixLists: []PrefixList{{Name: "pl", Rules: []PrefixListRule{{Seq: 5, Action: "permit", Prefix: "any"}}}},
+		RouteMaps: []RouteMap{{
+			Name: "rm", Action: "permit", Sequence: 10, Match: []string{"ip address prefix-list pl"},
+		}},
+	}
+
+	assert.Equal(t, expected, config)
+}
+
+func TestParseRunningConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		runningConfig string
+		expectedError string
+	}{
+		{
+			name:          "invalid asn",
+			runningConfig: "router bgp abc\n",
+			expectedError: `failed to parse line 1 "router bgp abc": expected a number but found abc`,
+		},
+		{
+			name:          "route map without sequence",
+			runningConfig: "!\nroute-map rm permit\n",
+			expectedError: `failed to parse line 2 "route-map rm permit": expected route-map <name> <permit|deny> <sequence>`,
+		},
+		{
+			name:          "invalid neighbor timers",
+			runningConfig: "router bgp 64500\n neighbor 10.0.0.1 timers 30 x\n",
+			expectedError: `failed to parse line 2 "neighbor 10.0.0.1 timers 30 x": expected a number but found x`,
+		},
+		{
+			name:          "prefix list without prefix",
+			runningConfig: "ip prefix-list pl seq 5 permit\n",
+			expectedError: `failed to parse line 1 "ip prefix-list pl seq 5 permit": ` +
+				`expected <permit|deny> <prefix> [ge <length>] [le <length>]`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := ParseRunningConfig(testCase.runningConfig)
+			assert.EqualError(t, err, testCase.expectedError)
+		})
+	}
+}
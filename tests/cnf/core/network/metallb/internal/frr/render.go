@@ -0,0 +1,308 @@
+package frr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// configWriter accumulates the lines of a rendered config.
+type configWriter struct {
+	builder strings.Builder
+}
+
+// line writes a line indented by depth spaces.
+func (writer *configWriter) line(depth int, format string, args ...any) {
+	writer.builder.WriteString(strings.Repeat(" ", depth))
+	fmt.Fprintf(&writer.builder, format, args...)
+	writer.builder.WriteString("\n")
+}
+
+// lines writes each of values indented by depth spaces, prefixed with prefix.
+func (writer *configWriter) lines(depth int, prefix string, values []string) {
+	for _, value := range values {
+		writer.line(depth, "%s%s", prefix, value)
+	}
+}
+
+// String renders the config as frr.conf.
+func (config *Config) String() string {
+	writer := &configWriter{}
+
+	writer.line(0, "!")
+
+	if config.Defaults != "" {
+		writer.line(0, "frr defaults %s", config.Defaults)
+	}
+
+	if config.Hostname != "" {
+		writer.line(0, "hostname %s", config.Hostname)
+	}
+
+	writer.lines(0, "", config.Global)
+	writer.line(0, "!")
+
+	if config.BFD != nil {
+		config.BFD.render(writer)
+	}
+
+	for _, route := range config.StaticRoutes {
+		route.render(writer, 0)
+	}
+
+	if len(config.StaticRoutes) > 0 {
+		writer.line(0, "!")
+	}
+
+	for _, vrf := range config.VRFs {
+		vrf.render(writer)
+	}
+
+	for _, iface := range config.Interfaces {
+		iface.render(writer)
+	}
+
+	for _, router := range config.Routers {
+		router.render(writer)
+	}
+
+	for _, list := range config.PrefixLists {
+		list.render(writer)
+	}
+
+	for _, list := range config.CommunityLists {
+		list.render(writer)
+	}
+
+	if len(config.PrefixLists) > 0 || len(config.CommunityLists) > 0 {
+		writer.line(0, "!")
+	}
+
+	for _, routeMap := range config.RouteMaps {
+		routeMap.render(writer)
+	}
+
+	writer.line(0, "line vty")
+	writer.line(0, "!")
+	writer.line(0, "end")
+
+	return writer.builder.String()
+}
+
+func (bfd *BFD) render(writer *configWriter) {
+	writer.line(0, "bfd")
+
+	for _, profile := range bfd.Profiles {
+		writer.line(1, "profile %s", profile.Name)
+		writeIntOption(writer, 2, "receive-interval", profile.ReceiveInterval)
+		writeIntOption(writer, 2, "transmit-interval", profile.TransmitInterval)
+		writeIntOption(writer, 2, "detect-multiplier", profile.DetectMultiplier)
+		writeBoolOption(writer, 2, "echo-mode", profile.EchoMode)
+		writeBoolOption(writer, 2, "passive-mode", profile.PassiveMode)
+		writeIntOption(writer, 2, "minimum-ttl", profile.MinimumTTL)
+		writer.lines(2, "", profile.Options)
+		writer.line(1, "exit")
+		writer.line(1, "!")
+	}
+
+	writer.line(0, "exit")
+	writer.line(0, "!")
+}
+
+func (route StaticRoute) render(writer *configWriter, depth int) {
+	family := "ip"
+	if strings.Contains(route.Prefix, ":") {
+		family = "ipv6"
+	}
+
+	writer.line(depth, "%s route %s %s", family, route.Prefix, route.NextHop)
+}
+
+func (vrf VRF) render(writer *configWriter) {
+	writer.line(0, "vrf %s", vrf.Name)
+	writeIntOption(writer, 1, "vni", vrf.VNI)
+
+	for _, route := range vrf.StaticRoutes {
+		route.render(writer, 1)
+	}
+
+	writer.line(0, "exit-vrf")
+	writer.line(0, "!")
+}
+
+func (iface Interface) render(writer *configWriter) {
+	if iface.VRF != "" {
+		writer.line(0, "interface %s vrf %s", iface.Name, iface.VRF)
+	} else {
+		writer.line(0, "interface %s", iface.Name)
+	}
+
+	writer.lines(1, "", iface.Lines)
+	writer.line(0, "exit")
+	writer.line(0, "!")
+}
+
+func (router Router) render(writer *configWriter) {
+	if router.VRF != "" {
+		writer.line(0, "router bgp %d vrf %s", router.ASN, router.VRF)
+	} else {
+		writer.line(0, "router bgp %d", router.ASN)
+	}
+
+	if router.RouterID != "" {
+		writer.line(1, "bgp router-id %s", router.RouterID)
+	}
+
+	writer.lines(1, "", router.Options)
+
+	for _, neighbor := range router.Neighbors {
+		neighbor.render(writer)
+	}
+
+	for _, family := range router.AddressFamilies {
+		writer.line(1, "!")
+		family.render(writer)
+	}
+
+	writer.line(0, "exit")
+	writer.line(0, "!")
+}
+
+func (neighbor Neighbor) render(writer *configWriter) {
+	prefix := "neighbor " + neighbor.Address + " "
+
+	switch {
+	case neighbor.PeerGroup:
+		writer.line(1, "%speer-group", prefix)
+	case neighbor.Interface && neighbor.MemberOf != "":
+		writer.line(1, "%sinterface peer-group %s", prefix, neighbor.MemberOf)
+	case neighbor.Interface:
+		writer.line(1, "%sinterface", prefix)
+	case neighbor.MemberOf != "":
+		writer.line(1, "%speer-group %s", prefix, neighbor.MemberOf)
+	}
+
+	writeStringOption(writer, 1, prefix+"remote-as", neighbor.RemoteAS)
+	writeIntOption(writer, 1, prefix+"port", neighbor.Port)
+	writeStringOption(writer, 1, prefix+"password", neighbor.Password)
+	writeStringOption(writer, 1, prefix+"description", neighbor.Description)
+	writeStringOption(writer, 1, prefix+"update-source", neighbor.UpdateSource)
+	writeIntOption(writer, 1, prefix+"ebgp-multihop", neighbor.EBGPMultihop)
+
+	if neighbor.KeepAlive != 0 || neighbor.HoldTime != 0 {
+		writer.line(1, "%stimers %d %d", prefix, neighbor.KeepAlive, neighbor.HoldTime)
+	}
+
+	writeIntOption(writer, 1, prefix+"timers connect", neighbor.ConnectTime)
+
+	switch {
+	case neighbor.BFDProfile != "":
+		writer.line(1, "%sbfd profile %s", prefix, neighbor.BFDProfile)
+	case neighbor.BFD:
+		writer.line(1, "%sbfd", prefix)
+	}
+
+	writeBoolOption(writer, 1, prefix+"graceful-restart", neighbor.GracefulRestart)
+	writer.lines(1, prefix, neighbor.Options)
+}
+
+func (family AddressFamily) render(writer *configWriter) {
+	writer.line(1, "address-family %s", family.Family)
+	writer.lines(2, "network ", family.Networks)
+	writer.lines(2, "redistribute ", family.Redistribute)
+
+	for _, neighbor := range family.Neighbors {
+		prefix := "neighbor " + neighbor.Address + " "
+
+		writeBoolOption(writer, 2, prefix+"activate", neighbor.Activate)
+		writeStringOption(writer, 2, prefix+"route-map", suffixed(neighbor.RouteMapIn, " in"))
+		writeStringOption(writer, 2, prefix+"route-map", suffixed(neighbor.RouteMapOut, " out"))
+		writeStringOption(writer, 2, prefix+"prefix-list", suffixed(neighbor.PrefixListIn, " in"))
+		writeStringOption(writer, 2, prefix+"prefix-list", suffixed(neighbor.PrefixListOut, " out"))
+		writer.lines(2, prefix, neighbor.Options)
+	}
+
+	writer.lines(2, "", family.Options)
+	writer.line(1, "exit-address-family")
+}
+
+func (list PrefixList) render(writer *configWriter) {
+	family := "ip"
+	if list.IPv6 {
+		family = "ipv6"
+	}
+
+	for _, rule := range list.Rules {
+		line := fmt.Sprintf("%s prefix-list %s", family, list.Name)
+
+		if rule.Seq != 0 {
+			line += fmt.Sprintf(" seq %d", rule.Seq)
+		}
+
+		line += fmt.Sprintf(" %s %s", rule.Action, rule.Prefix)
+
+		if rule.GE != 0 {
+			line += fmt.Sprintf(" ge %d", rule.GE)
+		}
+
+		if rule.LE != 0 {
+			line += fmt.Sprintf(" le %d", rule.LE)
+		}
+
+		writer.line(0, "%s", line)
+	}
+}
+
+func (list CommunityList) render(writer *configWriter) {
+	listType := "standard"
+	if list.Expanded {
+		listType = "expanded"
+	}
+
+	for _, rule := range list.Rules {
+		if rule.Seq != 0 {
+			writer.line(0, "bgp community-list %s %s seq %d %s %s",
+				listType, list.Name, rule.Seq, rule.Action, rule.Community)
+		} else {
+			writer.line(0, "bgp community-list %s %s %s %s", listType, list.Name, rule.Action, rule.Community)
+		}
+	}
+}
+
+func (routeMap RouteMap) render(writer *configWriter) {
+	writer.line(0, "route-map %s %s %d", routeMap.Name, routeMap.Action, routeMap.Sequence)
+	writer.lines(1, "match ", routeMap.Match)
+	writer.lines(1, "set ", routeMap.Set)
+	writer.lines(1, "", routeMap.Options)
+	writer.line(0, "exit")
+	writer.line(0, "!")
+}
+
+// writeStringOption writes name followed by value if value is not empty.
+func writeStringOption(writer *configWriter, depth int, name, value string) {
+	if value != "" {
+		writer.line(depth, "%s %s", name, value)
+	}
+}
+
+// writeIntOption writes name followed by value if value is not zero.
+func writeIntOption(writer *configWriter, depth int, name string, value int) {
+	if value != 0 {
+		writer.line(depth, "%s %d", name, value)
+	}
+}
+
+// writeBoolOption writes name if value is set.
+func writeBoolOption(writer *configWriter, depth int, name string, value bool) {
+	if value {
+		writer.line(depth, "%s", name)
+	}
+}
+
+// suffixed returns value with suffix appended, or an empty string if value is empty.
+func suffixed(value, suffix string) string {
+	if value == "" {
+		return ""
+	}
+
+	return value + suffix
+}
@@ -0,0 +1,119 @@
+package frr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   *Config
+		expected string
+	}{
+		{
+			name:     "empty config",
+			config:   &Config{},
+			expected: "!\n!\nline vty\n!\nend\n",
+		},
+		{
+			name: "vrf router with prefix list and route map",
+			config: &Config{
+				Hostname: "frr-pod",
+				VRFs:     []VRF{{Name: "red", VNI: 100}},
+				Routers: []Router{{
+					ASN: 64500,
+					VRF: "red",
+					Neighbors: []Neighbor{{
+						Address: "10.0.0.1", RemoteAS: "64501", KeepAlive: 10, HoldTime: 30, BFDProfile: "fast",
+					}},
+					AddressFamilies: []AddressFamily{{
+						Family:    "ipv4 unicast",
+						Neighbors: []AddressFamilyNeighbor{{Address: "10.0.0.1", Activate: true, RouteMapIn: "filter"}},
+					}},
+				}},
+				PrefixLists: []PrefixList{{
+					Name:  "allowed",
+					Rules: []PrefixListRule{{Seq: 5, Action: "permit", Prefix: "192.168.0.0/16", LE: 24}},
+				}},
+				RouteMaps: []RouteMap{{
+					Name:     "filter",
+					Action:   "permit",
+					Sequence: 10,
+					Match:    []string{"ip address prefix-list allowed"},
+				}},
+			},
+			expected: `!
+hostname frr-pod
+!
+vrf red
+ vni 100
+exit-vrf
+!
+router bgp 64500 vrf red
+ neighbor 10.0.0.1 remote-as 64501
+ neighbor 10.0.0.1 timers 10 30
+ neighbor 10.0.0.1 bfd profile fast
+ !
+ address-family ipv4 unicast
+  neighbor 10.0.0.1 activate
+  neighbor 10.0.0.1 route-map filter in
+ exit-address-family
+exit
+!
+ip prefix-list allowed seq 5 permit 192.168.0.0/16 le 24
+!
+route-map filter permit 10
+ match ip address prefix-list allowed
+exit
+!
+line vty
+!
+end
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.config.String())
+		})
+	}
+}
+
+func TestDefineBGPConfigRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config string
+	}{
+		{
+			name:   "bgp config",
+			config: DefineBGPConfig(64500, 64501, []string{"10.0.0.1", "fd00::1"}, true, true),
+		},
+		{
+			name: "bgp config with static routes and networks",
+			config: DefineBGPConfigWithStaticRouteAndNetwork(64500, 64501, []string{"172.16.0.1", "172.16.0.2"},
+				[]string{"192.168.100.0/24", "192.168.200.0/24"}, []string{"2001:100::/64", "2001:200::/64"},
+				[]string{"10.0.0.1", "10.0.0.2"}, false, true),
+		},
+		{
+			name: "bgp config with ipv4 networks",
+			config: DefineBGPConfigWithIPv4Network(64500, 64501, []string{"192.168.100.0/24", "192.168.200.0/24"},
+				[]string{"10.0.0.1"}, false, false),
+		},
+		{
+			name: "unnumbered bgp config",
+			config: DefineBGPConfigWithUnnumbered(64500, 64501, "eth1", []string{"192.168.100.0/24"},
+				[]string{"2001:100::/64"}, true, true),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			parsed, err := ParseRunningConfig(testCase.config)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.config, parsed.String())
+		})
+	}
+}
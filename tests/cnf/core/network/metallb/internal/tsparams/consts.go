@@ -54,25 +54,6 @@ const (
 	LabelValue2 = "nginx2"
 	// MLBNginxPodName represents the pod name used for the MetalLB NGINX configuration.
 	MLBNginxPodName = "mlbnginxtpod"
-	// FRRBaseConfig represents FRR daemon minimal configuration.
-	FRRBaseConfig = `!
-frr defaults traditional
-hostname frr-pod
-log file /tmp/frr.log
-log timestamp precision 3
-!
-debug zebra nht
-debug bgp neighbor-events
-!
-bfd
-!
-`
-	// FRRDefaultBGPPreConfig represents FRR daemon BGP minimal config.
-	FRRDefaultBGPPreConfig = ` bgp router-id 10.10.10.11
-  no bgp ebgp-requires-policy
-  no bgp default ipv4-unicast
-  no bgp network import-check
-`
 	// FRRDefaultConfigMapName represents default FRR configMap name.
 	FRRDefaultConfigMapName = "frr-config"
 	// LocalBGPASN represents local BGP AS number.
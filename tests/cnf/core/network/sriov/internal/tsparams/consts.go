@@ -22,4 +22,6 @@ const (
 	// LabelWebhookInjector represents sriov webhook injector match conditions tests that can be used
 	// for test cases selection.
 	LabelWebhookInjector = "webhook-resource-injector"
+	// SriovOperatorCSVPrefix is the prefix of the SR-IOV operator ClusterServiceVersion name.
+	SriovOperatorCSVPrefix = "sriov-network-operator"
 )
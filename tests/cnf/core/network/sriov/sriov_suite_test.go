@@ -12,6 +12,7 @@ import (
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/sriov/internal/tsparams"
 	_ "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/sriov/tests"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/capability"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/params"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/reporter"
@@ -20,6 +21,11 @@ import (
 var (
 	_, currentFile, _, _ = runtime.Caller(0)
 	testNS               = namespace.NewBuilder(APIClient, tsparams.TestNamespaceName)
+	collectors           = reporter.NewRegistry(
+		reporter.NewCRDumper("", tsparams.ReporterNamespacesToDump, tsparams.ReporterCRDsToDump),
+		reporter.NewSpecWindowCollector(APIClient, tsparams.ReporterNamespacesToDump),
+		reporter.NewMustGatherLiteCollector(APIClient),
+	)
 )
 
 func TestLB(t *testing.T) {
@@ -28,9 +34,19 @@ func TestLB(t *testing.T) {
 
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "sriov", Label(tsparams.Labels...), reporterConfig)
+	reporter.AddFingerprintProperties(reporterConfig.JUnitReport)
 }
 
+var _ = ReportBeforeSuite(func(Report) {
+	reporter.SaveClusterFingerprint(APIClient)
+})
+
 var _ = BeforeSuite(func() {
+	By("Checking the cluster has the capabilities required by sriov tests")
+	capability.SkipUnless(APIClient,
+		capability.Operator(NetConfig.SriovOperatorNamespace, tsparams.SriovOperatorCSVPrefix, ""),
+		capability.MinNodes(NetConfig.WorkerLabel, 1))
+
 	By("Creating test namespace with privileged labels")
 	for key, value := range params.PrivilegedNSLabels {
 		testNS.WithLabel(key, value)
@@ -47,16 +63,23 @@ var _ = BeforeSuite(func() {
 	Expect(err).ToNot(HaveOccurred(), "Failed to pull test image on nodes")
 })
 var _ = AfterSuite(func() {
+	// The namespace is only created once the capability checks pass, so there is nothing to clean up if they skipped
+	// the suite.
+	if testNS.Object == nil {
+		return
+	}
+
 	By("Deleting test namespace")
 	err := testNS.DeleteAndWait(tsparams.DefaultTimeout)
 	Expect(err).ToNot(HaveOccurred(), "error to delete test namespace")
 })
 
 var _ = JustAfterEach(func() {
-	reporter.ReportIfFailed(
-		CurrentSpecReport(), currentFile, tsparams.ReporterNamespacesToDump, tsparams.ReporterCRDsToDump)
+	collectors.ReportIfFailed(CurrentSpecReport(), currentFile)
 })
 
 var _ = ReportAfterSuite("", func(report Report) {
 	reportxml.Create(report, NetConfig.GetReportPath(), NetConfig.TCPrefix)
+	reporter.AddFingerprintProperties(NetConfig.GetReportPath())
+	reporter.WriteRunSummary(report, currentFile)
 })
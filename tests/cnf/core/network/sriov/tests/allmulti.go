@@ -18,6 +18,7 @@ import (
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/sriov/internal/tsparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/pcap"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 )
@@ -48,8 +49,8 @@ const (
 	clientAllmultiEnabledIPv4Mac  = "20:04:0f:f1:88:11"
 	clientAllmultiDisabledIPv6Mac = "60:00:00:00:00:12"
 	clientAllmultiDisabledIPv4Mac = "20:04:0f:f1:88:12"
-	multicastIPv6GroupIP          = "ff05:5::5:"
-	multicastIPv4GroupIP          = "239.100.100.250:"
+	multicastIPv6GroupIP          = "ff05:5::5"
+	multicastIPv4GroupIP          = "239.100.100.250"
 	bondNadNameDefault            = "bondnaddefault"
 	bondNadNameAllMulti           = "bondnadallmulti"
 )
@@ -62,8 +63,8 @@ var (
 		"ping -I net1 ff05:5::05"}
 	multicastPingDualNet1Net2StackCMD = []string{"bash", "-c", "sleep 5; ping -I net1 239.100.100.250 & " +
 		"ping -I net1 ff05:5::05 & ping -I net2 239.100.100.250 & ping -I net2 ff05:5::05"}
-	tcpDumpCMD           = []string{"bash", "-c", "tcpdump -i net1 -c 10"}
-	tcpDumpCMDNet2       = []string{"bash", "-c", "tcpdump -i net2 -c 10"}
+	captureNet1          = pcap.Options{Interface: "net1", Duration: time.Second, Count: 10}
+	captureNet2          = pcap.Options{Interface: "net2", Duration: time.Second, Count: 10}
 	addIPv6MCGroupMacCMD = []string{"bash", "-c", "ip maddr add 33:33:0:0:0:5 dev net1"}
 	addIPv4MCGroupMacCMD = []string{"bash", "-c", "ip maddr add 01:00:5e:64:64:fa dev net1"}
 )
@@ -445,10 +446,10 @@ func runAllMultiTestCases(
 	Expect(err).ToNot(HaveOccurred(), "Failed to ping between the multicast source and the clients")
 
 	By("Verify multicast group is not accessible from container without allmulti enabled")
-	assertMulticastTrafficIsNotReceived(defaultClientPod, tcpDumpCMD, multicastGroupIP)
+	assertMulticastTrafficIsNotReceived(defaultClientPod, captureNet1, multicastGroupIP)
 
 	By("Verify multicast group is accessible from container with allmulti enabled")
-	assertMulticastTrafficIsReceived(allMultiEnabledPod, tcpDumpCMD, multicastGroupIP)
+	assertMulticastTrafficIsReceived(allMultiEnabledPod, captureNet1, multicastGroupIP)
 
 	By("Add client without allmulti enabled to the multicast group")
 
@@ -456,7 +457,7 @@ func runAllMultiTestCases(
 	Expect(err).ToNot(HaveOccurred(), "Failed to add the multicast group mac address")
 
 	By("Verify the client receives traffic from the multicast group after being added to the group")
-	assertMulticastTrafficIsReceived(defaultClientPod, tcpDumpCMD, multicastGroupIP)
+	assertMulticastTrafficIsReceived(defaultClientPod, captureNet1, multicastGroupIP)
 }
 
 // defineBondNAD returns network attachment definition for a Bond interface.
@@ -500,22 +501,22 @@ func runAllMultiDualInterfaceTestCase(
 	multicastGroupIPv4 string,
 	multicastGroupIPv6 string) {
 	By("Verify IPv4 multicast group is not accessible from default container without allmulti enabled")
-	assertMulticastTrafficIsNotReceived(defaultClientPod, tcpDumpCMD, multicastGroupIPv4)
+	assertMulticastTrafficIsNotReceived(defaultClientPod, captureNet1, multicastGroupIPv4)
 
 	By("Verify IPv6 multicast group is not accessible from default container without allmulti enabled")
-	assertMulticastTrafficIsNotReceived(defaultClientPod, tcpDumpCMD, multicastGroupIPv6)
+	assertMulticastTrafficIsNotReceived(defaultClientPod, captureNet1, multicastGroupIPv6)
 
 	By("Verify IPv4 multicast group is accessible from allmulti enabled container net1 with allmulti enabled")
-	assertMulticastTrafficIsReceived(allMultiEnabledPod, tcpDumpCMDNet2, multicastGroupIPv4)
+	assertMulticastTrafficIsReceived(allMultiEnabledPod, captureNet2, multicastGroupIPv4)
 
 	By("Verify IPv6 multicast group is accessible from allmulti enabled container net1 with allmulti enabled")
-	assertMulticastTrafficIsReceived(allMultiEnabledPod, tcpDumpCMDNet2, multicastGroupIPv6)
+	assertMulticastTrafficIsReceived(allMultiEnabledPod, captureNet2, multicastGroupIPv6)
 
 	By("Verify IPv4 multicast group is not accessible from allmulti enabled container net2 without allmulti enabled")
-	assertMulticastTrafficIsNotReceived(allMultiEnabledPod, tcpDumpCMD, multicastGroupIPv4)
+	assertMulticastTrafficIsNotReceived(allMultiEnabledPod, captureNet1, multicastGroupIPv4)
 
 	By("Verify IPv6 multicast group is not accessible from allmulti enabled container net2 without allmulti enabled")
-	assertMulticastTrafficIsNotReceived(allMultiEnabledPod, tcpDumpCMD, multicastGroupIPv6)
+	assertMulticastTrafficIsNotReceived(allMultiEnabledPod, captureNet1, multicastGroupIPv6)
 
 	By("Add client without allmulti enabled to the IPv4 multicast group")
 
@@ -523,7 +524,7 @@ func runAllMultiDualInterfaceTestCase(
 	Expect(err).ToNot(HaveOccurred(), "Failed to add the multicast group mac address")
 
 	By("Verify the client receives IPv4 multicast traffic after being added to the group")
-	assertMulticastTrafficIsReceived(allMultiEnabledPod, tcpDumpCMD, multicastGroupIPv4)
+	assertMulticastTrafficIsReceived(allMultiEnabledPod, captureNet1, multicastGroupIPv4)
 
 	By("Add client without allmulti enabled to the IPv6 multicast group")
 
@@ -531,34 +532,31 @@ func runAllMultiDualInterfaceTestCase(
 	Expect(err).ToNot(HaveOccurred(), "Failed to add the multicast group mac address")
 
 	By("Verify the client receives IPv6 multicast traffic after being added to the group")
-	assertMulticastTrafficIsReceived(allMultiEnabledPod, tcpDumpCMD, multicastGroupIPv6)
+	assertMulticastTrafficIsReceived(allMultiEnabledPod, captureNet1, multicastGroupIPv6)
 }
 
 // assertMulticastTrafficIsNotReceived uses Consistently waiting a specific amount to time to verify that the
-// multicastGroupIP is not received.
-func assertMulticastTrafficIsNotReceived(clientPod *pod.Builder, testCmd []string, multicastGroupIP string) {
-	execCommand := func() string {
-		output, err := clientPod.ExecCommand(testCmd)
-		if err != nil {
-			glog.V(100).Infof("Error executing command: %s", err)
-		}
-
-		return output.String()
-	}
+// client does not capture packets sent to the multicastGroupIP.
+func assertMulticastTrafficIsNotReceived(clientPod *pod.Builder, captureOptions pcap.Options, multicastGroupIP string) {
+	Consistently(captureFrames(clientPod, captureOptions), 5*time.Second, 1*time.Second).
+		ShouldNot(pcap.ContainFrame(pcap.WithDstIP(multicastGroupIP)))
+}
 
-	Consistently(execCommand, 5*time.Second, 1*time.Second).ShouldNot(MatchRegexp(multicastGroupIP))
+// assertMulticastTrafficIsReceived uses Eventually expecting to capture packets sent to the multicastGroupIP.
+func assertMulticastTrafficIsReceived(clientPod *pod.Builder, captureOptions pcap.Options, multicastGroupIP string) {
+	Eventually(captureFrames(clientPod, captureOptions), 5*time.Second, 1*time.Second).
+		Should(pcap.ContainFrame(pcap.WithDstIP(multicastGroupIP)))
 }
 
-// assertMulticastTrafficIsReceived uses Eventually expecting to see the multicastGroupIP.
-func assertMulticastTrafficIsReceived(clientPod *pod.Builder, testCmd []string, multicastGroupIP string) {
-	execCommand := func() string {
-		output, err := clientPod.ExecCommand(testCmd)
+// captureFrames returns a function that captures packets on the client pod, logging errors so that a failed capture
+// counts as no packets received.
+func captureFrames(clientPod *pod.Builder, captureOptions pcap.Options) func() []pcap.Frame {
+	return func() []pcap.Frame {
+		frames, err := pcap.Capture(clientPod, captureOptions)
 		if err != nil {
-			glog.V(100).Infof("Error executing command: %s", err)
+			glog.V(100).Infof("Error capturing packets: %s", err)
 		}
 
-		return output.String()
+		return frames
 	}
-
-	Eventually(execCommand, 5*time.Second, 1*time.Second).Should(MatchRegexp(multicastGroupIP))
 }
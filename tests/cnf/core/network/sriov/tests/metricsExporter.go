@@ -22,6 +22,9 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netenv"
 
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netparam"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netswitch"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/sriovdiscovery"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/testpmd"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/sriov/internal/sriovenv"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/sriov/internal/tsparams"
 	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/types"
@@ -47,10 +50,8 @@ var _ = Describe("SriovMetricsExporter", Ordered, Label(tsparams.LabelSriovMetri
 	ContinueOnFailure, func() {
 
 		var (
-			workerNodeList           []*nodes.Builder
-			sriovmetricsdaemonset    *daemonset.Builder
-			sriovInterfacesUnderTest []string
-			sriovVendorID            string
+			sriovmetricsdaemonset *daemonset.Builder
+			sriovPFs              []sriovdiscovery.PF
 		)
 
 		BeforeAll(func() {
@@ -59,20 +60,20 @@ var _ = Describe("SriovMetricsExporter", Ordered, Label(tsparams.LabelSriovMetri
 			Expect(err).ToNot(HaveOccurred(),
 				"Cluster doesn't support Sriov Metrics Exporter test cases as it doesn't have enough nodes")
 
-			By("Validating SR-IOV interfaces")
-			workerNodeList, err = nodes.List(APIClient,
+			By("Discovering SR-IOV interfaces")
+			workerNodeList, err := nodes.List(APIClient,
 				metav1.ListOptions{LabelSelector: labels.Set(NetConfig.WorkerLabelMap).String()})
 			Expect(err).ToNot(HaveOccurred(), "Failed to discover worker nodes")
 
-			Expect(sriovenv.ValidateSriovInterfaces(workerNodeList, 2)).ToNot(HaveOccurred(),
-				"Failed to get required SR-IOV interfaces")
+			pfFilter := sriovdiscovery.Filter{LinkUp: true, MinTotalVFs: 6}
 
-			sriovInterfacesUnderTest, err = NetConfig.GetSriovInterfaces(2)
-			Expect(err).ToNot(HaveOccurred(), "Failed to retrieve SR-IOV interfaces for testing")
+			if NetConfig.SriovInterfaces != "" {
+				pfFilter.Names, err = NetConfig.GetSriovInterfaces(1)
+				Expect(err).ToNot(HaveOccurred(), "Failed to retrieve SR-IOV interfaces for testing")
+			}
 
-			By("Fetching SR-IOV Device ID for interface under test")
-			sriovVendorID = discoverInterfaceUnderTestVendorID(sriovInterfacesUnderTest[0], workerNodeList[0].Definition.Name)
-			Expect(sriovVendorID).ToNot(BeEmpty(), "Expected sriovDeviceID not to be empty")
+			sriovPFs, err = sriovdiscovery.Discover(APIClient, NetConfig.SriovOperatorNamespace, workerNodeList, pfFilter)
+			Expect(err).ToNot(HaveOccurred(), "Failed to discover SR-IOV interfaces")
 
 			By("Enable Sriov Metrics Exporter feature in default SriovOperatorConfig CR")
 			setMetricsExporter(true)
@@ -121,16 +122,13 @@ var _ = Describe("SriovMetricsExporter", Ordered, Label(tsparams.LabelSriovMetri
 
 		Context("Netdevice to Netdevice", func() {
 			It("Same PF", reportxml.ID("74762"), func() {
-				runNettoNetTests(sriovInterfacesUnderTest[0], sriovInterfacesUnderTest[0],
-					workerNodeList[0].Object.Name, workerNodeList[0].Object.Name, sriovVendorID)
+				runNettoNetTests(findPFPairOrSkip(sriovPFs, sriovdiscovery.TopologySamePF))
 			})
 			It("Different PF", reportxml.ID("75929"), func() {
-				runNettoNetTests(sriovInterfacesUnderTest[0], sriovInterfacesUnderTest[1],
-					workerNodeList[0].Object.Name, workerNodeList[0].Object.Name, sriovVendorID)
+				runNettoNetTests(findPFPairOrSkip(sriovPFs, sriovdiscovery.TopologyDifferentPFSameNode))
 			})
 			It("Different Worker", reportxml.ID("75930"), func() {
-				runNettoNetTests(sriovInterfacesUnderTest[0], sriovInterfacesUnderTest[0],
-					workerNodeList[0].Object.Name, workerNodeList[1].Object.Name, sriovVendorID)
+				runNettoNetTests(findPFPairOrSkip(sriovPFs, sriovdiscovery.TopologyDifferentNodes))
 			})
 		})
 
@@ -151,16 +149,13 @@ var _ = Describe("SriovMetricsExporter", Ordered, Label(tsparams.LabelSriovMetri
 				clearClientServerMacTableFromSwitch()
 			})
 			It("Same PF", reportxml.ID("74797"), func() {
-				runNettoVfioTests(sriovInterfacesUnderTest[0], sriovInterfacesUnderTest[0],
-					workerNodeList[0].Object.Name, workerNodeList[0].Object.Name, sriovVendorID)
+				runNettoVfioTests(findPFPairOrSkip(sriovPFs, sriovdiscovery.TopologySamePF))
 			})
 			It("Different PF", reportxml.ID("75931"), func() {
-				runNettoVfioTests(sriovInterfacesUnderTest[0], sriovInterfacesUnderTest[1],
-					workerNodeList[0].Object.Name, workerNodeList[0].Object.Name, sriovVendorID)
+				runNettoVfioTests(findPFPairOrSkip(sriovPFs, sriovdiscovery.TopologyDifferentPFSameNode))
 			})
 			It("Different Worker", reportxml.ID("75932"), func() {
-				runNettoVfioTests(sriovInterfacesUnderTest[0], sriovInterfacesUnderTest[0],
-					workerNodeList[0].Object.Name, workerNodeList[1].Object.Name, sriovVendorID)
+				runNettoVfioTests(findPFPairOrSkip(sriovPFs, sriovdiscovery.TopologyDifferentNodes))
 			})
 		})
 
@@ -182,30 +177,27 @@ var _ = Describe("SriovMetricsExporter", Ordered, Label(tsparams.LabelSriovMetri
 
 			})
 			It("Same PF", reportxml.ID("74800"), func() {
-				runVfiotoVfioTests(sriovInterfacesUnderTest[0], sriovInterfacesUnderTest[0],
-					workerNodeList[0].Object.Name, workerNodeList[0].Object.Name, sriovVendorID)
+				runVfiotoVfioTests(findPFPairOrSkip(sriovPFs, sriovdiscovery.TopologySamePF))
 			})
 			It("Different PF", reportxml.ID("75933"), func() {
-				runVfiotoVfioTests(sriovInterfacesUnderTest[0], sriovInterfacesUnderTest[1],
-					workerNodeList[0].Object.Name, workerNodeList[0].Object.Name, sriovVendorID)
+				runVfiotoVfioTests(findPFPairOrSkip(sriovPFs, sriovdiscovery.TopologyDifferentPFSameNode))
 			})
 			It("Different Worker", reportxml.ID("75934"), func() {
-				runVfiotoVfioTests(sriovInterfacesUnderTest[0], sriovInterfacesUnderTest[0],
-					workerNodeList[0].Object.Name, workerNodeList[1].Object.Name, sriovVendorID)
+				runVfiotoVfioTests(findPFPairOrSkip(sriovPFs, sriovdiscovery.TopologyDifferentNodes))
 			})
 		})
 
 	})
 
-func runNettoNetTests(clientPf, serverPf, clientWorker, serverWorker, devID string) {
+func runNettoNetTests(pfPair sriovdiscovery.Pair) {
 	By("Define and Create SriovNodePolicy, SriovNetwork and Pod Resources")
 
 	clientResources := defineTestResources("client",
-		clientPf, devID, "netdevice",
-		clientWorker, 0, false)
+		pfPair.Client.Name, pfPair.Client.Vendor, "netdevice",
+		pfPair.Client.Node, 0, false)
 	serverResources := defineTestResources("server",
-		serverPf, devID, "netdevice",
-		serverWorker, 1, false)
+		pfPair.Server.Name, pfPair.Server.Vendor, "netdevice",
+		pfPair.Server.Node, 1, false)
 
 	cPod, _ := createTestResources(clientResources, serverResources)
 
@@ -217,15 +209,15 @@ func runNettoNetTests(clientPf, serverPf, clientWorker, serverWorker, devID stri
 	checkMetricsWithPromQL()
 }
 
-func runNettoVfioTests(clientPf, serverPf, clientWorker, serverWorker, devID string) {
+func runNettoVfioTests(pfPair sriovdiscovery.Pair) {
 	By("Define and Create SriovNodePolicy, SriovNetwork and Pod Resources")
 
 	clientResources := defineTestResources("client",
-		clientPf, devID, "netdevice",
-		clientWorker, 0, false)
+		pfPair.Client.Name, pfPair.Client.Vendor, "netdevice",
+		pfPair.Client.Node, 0, false)
 	serverResources := defineTestResources("server",
-		serverPf, devID, "vfiopci",
-		serverWorker, 1, true)
+		pfPair.Server.Name, pfPair.Server.Vendor, "vfiopci",
+		pfPair.Server.Node, 1, true)
 
 	cPod, _ := createTestResources(clientResources, serverResources)
 
@@ -244,15 +236,15 @@ func runNettoVfioTests(clientPf, serverPf, clientWorker, serverWorker, devID str
 	checkMetricsWithPromQL()
 }
 
-func runVfiotoVfioTests(clientPf, serverPf, clientWorker, serverWorker, devID string) {
+func runVfiotoVfioTests(pfPair sriovdiscovery.Pair) {
 	By("Define and Create SriovNodePolicy, SriovNetwork and Pod Resources")
 
 	clientResources := defineTestResources("client",
-		clientPf, devID, "vfiopci",
-		clientWorker, 0, true)
+		pfPair.Client.Name, pfPair.Client.Vendor, "vfiopci",
+		pfPair.Client.Node, 0, true)
 	serverResources := defineTestResources("server",
-		serverPf, devID, "vfiopci",
-		serverWorker, 1, true)
+		pfPair.Server.Name, pfPair.Server.Vendor, "vfiopci",
+		pfPair.Server.Node, 1, true)
 
 	_, _ = createTestResources(clientResources, serverResources)
 
@@ -317,10 +309,20 @@ func defineNetwork(role, devType string) *sriov.NetworkBuilder {
 func defineDPDKPod(role, devType, worker string) *pod.Builder {
 	var (
 		rootUser      int64
-		testpmdCmd    []string
 		netAnnotation []*types.NetworkSelectionElement
 	)
 
+	pmdOptions := testpmd.Options{
+		Binary:          "testpmd",
+		PCIAddresses:    []string{fmt.Sprintf("${PCIDEVICE_OPENSHIFT_IO_%s}", strings.ToUpper(role+devType))},
+		IOVAMode:        "va",
+		PortMask:        "0x1",
+		ForwardingCores: 2,
+		PortTopology:    "loop",
+		NoMlockall:      true,
+		StatsPeriod:     5,
+	}
+
 	securityContext := corev1.SecurityContext{
 		RunAsUser: &rootUser,
 		Capabilities: &corev1.Capabilities{
@@ -337,9 +339,8 @@ func defineDPDKPod(role, devType, worker string) *pod.Builder {
 				IPRequest:  []string{tsparams.ClientIPv4IPAddress},
 			},
 		}
-		testpmdCmd = []string{"bash", "-c", fmt.Sprintf("testpmd -a ${PCIDEVICE_OPENSHIFT_IO_%s} --iova-mode=va -- "+
-			"--portmask=0x1 --nb-cores=2 --forward-mode=txonly --port-topology=loop --no-mlockall "+
-			"--stats-period 5 --eth-peer=0,%s", strings.ToUpper(role+devType), tsparams.ServerMacAddress)}
+		pmdOptions.ForwardMode = testpmd.ForwardModeTxOnly
+		pmdOptions.EthPeers = []testpmd.EthPeer{{Port: 0, MAC: tsparams.ServerMacAddress}}
 	case "server":
 		netAnnotation = []*types.NetworkSelectionElement{
 			{
@@ -348,12 +349,10 @@ func defineDPDKPod(role, devType, worker string) *pod.Builder {
 				IPRequest:  []string{tsparams.ServerIPv4IPAddress},
 			},
 		}
-		testpmdCmd = []string{"bash", "-c", fmt.Sprintf("testpmd -a ${PCIDEVICE_OPENSHIFT_IO_%s} --iova-mode=va -- "+
-			"--portmask=0x1 --nb-cores=2 --forward-mode=macswap --port-topology=loop --no-mlockall "+
-			"--stats-period 5", strings.ToUpper(role+devType))}
+		pmdOptions.ForwardMode = testpmd.ForwardModeMACSwap
 	}
 
-	dpdkContainer, err := pod.NewContainerBuilder("testpmd", NetConfig.DpdkTestContainer, testpmdCmd).
+	dpdkContainer, err := pod.NewContainerBuilder("testpmd", NetConfig.DpdkTestContainer, pmdOptions.ContainerCommand()).
 		WithSecurityContext(&securityContext).
 		WithResourceLimit("1Gi", "1Gi", 4).
 		WithResourceRequest("1Gi", "1Gi", 4).
@@ -464,6 +463,19 @@ func fetchScalarFromPromQLoutput(res string) int {
 	return finalVal
 }
 
+// findPFPairOrSkip returns a pair of discovered PFs of the same model with the topology, skipping the test if the
+// cluster has no such pair.
+func findPFPairOrSkip(sriovPFs []sriovdiscovery.PF, topology sriovdiscovery.Topology) sriovdiscovery.Pair {
+	pfPair, err := sriovdiscovery.FindPair(sriovPFs, sriovdiscovery.Constraints{Topology: topology, SameModel: true})
+	if err != nil {
+		Skip(err.Error())
+	}
+
+	By(fmt.Sprintf("Running traffic from SR-IOV PF %s to %s", pfPair.Client, pfPair.Server))
+
+	return pfPair
+}
+
 func setMetricsExporter(flag bool) {
 	defaultOperatorConfig, err := sriov.PullOperatorConfig(APIClient, NetConfig.SriovOperatorNamespace)
 	Expect(err).ToNot(HaveOccurred(), "Failed to fetch default Sriov Operator Config")
@@ -482,7 +494,7 @@ func clearClientServerMacTableFromSwitch() {
 	switchCredentials, err := sriovenv.NewSwitchCredentials()
 	Expect(err).ToNot(HaveOccurred(), "Failed to get switch credentials")
 
-	jnpr, err := cmd.NewSession(switchCredentials.SwitchIP, switchCredentials.User, switchCredentials.Password)
+	jnpr, err := netswitch.NewJunos(switchCredentials.SwitchIP, switchCredentials.User, switchCredentials.Password)
 	Expect(err).ToNot(HaveOccurred(), "Failed to fetch Switch Credentials")
 
 	defer jnpr.Close()
@@ -3,8 +3,8 @@ package tests
 import (
 	"fmt"
 	"net"
-	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/configmap"
@@ -16,7 +16,9 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netnmstate"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netparam"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/testpmd"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/pcap"
 	multus "gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/types"
 	corev1 "k8s.io/api/core/v1"
 
@@ -29,6 +31,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/sriov"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netenv"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netinittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netswitch"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/sriov/internal/sriovenv"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/sriov/internal/tsparams"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -76,28 +79,29 @@ var _ = Describe("QinQ", Ordered, Label(tsparams.LabelQinQTestCases), ContinueOn
 			"-port 4444 -listen & testcmd -interface net3 -protocol tcp -port 4444 -listen"}
 		testCmdBond0 = []string{"bash", "-c", "sleep 5; testcmd -interface bond0.100 -protocol tcp " +
 			"-port 4444 -listen"}
-		tcpDumpNet1CMD              = []string{"bash", "-c", "tcpdump -i net1 -e > /tmp/tcpdump"}
-		tcpDumpReadFileCMD          = []string{"bash", "-c", "tail -20 /tmp/tcpdump"}
-		tcpDumpDot1ADOutput         = "(ethertype 802\\.1Q-QinQ \\(0x88a8\\)).*?(ethertype 802\\.1Q.*?vlan 100)"
-		tcpDumpDot1QOutput          = "(ethertype 802\\.1Q \\(0x8100\\)).*?(ethertype 802\\.1Q.*?vlan 100)"
-		tcpDumpDot1QDPDKOutput      = "(ethertype 802\\.1Q \\(0x8100\\)).*?(ethertype 802\\.1Q \\(0x8100\\), vlan 100)"
-		tcpDumpDot1ADDPDKOutput     = "(ethertype 802\\.1Q-QinQ \\(0x88a8\\)).*?(ethertype 802\\.1Q \\(0x8100\\), vlan 100)"
-		tcpDumpDot1ADCVLAN101Output = "(ethertype 802\\.1Q-QinQ \\(0x88a8\\)).*?(ethertype 802\\.1Q.*?vlan 101)"
-		tcpDumpDot1QCVLAN101QOutput = "(ethertype 802\\.1Q \\(0x8100\\)).*?(ethertype 802\\.1Q.*?vlan 101)"
-		workerNodeList              = []*nodes.Builder{}
-		srIovInterfacesUnderTest    []string
-		sriovDeviceID               string
-		switchCredentials           *sriovenv.SwitchCredentials
-		switchConfig                *netconfig.NetworkConfig
-		switchInterfaces            []string
-		serverIPV4IP, _, _          = net.ParseCIDR(tsparams.ServerIPv4IPAddress)
-		serverIPV6IP, _, _          = net.ParseCIDR(tsparams.ServerIPv6IPAddress)
-		serverIPV4IP2, _, _         = net.ParseCIDR(tsparams.ServerIPv4IPAddress2)
-		serverIPV6IP2, _, _         = net.ParseCIDR(tsparams.ServerIPv6IPAddress2)
-		serverIPAddressesNet2       = []string{serverIPV4IP.String(), serverIPV6IP.String()}
-		serverIPAddressesNet3       = []string{serverIPV4IP2.String(), serverIPV6IP2.String()}
-		clientIPAddressesNet2       = []string{tsparams.ClientIPv4IPAddress, tsparams.ClientIPv6IPAddress}
-		clientIPAddressesNet3       = []string{tsparams.ClientIPv6IPAddress2, tsparams.ClientIPv6IPAddress2}
+		tcpDumpNet1CMD           = pcap.Options{Interface: intNet1, SnapLength: 128}.ContainerCommand()
+		dot1ADFrame              = []pcap.FrameCondition{pcap.WithOuterTPID(pcap.TPIDDot1AD), pcap.WithInnerVLAN(100)}
+		dot1QFrame               = []pcap.FrameCondition{pcap.WithOuterTPID(pcap.TPIDDot1Q), pcap.WithInnerVLAN(100)}
+		dot1ADCVLAN101Frame      = []pcap.FrameCondition{pcap.WithOuterTPID(pcap.TPIDDot1AD), pcap.WithInnerVLAN(101)}
+		dot1QCVLAN101Frame       = []pcap.FrameCondition{pcap.WithOuterTPID(pcap.TPIDDot1Q), pcap.WithInnerVLAN(101)}
+		workerNodeList           = []*nodes.Builder{}
+		srIovInterfacesUnderTest []string
+		sriovDeviceID            string
+		switchCredentials        *sriovenv.SwitchCredentials
+		switchConfig             *netconfig.NetworkConfig
+		switchInterfaces         []string
+		serverIPV4IP, _, _       = net.ParseCIDR(tsparams.ServerIPv4IPAddress)
+		serverIPV6IP, _, _       = net.ParseCIDR(tsparams.ServerIPv6IPAddress)
+		serverIPV4IP2, _, _      = net.ParseCIDR(tsparams.ServerIPv4IPAddress2)
+		serverIPV6IP2, _, _      = net.ParseCIDR(tsparams.ServerIPv6IPAddress2)
+		serverIPAddressesNet2    = []string{serverIPV4IP.String(), serverIPV6IP.String()}
+		serverIPAddressesNet3    = []string{serverIPV4IP2.String(), serverIPV6IP2.String()}
+		clientIPAddressesNet2    = []string{tsparams.ClientIPv4IPAddress, tsparams.ClientIPv6IPAddress}
+		clientIPAddressesNet3    = []string{tsparams.ClientIPv6IPAddress2, tsparams.ClientIPv6IPAddress2}
+		dot1QDPDKFrame           = []pcap.FrameCondition{
+			pcap.WithOuterTPID(pcap.TPIDDot1Q), pcap.WithInnerTag(pcap.TPIDDot1Q, 100)}
+		dot1ADDPDKFrame = []pcap.FrameCondition{
+			pcap.WithOuterTPID(pcap.TPIDDot1AD), pcap.WithInnerTag(pcap.TPIDDot1Q, 100)}
 	)
 
 	BeforeAll(func() {
@@ -174,7 +178,7 @@ var _ = Describe("QinQ", Ordered, Label(tsparams.LabelQinQTestCases), ContinueOn
 				validateTCPTraffic(clientPod, intNet2, serverIPAddressesNet2)
 
 				By("Validate that the TCP traffic is double tagged")
-				readAndValidateTCPDump(tcpDumpContainer, tcpDumpReadFileCMD, tcpDumpDot1ADOutput)
+				validateCapturedFrame(tcpDumpContainer, dot1ADFrame...)
 			})
 
 		It("Verify network traffic over a 802.1ad QinQ tunnel between two SRIOV containers in different nodes",
@@ -201,7 +205,7 @@ var _ = Describe("QinQ", Ordered, Label(tsparams.LabelQinQTestCases), ContinueOn
 				validateTCPTraffic(clientPod, intNet2, serverIPAddressesNet2)
 
 				By("Validate that the TCP traffic is double tagged")
-				readAndValidateTCPDump(tcpDumpContainer, tcpDumpReadFileCMD, tcpDumpDot1ADOutput)
+				validateCapturedFrame(tcpDumpContainer, dot1ADFrame...)
 			})
 
 		It("Verify network traffic over an 802.1ad Q-in-Q tunnel with multiple C-VLANs using the same S-VLAN",
@@ -234,14 +238,14 @@ var _ = Describe("QinQ", Ordered, Label(tsparams.LabelQinQTestCases), ContinueOn
 				validateTCPTraffic(clientPod, intNet2, serverIPAddressesNet2)
 
 				By("Validate that the TCP traffic is double tagged with CVLAN100 ")
-				readAndValidateTCPDump(tcpDumpContainer, tcpDumpReadFileCMD, tcpDumpDot1ADOutput)
+				validateCapturedFrame(tcpDumpContainer, dot1ADFrame...)
 
 				By("Validate IPv4 and IPv6 tcp traffic and dot1ad encapsulation from the client to server " +
 					"with CVLAN101.")
 				validateTCPTraffic(clientPod, intNet3, serverIPAddressesNet3)
 
 				By("Validate that the TCP traffic is double tagged with CVLAN101 ")
-				readAndValidateTCPDump(tcpDumpContainer, tcpDumpReadFileCMD, tcpDumpDot1ADCVLAN101Output)
+				validateCapturedFrame(tcpDumpContainer, dot1ADCVLAN101Frame...)
 			})
 
 		It("Verify a negative test with an 802.1ad to 802.1q tunnel between two SRIOV containers",
@@ -300,13 +304,13 @@ var _ = Describe("QinQ", Ordered, Label(tsparams.LabelQinQTestCases), ContinueOn
 				validateTCPTraffic(clientDotADPod, intNet2, serverIPAddressesNet2)
 
 				By("Validate that the 802.1AD TCP traffic is double tagged")
-				readAndValidateTCPDump(tcpDumpContainer, tcpDumpReadFileCMD, tcpDumpDot1ADOutput)
+				validateCapturedFrame(tcpDumpContainer, dot1ADFrame...)
 
 				By("Validate IPv4 and IPv6 tcp traffic and dot1q encapsulation from the client to server")
 				validateTCPTraffic(clientDotQPod, intNet2, serverIPAddressesNet2)
 
 				By("Validate that the 802.1Q TCP traffic is double tagged")
-				readAndValidateTCPDump(tcpDumpContainer, tcpDumpReadFileCMD, tcpDumpDot1QCVLAN101QOutput)
+				validateCapturedFrame(tcpDumpContainer, dot1QCVLAN101Frame...)
 			})
 
 		It("Verify network traffic over a 802.1ad Q-in-Q tunneling with Bond interfaces between two clients "+
@@ -340,7 +344,7 @@ var _ = Describe("QinQ", Ordered, Label(tsparams.LabelQinQTestCases), ContinueOn
 				validateTCPTraffic(clientPod, intBond0, serverIPAddressesNet2)
 
 				By("Validate that the TCP traffic is double tagged")
-				readAndValidateTCPDump(tcpDumpContainer, tcpDumpReadFileCMD, tcpDumpDot1ADOutput)
+				validateCapturedFrame(tcpDumpContainer, dot1ADFrame...)
 			})
 		AfterAll(func() {
 			By("Clean the test env of sriov and pod deployments")
@@ -404,7 +408,7 @@ var _ = Describe("QinQ", Ordered, Label(tsparams.LabelQinQTestCases), ContinueOn
 				validateTCPTraffic(clientPod, intNet2, serverIPAddressesNet2)
 
 				By("Validate that the TCP traffic is double tagged")
-				readAndValidateTCPDump(tcpDumpContainer, tcpDumpReadFileCMD, tcpDumpDot1QOutput)
+				validateCapturedFrame(tcpDumpContainer, dot1QFrame...)
 			})
 
 		It("Verify network traffic over a 802.1Q QinQ tunnel between two SRIOV containers in different nodes",
@@ -431,7 +435,7 @@ var _ = Describe("QinQ", Ordered, Label(tsparams.LabelQinQTestCases), ContinueOn
 				validateTCPTraffic(clientPod, intNet2, serverIPAddressesNet2)
 
 				By("Validate that the TCP traffic is double tagged")
-				readAndValidateTCPDump(tcpDumpContainer, tcpDumpReadFileCMD, tcpDumpDot1QOutput)
+				validateCapturedFrame(tcpDumpContainer, dot1QFrame...)
 			})
 
 		It("Verify network traffic over a double tagged 802.1Q tunnel with multiple C-VLANs using the same S-VLAN",
@@ -464,14 +468,14 @@ var _ = Describe("QinQ", Ordered, Label(tsparams.LabelQinQTestCases), ContinueOn
 				validateTCPTraffic(clientPod, intNet2, serverIPAddressesNet2)
 
 				By("Validate that the TCP traffic is double tagged with CVLAN100 ")
-				readAndValidateTCPDump(tcpDumpContainer, tcpDumpReadFileCMD, tcpDumpDot1QOutput)
+				validateCapturedFrame(tcpDumpContainer, dot1QFrame...)
 
 				By("Validate IPv4 and IPv6 tcp traffic and dot1q encapsulation from the client to server " +
 					"using CVLAN101")
 				validateTCPTraffic(clientPod, intNet3, serverIPAddressesNet3)
 
 				By("Validate that the TCP traffic is double tagged with CVLAN101 ")
-				readAndValidateTCPDump(tcpDumpContainer, tcpDumpReadFileCMD, tcpDumpDot1QCVLAN101QOutput)
+				validateCapturedFrame(tcpDumpContainer, dot1QCVLAN101Frame...)
 			})
 		AfterAll(func() {
 			By("Clean the test env of sriov and pod deployments")
@@ -527,16 +531,16 @@ var _ = Describe("QinQ", Ordered, Label(tsparams.LabelQinQTestCases), ContinueOn
 					clientNameDPDKDot1ad,
 					srIovNetworkDPDKDot1AD,
 					nadCVLANDpdk,
-					tcpDumpDot1ADDPDKOutput)
+					dot1ADDPDKFrame)
 			})
 
 		It("Verify network traffic over a 802.1q QinQ tunnel between two DPDK pods on the same PF",
 			reportxml.ID("72638"), func() {
-				testOutPutString := tcpDumpDot1QDPDKOutput
+				expectedFrame := dot1QDPDKFrame
 				if sriovDeviceID == intelDeviceIDE710 {
 					vlan, err := strconv.Atoi(NetConfig.VLAN)
 					Expect(err).ToNot(HaveOccurred(), "Failed to convert VLAN value")
-					testOutPutString = fmt.Sprintf("(ethertype 802\\.1Q \\(0x8100\\)).*?(vlan %d)", vlan)
+					expectedFrame = []pcap.FrameCondition{pcap.WithOuterTag(pcap.TPIDDot1Q, uint16(vlan))}
 				}
 
 				runQinQDpdkTestCases(
@@ -545,7 +549,7 @@ var _ = Describe("QinQ", Ordered, Label(tsparams.LabelQinQTestCases), ContinueOn
 					clientNameDPDKDot1q,
 					srIovNetworkDPDKDot1Q,
 					nadCVLANDpdk,
-					testOutPutString)
+					expectedFrame)
 			})
 		AfterAll(func() {
 
@@ -627,7 +631,7 @@ var _ = Describe("QinQ", Ordered, Label(tsparams.LabelQinQTestCases), ContinueOn
 				validateTCPTraffic(clientPod, intNet2, serverIPAddressesNet2)
 
 				By("Validate that the TCP traffic is double tagged")
-				readAndValidateTCPDump(tcpDumpContainer, tcpDumpReadFileCMD, tcpDumpDot1ADOutput)
+				validateCapturedFrame(tcpDumpContainer, dot1ADFrame...)
 			})
 
 		AfterAll(func() {
@@ -811,40 +815,19 @@ func validateTCPTraffic(clientPod *pod.Builder, interfaceName string, destIPAddr
 	}
 }
 
-// readAndValidateTCPDump checks that the inner C-VLAN is present verifying that the packet was double tagged.
-func readAndValidateTCPDump(clientPod *pod.Builder, testCmd []string, pattern string) {
-	By("Start to capture traffic on the promiscuous client")
+// validateCapturedFrame checks that the capture of capturePod has a frame with the expected VLAN tags, verifying that
+// the traffic was double tagged.
+func validateCapturedFrame(capturePod *pod.Builder, expectedFrame ...pcap.FrameCondition) {
+	By("Read the traffic captured on the promiscuous client")
 
-	output, err := clientPod.ExecCommand(testCmd)
-	Expect(err).ToNot(HaveOccurred(), fmt.Sprintf("Error executing command: %s", output.String()))
-
-	err = validateDot1Encapsulation(output.String(), pattern)
-	Expect(err).ToNot(HaveOccurred(), fmt.Sprintf("Failed to validate qinq encapsulation %s", output.String()))
-}
-
-func validateDot1Encapsulation(fileOutput, dot1X string) error {
-	// Compile the regular expression
-	regex := regexp.MustCompile(dot1X)
-	fmt.Println("REGEX", regex.String())
-	match := regex.FindStringSubmatch(fileOutput)
-
-	// Check if the regular expression matched at all
-	if len(match) == 0 {
-		return fmt.Errorf("regular expression did not match")
-	}
-
-	if len(match) != 3 {
-		return fmt.Errorf("failed to match double encapsulation")
-	}
-	// Output the matches
-	fmt.Println("Matched S-VLAN", match[1])
-	fmt.Println("Matched C-VLAN", match[2])
-
-	return nil
+	Eventually(func() ([]pcap.Frame, error) {
+		return pcap.ReadFile(capturePod, pcap.DefaultPath)
+	}, time.Minute, tsparams.RetryInterval).Should(pcap.ContainFrame(expectedFrame...),
+		"Failed to validate qinq encapsulation")
 }
 
 func enableDot1ADonSwitchInterfaces(credentials *sriovenv.SwitchCredentials, switchInterfaces []string) error {
-	jnpr, err := cmd.NewSession(credentials.SwitchIP, credentials.User, credentials.Password)
+	jnpr, err := netswitch.NewJunos(credentials.SwitchIP, credentials.User, credentials.Password)
 	if err != nil {
 		return err
 	}
@@ -864,7 +847,7 @@ func enableDot1ADonSwitchInterfaces(credentials *sriovenv.SwitchCredentials, swi
 }
 
 func disableQinQOnSwitch(switchCredentials *sriovenv.SwitchCredentials, switchInterfaces []string) error {
-	jnpr, err := cmd.NewSession(switchCredentials.SwitchIP, switchCredentials.User, switchCredentials.Password)
+	jnpr, err := netswitch.NewJunos(switchCredentials.SwitchIP, switchCredentials.User, switchCredentials.Password)
 	if err != nil {
 		return err
 	}
@@ -884,27 +867,30 @@ func disableQinQOnSwitch(switchCredentials *sriovenv.SwitchCredentials, switchIn
 	return nil
 }
 
-func defineTestServerPmdCmd(ethPeer, pciAddress string) []string {
-	baseCmd := fmt.Sprintf("dpdk-testpmd -a %s -- --forward-mode txonly --eth-peer=0,%s "+
-		"--cmdline-file=/etc/cmd/cmd_file --stats-period 5", pciAddress, ethPeer)
-
-	return []string{"/bin/bash", "-c", baseCmd}
+func defineTestServerPmdOptions(ethPeer, pciAddress string) testpmd.Options {
+	return testpmd.Options{
+		PCIAddresses: []string{pciAddress},
+		ForwardMode:  testpmd.ForwardModeTxOnly,
+		EthPeers:     []testpmd.EthPeer{{Port: 0, MAC: ethPeer}},
+		TxVLANs:      []testpmd.TxVLAN{{Port: 0, VLAN: 100}},
+		CmdlineFile:  "/etc/cmd/cmd_file",
+		StatsPeriod:  5,
+	}
 }
 
-func defineTestClientPmdCmd(pciAddress string) []string {
-	baseCmd := fmt.Sprintf(
-		"timeout -s SIGKILL 20 dpdk-testpmd "+
-			"--vdev=virtio_user0,path=/dev/vhost-net,queues=2,queue_size=1024,iface=net2 -a %s "+
-			"-- --stats-period 5", pciAddress)
-
-	return []string{baseCmd}
+func defineTestClientPmdOptions(pciAddress string) testpmd.Options {
+	return testpmd.Options{
+		PCIAddresses: []string{pciAddress},
+		VDevs:        []string{"virtio_user0,path=/dev/vhost-net,queues=2,queue_size=1024,iface=net2"},
+		StatsPeriod:  5,
+	}
 }
 
 func defineAndCreateServerDPDKPod(
 	podName,
 	nodeName string,
 	serverPodNetConfig []*multus.NetworkSelectionElement,
-	podCmd []string) *pod.Builder {
+	pmdOptions testpmd.Options) *pod.Builder {
 	var rootUser int64
 	securityContext := corev1.SecurityContext{
 		RunAsUser: &rootUser,
@@ -913,19 +899,15 @@ func defineAndCreateServerDPDKPod(
 		},
 	}
 
-	dpdkContainerCfg, err := pod.NewContainerBuilder(podName, NetConfig.DpdkTestContainer, podCmd).
+	dpdkContainerCfg, err := pod.NewContainerBuilder(podName, NetConfig.DpdkTestContainer,
+		pmdOptions.ContainerCommand()).
 		WithSecurityContext(&securityContext).WithResourceLimit("2Gi", "1Gi", 4).
 		WithResourceRequest("2Gi", "1Gi", 4).WithEnvVar("RUN_TYPE", "testcmd").
 		GetContainerCfg()
 
 	Expect(err).ToNot(HaveOccurred(), "Fail to define server dpdk container")
 
-	dpdkPort0Cmd := `port stop 0
-tx_vlan set 0 100
-port start 0
-start
-`
-	configMapData := map[string]string{"cmd_file": dpdkPort0Cmd}
+	configMapData := map[string]string{"cmd_file": strings.Join(pmdOptions.StartupCommands(), "\n") + "\n"}
 	configMap, err := configmap.NewBuilder(APIClient, "dpdk-port-cmd", tsparams.TestNamespaceName).
 		WithData(configMapData).Create()
 	Expect(err).ToNot(HaveOccurred(), "Failed to create config map")
@@ -951,7 +933,7 @@ func defineAndCreateClientDPDKPod(
 			Add: []corev1.Capability{"IPC_LOCK", "SYS_RESOURCE", "NET_RAW", "NET_ADMIN"},
 		},
 	}
-	testCommand := []string{"bash", "-c", "tcpdump -i net2 -e > /tmp/tcpdump"}
+	testCommand := pcap.Options{Interface: "net2", SnapLength: 128}.ContainerCommand()
 
 	dpdkContainerCfg, err := pod.NewContainerBuilder(podName, NetConfig.DpdkTestContainer,
 		[]string{"/bin/bash", "-c", "sleep INF"}).WithSecurityContext(&securityContext).
@@ -969,13 +951,14 @@ func defineAndCreateClientDPDKPod(
 	return dpdkPod
 }
 
-func runQinQDpdkTestCases(nodeName, serverName, clientName, sriovNetworkName, nadCVLANDpdk, outPutSubString string) {
+func runQinQDpdkTestCases(
+	nodeName, serverName, clientName, sriovNetworkName, nadCVLANDpdk string, expectedFrame []pcap.FrameCondition) {
 	By("Define and create a 802.1AD dpdk server container")
 
 	annotation := pod.StaticIPAnnotationWithMacAddress(sriovNetworkName, []string{}, tsparams.ServerMacAddress)
-	testCmdServer := defineTestServerPmdCmd(tsparams.ClientMacAddress,
+	serverPmdOptions := defineTestServerPmdOptions(tsparams.ClientMacAddress,
 		"${PCIDEVICE_OPENSHIFT_IO_SRIOVPOLICYVFIOPCI}")
-	_ = defineAndCreateServerDPDKPod(serverName, nodeName, annotation, testCmdServer)
+	_ = defineAndCreateServerDPDKPod(serverName, nodeName, annotation, serverPmdOptions)
 
 	By("Define and create a dpdk client container")
 
@@ -990,13 +973,14 @@ func runQinQDpdkTestCases(nodeName, serverName, clientName, sriovNetworkName, na
 
 	By("Validate dpdk_testpmd traffic from the server to the client using CVLAN100.")
 
-	clientRxCmd := defineTestClientPmdCmd("${PCIDEVICE_OPENSHIFT_IO_SRIOVPOLICYVFIOPCI}")
-
-	err := cmd.RxTrafficOnClientPod(clientDpdk, clientRxCmd[0])
-	Expect(err).ToNot(HaveOccurred(), "The Receive traffic test on the the client pod failed")
+	result, err := testpmd.Run(clientDpdk,
+		defineTestClientPmdOptions("${PCIDEVICE_OPENSHIFT_IO_SRIOVPOLICYVFIOPCI}"), 20*time.Second, false)
+	Expect(err).ToNot(HaveOccurred(), "Failed to run testpmd on the client pod")
+	Expect(result.Check(0, testpmd.ReceivedPackets())).ToNot(HaveOccurred(),
+		"The Receive traffic test on the the client pod failed")
 
 	By("Validate that the TCP traffic is double tagged")
-	readAndValidateTCPDump(clientDpdk, []string{"bash", "-c", "tail -20 /tmp/tcpdump"}, outPutSubString)
+	validateCapturedFrame(clientDpdk, expectedFrame...)
 }
 
 // defineBondNAD returns network attachment definition for a Bond interface.
@@ -2,13 +2,10 @@ package cnfconfig
 
 import (
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -31,50 +28,12 @@ func NewCNFConfig() *CNFConfig {
 	_, filename, _, _ := runtime.Caller(0)
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultCnfParamsFile)
-	err := readFile(&coreConf, confFile)
-
+	err := config.Load(&coreConf, confFile)
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
-
-		return nil
-	}
-
-	err = readEnv(&coreConf)
-
-	if err != nil {
-		log.Print("Error to read environment variables")
+		log.Printf("Error to load config file %s: %v", confFile, err)
 
 		return nil
 	}
 
 	return &coreConf
 }
-
-func readFile(cnfConfig *CNFConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-	err = decoder.Decode(&cnfConfig)
-
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(cnfConfig *CNFConfig) error {
-	err := envconfig.Process("", cnfConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
@@ -40,3 +40,19 @@ func UpdateAndWaitForSync(app *argocd.ApplicationBuilder, synced bool, elements
 
 	return nil
 }
+
+// SetGitSource sets the repo URL, target revision, and path of the provided Argo CD application and waits for the
+// source to be updated and synced.
+func SetGitSource(app *argocd.ApplicationBuilder, repoURL, targetRevision, path string) error {
+	_, err := app.WithGitDetails(repoURL, targetRevision, path).Update(true)
+	if err != nil {
+		return fmt.Errorf("failed to update the application source: %w", err)
+	}
+
+	err = app.WaitForSourceUpdate(true, tsparams.ArgoCdChangeTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to wait for the application to sync: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package gitserver
+
+import (
+	"embed"
+	"fmt"
+	"maps"
+	"path"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/argocd"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/gitdetails"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/tsparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/ztplint"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
+)
+
+// fixtures contains a directory for each Argo CD app. The files in each directory are rendered and pushed relative to
+// the git path of the app, so fixtures/policies/ztp-test/custom-interval ends up at the path the tests append to the
+// policies app for tsparams.ZtpTestPathCustomInterval.
+//
+//go:embed fixtures
+var fixtures embed.FS
+
+// fixtureRoot is the directory in fixtures containing a directory for each app.
+const fixtureRoot = "fixtures"
+
+// FixtureData is the data available to fixture templates.
+type FixtureData struct {
+	// Spoke1Name is the name of the first spoke cluster.
+	Spoke1Name string
+	// TestNamespace is the namespace on the hub used for ZTP tests.
+	TestNamespace string
+}
+
+// AppSource is the git source of an Argo CD app, saved so it can be restored after the app has been pointed at the git
+// server.
+type AppSource struct {
+	// AppName is the name of the app in ranparam.OpenshiftGitOpsNamespace.
+	AppName        string
+	RepoURL        string
+	TargetRevision string
+	Path           string
+}
+
+// SetupApps points each of appNames at a repository on the server that is a copy of its current source with the
+// rendered fixtures for that app committed on top. Apps keep the same path so tests that append to it continue to
+// work. It returns the original sources of the apps that were updated, which should be passed to RestoreApps even if an
+// error is returned.
+func (server *Server) SetupApps(
+	apiClient *clients.Settings, data FixtureData, appNames ...string) ([]AppSource, error) {
+	var sources []AppSource
+
+	for _, appName := range appNames {
+		app, err := argocd.PullApplication(apiClient, appName, ranparam.OpenshiftGitOpsNamespace)
+		if err != nil {
+			return sources, fmt.Errorf("failed to pull app %s: %w", appName, err)
+		}
+
+		if app.Definition.Spec.Source == nil {
+			return sources, fmt.Errorf("app %s has no source", appName)
+		}
+
+		source := AppSource{
+			AppName:        appName,
+			RepoURL:        app.Definition.Spec.Source.RepoURL,
+			TargetRevision: app.Definition.Spec.Source.TargetRevision,
+			Path:           app.Definition.Spec.Source.Path,
+		}
+
+		files, err := renderAppFixtures(appName, source.Path, data)
+		if err != nil {
+			return sources, err
+		}
+
+		err = server.Seed(appName, source.RepoURL, revisionOrHead(source.TargetRevision))
+		if err != nil {
+			return sources, err
+		}
+
+		err = server.Push(appName, files, "Add ZTP test fixtures")
+		if err != nil {
+			return sources, err
+		}
+
+		glog.V(tsparams.LogLevel).Infof("Pointing app %s at %s", appName, server.RepoURL(appName))
+
+		sources = append(sources, source)
+
+		err = gitdetails.SetGitSource(app, server.RepoURL(appName), tsparams.GitFixtureBranch, source.Path)
+		if err != nil {
+			return sources, err
+		}
+	}
+
+	return sources, nil
+}
+
+// RestoreApps points each app in sources back at its original source. It attempts to restore every app before
+// returning the first error.
+func RestoreApps(apiClient *clients.Settings, sources []AppSource) error {
+	var firstErr error
+
+	for _, source := range sources {
+		glog.V(tsparams.LogLevel).Infof("Restoring app %s to %s", source.AppName, source.RepoURL)
+
+		app, err := argocd.PullApplication(apiClient, source.AppName, ranparam.OpenshiftGitOpsNamespace)
+		if err == nil {
+			err = gitdetails.SetGitSource(app, source.RepoURL, revisionOrHead(source.TargetRevision), source.Path)
+		}
+
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to restore app %s: %w", source.AppName, err)
+		}
+	}
+
+	return firstErr
+}
+
+// LintFixtures renders the fixtures for each of appNames with data and returns an error listing every issue found by
+// ztplint. This only uses the local fixtures, so it finds problems in seconds rather than after Argo CD syncs them.
+func LintFixtures(data FixtureData, appNames ...string) error {
+	files := make(map[string][]byte)
+
+	for _, appName := range appNames {
+		rendered, err := renderAppFixtures(appName, appName, data)
+		if err != nil {
+			return err
+		}
+
+		maps.Copy(files, rendered)
+	}
+
+	issues := ztplint.Linter{}.Lint(files)
+	if len(issues) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		messages = append(messages, issue.String())
+	}
+
+	return fmt.Errorf("found %d issues in ZTP fixtures:\n%s", len(issues), strings.Join(messages, "\n"))
+}
+
+// renderAppFixtures renders the fixtures for appName with data, keyed by their path in the repository given the app is
+// at appPath.
+func renderAppFixtures(appName, appPath string, data FixtureData) (map[string][]byte, error) {
+	rendered, err := Render(fixtures, path.Join(fixtureRoot, appName), data)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte, len(rendered))
+	for filePath, content := range rendered {
+		files[path.Join(appPath, filePath)] = content
+	}
+
+	return files, nil
+}
+
+// revisionOrHead returns revision, or HEAD if it is empty. Argo CD treats an empty target revision the same as HEAD,
+// but application builders require one to be set.
+func revisionOrHead(revision string) string {
+	if revision == "" {
+		return "HEAD"
+	}
+
+	return revision
+}
@@ -0,0 +1,264 @@
+package gitserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/route"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/service"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/tsparams"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// repositoryRoot is the directory in the git server pod containing the bare repositories.
+	repositoryRoot = "/srv/git"
+	// maxArchiveSize is the largest encoded archive that can be pushed. The archive is passed as an argument to exec,
+	// which ends up in the URL of the request to the API server, so it must stay small.
+	maxArchiveSize = 512 * 1024
+)
+
+// serverScript is run by the git server pod. Requests for /<name>/raw/<revision>/<path> return the file at path in
+// revision of the repository <name>.git, which is the form Argo CD application builders use to check whether paths
+// exist. All other requests are passed to git http-backend so the repositories can be cloned and fetched using the
+// smart HTTP protocol, which Argo CD requires.
+const serverScript = `
+import http.server, os, subprocess, sys
+
+ROOT = sys.argv[2]
+
+class Handler(http.server.BaseHTTPRequestHandler):
+    def do_GET(self):
+        self.respond(True)
+
+    def do_HEAD(self):
+        self.respond(False)
+
+    def do_POST(self):
+        self.respond(True)
+
+    def respond(self, send_body):
+        path, _, query = self.path.partition("?")
+        repo, raw, rest = path.strip("/").partition("/raw/")
+        if raw and "/" not in repo and not repo.endswith(".git"):
+            revision, _, file_path = rest.partition("/")
+            result = subprocess.run(["git", "-C", os.path.join(ROOT, repo + ".git"), "show",
+                                     revision + ":" + file_path], capture_output=True)
+            found = result.returncode == 0
+            self.reply(200 if found else 404, {}, result.stdout if found else b"", send_body)
+            return
+        length = int(self.headers.get("Content-Length") or 0)
+        env = dict(os.environ, GIT_PROJECT_ROOT=ROOT, GIT_HTTP_EXPORT_ALL="1", PATH_INFO=path, QUERY_STRING=query,
+                   REQUEST_METHOD=self.command, CONTENT_TYPE=self.headers.get("Content-Type", ""),
+                   CONTENT_LENGTH=str(length), REMOTE_ADDR=self.client_address[0],
+                   HTTP_CONTENT_ENCODING=self.headers.get("Content-Encoding", ""),
+                   GIT_PROTOCOL=self.headers.get("Git-Protocol", ""))
+        result = subprocess.run(["git", "http-backend"], input=self.rfile.read(length), env=env, capture_output=True)
+        head, _, body = result.stdout.partition(b"\r\n\r\n")
+        status, headers = 200, {}
+        for line in head.decode().split("\r\n"):
+            name, _, value = line.partition(":")
+            if name.lower() == "status":
+                status = int(value.split()[0])
+            elif name:
+                headers[name] = value.strip()
+        self.reply(status, headers, body, send_body)
+
+    def reply(self, status, headers, body, send_body):
+        self.send_response(status)
+        for name, value in headers.items():
+            self.send_header(name, value)
+        self.send_header("Content-Length", str(len(body)))
+        self.end_headers()
+        if send_body:
+            self.wfile.write(body)
+
+os.makedirs(ROOT, exist_ok=True)
+print("Serving repositories in %s on port %s" % (ROOT, sys.argv[1]), flush=True)
+http.server.ThreadingHTTPServer(("", int(sys.argv[1])), Handler).serve_forever()
+`
+
+// seedScript replaces the bare repository at $1 with a clone of $2 and points the branch $3 at the revision $4.
+const seedScript = `set -e
+rm -rf "$1"
+git clone -q --bare "$2" "$1"
+git -C "$1" branch -f "$3" "$4"
+`
+
+// pushScript commits the base64 encoded gzipped tar archive $3 on top of the branch $2 of the bare repository at $1
+// with the message $4.
+const pushScript = `set -e
+work=$(mktemp -d)
+trap 'rm -rf "$work"' EXIT
+git clone -q --branch "$2" "$1" "$work"
+echo "$3" | base64 -d | tar -xz -C "$work"
+cd "$work"
+git add -A
+git -c user.name=eco-gotests -c user.email=eco-gotests@redhat.com commit -q --allow-empty -m "$4"
+git push -q origin "$2"
+`
+
+// Server is a git server running on a cluster. Repositories are only stored in the pod, so they are lost if the pod is
+// deleted.
+type Server struct {
+	// URL is the base URL of the server, reachable both from the cluster and from outside it through a route.
+	URL string
+
+	apiClient *clients.Settings
+	serverPod *pod.Builder
+}
+
+// Deploy creates a git server in tsparams.GitServerNamespace using image, which must have python3, git, and tar. The
+// server is exposed through a plain HTTP route so Argo CD and the tests reach it using the same URL.
+func Deploy(apiClient *clients.Settings, image string) (*Server, error) {
+	glog.V(tsparams.LogLevel).Infof("Deploying git server in namespace %s", tsparams.GitServerNamespace)
+
+	_, err := namespace.NewBuilder(apiClient, tsparams.GitServerNamespace).Create()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git server namespace: %w", err)
+	}
+
+	labels := map[string]string{"app": tsparams.GitServerName}
+
+	serverPod := pod.NewBuilder(apiClient, tsparams.GitServerName, tsparams.GitServerNamespace, image).
+		WithLabels(labels).
+		WithVolume(corev1.Volume{
+			Name:         "repositories",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}).
+		RedefineDefaultCMD([]string{
+			"python3", "-c", serverScript, strconv.Itoa(tsparams.GitServerPort), repositoryRoot,
+		})
+	serverPod.Definition.Spec.Containers[0].VolumeMounts = append(serverPod.Definition.Spec.Containers[0].VolumeMounts,
+		corev1.VolumeMount{Name: "repositories", MountPath: repositoryRoot})
+
+	serverPod, err = serverPod.CreateAndWaitUntilRunning(tsparams.GitServerTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git server pod: %w", err)
+	}
+
+	_, err = service.NewBuilder(apiClient, tsparams.GitServerName, tsparams.GitServerNamespace, labels, corev1.ServicePort{
+		Protocol:   corev1.ProtocolTCP,
+		Port:       tsparams.GitServerPort,
+		TargetPort: intstr.FromInt(tsparams.GitServerPort),
+	}).Create()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git server service: %w", err)
+	}
+
+	_, err = route.NewBuilder(apiClient, tsparams.GitServerName, tsparams.GitServerNamespace, tsparams.GitServerName).
+		WithTargetPortNumber(tsparams.GitServerPort).
+		Create()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git server route: %w", err)
+	}
+
+	// The host is assigned by the router after the route is created, so it may not be in the create response.
+	serverRoute, err := route.Pull(apiClient, tsparams.GitServerName, tsparams.GitServerNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull git server route: %w", err)
+	}
+
+	if serverRoute.Object.Spec.Host == "" {
+		return nil, fmt.Errorf("git server route %s has no host", tsparams.GitServerName)
+	}
+
+	return &Server{URL: "http://" + serverRoute.Object.Spec.Host, apiClient: apiClient, serverPod: serverPod}, nil
+}
+
+// Delete deletes the namespace of the git server, including all of its repositories.
+func Delete(apiClient *clients.Settings) error {
+	glog.V(tsparams.LogLevel).Infof("Deleting git server namespace %s", tsparams.GitServerNamespace)
+
+	return namespace.NewBuilder(apiClient, tsparams.GitServerNamespace).DeleteAndWait(tsparams.GitServerTimeout)
+}
+
+// RepoURL returns the URL of the repository with the provided name.
+func (server *Server) RepoURL(name string) string {
+	return fmt.Sprintf("%s/%s.git", server.URL, name)
+}
+
+// Seed creates the repository with the provided name as a copy of repoURL, replacing it if it already exists, and
+// creates tsparams.GitFixtureBranch at revision. The source repository must be accessible from the server without
+// credentials.
+func (server *Server) Seed(name, repoURL, revision string) error {
+	glog.V(tsparams.LogLevel).Infof("Seeding git repository %s from %s at %s", name, repoURL, revision)
+
+	output, err := server.serverPod.ExecCommand([]string{
+		"sh", "-c", seedScript, "sh", server.repoPath(name), repoURL, tsparams.GitFixtureBranch, revision,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to seed repository %s from %s: %w: %s", name, repoURL, err, output.String())
+	}
+
+	return nil
+}
+
+// Push commits files, keyed by their path in the repository, to tsparams.GitFixtureBranch of the repository with the
+// provided name. Existing files not in files are left unchanged.
+func (server *Server) Push(name string, files map[string][]byte, message string) error {
+	glog.V(tsparams.LogLevel).Infof("Pushing %d files to git repository %s", len(files), name)
+
+	encoded, err := archive(files)
+	if err != nil {
+		return fmt.Errorf("failed to archive files for repository %s: %w", name, err)
+	}
+
+	if len(encoded) > maxArchiveSize {
+		return fmt.Errorf("archive for repository %s is %d bytes, larger than the maximum of %d",
+			name, len(encoded), maxArchiveSize)
+	}
+
+	output, err := server.serverPod.ExecCommand([]string{
+		"sh", "-c", pushScript, "sh", server.repoPath(name), tsparams.GitFixtureBranch, encoded, message,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to push to repository %s: %w: %s", name, err, output.String())
+	}
+
+	return nil
+}
+
+// WaitUntilReachable waits up to timeout for the route of the server to respond. Routes take some time to be admitted
+// by the router after they are created.
+func (server *Server) WaitUntilReachable(timeout time.Duration) error {
+	return wait.PollUntilContextTimeout(
+		context.TODO(), tsparams.ArgoCdChangeInterval, timeout, true, func(ctx context.Context) (bool, error) {
+			request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+			if err != nil {
+				return false, err
+			}
+
+			response, err := http.DefaultClient.Do(request)
+			if err != nil {
+				glog.V(tsparams.LogLevel).Infof("Git server at %s is not reachable yet: %v", server.URL, err)
+
+				return false, nil
+			}
+
+			_ = response.Body.Close()
+
+			// The router responds with these statuses while it cannot reach the server.
+			switch response.StatusCode {
+			case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+				return false, nil
+			default:
+				return true, nil
+			}
+		})
+}
+
+// repoPath returns the path of the bare repository with the provided name in the server pod.
+func (server *Server) repoPath(name string) string {
+	return path.Join(repositoryRoot, name+".git")
+}
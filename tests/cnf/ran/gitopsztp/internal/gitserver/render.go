@@ -0,0 +1,127 @@
+package gitserver
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// templateSuffix is the suffix of fixture files that are rendered as templates.
+const templateSuffix = ".tmpl"
+
+// Render returns every file under root in fsys, keyed by its path relative to root. Files ending in .tmpl are executed
+// as text/template templates with data and have the suffix removed, while other files are returned unchanged. If root
+// does not exist, no files are returned.
+func Render(fsys fs.FS, root string, data any) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	err := fs.WalkDir(fsys, root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, filePath)
+		if err != nil {
+			return err
+		}
+
+		relativePath := strings.TrimPrefix(strings.TrimPrefix(filePath, root), "/")
+
+		if strings.HasSuffix(relativePath, templateSuffix) {
+			relativePath = strings.TrimSuffix(relativePath, templateSuffix)
+
+			content, err = execute(filePath, content, data)
+			if err != nil {
+				return err
+			}
+		}
+
+		files[relativePath] = content
+
+		return nil
+	})
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return files, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to render fixtures in %s: %w", root, err)
+	}
+
+	return files, nil
+}
+
+// execute executes the template named name with content using data. Missing keys are errors so typos in fixtures are
+// caught before they reach Argo CD.
+func execute(name string, content []byte, data any) ([]byte, error) {
+	parsed, err := template.New(name).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	var rendered bytes.Buffer
+
+	err = parsed.Execute(&rendered, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute template %s: %w", name, err)
+	}
+
+	return rendered.Bytes(), nil
+}
+
+// archive returns files, keyed by their path, as a base64 encoded gzipped tar archive. Files are added in sorted order
+// so the same files always produce the same archive.
+func archive(files map[string][]byte) (string, error) {
+	var buffer bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for _, filePath := range slices.Sorted(maps.Keys(files)) {
+		cleanPath := path.Clean(filePath)
+		if path.IsAbs(cleanPath) || cleanPath == ".." || strings.HasPrefix(cleanPath, "../") {
+			return "", fmt.Errorf("file path %s is outside of the repository", filePath)
+		}
+
+		err := tarWriter.WriteHeader(&tar.Header{
+			Name:     cleanPath,
+			Mode:     0644,
+			Size:     int64(len(files[filePath])),
+			Typeflag: tar.TypeReg,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		_, err = tarWriter.Write(files[filePath])
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err := tarWriter.Close()
+	if err != nil {
+		return "", err
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(buffer.Bytes()), nil
+}
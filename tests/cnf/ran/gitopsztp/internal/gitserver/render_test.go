@@ -0,0 +1,140 @@
+package gitserver
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	testFS := fstest.MapFS{
+		"fixtures/policies/test/kustomization.yaml":   {Data: []byte("generators:\n  - policy.yaml\n")},
+		"fixtures/policies/test/policy.yaml.tmpl":     {Data: []byte("namespace: {{ .TestNamespace }}\n")},
+		"fixtures/policies/test/cluster.yaml.tmpl":    {Data: []byte("name: {{ .Spoke1Name }}\n")},
+		"fixtures/policies/invalid/missing.yaml.tmpl": {Data: []byte("name: {{ .Missing }}\n")},
+	}
+
+	testCases := []struct {
+		name          string
+		root          string
+		expectedFiles map[string][]byte
+		expectedError bool
+	}{
+		{
+			name: "templates and plain files",
+			root: "fixtures/policies/test",
+			expectedFiles: map[string][]byte{
+				"kustomization.yaml": []byte("generators:\n  - policy.yaml\n"),
+				"policy.yaml":        []byte("namespace: ztp-test\n"),
+				"cluster.yaml":       []byte("name: spoke1\n"),
+			},
+		},
+		{
+			name:          "missing root",
+			root:          "fixtures/clusters",
+			expectedFiles: map[string][]byte{},
+		},
+		{
+			name:          "missing key",
+			root:          "fixtures/policies/invalid",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		files, err := Render(testFS, testCase.root, FixtureData{Spoke1Name: "spoke1", TestNamespace: "ztp-test"})
+
+		if testCase.expectedError {
+			assert.Error(t, err, testCase.name)
+
+			continue
+		}
+
+		assert.NoError(t, err, testCase.name)
+		assert.Equal(t, testCase.expectedFiles, files, testCase.name)
+	}
+}
+
+func TestLintFixtures(t *testing.T) {
+	err := LintFixtures(FixtureData{Spoke1Name: "spoke1", TestNamespace: "ztp-test"}, "policies", "clusters")
+	assert.NoError(t, err)
+}
+
+func TestArchive(t *testing.T) {
+	testCases := []struct {
+		name          string
+		files         map[string][]byte
+		expectedError bool
+	}{
+		{
+			name: "nested files",
+			files: map[string][]byte{
+				"policies/ztp-test/kustomization.yaml": []byte("generators: []\n"),
+				"policies/ztp-test/policy.yaml":        []byte("kind: PolicyGenerator\n"),
+			},
+		},
+		{
+			name:  "no files",
+			files: map[string][]byte{},
+		},
+		{
+			name:          "absolute path",
+			files:         map[string][]byte{"/etc/passwd": nil},
+			expectedError: true,
+		},
+		{
+			name:          "path outside repository",
+			files:         map[string][]byte{"policies/../../escape.yaml": nil},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		encoded, err := archive(testCase.files)
+
+		if testCase.expectedError {
+			assert.Error(t, err, testCase.name)
+
+			continue
+		}
+
+		assert.NoError(t, err, testCase.name)
+		assert.Equal(t, testCase.files, extract(t, encoded), testCase.name)
+	}
+}
+
+// extract returns the files in the base64 encoded gzipped tar archive, keyed by their path.
+func extract(t *testing.T, encoded string) map[string][]byte {
+	t.Helper()
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	assert.NoError(t, err)
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(decoded))
+	assert.NoError(t, err)
+
+	files := make(map[string][]byte)
+	tarReader := tar.NewReader(gzipReader)
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		assert.NoError(t, err)
+
+		content, err := io.ReadAll(tarReader)
+		assert.NoError(t, err)
+
+		files[header.Name] = content
+	}
+
+	return files
+}
@@ -117,6 +117,18 @@ const (
 	// ZtpKustomizationPath is the path to the kustomization file in the ztp test.
 	ZtpKustomizationPath = "/kustomization.yaml"
 
+	// GitServerNamespace is the namespace on the hub for the in-cluster git server. It is separate from TestNamespace
+	// since that is recreated before each test.
+	GitServerNamespace = "ztp-git-server"
+	// GitServerName is the name of the git server pod, service, and route.
+	GitServerName = "ztp-git-server"
+	// GitServerPort is the port the git server listens on.
+	GitServerPort = 8080
+	// GitServerTimeout is the timeout for creating and deleting the git server.
+	GitServerTimeout = 5 * time.Minute
+	// GitFixtureBranch is the branch with the rendered fixtures that the Argo CD apps are pointed at.
+	GitFixtureBranch = "ztp-test-fixtures"
+
 	// TestNamespace is the namespace used for ZTP tests.
 	TestNamespace = "ztp-test"
 	// AcmCrsPolicyName is the name of the policy for ACM CRs.
@@ -0,0 +1,107 @@
+package ztplint
+
+import (
+	"maps"
+	"slices"
+)
+
+// nodeRoles are the valid roles of nodes in a ClusterInstance.
+var nodeRoles = []string{"master", "worker", "arbiter"}
+
+// templateRef is a reference to a ConfigMap with installation templates.
+type templateRef struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace"`
+}
+
+// clusterInstance is a ClusterInstance, limited to the fields the linter uses.
+type clusterInstance struct {
+	typeMeta `yaml:",inline"`
+	Metadata objectMeta `yaml:"metadata"`
+	Spec     struct {
+		ClusterName            string        `yaml:"clusterName"`
+		BaseDomain             string        `yaml:"baseDomain"`
+		ClusterImageSetNameRef string        `yaml:"clusterImageSetNameRef"`
+		PullSecretRef          templateRef   `yaml:"pullSecretRef"`
+		TemplateRefs           []templateRef `yaml:"templateRefs"`
+		Nodes                  []struct {
+			HostName       string        `yaml:"hostName"`
+			Role           string        `yaml:"role"`
+			BmcAddress     string        `yaml:"bmcAddress"`
+			BootMACAddress string        `yaml:"bootMACAddress"`
+			TemplateRefs   []templateRef `yaml:"templateRefs"`
+		} `yaml:"nodes"`
+	} `yaml:"spec"`
+}
+
+// lintClusterInstance checks the required fields of a ClusterInstance and its nodes.
+func (checker *checker) lintClusterInstance(doc document) {
+	var instance clusterInstance
+
+	if !checker.decode(doc, &instance) {
+		return
+	}
+
+	checker.checkAPIVersion(doc, instance.APIVersion, clusterInstanceAPIVersion)
+
+	spec := instance.Spec
+	required := map[string]string{
+		"metadata.name":               instance.Metadata.Name,
+		"metadata.namespace":          instance.Metadata.Namespace,
+		"spec.clusterName":            spec.ClusterName,
+		"spec.baseDomain":             spec.BaseDomain,
+		"spec.clusterImageSetNameRef": spec.ClusterImageSetNameRef,
+		"spec.pullSecretRef.name":     spec.PullSecretRef.Name,
+	}
+
+	for _, field := range slices.Sorted(maps.Keys(required)) {
+		if required[field] == "" {
+			checker.report(doc, "ClusterInstance has no %s", field)
+		}
+	}
+
+	checker.checkTemplateRefs(doc, "ClusterInstance", spec.TemplateRefs)
+
+	if len(spec.Nodes) == 0 {
+		checker.report(doc, "ClusterInstance has no nodes")
+	}
+
+	var hostNames []string
+
+	for index, node := range spec.Nodes {
+		if node.HostName == "" {
+			checker.report(doc, "node %d has no hostName", index)
+
+			continue
+		}
+
+		if slices.Contains(hostNames, node.HostName) {
+			checker.report(doc, "node %s appears more than once", node.HostName)
+		}
+
+		hostNames = append(hostNames, node.HostName)
+
+		if !slices.Contains(nodeRoles, node.Role) {
+			checker.report(doc, "node %s has role %q but expected one of %v", node.HostName, node.Role, nodeRoles)
+		}
+
+		if node.BmcAddress == "" || node.BootMACAddress == "" {
+			checker.report(doc, "node %s must have bmcAddress and bootMACAddress", node.HostName)
+		}
+
+		checker.checkTemplateRefs(doc, "node "+node.HostName, node.TemplateRefs)
+	}
+}
+
+// checkTemplateRefs reports if refs is empty or has a reference without a name or namespace.
+func (checker *checker) checkTemplateRefs(doc document, owner string, refs []templateRef) {
+	if len(refs) == 0 {
+		checker.report(doc, "%s has no templateRefs", owner)
+	}
+
+	for _, ref := range refs {
+		if ref.Name == "" || ref.Namespace == "" {
+			checker.report(doc, "%s has a templateRef without a name and namespace", owner)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package ztplint
+
+import "testing"
+
+const validClusterInstance = `apiVersion: siteconfig.open-cluster-management.io/v1alpha1
+kind: ClusterInstance
+metadata:
+  name: spoke1
+  namespace: spoke1
+spec:
+  clusterName: spoke1
+  baseDomain: example.com
+  clusterImageSetNameRef: openshift-4.18
+  pullSecretRef:
+    name: pull-secret
+  templateRefs:
+    - name: ai-cluster-templates-v1
+      namespace: open-cluster-management
+  nodes:
+    - hostName: spoke1.example.com
+      role: master
+      bmcAddress: redfish-virtualmedia://10.0.0.1/redfish/v1/Systems/1
+      bootMACAddress: "00:00:00:00:00:01"
+      templateRefs:
+        - name: ai-node-templates-v1
+          namespace: open-cluster-management
+`
+
+func TestLintClusterInstance(t *testing.T) {
+	testCases := []struct {
+		name             string
+		content          string
+		expectedMessages []string
+	}{
+		{
+			name:    "valid",
+			content: validClusterInstance,
+		},
+		{
+			name: "missing fields",
+			content: `apiVersion: siteconfig.open-cluster-management.io/v1alpha1
+kind: ClusterInstance
+metadata:
+  name: spoke1
+  namespace: spoke1
+spec:
+  clusterName: spoke1
+  clusterImageSetNameRef: openshift-4.18
+  pullSecretRef:
+    name: pull-secret
+  templateRefs:
+    - name: ai-cluster-templates-v1
+      namespace: open-cluster-management
+`,
+			expectedMessages: []string{"ClusterInstance has no spec.baseDomain", "ClusterInstance has no nodes"},
+		},
+		{
+			name: "invalid nodes",
+			content: `apiVersion: siteconfig.open-cluster-management.io/v1alpha1
+kind: ClusterInstance
+metadata:
+  name: spoke1
+  namespace: spoke1
+spec:
+  clusterName: spoke1
+  baseDomain: example.com
+  clusterImageSetNameRef: openshift-4.18
+  pullSecretRef:
+    name: pull-secret
+  templateRefs:
+    - name: ai-cluster-templates-v1
+      namespace: open-cluster-management
+  nodes:
+    - hostName: node1
+      role: controller
+      bmcAddress: redfish-virtualmedia://10.0.0.1/redfish/v1/Systems/1
+      bootMACAddress: "00:00:00:00:00:01"
+      templateRefs:
+        - name: ai-node-templates-v1
+          namespace: open-cluster-management
+    - hostName: node1
+      role: worker
+`,
+			expectedMessages: []string{
+				`node node1 has role "controller" but expected one of [master worker arbiter]`,
+				"node node1 appears more than once",
+				"node node1 must have bmcAddress and bootMACAddress",
+				"node node1 has no templateRefs",
+			},
+		},
+		{
+			name: "wrong apiVersion",
+			content: `apiVersion: siteconfig.open-cluster-management.io/v1
+kind: ClusterInstance
+metadata:
+  name: spoke1
+`,
+			expectedMessages: []string{
+				`ClusterInstance has apiVersion "siteconfig.open-cluster-management.io/v1" but expected ` +
+					`"siteconfig.open-cluster-management.io/v1alpha1"`,
+			},
+		},
+		{
+			name: "schema mismatch",
+			content: `apiVersion: siteconfig.open-cluster-management.io/v1alpha1
+kind: ClusterInstance
+spec:
+  nodes: node1
+`,
+			expectedMessages: []string{"does not match the ClusterInstance schema"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		issues := Linter{}.Lint(map[string][]byte{"clusters/spoke1.yaml": []byte(testCase.content)})
+		assertMessages(t, testCase.name, testCase.expectedMessages, issues)
+	}
+}
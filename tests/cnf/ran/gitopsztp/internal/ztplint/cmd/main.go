@@ -0,0 +1,107 @@
+/*
+Ztplint is a tool to check the PolicyGenerator, PolicyGenTemplate, ClusterInstance, and kustomization files in a ZTP git
+repository without a cluster. It checks them against the schema of each kind, checks that referenced generators,
+resources, manifests, and source CRs exist, checks the syntax of hub templates, and checks that policies are ordered
+consistently by their ztp-deploy-wave annotations. Every issue found is printed to stdout.
+
+Upon finding no issues the exit code is 0. If any issues are found the exit code is 1 and if any other error occurs it
+will be logged to stderr and the exit code will be 2.
+
+Usage:
+
+	go run ./tests/cnf/ran/gitopsztp/internal/ztplint/cmd [flags]
+
+The flags are:
+
+	-h, -help
+		Print this help message
+
+	-d, -dir string
+		Directory of the repository to lint. Uses the current directory if left blank
+
+	-s, -source-crs string
+		Directory containing the source CRs built into ztp-site-generate. PolicyGenTemplate source files not in the
+		repository are assumed to be built in if left blank
+
+	-v int
+		Log level verbosity for glog. Use 100 for logging all messages or leave blank for none
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/ztplint"
+)
+
+var (
+	help      bool
+	dir       string
+	sourceCRs string
+)
+
+//nolint:gochecknoinits // This is a main package so init is fine.
+func init() {
+	const (
+		helpUsage      = "Print this help message"
+		dirUsage       = "Directory of the repository to lint. Uses the current directory if left blank"
+		sourceCRsUsage = "Directory containing the source CRs built into ztp-site-generate. PolicyGenTemplate source " +
+			"files not in the repository are assumed to be built in if left blank"
+
+		defaultHelp      = false
+		defaultDir       = "."
+		defaultSourceCRs = ""
+
+		shorthand = " (shorthand)"
+	)
+
+	flag.BoolVar(&help, "help", defaultHelp, helpUsage)
+	flag.BoolVar(&help, "h", defaultHelp, helpUsage+shorthand)
+
+	flag.StringVar(&dir, "dir", defaultDir, dirUsage)
+	flag.StringVar(&dir, "d", defaultDir, dirUsage+shorthand)
+
+	flag.StringVar(&sourceCRs, "source-crs", defaultSourceCRs, sourceCRsUsage)
+	flag.StringVar(&sourceCRs, "s", defaultSourceCRs, sourceCRsUsage+shorthand)
+}
+
+func main() {
+	// Also send glog messages to stderr
+	_ = flag.Lookup("logtostderr").Value.Set("true")
+
+	flag.Parse()
+
+	if help {
+		flag.Usage()
+
+		return
+	}
+
+	files, err := ztplint.ReadFiles(os.DirFS(dir), ".")
+	if err != nil {
+		glog.Errorf("Failed to read repository %s: %v", dir, err)
+
+		os.Exit(2)
+	}
+
+	linter := ztplint.Linter{}
+	if sourceCRs != "" {
+		linter.SourceCRs = os.DirFS(sourceCRs)
+	}
+
+	issues := linter.Lint(files)
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+
+	if len(issues) > 0 {
+		fmt.Printf("Found %d issues in %d files\n", len(issues), len(files))
+
+		os.Exit(1)
+	}
+
+	fmt.Printf("Found no issues in %d files\n", len(files))
+}
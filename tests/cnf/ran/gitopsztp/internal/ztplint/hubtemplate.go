@@ -0,0 +1,91 @@
+package ztplint
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+const (
+	hubTemplateStart = "{{hub"
+	hubTemplateEnd   = "hub}}"
+)
+
+// hubTemplateFuncs contains the functions available in hub templates. Only the names matter since templates are parsed
+// but never executed. These are the ACM template functions along with the sprig functions ACM supports.
+var hubTemplateFuncs = stubFuncs(
+	// ACM functions.
+	"fromSecret", "fromConfigMap", "fromClusterClaim", "lookup", "base64enc", "base64dec", "indent", "autoindent",
+	"toInt", "toBool", "toLiteral", "copySecretData", "copyConfigMapData", "protect", "getNodesWithExactRoles",
+	"hasNodesWithExactRoles", "atoi",
+	// Sprig functions.
+	"add", "append", "cat", "concat", "contains", "default", "dict", "empty", "fail", "fromJson", "has", "hasKey",
+	"hasPrefix", "hasSuffix", "join", "list", "lower", "mul", "mustAppend", "mustFromJson", "mustHas", "mustToJson",
+	"mustToRawJson", "quote", "replace", "semver", "semverCompare", "split", "splitn", "sub", "substr", "ternary",
+	"toJson", "toRawJson", "trim", "trimAll", "trimPrefix", "trimSuffix", "upper",
+)
+
+// checkHubTemplates returns an error for each string in value, which is a decoded YAML document, with invalid hub
+// template syntax.
+func checkHubTemplates(value any) []error {
+	var errs []error
+
+	walkStrings(value, func(str string) {
+		err := checkHubTemplate(str)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	})
+
+	return errs
+}
+
+// checkHubTemplate returns an error if the hub templates in str are not terminated or cannot be parsed.
+func checkHubTemplate(str string) error {
+	if !strings.Contains(str, hubTemplateStart) && !strings.Contains(str, hubTemplateEnd) {
+		return nil
+	}
+
+	starts := strings.Count(str, hubTemplateStart)
+	ends := strings.Count(str, hubTemplateEnd)
+
+	if starts != ends {
+		return fmt.Errorf("%q has %d %s delimiters but %d %s delimiters",
+			str, starts, hubTemplateStart, ends, hubTemplateEnd)
+	}
+
+	_, err := template.New("hub").Delims(hubTemplateStart, hubTemplateEnd).Funcs(hubTemplateFuncs).Parse(str)
+	if err != nil {
+		return fmt.Errorf("%q: %w", str, err)
+	}
+
+	return nil
+}
+
+// walkStrings calls visit for every string in value, including map keys.
+func walkStrings(value any, visit func(string)) {
+	switch typed := value.(type) {
+	case string:
+		visit(typed)
+	case map[string]any:
+		for key, element := range typed {
+			visit(key)
+			walkStrings(element, visit)
+		}
+	case []any:
+		for _, element := range typed {
+			walkStrings(element, visit)
+		}
+	}
+}
+
+// stubFuncs returns a template.FuncMap with a function that does nothing for each of names.
+func stubFuncs(names ...string) template.FuncMap {
+	funcs := make(template.FuncMap, len(names))
+
+	for _, name := range names {
+		funcs[name] = func(...any) any { return nil }
+	}
+
+	return funcs
+}
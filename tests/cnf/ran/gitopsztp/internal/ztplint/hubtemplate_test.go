@@ -0,0 +1,74 @@
+package ztplint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckHubTemplate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		str           string
+		expectedError bool
+	}{
+		{
+			name: "no template",
+			str:  "plain value",
+		},
+		{
+			name: "managed cluster template",
+			str:  `{{ fromConfigMap "" "name" "key" }}`,
+		},
+		{
+			name: "valid hub template",
+			str:  `{{hub fromConfigMap "" "site-data" (printf "%s-vlan" .ManagedClusterName) | toInt hub}}`,
+		},
+		{
+			name: "trim markers",
+			str:  `{{hub- .ManagedClusterLabels.region -hub}}`,
+		},
+		{
+			name:          "unterminated",
+			str:           `{{hub fromConfigMap "" "site-data" "key"`,
+			expectedError: true,
+		},
+		{
+			name:          "stray end delimiter",
+			str:           `value hub}}`,
+			expectedError: true,
+		},
+		{
+			name:          "unknown function",
+			str:           `{{hub fromConfigMapp "" "site-data" "key" hub}}`,
+			expectedError: true,
+		},
+		{
+			name:          "unbalanced parentheses",
+			str:           `{{hub (printf "%s" .ManagedClusterName hub}}`,
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := checkHubTemplate(testCase.str)
+
+		if testCase.expectedError {
+			assert.Error(t, err, testCase.name)
+		} else {
+			assert.NoError(t, err, testCase.name)
+		}
+	}
+}
+
+func TestCheckHubTemplates(t *testing.T) {
+	value := map[string]any{
+		"valid": `{{hub .ManagedClusterName hub}}`,
+		"nested": []any{
+			map[string]any{"invalid": `{{hub .ManagedClusterName`},
+			42,
+		},
+	}
+
+	assert.Len(t, checkHubTemplates(value), 1)
+}
@@ -0,0 +1,340 @@
+package ztplint
+
+import (
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+)
+
+// waveAnnotation is the annotation ZTP uses to order policies. Policies in lower waves are applied first.
+const waveAnnotation = "ran.openshift.io/ztp-deploy-wave"
+
+// policyGenerator is a PolicyGenerator, limited to the fields the linter uses.
+type policyGenerator struct {
+	typeMeta       `yaml:",inline"`
+	Metadata       objectMeta              `yaml:"metadata"`
+	PolicyDefaults generatorPolicyDefaults `yaml:"policyDefaults"`
+	Policies       []generatorPolicy       `yaml:"policies"`
+}
+
+// generatorPolicyDefaults is the policyDefaults of a PolicyGenerator.
+type generatorPolicyDefaults struct {
+	Namespace         string            `yaml:"namespace"`
+	PolicyAnnotations map[string]string `yaml:"policyAnnotations"`
+}
+
+// generatorPolicy is a policy in a PolicyGenerator.
+type generatorPolicy struct {
+	Name              string                `yaml:"name"`
+	PolicyAnnotations map[string]string     `yaml:"policyAnnotations"`
+	Manifests         []generatorManifest   `yaml:"manifests"`
+	Dependencies      []generatorDependency `yaml:"dependencies"`
+}
+
+// generatorManifest is a manifest of a policy in a PolicyGenerator.
+type generatorManifest struct {
+	Path    string           `yaml:"path"`
+	Patches []map[string]any `yaml:"patches"`
+}
+
+// generatorDependency is a dependency of a policy in a PolicyGenerator.
+type generatorDependency struct {
+	Name string `yaml:"name"`
+	Kind string `yaml:"kind"`
+}
+
+// policyGenTemplate is a PolicyGenTemplate, limited to the fields the linter uses.
+type policyGenTemplate struct {
+	typeMeta `yaml:",inline"`
+	Metadata objectMeta `yaml:"metadata"`
+	Spec     struct {
+		SourceFiles []struct {
+			FileName   string     `yaml:"fileName"`
+			PolicyName string     `yaml:"policyName"`
+			Metadata   objectMeta `yaml:"metadata"`
+		} `yaml:"sourceFiles"`
+	} `yaml:"spec"`
+}
+
+// lintPolicyGenerator checks the required fields, manifests, and waves of a PolicyGenerator.
+func (checker *checker) lintPolicyGenerator(doc document) {
+	var generator policyGenerator
+
+	if !checker.decode(doc, &generator) {
+		return
+	}
+
+	checker.checkAPIVersion(doc, generator.APIVersion, policyGeneratorAPIVersion)
+
+	if generator.Metadata.Name == "" {
+		checker.report(doc, "PolicyGenerator has no metadata.name")
+	}
+
+	if generator.PolicyDefaults.Namespace == "" {
+		checker.report(doc, "PolicyGenerator has no policyDefaults.namespace")
+	}
+
+	if len(generator.Policies) == 0 {
+		checker.report(doc, "PolicyGenerator has no policies")
+	}
+
+	defaultWave, hasDefaultWave := checker.parseWave(doc, generator.PolicyDefaults.PolicyAnnotations, "policyDefaults")
+	waves := make(map[string]int)
+
+	for _, policy := range generator.Policies {
+		if policy.Name == "" {
+			checker.report(doc, "PolicyGenerator has a policy with no name")
+
+			continue
+		}
+
+		if len(policy.Manifests) == 0 {
+			checker.report(doc, "policy %s has no manifests", policy.Name)
+		}
+
+		manifestWaves := checker.generatorManifestWaves(doc, policy)
+
+		wave, hasWave := checker.parseWave(doc, policy.PolicyAnnotations, "policy "+policy.Name)
+		if !hasWave {
+			wave, hasWave = defaultWave, hasDefaultWave
+		}
+
+		if hasWave {
+			waves[policy.Name] = wave
+
+			continue
+		}
+
+		// Without a policy annotation, ZTP uses the wave of the manifests, which must then agree.
+		if len(manifestWaves) > 1 {
+			checker.report(doc, "policy %s has manifests in different waves %v", policy.Name, manifestWaves)
+		}
+
+		if len(manifestWaves) > 0 {
+			waves[policy.Name] = manifestWaves[0]
+		}
+	}
+
+	checker.checkDependencyWaves(doc, generator.Policies, waves)
+}
+
+// generatorManifestWaves checks that the manifests of policy exist and returns the distinct waves they are in, sorted.
+// Patches to the wave annotation take precedence over the annotation in the manifest.
+func (checker *checker) generatorManifestWaves(doc document, policy generatorPolicy) []int {
+	waves := make(map[int]bool)
+	directory := path.Dir(doc.file)
+
+	for _, manifest := range policy.Manifests {
+		if manifest.Path == "" {
+			checker.report(doc, "policy %s has a manifest with no path", policy.Name)
+
+			continue
+		}
+
+		manifestPath := path.Join(directory, manifest.Path)
+		if !checker.exists(manifestPath) {
+			checker.report(doc, "policy %s manifest %s does not exist", policy.Name, manifest.Path)
+
+			continue
+		}
+
+		patchedWave, isPatched := checker.patchedWave(doc, policy.Name, manifest.Patches)
+		if isPatched {
+			waves[patchedWave] = true
+
+			continue
+		}
+
+		for _, manifestFile := range checker.filesUnder(manifestPath) {
+			for _, wave := range checker.fileWaves(doc, manifestFile, checker.files[manifestFile]) {
+				waves[wave] = true
+			}
+		}
+	}
+
+	return slices.Sorted(maps.Keys(waves))
+}
+
+// patchedWave returns the wave set by patches, if any of them set one.
+func (checker *checker) patchedWave(doc document, policyName string, patches []map[string]any) (int, bool) {
+	for _, patch := range patches {
+		metadata, _ := patch["metadata"].(map[string]any)
+		annotations, _ := metadata["annotations"].(map[string]any)
+
+		value, ok := annotations[waveAnnotation]
+		if !ok {
+			continue
+		}
+
+		return checker.parseWave(doc, map[string]string{waveAnnotation: toString(value)}, "policy "+policyName+" patch")
+	}
+
+	return 0, false
+}
+
+// fileWaves returns the waves of the CRs in content, which is the file at filePath. Files that cannot be parsed are
+// reported when they are linted themselves, so they have no waves here.
+func (checker *checker) fileWaves(doc document, filePath string, content []byte) []int {
+	documents, err := parseDocuments(filePath, content)
+	if err != nil {
+		return nil
+	}
+
+	var waves []int
+
+	for _, manifest := range documents {
+		var cr struct {
+			Metadata objectMeta `yaml:"metadata"`
+		}
+
+		if manifest.node.Decode(&cr) != nil {
+			continue
+		}
+
+		wave, hasWave := checker.parseWave(doc, cr.Metadata.Annotations, filePath)
+		if hasWave {
+			waves = append(waves, wave)
+		}
+	}
+
+	return waves
+}
+
+// checkDependencyWaves reports dependencies on policies from the same PolicyGenerator that are applied in a later wave,
+// since those would never be satisfied before the dependent policy is applied.
+func (checker *checker) checkDependencyWaves(
+	doc document, policies []generatorPolicy, waves map[string]int) {
+	for _, policy := range policies {
+		policyWave, hasWave := waves[policy.Name]
+		if !hasWave {
+			continue
+		}
+
+		for _, dependency := range policy.Dependencies {
+			if dependency.Kind != "" && dependency.Kind != "Policy" {
+				continue
+			}
+
+			dependencyWave, hasDependencyWave := waves[dependency.Name]
+			if hasDependencyWave && dependencyWave > policyWave {
+				checker.report(doc, "policy %s in wave %d depends on policy %s in later wave %d",
+					policy.Name, policyWave, dependency.Name, dependencyWave)
+			}
+		}
+	}
+}
+
+// lintPolicyGenTemplate checks the required fields, source files, and waves of a PolicyGenTemplate.
+func (checker *checker) lintPolicyGenTemplate(doc document) {
+	var pgt policyGenTemplate
+
+	if !checker.decode(doc, &pgt) {
+		return
+	}
+
+	checker.checkAPIVersion(doc, pgt.APIVersion, policyGenTemplateAPIVersion)
+
+	if pgt.Metadata.Name == "" || pgt.Metadata.Namespace == "" {
+		checker.report(doc, "PolicyGenTemplate must have metadata.name and metadata.namespace")
+	}
+
+	if len(pgt.Spec.SourceFiles) == 0 {
+		checker.report(doc, "PolicyGenTemplate has no spec.sourceFiles")
+	}
+
+	policyWaves := make(map[string]map[int]bool)
+
+	for _, sourceFile := range pgt.Spec.SourceFiles {
+		if sourceFile.FileName == "" {
+			checker.report(doc, "PolicyGenTemplate has a source file with no fileName")
+
+			continue
+		}
+
+		if sourceFile.PolicyName == "" {
+			checker.report(doc, "source file %s has no policyName", sourceFile.FileName)
+
+			continue
+		}
+
+		sourcePath, content, found := checker.findSourceCR(doc, sourceFile.FileName)
+		if !found {
+			checker.report(doc, "source file %s does not exist", sourceFile.FileName)
+
+			continue
+		}
+
+		if policyWaves[sourceFile.PolicyName] == nil {
+			policyWaves[sourceFile.PolicyName] = make(map[int]bool)
+		}
+
+		wave, hasWave := checker.parseWave(doc, sourceFile.Metadata.Annotations, "source file "+sourceFile.FileName)
+		if hasWave {
+			policyWaves[sourceFile.PolicyName][wave] = true
+
+			continue
+		}
+
+		for _, fileWave := range checker.fileWaves(doc, sourcePath, content) {
+			policyWaves[sourceFile.PolicyName][fileWave] = true
+		}
+	}
+
+	// ZTP requires every CR in a policy to be in the same wave.
+	for _, policyName := range slices.Sorted(maps.Keys(policyWaves)) {
+		if waves := policyWaves[policyName]; len(waves) > 1 {
+			checker.report(doc, "policy %s has source files in different waves %v",
+				policyName, slices.Sorted(maps.Keys(waves)))
+		}
+	}
+}
+
+// findSourceCR returns the path and content of fileName and whether it was found. Source CRs in the source-crs
+// directory next to the PolicyGenTemplate take precedence over the built in ones. When the built in source CRs are
+// unknown, fileName is assumed to be one of them and no content is returned.
+func (checker *checker) findSourceCR(doc document, fileName string) (string, []byte, bool) {
+	sourcePath := path.Join(path.Dir(doc.file), "source-crs", fileName)
+	if content, ok := checker.files[sourcePath]; ok {
+		return sourcePath, content, true
+	}
+
+	if checker.linter.SourceCRs == nil {
+		return fileName, nil, true
+	}
+
+	content, err := fs.ReadFile(checker.linter.SourceCRs, fileName)
+
+	return fileName, content, err == nil
+}
+
+// parseWave returns the wave in annotations and whether there is one. Waves that are not non-negative integers are
+// reported, using source to say where the annotation is.
+func (checker *checker) parseWave(doc document, annotations map[string]string, source string) (int, bool) {
+	value, ok := annotations[waveAnnotation]
+	if !ok {
+		return 0, false
+	}
+
+	wave, err := strconv.Atoi(value)
+	if err != nil || wave < 0 {
+		checker.report(doc, "%s has %s %q, which is not a non-negative integer", source, waveAnnotation, value)
+
+		return 0, false
+	}
+
+	return wave, true
+}
+
+// toString returns value as it would appear in YAML, so numeric annotations can be parsed as waves.
+func toString(value any) string {
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case int:
+		return strconv.Itoa(typed)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,297 @@
+package ztplint
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+const (
+	waveTenConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: wave-ten
+  annotations:
+    ran.openshift.io/ztp-deploy-wave: "10"
+`
+	waveTwentyConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: wave-twenty
+  annotations:
+    ran.openshift.io/ztp-deploy-wave: "20"
+`
+)
+
+func TestLintPolicyGenerator(t *testing.T) {
+	testCases := []struct {
+		name             string
+		generator        string
+		expectedMessages []string
+	}{
+		{
+			name: "valid",
+			generator: `apiVersion: policy.open-cluster-management.io/v1
+kind: PolicyGenerator
+metadata:
+  name: common
+policyDefaults:
+  namespace: ztp-common
+policies:
+  - name: config
+    manifests:
+      - path: source-crs/wave-ten.yaml
+  - name: later
+    dependencies:
+      - name: config
+    manifests:
+      - path: source-crs/wave-twenty.yaml
+`,
+		},
+		{
+			name: "missing fields",
+			generator: `apiVersion: policy.open-cluster-management.io/v1beta1
+kind: PolicyGenerator
+metadata:
+  name: common
+policies:
+  - manifests:
+      - path: source-crs/wave-ten.yaml
+  - name: empty
+`,
+			expectedMessages: []string{
+				`PolicyGenerator has apiVersion "policy.open-cluster-management.io/v1beta1"`,
+				"PolicyGenerator has no policyDefaults.namespace",
+				"PolicyGenerator has a policy with no name",
+				"policy empty has no manifests",
+			},
+		},
+		{
+			name: "missing manifest",
+			generator: `apiVersion: policy.open-cluster-management.io/v1
+kind: PolicyGenerator
+metadata:
+  name: common
+policyDefaults:
+  namespace: ztp-common
+policies:
+  - name: config
+    manifests:
+      - path: source-crs/missing.yaml
+      - path: source-crs
+`,
+			expectedMessages: []string{"policy config manifest source-crs/missing.yaml does not exist"},
+		},
+		{
+			name: "mixed manifest waves",
+			generator: `apiVersion: policy.open-cluster-management.io/v1
+kind: PolicyGenerator
+metadata:
+  name: common
+policyDefaults:
+  namespace: ztp-common
+policies:
+  - name: config
+    manifests:
+      - path: source-crs/wave-ten.yaml
+      - path: source-crs/wave-twenty.yaml
+`,
+			expectedMessages: []string{"policy config has manifests in different waves [10 20]"},
+		},
+		{
+			name: "policy annotation overrides manifest waves",
+			generator: `apiVersion: policy.open-cluster-management.io/v1
+kind: PolicyGenerator
+metadata:
+  name: common
+policyDefaults:
+  namespace: ztp-common
+  policyAnnotations:
+    ran.openshift.io/ztp-deploy-wave: "5"
+policies:
+  - name: config
+    manifests:
+      - path: source-crs/wave-ten.yaml
+      - path: source-crs/wave-twenty.yaml
+`,
+		},
+		{
+			name: "patched wave",
+			generator: `apiVersion: policy.open-cluster-management.io/v1
+kind: PolicyGenerator
+metadata:
+  name: common
+policyDefaults:
+  namespace: ztp-common
+policies:
+  - name: config
+    manifests:
+      - path: source-crs/wave-ten.yaml
+      - path: source-crs/wave-twenty.yaml
+        patches:
+          - metadata:
+              annotations:
+                ran.openshift.io/ztp-deploy-wave: 10
+`,
+		},
+		{
+			name: "invalid wave",
+			generator: `apiVersion: policy.open-cluster-management.io/v1
+kind: PolicyGenerator
+metadata:
+  name: common
+policyDefaults:
+  namespace: ztp-common
+policies:
+  - name: config
+    policyAnnotations:
+      ran.openshift.io/ztp-deploy-wave: "first"
+    manifests:
+      - path: source-crs/wave-ten.yaml
+`,
+			expectedMessages: []string{`policy config has ran.openshift.io/ztp-deploy-wave "first"`},
+		},
+		{
+			name: "dependency in later wave",
+			generator: `apiVersion: policy.open-cluster-management.io/v1
+kind: PolicyGenerator
+metadata:
+  name: common
+policyDefaults:
+  namespace: ztp-common
+policies:
+  - name: config
+    dependencies:
+      - name: later
+        kind: Policy
+    manifests:
+      - path: source-crs/wave-ten.yaml
+  - name: later
+    manifests:
+      - path: source-crs/wave-twenty.yaml
+`,
+			expectedMessages: []string{"policy config in wave 10 depends on policy later in later wave 20"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		issues := Linter{}.Lint(map[string][]byte{
+			"policies/common.yaml":                 []byte(testCase.generator),
+			"policies/source-crs/wave-ten.yaml":    []byte(waveTenConfigMap),
+			"policies/source-crs/wave-twenty.yaml": []byte(waveTwentyConfigMap),
+		})
+		assertMessages(t, testCase.name, testCase.expectedMessages, issues)
+	}
+}
+
+func TestLintPolicyGenTemplate(t *testing.T) {
+	builtInSourceCRs := fstest.MapFS{"BuiltIn.yaml": {Data: []byte(waveTwentyConfigMap)}}
+
+	testCases := []struct {
+		name             string
+		sourceCRs        fstest.MapFS
+		pgt              string
+		expectedMessages []string
+	}{
+		{
+			name:      "valid",
+			sourceCRs: builtInSourceCRs,
+			pgt: `apiVersion: ran.openshift.io/v1
+kind: PolicyGenTemplate
+metadata:
+  name: common
+  namespace: ztp-common
+spec:
+  bindingRules:
+    common: "true"
+  sourceFiles:
+    - fileName: wave-ten.yaml
+      policyName: config
+    - fileName: BuiltIn.yaml
+      policyName: later
+`,
+		},
+		{
+			name: "unknown built in source CRs",
+			pgt: `apiVersion: ran.openshift.io/v1
+kind: PolicyGenTemplate
+metadata:
+  name: common
+  namespace: ztp-common
+spec:
+  sourceFiles:
+    - fileName: Unknown.yaml
+      policyName: config
+`,
+		},
+		{
+			name:      "missing source file",
+			sourceCRs: builtInSourceCRs,
+			pgt: `apiVersion: ran.openshift.io/v1
+kind: PolicyGenTemplate
+metadata:
+  name: common
+  namespace: ztp-common
+spec:
+  sourceFiles:
+    - fileName: Unknown.yaml
+      policyName: config
+    - fileName: wave-ten.yaml
+`,
+			expectedMessages: []string{
+				"source file Unknown.yaml does not exist",
+				"source file wave-ten.yaml has no policyName",
+			},
+		},
+		{
+			name:      "mixed waves",
+			sourceCRs: builtInSourceCRs,
+			pgt: `apiVersion: ran.openshift.io/v1
+kind: PolicyGenTemplate
+metadata:
+  name: common
+spec:
+  sourceFiles:
+    - fileName: wave-ten.yaml
+      policyName: config
+    - fileName: BuiltIn.yaml
+      policyName: config
+`,
+			expectedMessages: []string{
+				"PolicyGenTemplate must have metadata.name and metadata.namespace",
+				"policy config has source files in different waves [10 20]",
+			},
+		},
+		{
+			name:      "source file annotation overrides wave",
+			sourceCRs: builtInSourceCRs,
+			pgt: `apiVersion: ran.openshift.io/v1
+kind: PolicyGenTemplate
+metadata:
+  name: common
+  namespace: ztp-common
+spec:
+  sourceFiles:
+    - fileName: wave-ten.yaml
+      policyName: config
+    - fileName: BuiltIn.yaml
+      policyName: config
+      metadata:
+        annotations:
+          ran.openshift.io/ztp-deploy-wave: "10"
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		linter := Linter{}
+		if testCase.sourceCRs != nil {
+			linter.SourceCRs = testCase.sourceCRs
+		}
+
+		issues := linter.Lint(map[string][]byte{
+			"policies/common.yaml":              []byte(testCase.pgt),
+			"policies/source-crs/wave-ten.yaml": []byte(waveTenConfigMap),
+		})
+		assertMessages(t, testCase.name, testCase.expectedMessages, issues)
+	}
+}
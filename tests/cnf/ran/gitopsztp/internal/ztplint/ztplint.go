@@ -0,0 +1,311 @@
+package ztplint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	kindPolicyGenerator   = "PolicyGenerator"
+	kindPolicyGenTemplate = "PolicyGenTemplate"
+	kindClusterInstance   = "ClusterInstance"
+	kindKustomization     = "Kustomization"
+
+	policyGeneratorAPIVersion   = "policy.open-cluster-management.io/v1"
+	policyGenTemplateAPIVersion = "ran.openshift.io/v1"
+	clusterInstanceAPIVersion   = "siteconfig.open-cluster-management.io/v1alpha1"
+
+	// kustomizationFileName is the name kustomize looks for in each directory.
+	kustomizationFileName = "kustomization.yaml"
+)
+
+// Issue is a problem found in a file.
+type Issue struct {
+	// File is the path of the file with the problem.
+	File string
+	// Document is the index of the YAML document in the file, starting at 0.
+	Document int
+	// Message describes the problem.
+	Message string
+}
+
+// String returns the issue in the form file[document]: message.
+func (issue Issue) String() string {
+	return fmt.Sprintf("%s[%d]: %s", issue.File, issue.Document, issue.Message)
+}
+
+// Linter checks PolicyGenerator, PolicyGenTemplate, ClusterInstance, and kustomization files for problems that would
+// otherwise only be found after Argo CD syncs them.
+type Linter struct {
+	// SourceCRs contains the source CRs built into the ztp-site-generate container, at the root of the filesystem.
+	// PolicyGenTemplates may use them without including them in the repository. If it is nil, PolicyGenTemplate
+	// source files not found in the repository are assumed to be built in.
+	SourceCRs fs.FS
+}
+
+// document is a single YAML document from a file.
+type document struct {
+	file  string
+	index int
+	node  *yaml.Node
+	kind  string
+	// generic is the document decoded without a schema, used for finding hub templates and annotations.
+	generic any
+}
+
+// typeMeta is the part of every document needed to choose how to lint it.
+type typeMeta struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+}
+
+// objectMeta is the metadata of a CR, limited to the fields the linter uses.
+type objectMeta struct {
+	Name        string            `yaml:"name"`
+	Namespace   string            `yaml:"namespace"`
+	Annotations map[string]string `yaml:"annotations"`
+}
+
+// checker contains the files being linted and collects issues found in them.
+type checker struct {
+	linter Linter
+	files  map[string][]byte
+	issues []Issue
+}
+
+// Lint returns every issue found in files, which are keyed by their path relative to the root of the repository. Files
+// that are not YAML are only used to check that references exist. Issues are sorted by file and document.
+func (linter Linter) Lint(files map[string][]byte) []Issue {
+	state := &checker{linter: linter, files: files}
+
+	for _, filePath := range slices.Sorted(maps.Keys(files)) {
+		if !isYAML(filePath) {
+			continue
+		}
+
+		documents, err := parseDocuments(filePath, files[filePath])
+		if err != nil {
+			state.issues = append(state.issues, Issue{File: filePath, Message: err.Error()})
+
+			continue
+		}
+
+		for _, doc := range documents {
+			state.lintDocument(doc)
+		}
+	}
+
+	slices.SortStableFunc(state.issues, func(a, b Issue) int {
+		if a.File != b.File {
+			return strings.Compare(a.File, b.File)
+		}
+
+		return a.Document - b.Document
+	})
+
+	return state.issues
+}
+
+// ReadFiles returns every file under root in fsys, keyed by its path relative to root.
+func ReadFiles(fsys fs.FS, root string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	err := fs.WalkDir(fsys, root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return fs.SkipDir
+			}
+
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, filePath)
+		if err != nil {
+			return err
+		}
+
+		if root != "." {
+			filePath = strings.TrimPrefix(filePath, root+"/")
+		}
+
+		files[filePath] = content
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read files in %s: %w", root, err)
+	}
+
+	return files, nil
+}
+
+// lintDocument checks the hub templates in doc and then checks it based on its kind.
+func (checker *checker) lintDocument(doc document) {
+	for _, err := range checkHubTemplates(doc.generic) {
+		checker.report(doc, "invalid hub template: %v", err)
+	}
+
+	switch {
+	case doc.kind == kindPolicyGenerator:
+		checker.lintPolicyGenerator(doc)
+	case doc.kind == kindPolicyGenTemplate:
+		checker.lintPolicyGenTemplate(doc)
+	case doc.kind == kindClusterInstance:
+		checker.lintClusterInstance(doc)
+	case doc.kind == kindKustomization || path.Base(doc.file) == kustomizationFileName:
+		checker.lintKustomization(doc)
+	}
+}
+
+// report adds an issue for doc with the formatted message.
+func (checker *checker) report(doc document, format string, args ...any) {
+	checker.issues = append(checker.issues, Issue{
+		File:     doc.file,
+		Document: doc.index,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// decode decodes doc into out, reporting an issue if it does not match the schema of out. It returns whether decoding
+// succeeded.
+func (checker *checker) decode(doc document, out any) bool {
+	err := doc.node.Decode(out)
+	if err != nil {
+		checker.report(doc, "does not match the %s schema: %v", doc.kind, err)
+
+		return false
+	}
+
+	return true
+}
+
+// checkAPIVersion reports an issue if the apiVersion of doc is not expected.
+func (checker *checker) checkAPIVersion(doc document, apiVersion, expected string) {
+	if apiVersion != expected {
+		checker.report(doc, "%s has apiVersion %q but expected %q", doc.kind, apiVersion, expected)
+	}
+}
+
+// exists returns whether filePath is a file or a directory containing files.
+func (checker *checker) exists(filePath string) bool {
+	filePath = path.Clean(filePath)
+	if _, ok := checker.files[filePath]; ok {
+		return true
+	}
+
+	for existing := range checker.files {
+		if strings.HasPrefix(existing, filePath+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filesUnder returns the YAML files at filePath, which may be a single file or a directory, in sorted order.
+func (checker *checker) filesUnder(filePath string) []string {
+	filePath = path.Clean(filePath)
+	if _, ok := checker.files[filePath]; ok {
+		return []string{filePath}
+	}
+
+	var found []string
+
+	for existing := range checker.files {
+		if strings.HasPrefix(existing, filePath+"/") && isYAML(existing) {
+			found = append(found, existing)
+		}
+	}
+
+	slices.Sort(found)
+
+	return found
+}
+
+// lintKustomization checks that the generators and local resources of a kustomization exist.
+func (checker *checker) lintKustomization(doc document) {
+	var kustomization struct {
+		Resources  []string `yaml:"resources"`
+		Generators []string `yaml:"generators"`
+	}
+
+	if !checker.decode(doc, &kustomization) {
+		return
+	}
+
+	directory := path.Dir(doc.file)
+
+	for _, generator := range kustomization.Generators {
+		if !checker.exists(path.Join(directory, generator)) {
+			checker.report(doc, "generator %s does not exist", generator)
+		}
+	}
+
+	for _, resource := range kustomization.Resources {
+		if strings.Contains(resource, "://") || strings.HasPrefix(resource, "github.com/") {
+			continue
+		}
+
+		if !checker.exists(path.Join(directory, resource)) {
+			checker.report(doc, "resource %s does not exist", resource)
+		}
+	}
+}
+
+// parseDocuments returns every non-empty YAML document in content.
+func parseDocuments(filePath string, content []byte) ([]document, error) {
+	var documents []document
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+
+	for index := 0; ; index++ {
+		node := &yaml.Node{}
+
+		err := decoder.Decode(node)
+		if errors.Is(err, io.EOF) {
+			return documents, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse YAML document %d: %w", index, err)
+		}
+
+		var meta typeMeta
+
+		// Documents that are not mappings, such as lists of patches, have no kind but are still checked for hub
+		// templates.
+		_ = node.Decode(&meta)
+
+		var generic any
+
+		err = node.Decode(&generic)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode YAML document %d: %w", index, err)
+		}
+
+		if generic == nil {
+			continue
+		}
+
+		documents = append(documents, document{file: filePath, index: index, node: node, kind: meta.Kind, generic: generic})
+	}
+}
+
+// isYAML returns whether filePath has a YAML extension.
+func isYAML(filePath string) bool {
+	return strings.HasSuffix(filePath, ".yaml") || strings.HasSuffix(filePath, ".yml")
+}
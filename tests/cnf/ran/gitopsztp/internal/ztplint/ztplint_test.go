@@ -0,0 +1,158 @@
+package ztplint
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	testCases := []struct {
+		name             string
+		files            map[string][]byte
+		expectedMessages []string
+	}{
+		{
+			name: "valid kustomization",
+			files: map[string][]byte{
+				"policies/kustomization.yaml": []byte("generators:\n  - common.yaml\nresources:\n  - ns.yaml\n"),
+				"policies/common.yaml":        []byte("kind: ConfigMap\n"),
+				"policies/ns.yaml":            []byte("kind: Namespace\n"),
+			},
+		},
+		{
+			name: "missing kustomization references",
+			files: map[string][]byte{
+				"policies/kustomization.yaml": []byte(
+					"generators:\n  - common.yaml\nresources:\n  - ns.yaml\n  - https://example.com/remote.yaml\n"),
+			},
+			expectedMessages: []string{"generator common.yaml does not exist", "resource ns.yaml does not exist"},
+		},
+		{
+			name: "kustomization directory resource",
+			files: map[string][]byte{
+				"kustomization.yaml":              []byte("resources:\n  - ztp-test\n"),
+				"ztp-test/kustomization.yaml":     []byte("generators: []\n"),
+				"ztp-test/source-crs/config.yaml": []byte("kind: ConfigMap\n"),
+			},
+		},
+		{
+			name:             "invalid YAML",
+			files:            map[string][]byte{"policies/broken.yaml": []byte("key: [unterminated\n")},
+			expectedMessages: []string{"failed to parse YAML document 0"},
+		},
+		{
+			name: "hub template in any document",
+			files: map[string][]byte{
+				"source-crs/config.yaml": []byte(
+					"kind: ConfigMap\n---\nkind: ConfigMap\ndata:\n  vlan: '{{hub fromConfigMap \"\" \"site\" hub'\n"),
+			},
+			expectedMessages: []string{"invalid hub template"},
+		},
+		{
+			name:  "non-YAML files ignored",
+			files: map[string][]byte{"README.md": []byte("{{hub")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		issues := Linter{}.Lint(testCase.files)
+		assertMessages(t, testCase.name, testCase.expectedMessages, issues)
+	}
+}
+
+func TestLintIssueOrder(t *testing.T) {
+	issues := Linter{}.Lint(map[string][]byte{
+		"b.yaml": []byte("kind: PolicyGenerator\n"),
+		"a.yaml": []byte("kind: ConfigMap\n---\nkind: PolicyGenerator\n"),
+	})
+
+	assert.NotEmpty(t, issues)
+
+	for index := 1; index < len(issues); index++ {
+		previous, current := issues[index-1], issues[index]
+		assert.True(t, previous.File < current.File ||
+			(previous.File == current.File && previous.Document <= current.Document), "issues are not sorted")
+	}
+
+	assert.Equal(t, "a.yaml", issues[0].File)
+	assert.Equal(t, 1, issues[0].Document)
+}
+
+func TestReadFiles(t *testing.T) {
+	testFS := fstest.MapFS{
+		"repo/policies/kustomization.yaml": {Data: []byte("generators: []\n")},
+		"repo/.git/HEAD":                   {Data: []byte("ref: refs/heads/main\n")},
+		"repo/.gitignore":                  {Data: []byte("*.swp\n")},
+	}
+
+	testCases := []struct {
+		name          string
+		root          string
+		expectedFiles []string
+		expectedError bool
+	}{
+		{
+			name:          "subdirectory",
+			root:          "repo",
+			expectedFiles: []string{".gitignore", "policies/kustomization.yaml"},
+		},
+		{
+			name:          "current directory",
+			root:          ".",
+			expectedFiles: []string{"repo/.gitignore", "repo/policies/kustomization.yaml"},
+		},
+		{
+			name:          "missing root",
+			root:          "missing",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		files, err := ReadFiles(testFS, testCase.root)
+
+		if testCase.expectedError {
+			assert.Error(t, err, testCase.name)
+
+			continue
+		}
+
+		assert.NoError(t, err, testCase.name)
+
+		var filePaths []string
+		for filePath := range files {
+			filePaths = append(filePaths, filePath)
+		}
+
+		assert.ElementsMatch(t, testCase.expectedFiles, filePaths, testCase.name)
+	}
+}
+
+// assertMessages asserts that each of expected is contained in the message of one of issues. If expected is empty,
+// issues must be too.
+func assertMessages(t *testing.T, name string, expected []string, issues []Issue) {
+	t.Helper()
+
+	if len(expected) == 0 {
+		assert.Empty(t, issues, name)
+
+		return
+	}
+
+	for _, message := range expected {
+		found := false
+
+		for _, issue := range issues {
+			if strings.Contains(issue.Message, message) {
+				found = true
+
+				break
+			}
+		}
+
+		assert.True(t, found, "%s: expected an issue containing %q in %v", name, message, issues)
+	}
+}
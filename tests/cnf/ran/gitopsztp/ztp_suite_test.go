@@ -13,6 +13,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/gitserver"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/tsparams"
 	_ "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/tests"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/rancluster"
@@ -20,7 +21,12 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/reporter"
 )
 
-var _, currentFile, _, _ = runtime.Caller(0)
+var (
+	_, currentFile, _, _ = runtime.Caller(0)
+
+	// originalAppSources are the sources of the Argo CD apps before they were pointed at the git server.
+	originalAppSources []gitserver.AppSource
+)
 
 func TestZtp(t *testing.T) {
 	_, reporterConfig := GinkgoConfiguration()
@@ -35,6 +41,28 @@ var _ = BeforeSuite(func() {
 	if !rancluster.AreClustersPresent([]*clients.Settings{HubAPIClient, Spoke1APIClient}) {
 		Skip("not all of the required clusters are present")
 	}
+
+	if !RANConfig.ZtpGitServer {
+		return
+	}
+
+	fixtureData := gitserver.FixtureData{Spoke1Name: RANConfig.Spoke1Name, TestNamespace: tsparams.TestNamespace}
+	appNames := []string{tsparams.ArgoCdPoliciesAppName, tsparams.ArgoCdClustersAppName}
+
+	By("linting the ZTP fixtures")
+	err := gitserver.LintFixtures(fixtureData, appNames...)
+	Expect(err).ToNot(HaveOccurred(), "ZTP fixtures are invalid")
+
+	By("deploying the git server")
+	server, err := gitserver.Deploy(HubAPIClient, RANConfig.ZtpGitServerImage)
+	Expect(err).ToNot(HaveOccurred(), "Failed to deploy git server")
+
+	err = server.WaitUntilReachable(tsparams.GitServerTimeout)
+	Expect(err).ToNot(HaveOccurred(), "Failed to wait for git server to be reachable")
+
+	By("pointing the Argo CD apps at the git server")
+	originalAppSources, err = server.SetupApps(HubAPIClient, fixtureData, appNames...)
+	Expect(err).ToNot(HaveOccurred(), "Failed to point the Argo CD apps at the git server")
 })
 
 var _ = BeforeEach(func() {
@@ -55,6 +83,16 @@ var _ = BeforeEach(func() {
 })
 
 var _ = AfterSuite(func() {
+	if RANConfig.ZtpGitServer {
+		By("restoring the original Argo CD app sources")
+		err := gitserver.RestoreApps(HubAPIClient, originalAppSources)
+		Expect(err).ToNot(HaveOccurred(), "Failed to restore the original Argo CD app sources")
+
+		By("deleting the git server")
+		err = gitserver.Delete(HubAPIClient)
+		Expect(err).ToNot(HaveOccurred(), "Failed to delete git server")
+	}
+
 	By("deleting test namespace")
 	for _, client := range []*clients.Settings{HubAPIClient, Spoke1APIClient} {
 		err := namespace.NewBuilder(client, tsparams.TestNamespace).DeleteAndWait(5 * time.Minute)
@@ -155,6 +155,14 @@ func DoesClusterLabelExist(client *clients.Settings, clusterName string, label s
 	return exists, nil
 }
 
+var (
+	// powerStatePollInterval is how often the power helpers check the system power state. It is a variable so unit
+	// tests can shorten it.
+	powerStatePollInterval = 30 * time.Second
+	// powerStateTimeout is how long the power helpers wait for the system to reach the desired power state.
+	powerStateTimeout = 3 * time.Minute
+)
+
 // PowerOffAndWait will trigger a power off and poll every 30 seconds for up to 3 minutes until the system is off.
 func PowerOffAndWait(bmcClient *bmc.BMC) error {
 	err := bmcClient.SystemPowerOff()
@@ -165,7 +173,7 @@ func PowerOffAndWait(bmcClient *bmc.BMC) error {
 	}
 
 	return wait.PollUntilContextTimeout(
-		context.TODO(), 30*time.Second, 3*time.Minute, true, func(ctx context.Context) (bool, error) {
+		context.TODO(), powerStatePollInterval, powerStateTimeout, true, func(ctx context.Context) (bool, error) {
 			powerState, err := bmcClient.SystemPowerState()
 			if err != nil {
 				glog.V(ranparam.LogLevel).Infof("Failed to get system power state: %v", err)
@@ -210,7 +218,7 @@ func PowerOnAndWait(bmcClient *bmc.BMC) error {
 	}
 
 	return wait.PollUntilContextTimeout(
-		context.TODO(), 30*time.Second, 3*time.Minute, true, func(ctx context.Context) (bool, error) {
+		context.TODO(), powerStatePollInterval, powerStateTimeout, true, func(ctx context.Context) (bool, error) {
 			powerState, err := bmcClient.SystemPowerState()
 			if err != nil {
 				glog.V(ranparam.LogLevel).Infof("Failed to get system power state: %v", err)
@@ -0,0 +1,131 @@
+package rancluster
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/redfishsim"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPowerHelpers(t *testing.T) {
+	powerStatePollInterval = 50 * time.Millisecond
+	powerStateTimeout = 500 * time.Millisecond
+
+	powerOffWithRetries := func(bmcClient *bmc.BMC) error { return PowerOffWithRetries(bmcClient, 3) }
+	powerOnWithRetries := func(bmcClient *bmc.BMC) error { return PowerOnWithRetries(bmcClient, 3) }
+
+	testCases := []struct {
+		name          string
+		initialState  string
+		setup         func(*redfishsim.Simulator)
+		helper        func(*bmc.BMC) error
+		expectedErr   bool
+		expectedState string
+		// expectedResets is the number of reset requests received, including failed ones.
+		expectedResets int
+	}{
+		{
+			name:           "power off immediately",
+			initialState:   redfishsim.PowerStateOn,
+			helper:         PowerOffAndWait,
+			expectedState:  redfishsim.PowerStateOff,
+			expectedResets: 1,
+		},
+		{
+			name:         "power off after transition",
+			initialState: redfishsim.PowerStateOn,
+			setup: func(simulator *redfishsim.Simulator) {
+				simulator.SetTransitionDelay(150 * time.Millisecond)
+			},
+			helper:         PowerOffAndWait,
+			expectedState:  redfishsim.PowerStateOff,
+			expectedResets: 1,
+		},
+		{
+			name:         "power off transition exceeds timeout",
+			initialState: redfishsim.PowerStateOn,
+			setup: func(simulator *redfishsim.Simulator) {
+				simulator.SetTransitionDelay(time.Hour)
+			},
+			helper:         PowerOffAndWait,
+			expectedErr:    true,
+			expectedState:  redfishsim.PowerStatePoweringOff,
+			expectedResets: 1,
+		},
+		{
+			name:         "power off reset fails",
+			initialState: redfishsim.PowerStateOn,
+			setup: func(simulator *redfishsim.Simulator) {
+				simulator.FailNext(redfishsim.EndpointReset, 1, http.StatusServiceUnavailable)
+			},
+			helper:         PowerOffAndWait,
+			expectedErr:    true,
+			expectedState:  redfishsim.PowerStateOn,
+			expectedResets: 1,
+		},
+		{
+			name:         "power off succeeds on retry",
+			initialState: redfishsim.PowerStateOn,
+			setup: func(simulator *redfishsim.Simulator) {
+				simulator.FailNext(redfishsim.EndpointReset, 2, http.StatusServiceUnavailable)
+			},
+			helper:         powerOffWithRetries,
+			expectedState:  redfishsim.PowerStateOff,
+			expectedResets: 3,
+		},
+		{
+			name:         "power off fails every retry",
+			initialState: redfishsim.PowerStateOn,
+			setup: func(simulator *redfishsim.Simulator) {
+				simulator.FailNext(redfishsim.EndpointReset, -1, http.StatusInternalServerError)
+			},
+			helper:         powerOffWithRetries,
+			expectedErr:    true,
+			expectedState:  redfishsim.PowerStateOn,
+			expectedResets: 3,
+		},
+		{
+			name:         "power on after transition",
+			initialState: redfishsim.PowerStateOff,
+			setup: func(simulator *redfishsim.Simulator) {
+				simulator.SetTransitionDelay(150 * time.Millisecond)
+			},
+			helper:         PowerOnAndWait,
+			expectedState:  redfishsim.PowerStateOn,
+			expectedResets: 1,
+		},
+		{
+			name:         "power on transition exceeds timeout on every retry",
+			initialState: redfishsim.PowerStateOff,
+			setup: func(simulator *redfishsim.Simulator) {
+				// Every attempt requests power on again, which restarts the transition, so a transition longer than
+				// the timeout never completes.
+				simulator.SetTransitionDelay(700 * time.Millisecond)
+			},
+			helper:         powerOnWithRetries,
+			expectedErr:    true,
+			expectedState:  redfishsim.PowerStatePoweringOn,
+			expectedResets: 3,
+		},
+	}
+
+	for _, testCase := range testCases {
+		simulator := redfishsim.New()
+		simulator.SetPowerState(0, testCase.initialState)
+
+		if testCase.setup != nil {
+			testCase.setup(simulator)
+		}
+
+		err := testCase.helper(simulator.BMC())
+		assert.Equal(t, testCase.expectedErr, err != nil, "test case: %s", testCase.name)
+		assert.Equal(t, testCase.expectedState, simulator.PowerState(0), "test case: %s", testCase.name)
+		assert.Equal(t, testCase.expectedResets, simulator.Requests(redfishsim.EndpointReset),
+			"test case: %s", testCase.name)
+
+		simulator.Close()
+	}
+}
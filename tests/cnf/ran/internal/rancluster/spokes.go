@@ -0,0 +1,77 @@
+package rancluster
+
+import (
+	"fmt"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranconfig"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// SelectSpokes returns the first count spokes from RANConfig.Spokes whose labels match the provided label selector,
+// such as "role=canary" or "zone in (a,b)". An empty selector matches every spoke and a count of zero returns all
+// matching spokes. An error is returned if fewer than count spokes match.
+func SelectSpokes(selector string, count int) ([]*ranconfig.SpokeConfig, error) {
+	if RANConfig == nil {
+		return nil, fmt.Errorf("cannot select spokes when RANConfig is nil")
+	}
+
+	return FilterSpokes(RANConfig.Spokes, selector, count)
+}
+
+// FilterSpokes returns the first count spokes whose labels match the provided label selector. Spokes without an API
+// client are never returned. An empty selector matches every spoke and a count of zero returns all matching spokes. An
+// error is returned if the selector is invalid or fewer than count spokes match.
+func FilterSpokes(spokes []*ranconfig.SpokeConfig, selector string, count int) ([]*ranconfig.SpokeConfig, error) {
+	if count < 0 {
+		return nil, fmt.Errorf("spoke count must not be negative, got %d", count)
+	}
+
+	parsedSelector, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spoke selector %q: %w", selector, err)
+	}
+
+	var matching []*ranconfig.SpokeConfig
+
+	for _, spoke := range spokes {
+		if spoke == nil || spoke.APIClient == nil || !parsedSelector.Matches(labels.Set(spoke.Labels)) {
+			continue
+		}
+
+		matching = append(matching, spoke)
+
+		if count > 0 && len(matching) == count {
+			return matching, nil
+		}
+	}
+
+	if len(matching) < count {
+		return nil, fmt.Errorf("found %d spokes matching selector %q but %d are required", len(matching), selector, count)
+	}
+
+	return matching, nil
+}
+
+// SpokeNames returns the names of the provided spokes, in the same order.
+func SpokeNames(spokes []*ranconfig.SpokeConfig) []string {
+	names := make([]string, 0, len(spokes))
+
+	for _, spoke := range spokes {
+		names = append(names, spoke.Name)
+	}
+
+	return names
+}
+
+// SpokeAPIClients returns the API clients of the provided spokes, in the same order.
+func SpokeAPIClients(spokes []*ranconfig.SpokeConfig) []*clients.Settings {
+	apiClients := make([]*clients.Settings, 0, len(spokes))
+
+	for _, spoke := range spokes {
+		apiClients = append(apiClients, spoke.APIClient)
+	}
+
+	return apiClients
+}
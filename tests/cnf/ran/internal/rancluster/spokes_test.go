@@ -0,0 +1,77 @@
+package rancluster
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterSpokes(t *testing.T) {
+	spokes := []*ranconfig.SpokeConfig{
+		{Name: "spoke1", APIClient: &clients.Settings{}, Labels: map[string]string{"role": "canary"}},
+		{Name: "spoke2", APIClient: &clients.Settings{}},
+		{Name: "spoke3", APIClient: &clients.Settings{}, Labels: map[string]string{"role": "canary", "zone": "a"}},
+		{Name: "spoke4", Labels: map[string]string{"role": "canary"}},
+		{Name: "spoke5", APIClient: &clients.Settings{}, Labels: map[string]string{"zone": "b"}},
+	}
+
+	testCases := []struct {
+		selector      string
+		count         int
+		expectedNames []string
+		expectedError bool
+	}{
+		{
+			selector:      "",
+			count:         0,
+			expectedNames: []string{"spoke1", "spoke2", "spoke3", "spoke5"},
+		},
+		{
+			selector:      "",
+			count:         2,
+			expectedNames: []string{"spoke1", "spoke2"},
+		},
+		{
+			selector:      "role=canary",
+			count:         0,
+			expectedNames: []string{"spoke1", "spoke3"},
+		},
+		{
+			selector:      "zone in (a,b)",
+			count:         2,
+			expectedNames: []string{"spoke3", "spoke5"},
+		},
+		{
+			selector:      "role!=canary",
+			count:         0,
+			expectedNames: []string{"spoke2", "spoke5"},
+		},
+		{
+			selector:      "role=canary",
+			count:         3,
+			expectedError: true,
+		},
+		{
+			selector:      "role=",
+			count:         -1,
+			expectedError: true,
+		},
+		{
+			selector:      "role in (",
+			count:         0,
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		filtered, err := FilterSpokes(spokes, testCase.selector, testCase.count)
+		assert.Equal(t, testCase.expectedError, err != nil)
+
+		if err == nil {
+			assert.Equal(t, testCase.expectedNames, SpokeNames(filtered))
+			assert.Len(t, SpokeAPIClients(filtered), len(testCase.expectedNames))
+		}
+	}
+}
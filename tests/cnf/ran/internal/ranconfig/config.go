@@ -1,20 +1,18 @@
 package ranconfig
 
 import (
-	"os"
 	"path/filepath"
 	"runtime"
 	"time"
 
 	"github.com/golang/glog"
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/internal/cnfconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/version"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -29,18 +27,47 @@ type RANConfig struct {
 	*Spoke1Config
 	*Spoke2Config
 
-	MetricSamplingInterval string   `yaml:"metricSamplingInterval" envconfig:"ECO_CNF_RAN_METRIC_SAMPLING_INTERVAL"`
-	NoWorkloadDuration     string   `yaml:"noWorkloadDuration" envconfig:"ECO_CNF_RAN_NO_WORKLOAD_DURATION"`
-	WorkloadDuration       string   `yaml:"workloadDuration" envconfig:"ECO_CNF_RAN_WORKLOAD_DURATION"`
-	StressngTestImage      string   `yaml:"stressngTestImage" envconfig:"ECO_CNF_RAN_STRESSNG_TEST_IMAGE"`
-	CnfTestImage           string   `yaml:"cnfTestImage" envconfig:"ECO_CNF_RAN_TEST_IMAGE"`
-	OcpUpgradeUpstreamURL  string   `yaml:"ocpUpgradeUpstreamUrl" envconfig:"ECO_CNF_RAN_OCP_UPGRADE_UPSTREAM_URL"`
-	PtpOperatorNamespace   string   `yaml:"ptpOperatorNamespace" envconfig:"ECO_CNF_RAN_PTP_OPERATOR_NAMESPACE"`
-	TalmPreCachePolicies   []string `yaml:"talmPreCachePolicies" envconfig:"ECO_CNF_RAN_TALM_PRECACHE_POLICIES"`
-	ZtpSiteGenerateImage   string   `yaml:"ztpSiteGenerateImage" envconfig:"ECO_CNF_RAN_ZTP_SITE_GENERATE_IMAGE"`
+	MetricSamplingInterval string `yaml:"metricSamplingInterval" envconfig:"ECO_CNF_RAN_METRIC_SAMPLING_INTERVAL"`
+	NoWorkloadDuration     string `yaml:"noWorkloadDuration" envconfig:"ECO_CNF_RAN_NO_WORKLOAD_DURATION"`
+	WorkloadDuration       string `yaml:"workloadDuration" envconfig:"ECO_CNF_RAN_WORKLOAD_DURATION"`
+	StressngTestImage      string `yaml:"stressngTestImage" envconfig:"ECO_CNF_RAN_STRESSNG_TEST_IMAGE"`
+	PowerNodeStats         bool   `yaml:"powerNodeStats" envconfig:"ECO_CNF_RAN_POWER_NODE_STATS"`
+	PowerBaselineDir       string `yaml:"powerBaselineDir" envconfig:"ECO_CNF_RAN_POWER_BASELINE_DIR"`
+	CnfTestImage           string `yaml:"cnfTestImage" envconfig:"ECO_CNF_RAN_TEST_IMAGE"`
+	OcpUpgradeUpstreamURL  string `yaml:"ocpUpgradeUpstreamUrl" envconfig:"ECO_CNF_RAN_OCP_UPGRADE_UPSTREAM_URL"`
+	//nolint:lll
+	PtpOperatorNamespace string   `yaml:"ptpOperatorNamespace" envconfig:"ECO_CNF_RAN_PTP_OPERATOR_NAMESPACE" validate:"required"`
+	TalmPreCachePolicies []string `yaml:"talmPreCachePolicies" envconfig:"ECO_CNF_RAN_TALM_PRECACHE_POLICIES"`
+	ZtpSiteGenerateImage string   `yaml:"ztpSiteGenerateImage" envconfig:"ECO_CNF_RAN_ZTP_SITE_GENERATE_IMAGE"`
+	LatencyTestImage     string   `yaml:"latencyTestImage" envconfig:"ECO_CNF_RAN_LATENCY_TEST_IMAGE"`
+	LatencyDuration      string   `yaml:"latencyDuration" envconfig:"ECO_CNF_RAN_LATENCY_DURATION"`
+	// PtpEventConsumerImage is the image of the pod receiving PTP events. It must have python3 and curl.
+	PtpEventConsumerImage string `yaml:"ptpEventConsumerImage" envconfig:"ECO_CNF_RAN_PTP_EVENT_CONSUMER_IMAGE"`
+	// PtpRecoveryTimeout is the longest the clock may take to lock again after a fault is removed.
+	PtpRecoveryTimeout time.Duration `yaml:"ptpRecoveryTimeout" envconfig:"ECO_CNF_RAN_PTP_RECOVERY_TIMEOUT"`
+	// ZtpGitServer enables deploying a git server on the hub with the fixtures from the test tree and pointing the
+	// ZTP Argo CD apps at it for the duration of the ZTP suite.
+	ZtpGitServer bool `yaml:"ztpGitServer" envconfig:"ECO_CNF_RAN_ZTP_GIT_SERVER"`
+	// ZtpGitServerImage is the image of the git server. It must have python3, git, and tar.
+	ZtpGitServerImage string `yaml:"ztpGitServerImage" envconfig:"ECO_CNF_RAN_ZTP_GIT_SERVER_IMAGE"`
+	// LatencyThresholds maps each power mode to the limits for each latency tool, such as cyclictest.
+	LatencyThresholds map[ranparam.PowerMode]map[string]LatencyLimits `yaml:"latencyThresholds" ignored:"true"`
 	// ClusterTemplateAffix is the version-dependent affix used for naming ClusterTemplates and other O-RAN
 	// resources.
 	ClusterTemplateAffix string `envconfig:"ECO_CNF_RAN_CLUSTER_TEMPLATE_AFFIX"`
+	// Spokes is every spoke cluster available to the tests. It always starts with spoke 1 and spoke 2, when present,
+	// followed by the spokes from the config file and SpokeKubeconfigs.
+	Spokes []*SpokeConfig `yaml:"spokes" ignored:"true"`
+	// SpokeKubeconfigs are paths to the kubeconfigs of additional spokes without any labels or BMC.
+	SpokeKubeconfigs []string `yaml:"-" envconfig:"ECO_CNF_RAN_KUBECONFIG_SPOKES"`
+}
+
+// LatencyLimits contains the maximum allowed latencies, in microseconds, for a single latency tool. Limits that are
+// zero are not checked.
+type LatencyLimits struct {
+	Max  float64 `yaml:"max"`
+	P99  float64 `yaml:"p99"`
+	P999 float64 `yaml:"p999"`
 }
 
 // HubConfig contains the configuration for the hub cluster, if present.
@@ -52,6 +79,11 @@ type HubConfig struct {
 	HubKubeconfig       string `envconfig:"ECO_CNF_RAN_KUBECONFIG_HUB"`
 	O2IMSBaseURL        string `envconfig:"ECO_CNF_RAN_O2IMS_BASE_URL"`
 	O2IMSToken          string `envconfig:"ECO_CNF_RAN_O2IMS_TOKEN"`
+	// O2IMSCallbackURL is the URL the O2IMS server uses to reach the notification receiver started by the O-RAN
+	// suite. It must resolve to the machine running the tests.
+	O2IMSCallbackURL string `envconfig:"ECO_CNF_RAN_O2IMS_CALLBACK_URL"`
+	// O2IMSCallbackAddress is the local address the notification receiver listens on.
+	O2IMSCallbackAddress string `yaml:"o2imsCallbackAddress" envconfig:"ECO_CNF_RAN_O2IMS_CALLBACK_ADDRESS"`
 }
 
 // Spoke1Config contains the configuration for the spoke 1 cluster, which should always be present.
@@ -91,7 +123,7 @@ func NewRANConfig() *RANConfig {
 	baseDir := filepath.Dir(filename)
 	configFile := filepath.Join(baseDir, PathToDefaultCnfRanParamsFile)
 
-	err := readConfig(&ranConfig, configFile)
+	err := config.Load(&ranConfig, configFile)
 	if err != nil {
 		glog.V(ranparam.LogLevel).Infof("Error reading main RAN Config: %v", err)
 
@@ -101,6 +133,7 @@ func NewRANConfig() *RANConfig {
 	ranConfig.newHubConfig(configFile)
 	ranConfig.newSpoke1Config(configFile)
 	ranConfig.newSpoke2Config(configFile)
+	ranConfig.newSpokesConfig()
 
 	return &ranConfig
 }
@@ -110,7 +143,7 @@ func (ranconfig *RANConfig) newHubConfig(configFile string) {
 
 	ranconfig.HubConfig = new(HubConfig)
 
-	err := readConfig(ranconfig.HubConfig, configFile)
+	err := config.Load(ranconfig.HubConfig, configFile)
 	if err != nil {
 		glog.V(ranparam.LogLevel).Infof("Failed to instantiate HubConfig: %v", err)
 	}
@@ -177,7 +210,7 @@ func (ranconfig *RANConfig) newSpoke1Config(configFile string) {
 
 	ranconfig.Spoke1Config = new(Spoke1Config)
 
-	err := readConfig(ranconfig.Spoke1Config, configFile)
+	err := config.Load(ranconfig.Spoke1Config, configFile)
 	if err != nil {
 		glog.V(ranparam.LogLevel).Infof("Failed to instantiate Spoke1Config: %v", err)
 	}
@@ -221,7 +254,7 @@ func (ranconfig *RANConfig) newSpoke2Config(configFile string) {
 
 	ranconfig.Spoke2Config = new(Spoke2Config)
 
-	err := readConfig(ranconfig.Spoke2Config, configFile)
+	err := config.Load(ranconfig.Spoke2Config, configFile)
 	if err != nil {
 		glog.V(ranparam.LogLevel).Infof("Failed to instantiate Spoke2Config: %v", err)
 	}
@@ -247,35 +280,3 @@ func (ranconfig *RANConfig) newSpoke2Config(configFile string) {
 
 	glog.V(ranparam.LogLevel).Infof("Found OCP version on spoke 2: %s", ranconfig.Spoke2Config.Spoke2OCPVersion)
 }
-
-func readConfig[C any](config *C, configFile string) error {
-	err := readFile(config, configFile)
-	if err != nil {
-		return err
-	}
-
-	return readEnv(config)
-}
-
-func readFile[C any](config *C, configFile string) error {
-	openedConfigFile, err := os.Open(configFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedConfigFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedConfigFile)
-
-	err = decoder.Decode(config)
-
-	return err
-}
-
-func readEnv[C any](config *C) error {
-	err := envconfig.Process("", config)
-
-	return err
-}
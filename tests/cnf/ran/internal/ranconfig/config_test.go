@@ -0,0 +1,40 @@
+package ranconfig
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/internal/cnfconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultLatencyConfig(t *testing.T) {
+	t.Setenv(config.EnvConfigFile, "")
+
+	ranConfig := RANConfig{CNFConfig: cnfconfig.NewCNFConfig()}
+
+	err := config.Load(&ranConfig, PathToDefaultCnfRanParamsFile)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, ranConfig.LatencyTestImage)
+	assert.NotEmpty(t, ranConfig.LatencyDuration)
+
+	testCases := []struct {
+		name      string
+		powerMode ranparam.PowerMode
+	}{
+		{name: "performance", powerMode: ranparam.PerformanceMode},
+		{name: "high performance", powerMode: ranparam.HighPerformanceMode},
+		{name: "power saving", powerMode: ranparam.PowerSavingMode},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			limits := ranConfig.LatencyThresholds[testCase.powerMode]
+
+			for _, tool := range []string{"cyclictest", "oslat", "hwlatdetect"} {
+				assert.NotZero(t, limits[tool].Max, "missing max limit for %s", tool)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package ranconfig
+
+import (
+	"maps"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/version"
+)
+
+// SpokeConfig contains the configuration for a single spoke cluster. Only Kubeconfig is required; the name is taken
+// from the kubeconfig when it is not provided.
+type SpokeConfig struct {
+	Name       string `yaml:"name"`
+	Kubeconfig string `yaml:"kubeconfig"`
+	// BMCHosts are used with the shared BMC credentials. Only the first host is used.
+	BMCHosts []string `yaml:"bmcHosts"`
+	// Labels are arbitrary key/value pairs, such as role=canary, used to select spokes for a test.
+	Labels     map[string]string `yaml:"labels"`
+	APIClient  *clients.Settings `yaml:"-"`
+	BMC        *bmc.BMC          `yaml:"-"`
+	OCPVersion string            `yaml:"-"`
+}
+
+// newSpokesConfig builds the list of all spokes. Spoke 1 and spoke 2 come first so existing tests keep using the same
+// clusters, then the spokes from the config file and SpokeKubeconfigs. Entries with the same kubeconfig as an earlier
+// spoke only add their name, labels, and BMC hosts to it.
+//
+// Spoke 2 has no BMC by default since the shared BMC hosts in Spoke1Config belong to spoke 1. It can be given one by
+// adding an entry with its kubeconfig and bmcHosts to the spokes in the config file.
+func (ranconfig *RANConfig) newSpokesConfig() {
+	glog.V(ranparam.LogLevel).Infof("Creating spokes list")
+
+	var spokes []*SpokeConfig
+
+	if ranconfig.Spoke1APIClient != nil {
+		spokes = append(spokes, &SpokeConfig{
+			Name:       ranconfig.Spoke1Name,
+			Kubeconfig: ranconfig.Spoke1Kubeconfig,
+			BMCHosts:   ranconfig.BMCHosts,
+			APIClient:  ranconfig.Spoke1APIClient,
+			BMC:        ranconfig.Spoke1BMC,
+			OCPVersion: ranconfig.Spoke1OCPVersion,
+		})
+	}
+
+	if ranconfig.Spoke2APIClient != nil {
+		spokes = append(spokes, &SpokeConfig{
+			Name:       ranconfig.Spoke2Name,
+			Kubeconfig: ranconfig.Spoke2Kubeconfig,
+			APIClient:  ranconfig.Spoke2APIClient,
+			OCPVersion: ranconfig.Spoke2OCPVersion,
+		})
+	}
+
+	configured := ranconfig.Spokes
+	for _, kubeconfig := range ranconfig.SpokeKubeconfigs {
+		configured = append(configured, &SpokeConfig{Kubeconfig: kubeconfig})
+	}
+
+	for _, spoke := range configured {
+		if spoke == nil || spoke.Kubeconfig == "" {
+			glog.V(ranparam.LogLevel).Infof("Skipping spoke %v without a kubeconfig", spoke)
+
+			continue
+		}
+
+		if existing := findSpokeByKubeconfig(spokes, spoke.Kubeconfig); existing != nil {
+			if existing.Name == "" {
+				existing.Name = spoke.Name
+			}
+
+			if existing.Labels == nil {
+				existing.Labels = make(map[string]string)
+			}
+
+			maps.Copy(existing.Labels, spoke.Labels)
+
+			if existing.BMC == nil && len(spoke.BMCHosts) > 0 {
+				existing.BMCHosts = spoke.BMCHosts
+				existing.BMC = ranconfig.newSpokeBMC(spoke.BMCHosts)
+			}
+
+			continue
+		}
+
+		ranconfig.connectSpoke(spoke)
+		spokes = append(spokes, spoke)
+	}
+
+	ranconfig.Spokes = spokes
+
+	glog.V(ranparam.LogLevel).Infof("Found %d spokes", len(ranconfig.Spokes))
+}
+
+// connectSpoke creates the API client and BMC for the spoke and updates its OCP version, as well as its name if it
+// was not provided.
+func (ranconfig *RANConfig) connectSpoke(spoke *SpokeConfig) {
+	spoke.APIClient = clients.New(spoke.Kubeconfig)
+	if spoke.APIClient == nil {
+		glog.V(ranparam.LogLevel).Infof("Failed to create API client for spoke with kubeconfig at %s", spoke.Kubeconfig)
+
+		return
+	}
+
+	if spoke.Name == "" {
+		spokeName, err := version.GetClusterName(spoke.Kubeconfig)
+		if err != nil {
+			glog.V(ranparam.LogLevel).Infof("Failed to get spoke name from kubeconfig at %s: %v", spoke.Kubeconfig, err)
+		} else {
+			spoke.Name = spokeName
+		}
+	}
+
+	var err error
+
+	spoke.OCPVersion, err = version.GetOCPVersion(spoke.APIClient)
+	if err != nil {
+		glog.V(ranparam.LogLevel).Infof("Failed to get OCP version from spoke %s: %v", spoke.Name, err)
+	}
+
+	spoke.BMC = ranconfig.newSpokeBMC(spoke.BMCHosts)
+}
+
+// newSpokeBMC returns a BMC for the first of bmcHosts using the shared BMC credentials, or nil if there are no hosts
+// or credentials.
+func (ranconfig *RANConfig) newSpokeBMC(bmcHosts []string) *bmc.BMC {
+	if len(bmcHosts) == 0 || ranconfig.BMCUsername == "" || ranconfig.BMCPassword == "" {
+		return nil
+	}
+
+	return bmc.New(bmcHosts[0]).
+		WithRedfishUser(ranconfig.BMCUsername, ranconfig.BMCPassword).
+		WithRedfishTimeout(ranconfig.BMCTimeout)
+}
+
+func findSpokeByKubeconfig(spokes []*SpokeConfig, kubeconfig string) *SpokeConfig {
+	for _, spoke := range spokes {
+		if spoke.Kubeconfig == kubeconfig {
+			return spoke
+		}
+	}
+
+	return nil
+}
@@ -8,12 +8,14 @@ import (
 
 	"github.com/golang/glog"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nto"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 )
 
 // DoesContainerExistInPod checks if a given container exists in a given pod.
@@ -82,3 +84,46 @@ func GetPodNameWithLabel(client *clients.Settings, podNamespace, podLabelSelecto
 
 	return podList[0].Definition.Name, nil
 }
+
+// GetPerformanceProfileWithCPUSet returns the first performance profile found with reserved and isolated cpuset.
+func GetPerformanceProfileWithCPUSet(client *clients.Settings) (*nto.Builder, error) {
+	profileBuilders, err := nto.ListProfiles(client)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, profileBuilder := range profileBuilders {
+		if profileBuilder.Object.Spec.CPU != nil &&
+			profileBuilder.Object.Spec.CPU.Reserved != nil &&
+			profileBuilder.Object.Spec.CPU.Isolated != nil {
+			return profileBuilder, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to find performance profile with reserved and isolated CPU set")
+}
+
+// GetPowerMode determines the power mode from the workloadHints object of the PerformanceProfile.
+func GetPowerMode(perfProfile *nto.Builder) (ranparam.PowerMode, error) {
+	workloadHints := perfProfile.Definition.Spec.WorkloadHints
+
+	if workloadHints == nil {
+		// No workloadHints object -> default is Performance mode
+		return ranparam.PerformanceMode, nil
+	}
+
+	realTime := ptr.Deref(workloadHints.RealTime, false)
+	highPowerConsumption := ptr.Deref(workloadHints.HighPowerConsumption, false)
+	perPodPowerManagement := ptr.Deref(workloadHints.PerPodPowerManagement, false)
+
+	switch {
+	case realTime && !highPowerConsumption && !perPodPowerManagement:
+		return ranparam.PerformanceMode, nil
+	case realTime && highPowerConsumption && !perPodPowerManagement:
+		return ranparam.HighPerformanceMode, nil
+	case realTime && !highPowerConsumption && perPodPowerManagement:
+		return ranparam.PowerSavingMode, nil
+	default:
+		return "", fmt.Errorf("unknown workloadHints power state configuration")
+	}
+}
@@ -60,3 +60,15 @@ const (
 	// HighlyAvailableCluster represents spoke cluster type as multi-node openshift (MNO) cluster.
 	HighlyAvailableCluster ClusterType = "HighlyAvailable"
 )
+
+// PowerMode represents the power mode set by the workload hints of a PerformanceProfile.
+type PowerMode string
+
+const (
+	// PowerSavingMode is the name of the power saving power state.
+	PowerSavingMode PowerMode = "powersaving"
+	// PerformanceMode is the name of the performance power state.
+	PerformanceMode PowerMode = "performance"
+	// HighPerformanceMode is the name of the high performance power state.
+	HighPerformanceMode PowerMode = "highperformance"
+)
@@ -56,3 +56,77 @@ func Median(input []float64) (float64, error) {
 
 	return (inputCopy[numElements/2] + inputCopy[numElements/2-1]) / 2, nil
 }
+
+// Max computes the maximum value of the input array.
+func Max(input []float64) (float64, error) {
+	if len(input) < 1 {
+		return math.NaN(), fmt.Errorf("input array must have at least 1 element")
+	}
+
+	return slices.Max(input), nil
+}
+
+// Percentile computes the value below which the provided percentile, between 0 and 100, of the input array falls. It
+// uses the nearest-rank method so the result is always an element of the input array.
+func Percentile(input []float64, percentile float64) (float64, error) {
+	if len(input) < 1 {
+		return math.NaN(), fmt.Errorf("input array must have at least 1 element")
+	}
+
+	if percentile < 0 || percentile > 100 {
+		return math.NaN(), fmt.Errorf("percentile must be between 0 and 100")
+	}
+
+	// sort a copy of the input array
+	inputCopy := make([]float64, len(input))
+	copy(inputCopy, input)
+
+	slices.Sort(inputCopy)
+
+	rank := int(nearestRank(percentile, float64(len(inputCopy))))
+
+	return inputCopy[max(rank-1, 0)], nil
+}
+
+// HistogramPercentile computes the percentile, between 0 and 100, of a histogram where counts[i] is the number of
+// samples with the value values[i]. Values must be sorted in ascending order. Like Percentile, it uses the nearest-rank
+// method so the result is always one of the values.
+func HistogramPercentile(values []float64, counts []uint64, percentile float64) (float64, error) {
+	if len(values) != len(counts) {
+		return math.NaN(), fmt.Errorf("values and counts must have the same length")
+	}
+
+	if percentile < 0 || percentile > 100 {
+		return math.NaN(), fmt.Errorf("percentile must be between 0 and 100")
+	}
+
+	var total uint64
+	for _, count := range counts {
+		total += count
+	}
+
+	if total < 1 {
+		return math.NaN(), fmt.Errorf("histogram must have at least 1 sample")
+	}
+
+	rank := uint64(nearestRank(percentile, float64(total)))
+
+	var cumulative uint64
+
+	for index, count := range counts {
+		cumulative += count
+
+		if count > 0 && cumulative >= rank {
+			return values[index], nil
+		}
+	}
+
+	return values[len(values)-1], nil
+}
+
+// nearestRank returns the 1-based rank of the percentile in a sorted array with count elements. A small tolerance is
+// subtracted before rounding up so floating point error, such as 99.9% of 1000 being slightly above 999, does not
+// change the rank.
+func nearestRank(percentile, count float64) float64 {
+	return math.Ceil(percentile/100*count - 1e-9)
+}
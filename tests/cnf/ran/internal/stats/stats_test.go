@@ -93,3 +93,135 @@ func TestMedian(t *testing.T) {
 		}
 	}
 }
+
+func TestMax(t *testing.T) {
+	testCases := []struct {
+		input          []float64
+		expectedOutput float64
+		expectedError  error
+	}{
+		{
+			input:          []float64{1, 5, 3, 4, 2},
+			expectedOutput: 5,
+			expectedError:  nil,
+		},
+		{
+			input:          []float64{},
+			expectedOutput: math.NaN(),
+			expectedError:  fmt.Errorf("input array must have at least 1 element"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		output, err := Max(testCase.input)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.InDelta(t, testCase.expectedOutput, output, epsilon)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	testCases := []struct {
+		input          []float64
+		percentile     float64
+		expectedOutput float64
+		expectedError  error
+	}{
+		{
+			input:          []float64{10, 1, 9, 2, 8, 3, 7, 4, 6, 5},
+			percentile:     90,
+			expectedOutput: 9,
+			expectedError:  nil,
+		},
+		{
+			input:          []float64{10, 1, 9, 2, 8, 3, 7, 4, 6, 5},
+			percentile:     0,
+			expectedOutput: 1,
+			expectedError:  nil,
+		},
+		{
+			input:          []float64{10, 1, 9, 2, 8, 3, 7, 4, 6, 5},
+			percentile:     100,
+			expectedOutput: 10,
+			expectedError:  nil,
+		},
+		{
+			input:          []float64{1},
+			percentile:     101,
+			expectedOutput: math.NaN(),
+			expectedError:  fmt.Errorf("percentile must be between 0 and 100"),
+		},
+		{
+			input:          []float64{},
+			percentile:     50,
+			expectedOutput: math.NaN(),
+			expectedError:  fmt.Errorf("input array must have at least 1 element"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		output, err := Percentile(testCase.input, testCase.percentile)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.InDelta(t, testCase.expectedOutput, output, epsilon)
+		}
+	}
+}
+
+func TestHistogramPercentile(t *testing.T) {
+	testCases := []struct {
+		values         []float64
+		counts         []uint64
+		percentile     float64
+		expectedOutput float64
+		expectedError  error
+	}{
+		{
+			values:         []float64{1, 2, 3, 4},
+			counts:         []uint64{900, 90, 9, 1},
+			percentile:     99,
+			expectedOutput: 2,
+			expectedError:  nil,
+		},
+		{
+			values:         []float64{1, 2, 3, 4},
+			counts:         []uint64{900, 90, 9, 1},
+			percentile:     99.9,
+			expectedOutput: 3,
+			expectedError:  nil,
+		},
+		{
+			values:         []float64{1, 2, 3, 4},
+			counts:         []uint64{0, 10, 0, 0},
+			percentile:     0,
+			expectedOutput: 2,
+			expectedError:  nil,
+		},
+		{
+			values:         []float64{1, 2},
+			counts:         []uint64{1},
+			percentile:     50,
+			expectedOutput: math.NaN(),
+			expectedError:  fmt.Errorf("values and counts must have the same length"),
+		},
+		{
+			values:         []float64{1, 2},
+			counts:         []uint64{0, 0},
+			percentile:     50,
+			expectedOutput: math.NaN(),
+			expectedError:  fmt.Errorf("histogram must have at least 1 sample"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		output, err := HistogramPercentile(testCase.values, testCase.counts, testCase.percentile)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.InDelta(t, testCase.expectedOutput, output, epsilon)
+		}
+	}
+}
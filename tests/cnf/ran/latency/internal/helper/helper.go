@@ -0,0 +1,128 @@
+package helper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/performanceprofile/controller/performanceprofile/components"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nto"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/latency/internal/parser"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/latency/internal/tsparams"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/cpuset"
+)
+
+// hwlatdetectHardLimit is the hard limit passed to hwlatdetect in microseconds. It is large enough that hwlatdetect
+// never fails on its own so the thresholds from the config are the only gate.
+const hwlatdetectHardLimit = 1000000
+
+// CreateLatencyPod creates a guaranteed, privileged pod on nodeName using the latency test image and the runtime class
+// of perfProfile. The pod has CPU load balancing, CPU quota, IRQ load balancing, and C-states disabled on its CPUs so
+// they are fully isolated while the tools run.
+func CreateLatencyPod(client *clients.Settings, perfProfile *nto.Builder, nodeName string) (*pod.Builder, error) {
+	glog.V(tsparams.LogLevel).Infof("Creating latency pod on node %s", nodeName)
+
+	latencyPod := pod.NewBuilder(client, tsparams.LatencyPodName, tsparams.TestingNamespace, RANConfig.LatencyTestImage).
+		DefineOnNode(nodeName).
+		WithPrivilegedFlag()
+
+	cpus := resource.MustParse(strconv.Itoa(tsparams.LatencyPodCPUs))
+	memory := resource.MustParse(tsparams.LatencyPodMemory)
+	resources := corev1.ResourceList{corev1.ResourceCPU: cpus, corev1.ResourceMemory: memory}
+
+	latencyPod.Definition.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+		Requests: resources,
+		Limits:   resources,
+	}
+
+	latencyPod.Definition.Annotations = map[string]string{
+		"cpu-load-balancing.crio.io": "disable",
+		"cpu-quota.crio.io":          "disable",
+		"irq-load-balancing.crio.io": "disable",
+		"cpu-c-states.crio.io":       "disable",
+		"cpu-freq-governor.crio.io":  "performance",
+	}
+
+	runtimeClass := fmt.Sprintf("%s-%s", components.ComponentNamePrefix, perfProfile.Definition.Name)
+	latencyPod.Definition.Spec.RuntimeClassName = &runtimeClass
+
+	latencyPod, err := latencyPod.CreateAndWaitUntilRunning(tsparams.LatencyTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if latencyPod.Object.Status.QOSClass != corev1.PodQOSGuaranteed {
+		return latencyPod, fmt.Errorf("latency pod has QoS class %s, expected %s",
+			latencyPod.Object.Status.QOSClass, corev1.PodQOSGuaranteed)
+	}
+
+	return latencyPod, nil
+}
+
+// GetPodCPUs returns the CPUs the latency pod is pinned to. The first CPU should be used for the main thread of the
+// tools and the remaining CPUs measured.
+func GetPodCPUs(latencyPod *pod.Builder) (cpuset.CPUSet, error) {
+	output, err := latencyPod.ExecCommand([]string{"sh", "-c", "taskset -c -p $$ | cut -d: -f2"})
+	if err != nil {
+		return cpuset.CPUSet{}, fmt.Errorf("failed to get cpuset of latency pod: %w", err)
+	}
+
+	cpus, err := cpuset.Parse(strings.TrimSpace(output.String()))
+	if err != nil {
+		return cpuset.CPUSet{}, fmt.Errorf("failed to parse cpuset of latency pod: %w", err)
+	}
+
+	if cpus.Size() < 2 {
+		return cpuset.CPUSet{}, fmt.Errorf("latency pod has %d cpus, need at least 2", cpus.Size())
+	}
+
+	return cpus, nil
+}
+
+// RunLatencyTool runs tool in the latency pod for duration and returns its parsed result. The first CPU of cpus runs
+// the main thread and the remaining CPUs are measured.
+func RunLatencyTool(
+	latencyPod *pod.Builder, tool parser.Tool, cpus cpuset.CPUSet, duration time.Duration) (*parser.Result, error) {
+	command, err := getToolCommand(tool, cpus, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	glog.V(tsparams.LogLevel).Infof("Running %s in latency pod: %s", tool, command)
+
+	output, err := latencyPod.ExecCommand([]string{"sh", "-c", command})
+	if err != nil {
+		return nil, fmt.Errorf("failed to run %s: %w, output: %s", tool, err, output.String())
+	}
+
+	return parser.Parse(tool, output.String())
+}
+
+// getToolCommand returns the shell command to run tool on cpus for duration.
+func getToolCommand(tool parser.Tool, cpus cpuset.CPUSet, duration time.Duration) (string, error) {
+	cpuList := cpus.List()
+	mainCPU := cpuList[0]
+	measuredCPUs := cpuset.New(cpuList[1:]...)
+	seconds := int(duration.Seconds())
+
+	switch tool {
+	case parser.Cyclictest:
+		return fmt.Sprintf("cyclictest -q -D %ds -p 95 -t %d -a %s -h 30 -m --mainaffinity %d",
+			seconds, measuredCPUs.Size(), measuredCPUs.String(), mainCPU), nil
+	case parser.Oslat:
+		return fmt.Sprintf("oslat --duration %ds --rtprio 1 --cpu-list %s --cpu-main-thread %d",
+			seconds, measuredCPUs.String(), mainCPU), nil
+	case parser.Hwlatdetect:
+		return fmt.Sprintf("hwlatdetect --duration=%ds --threshold=1 --hardlimit=%d",
+			seconds, hwlatdetectHardLimit), nil
+	default:
+		return "", fmt.Errorf("unknown latency tool %q", tool)
+	}
+}
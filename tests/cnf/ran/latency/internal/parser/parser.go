@@ -0,0 +1,150 @@
+// Package parser parses the output of the cyclictest, oslat, and hwlatdetect latency tools into histograms and
+// computes their maximum and percentile latencies.
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/stats"
+)
+
+// Tool is the name of a latency measurement tool.
+type Tool string
+
+const (
+	// Cyclictest measures the latency of waking up a real-time thread from a timer.
+	Cyclictest Tool = "cyclictest"
+	// Oslat measures the latency of a busy loop on isolated CPUs, caused by interruptions from the OS.
+	Oslat Tool = "oslat"
+	// Hwlatdetect measures latency caused by the hardware or firmware, such as SMIs.
+	Hwlatdetect Tool = "hwlatdetect"
+)
+
+// Result is the latency measured by a single tool, combined across all measured CPUs. All latencies are in
+// microseconds.
+type Result struct {
+	Tool Tool
+	// Values and Counts form a histogram where Counts[i] samples had a latency of Values[i]. Values are sorted.
+	Values []float64
+	Counts []uint64
+	// Samples is the sum of Counts.
+	Samples uint64
+	// Max is the maximum latency reported by the tool, which may be larger than the largest value in the histogram.
+	Max  float64
+	P99  float64
+	P999 float64
+}
+
+// Check returns an error for every limit in limits that the result exceeds. Limits that are zero are not checked.
+func (result *Result) Check(limits ranconfig.LatencyLimits) error {
+	var errs []error
+
+	checks := []struct {
+		name  string
+		value float64
+		limit float64
+	}{
+		{name: "max", value: result.Max, limit: limits.Max},
+		{name: "p99", value: result.P99, limit: limits.P99},
+		{name: "p99.9", value: result.P999, limit: limits.P999},
+	}
+
+	for _, check := range checks {
+		if check.limit > 0 && check.value > check.limit {
+			errs = append(errs, fmt.Errorf(
+				"%s %s latency %.0fus exceeds limit of %.0fus", result.Tool, check.name, check.value, check.limit))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// String returns a one line summary of the result.
+func (result *Result) String() string {
+	return fmt.Sprintf("%s: samples=%d max=%.0fus p99=%.0fus p99.9=%.0fus",
+		result.Tool, result.Samples, result.Max, result.P99, result.P999)
+}
+
+// newResult creates a result from a histogram mapping each latency to its number of samples and computes the
+// percentiles. The maximum is the largest of the provided maximum and the largest value with samples.
+func newResult(tool Tool, histogram map[float64]uint64, maximum float64) (*Result, error) {
+	result := &Result{Tool: tool, Max: maximum}
+
+	for _, value := range slices.Sorted(maps.Keys(histogram)) {
+		result.Values = append(result.Values, value)
+		result.Counts = append(result.Counts, histogram[value])
+		result.Samples += histogram[value]
+
+		if histogram[value] > 0 {
+			result.Max = max(result.Max, value)
+		}
+	}
+
+	if result.Samples == 0 {
+		return result, nil
+	}
+
+	var err error
+
+	result.P99, err = stats.HistogramPercentile(result.Values, result.Counts, 99)
+	if err != nil {
+		return nil, err
+	}
+
+	result.P999, err = stats.HistogramPercentile(result.Values, result.Counts, 99.9)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// parseUints parses every whitespace separated field as an unsigned integer.
+func parseUints(fields []string) ([]uint64, error) {
+	values := make([]uint64, 0, len(fields))
+
+	for _, field := range fields {
+		value, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// sum returns the sum of values.
+func sum(values []uint64) uint64 {
+	var total uint64
+
+	for _, value := range values {
+		total += value
+	}
+
+	return total
+}
+
+// maxOf returns the maximum of values, or zero if there are none.
+func maxOf(values []uint64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	return float64(slices.Max(values))
+}
+
+// trimFields returns the whitespace separated fields of line after prefix and before suffix.
+func trimFields(line, prefix, suffix string) []string {
+	line = strings.TrimPrefix(strings.TrimSpace(line), prefix)
+	line = strings.TrimSuffix(strings.TrimSpace(line), suffix)
+
+	return strings.Fields(line)
+}
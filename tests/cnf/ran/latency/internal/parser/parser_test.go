@@ -0,0 +1,186 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	cyclictestOutput = `# /dev/cpu_dma_latency set to 0us
+# Histogram
+000000 000000	000000
+000001 000500	000400
+000002 000080	000010
+000003 000005	000004
+000004 000000	000000
+# Total: 000000585 000000414
+# Min Latencies: 00001 00001
+# Avg Latencies: 00001 00001
+# Max Latencies: 00003 00042
+# Histogram Overflows: 00000 00001
+# Histogram Overflow at cycle number:
+# Thread 0:
+# Thread 1: 00123
+`
+	oslatOutput = `oslat V 2.00
+Total runtime: 		10 seconds
+Thread priority: 	SCHED_FIFO:1
+CPU list: 		2-3
+CPU for main thread: 	1
+Workload: 		no
+Workload mem: 		0 (KiB)
+Preheat cores: 		2
+
+Pre-heat for 1 seconds...
+Test starts...
+Test completed.
+
+        Core:	 2 3
+Counter Freq:	 2095 2095 (Mhz)
+    001 (us):	 900 98
+    002 (us):	 0 1
+    003 (us):	 0 0
+    032 (us):	 0 1 (including overflows)
+     Minimum:	 1 1 (us)
+     Average:	 1.000 1.040 (us)
+     Maximum:	 1 40 (us)
+     Max-Min:	 0 39 (us)
+    Duration:	 9.998 9.998 (sec)
+`
+	hwlatdetectOutput = `hwlatdetect:  test duration 600 seconds
+   detector: tracer
+   parameters:
+        Latency threshold: 1us
+        Sample window:     1000000us
+        Sample width:      950000us
+     Non-sampling period:  50000us
+        Output File:       None
+
+Starting test
+test finished
+Max Latency: 12us
+Samples recorded: 3
+Samples exceeding threshold: 3
+ts: 1610542359.528744491, inner:3, outer:0
+ts: 1610542383.529164061, inner:2, outer:12
+ts: 1610542420.529455723, inner:4, outer:4
+`
+	hwlatdetectBelowThresholdOutput = `hwlatdetect:  test duration 600 seconds
+Starting test
+test finished
+Max Latency: Below threshold
+Samples recorded: 0
+Samples exceeding threshold: 0
+`
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		tool          Tool
+		output        string
+		expected      *Result
+		expectedError bool
+	}{
+		{
+			tool:   Cyclictest,
+			output: cyclictestOutput,
+			expected: &Result{
+				Tool:    Cyclictest,
+				Values:  []float64{0, 1, 2, 3, 4, 42},
+				Counts:  []uint64{0, 900, 90, 9, 0, 1},
+				Samples: 1000,
+				Max:     42,
+				P99:     2,
+				P999:    3,
+			},
+		},
+		{
+			tool:   Oslat,
+			output: oslatOutput,
+			expected: &Result{
+				Tool:    Oslat,
+				Values:  []float64{1, 2, 3, 32},
+				Counts:  []uint64{998, 1, 0, 1},
+				Samples: 1000,
+				Max:     40,
+				P99:     1,
+				P999:    2,
+			},
+		},
+		{
+			tool:   Hwlatdetect,
+			output: hwlatdetectOutput,
+			expected: &Result{
+				Tool:    Hwlatdetect,
+				Values:  []float64{3, 4, 12},
+				Counts:  []uint64{1, 1, 1},
+				Samples: 3,
+				Max:     12,
+				P99:     12,
+				P999:    12,
+			},
+		},
+		{
+			tool:     Hwlatdetect,
+			output:   hwlatdetectBelowThresholdOutput,
+			expected: &Result{Tool: Hwlatdetect},
+		},
+		{
+			tool:          Cyclictest,
+			output:        "000001 000500\n",
+			expectedError: true,
+		},
+		{
+			tool:          Oslat,
+			output:        "Test completed.\n",
+			expectedError: true,
+		},
+		{
+			tool:          Tool("stress-ng"),
+			output:        cyclictestOutput,
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		result, err := Parse(testCase.tool, testCase.output)
+		assert.Equal(t, testCase.expectedError, err != nil, "tool: %s", testCase.tool)
+
+		if err == nil {
+			assert.Equal(t, testCase.expected, result)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	result := &Result{Tool: Cyclictest, Max: 42, P99: 2, P999: 3}
+
+	testCases := []struct {
+		limits        ranconfig.LatencyLimits
+		expectedError bool
+	}{
+		{
+			limits:        ranconfig.LatencyLimits{Max: 50, P99: 2, P999: 3},
+			expectedError: false,
+		},
+		{
+			limits:        ranconfig.LatencyLimits{},
+			expectedError: false,
+		},
+		{
+			limits:        ranconfig.LatencyLimits{Max: 20},
+			expectedError: true,
+		},
+		{
+			limits:        ranconfig.LatencyLimits{P999: 2},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := result.Check(testCase.limits)
+		assert.Equal(t, testCase.expectedError, err != nil, "limits: %+v", testCase.limits)
+	}
+}
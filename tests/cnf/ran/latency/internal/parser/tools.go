@@ -0,0 +1,166 @@
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Matches a cyclictest histogram line, which is the latency followed by the count for each thread.
+	cyclictestBucket = regexp.MustCompile(`^(\d+)((?:\s+\d+)+)$`)
+	// Matches an oslat histogram line, such as "    001 (us):  1234 5678", with an optional overflow note.
+	oslatBucket = regexp.MustCompile(`^\s*(\d+) \(us\):((?:\s+\d+)+)`)
+	// Matches an hwlatdetect sample, such as "ts: 1610542359.528744491, inner:12, outer:10".
+	hwlatdetectSample = regexp.MustCompile(`^ts:\s*[\d.]+,\s*inner:\s*(\d+),\s*outer:\s*(\d+)`)
+	// Matches the hwlatdetect maximum, which is either a latency or "Below threshold".
+	hwlatdetectMax = regexp.MustCompile(`^Max Latency:\s*(\d+)us`)
+)
+
+// ParseCyclictest parses the output of cyclictest run with the -q and -h flags. Samples counted as histogram overflows
+// are added to the histogram at the maximum latency.
+func ParseCyclictest(output string) (*Result, error) {
+	histogram := make(map[float64]uint64)
+
+	var (
+		maxLatencies []uint64
+		overflows    uint64
+		foundMax     bool
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, "# Max Latencies:"):
+			values, err := parseUints(trimFields(line, "# Max Latencies:", ""))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse cyclictest max latencies %q: %w", line, err)
+			}
+
+			maxLatencies = values
+			foundMax = true
+		case strings.HasPrefix(line, "# Histogram Overflows:"):
+			values, err := parseUints(trimFields(line, "# Histogram Overflows:", ""))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse cyclictest histogram overflows %q: %w", line, err)
+			}
+
+			overflows = sum(values)
+		case cyclictestBucket.MatchString(line):
+			matches := cyclictestBucket.FindStringSubmatch(line)
+
+			latency, _ := strconv.ParseFloat(matches[1], 64)
+			// The regular expression ensures these are all integers.
+			counts, _ := parseUints(strings.Fields(matches[2]))
+
+			histogram[latency] += sum(counts)
+		}
+	}
+
+	if !foundMax {
+		return nil, fmt.Errorf("cyclictest output does not contain max latencies, was it run with -q and -h")
+	}
+
+	maximum := maxOf(maxLatencies)
+	if overflows > 0 {
+		histogram[maximum] += overflows
+	}
+
+	return newResult(Cyclictest, histogram, maximum)
+}
+
+// ParseOslat parses the output of oslat. The overflow bucket is included in the histogram as its own latency.
+func ParseOslat(output string) (*Result, error) {
+	histogram := make(map[float64]uint64)
+
+	var (
+		maxLatencies []uint64
+		foundMax     bool
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(strings.TrimSpace(line), "Maximum:"):
+			values, err := parseUints(trimFields(line, "Maximum:", "(us)"))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse oslat maximum %q: %w", line, err)
+			}
+
+			maxLatencies = values
+			foundMax = true
+		case oslatBucket.MatchString(line):
+			matches := oslatBucket.FindStringSubmatch(line)
+
+			latency, _ := strconv.ParseFloat(matches[1], 64)
+			// The regular expression ensures these are all integers.
+			counts, _ := parseUints(strings.Fields(matches[2]))
+
+			histogram[latency] += sum(counts)
+		}
+	}
+
+	if !foundMax {
+		return nil, fmt.Errorf("oslat output does not contain the maximum latency")
+	}
+
+	return newResult(Oslat, histogram, maxOf(maxLatencies))
+}
+
+// ParseHwlatdetect parses the output of hwlatdetect. Since hwlatdetect only reports samples above its threshold, the
+// percentiles are only of those samples and are zero if there are none.
+func ParseHwlatdetect(output string) (*Result, error) {
+	histogram := make(map[float64]uint64)
+
+	var (
+		maximum  float64
+		foundMax bool
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, "Max Latency:"):
+			foundMax = true
+
+			if matches := hwlatdetectMax.FindStringSubmatch(line); matches != nil {
+				maximum, _ = strconv.ParseFloat(matches[1], 64)
+			}
+		case hwlatdetectSample.MatchString(line):
+			matches := hwlatdetectSample.FindStringSubmatch(line)
+
+			inner, _ := strconv.ParseFloat(matches[1], 64)
+			outer, _ := strconv.ParseFloat(matches[2], 64)
+
+			histogram[max(inner, outer)]++
+		}
+	}
+
+	if !foundMax {
+		return nil, fmt.Errorf("hwlatdetect output does not contain the max latency")
+	}
+
+	return newResult(Hwlatdetect, histogram, maximum)
+}
+
+// Parse parses the output of the provided tool.
+func Parse(tool Tool, output string) (*Result, error) {
+	switch tool {
+	case Cyclictest:
+		return ParseCyclictest(output)
+	case Oslat:
+		return ParseOslat(output)
+	case Hwlatdetect:
+		return ParseHwlatdetect(output)
+	default:
+		return nil, fmt.Errorf("unknown latency tool %q", tool)
+	}
+}
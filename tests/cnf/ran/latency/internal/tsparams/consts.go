@@ -0,0 +1,35 @@
+package tsparams
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// LabelSuite is the label for all the tests in this suite.
+	LabelSuite = "latency"
+	// LabelCyclictest is the label for the cyclictest test case.
+	LabelCyclictest = "cyclictest"
+	// LabelOslat is the label for the oslat test case.
+	LabelOslat = "oslat"
+	// LabelHwlatdetect is the label for the hwlatdetect test case.
+	LabelHwlatdetect = "hwlatdetect"
+	// TestingNamespace is the tests namespace.
+	TestingNamespace = "ran-latency-test"
+
+	// LatencyPodName is the name of the pod running the latency tools.
+	LatencyPodName = "latency-test-pod"
+	// LatencyPodCPUs is the number of CPUs requested by the latency pod. One CPU runs the main thread of the tool and
+	// the rest are measured.
+	LatencyPodCPUs = 4
+	// LatencyPodMemory is the amount of memory requested by the latency pod.
+	LatencyPodMemory = "1Gi"
+	// LatencyTimeout is the timeout for creating and deleting the latency pod.
+	LatencyTimeout = 10 * time.Minute
+	// LatencyToolGrace is added to the latency duration to allow the tools time to start and print their results.
+	LatencyToolGrace = 2 * time.Minute
+
+	// LogLevel is the verbosity of glog statements in this test suite.
+	LogLevel glog.Level = 90
+)
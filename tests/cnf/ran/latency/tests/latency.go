@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nto"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranhelper"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/latency/internal/helper"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/latency/internal/parser"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/latency/internal/tsparams"
+	"k8s.io/utils/cpuset"
+)
+
+var _ = Describe("Real-time latency on isolated CPUs", Ordered, func() {
+	var (
+		perfProfile *nto.Builder
+		powerMode   ranparam.PowerMode
+		thresholds  map[string]ranconfig.LatencyLimits
+		duration    time.Duration
+		latencyPod  *pod.Builder
+		podCPUs     cpuset.CPUSet
+	)
+
+	BeforeAll(func() {
+		if RANConfig.LatencyTestImage == "" {
+			Skip("Latency test image must be provided to run latency tests")
+		}
+
+		var err error
+
+		duration, err = time.ParseDuration(RANConfig.LatencyDuration)
+		Expect(err).ToNot(HaveOccurred(), "Failed to parse latency duration %q", RANConfig.LatencyDuration)
+
+		nodeList, err := nodes.List(Spoke1APIClient)
+		Expect(err).ToNot(HaveOccurred(), "Failed to list cluster nodes")
+
+		if len(nodeList) != 1 {
+			Skip("Latency tests only support SNO clusters")
+		}
+
+		By("getting the performance profile and its power mode")
+		perfProfile, err = ranhelper.GetPerformanceProfileWithCPUSet(Spoke1APIClient)
+		Expect(err).ToNot(HaveOccurred(), "Failed to get performance profile")
+
+		powerMode, err = ranhelper.GetPowerMode(perfProfile)
+		Expect(err).ToNot(HaveOccurred(), "Failed to get power mode of performance profile")
+
+		thresholds = RANConfig.LatencyThresholds[powerMode]
+		if len(thresholds) == 0 {
+			Skip("No latency thresholds are configured for power mode " + string(powerMode))
+		}
+
+		By("creating the latency pod")
+		latencyPod, err = helper.CreateLatencyPod(Spoke1APIClient, perfProfile, nodeList[0].Object.Name)
+		Expect(err).ToNot(HaveOccurred(), "Failed to create latency pod")
+
+		podCPUs, err = helper.GetPodCPUs(latencyPod)
+		Expect(err).ToNot(HaveOccurred(), "Failed to get CPUs of latency pod")
+	})
+
+	AfterAll(func() {
+		if latencyPod == nil || !latencyPod.Exists() {
+			return
+		}
+
+		By("deleting the latency pod")
+		_, err := latencyPod.DeleteAndWait(tsparams.LatencyTimeout)
+		Expect(err).ToNot(HaveOccurred(), "Failed to delete latency pod")
+	})
+
+	// runAndCheck runs the tool in the latency pod and checks its result against the thresholds for the current power
+	// mode, skipping if there are none for the tool.
+	runAndCheck := func(tool parser.Tool) {
+		limits, ok := thresholds[string(tool)]
+		if !ok {
+			Skip("No latency thresholds are configured for " + string(tool))
+		}
+
+		By("running " + string(tool))
+		result, err := helper.RunLatencyTool(latencyPod, tool, podCPUs, duration)
+		Expect(err).ToNot(HaveOccurred(), "Failed to run %s", tool)
+
+		GinkgoWriter.Printf("%s (power mode %s)\n", result, powerMode)
+		AddReportEntry(string(tool), result.String())
+
+		By("checking the latency against the thresholds")
+		Expect(result.Check(limits)).To(Succeed(), "Latency exceeded thresholds for power mode %s", powerMode)
+	}
+
+	// 82948 - Verify cyclictest latency is within thresholds
+	It("verifies cyclictest latency is within thresholds", Label(tsparams.LabelCyclictest), reportxml.ID("82948"), func() {
+		runAndCheck(parser.Cyclictest)
+	})
+
+	// 82949 - Verify oslat latency is within thresholds
+	It("verifies oslat latency is within thresholds", Label(tsparams.LabelOslat), reportxml.ID("82949"), func() {
+		runAndCheck(parser.Oslat)
+	})
+
+	// 82950 - Verify hwlatdetect latency is within thresholds
+	It("verifies hwlatdetect latency is within thresholds", Label(tsparams.LabelHwlatdetect),
+		reportxml.ID("82950"), func() {
+			runAndCheck(parser.Hwlatdetect)
+		})
+})
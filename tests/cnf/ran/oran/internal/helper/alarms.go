@@ -0,0 +1,109 @@
+package helper
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/deployment"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/oran/internal/o2ims"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/oran/internal/tsparams"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// prometheusRuleGVK is the GroupVersionKind of the PrometheusRule used to raise the test alarm.
+var prometheusRuleGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"}
+
+// NewO2IMSClient returns an o2ims client for the alarm and subscription APIs using the base URL and token from
+// RANConfig. Like the oranapi clients, it skips verifying the server certificate.
+func NewO2IMSClient() *o2ims.Client {
+	return o2ims.NewClient(RANConfig.O2IMSBaseURL, RANConfig.O2IMSToken, &tls.Config{InsecureSkipVerify: true})
+}
+
+// NewO2IMSReceiver starts a notification receiver using the callback URL and address from RANConfig.
+func NewO2IMSReceiver() (*o2ims.Receiver, error) {
+	if RANConfig.O2IMSCallbackURL == "" {
+		return nil, fmt.Errorf("the O2IMS callback URL must be provided to receive notifications")
+	}
+
+	return o2ims.NewReceiver(RANConfig.O2IMSCallbackAddress, RANConfig.O2IMSCallbackURL)
+}
+
+// CreateAlarmWorkload creates the alarm namespace on the spoke, labeled for cluster monitoring, along with a single
+// replica Deployment and a PrometheusRule that fires tsparams.AlertName when the Deployment has no available replicas.
+// Scaling the returned Deployment to zero raises the alarm and scaling it back to one clears it.
+func CreateAlarmWorkload(client *clients.Settings, timeout time.Duration) (*deployment.Builder, error) {
+	_, err := namespace.NewBuilder(client, tsparams.AlarmNamespace).
+		WithLabel("openshift.io/cluster-monitoring", "true").
+		Create()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create alarm namespace %s: %w", tsparams.AlarmNamespace, err)
+	}
+
+	container, err := pod.NewContainerBuilder(tsparams.TestName, RANConfig.CnfTestImage, []string{"sleep", "infinity"}).
+		GetContainerCfg()
+	if err != nil {
+		return nil, fmt.Errorf("failed to define alarm workload container: %w", err)
+	}
+
+	workload, err := deployment.NewBuilder(
+		client, tsparams.TestName, tsparams.AlarmNamespace, map[string]string{"app": tsparams.TestName}, *container).
+		WithReplicas(1).
+		CreateAndWaitUntilReady(timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create alarm workload: %w", err)
+	}
+
+	// The PrometheusRule is created as unstructured since eco-goinfra does not provide a builder for it.
+	rule := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"groups": []any{map[string]any{
+				"name": tsparams.TestName,
+				"rules": []any{map[string]any{
+					"alert": tsparams.AlertName,
+					"expr": fmt.Sprintf(`kube_deployment_status_replicas_available{namespace="%s",deployment="%s"} < 1`,
+						tsparams.AlarmNamespace, tsparams.TestName),
+					"for":    tsparams.AlertFor,
+					"labels": map[string]any{"severity": tsparams.AlertSeverity},
+					"annotations": map[string]any{
+						"summary":     "O-RAN test workload is unavailable",
+						"description": "The O-RAN test Deployment has no available replicas.",
+					},
+				}},
+			}},
+		},
+	}}
+	rule.SetGroupVersionKind(prometheusRuleGVK)
+	rule.SetName(tsparams.TestName)
+	rule.SetNamespace(tsparams.AlarmNamespace)
+
+	err = client.Create(context.TODO(), rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create alarm PrometheusRule: %w", err)
+	}
+
+	return workload, nil
+}
+
+// DeleteAlarmWorkload deletes the alarm namespace on the spoke, which includes the Deployment and PrometheusRule, if
+// it exists.
+func DeleteAlarmWorkload(client *clients.Settings, timeout time.Duration) error {
+	alarmNamespace := namespace.NewBuilder(client, tsparams.AlarmNamespace)
+	if !alarmNamespace.Exists() {
+		return nil
+	}
+
+	return alarmNamespace.DeleteAndWait(timeout)
+}
+
+// IsTestAlarm returns whether the alarm notification is for tsparams.AlertName. The O2IMS server includes the labels
+// of the alert as extensions on the alarm.
+func IsTestAlarm(notification o2ims.AlarmEventNotification) bool {
+	return notification.Extensions["alertname"] == tsparams.AlertName
+}
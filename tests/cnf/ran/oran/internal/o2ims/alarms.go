@@ -0,0 +1,268 @@
+package o2ims
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/oran/api/filter"
+)
+
+// PerceivedSeverity is the severity of an alarm as defined by O2IMS.
+type PerceivedSeverity int
+
+const (
+	// SeverityCritical is the severity of critical alarms.
+	SeverityCritical PerceivedSeverity = iota
+	// SeverityMajor is the severity of major alarms.
+	SeverityMajor
+	// SeverityMinor is the severity of minor alarms.
+	SeverityMinor
+	// SeverityWarning is the severity of warning alarms.
+	SeverityWarning
+	// SeverityIndeterminate is the severity of alarms whose severity cannot be determined.
+	SeverityIndeterminate
+	// SeverityCleared is the severity of alarms that have been cleared.
+	SeverityCleared
+)
+
+// AlarmEventType is the type of an alarm event notification.
+type AlarmEventType int
+
+const (
+	// AlarmEventNew is the event type for a newly raised alarm.
+	AlarmEventNew AlarmEventType = iota
+	// AlarmEventChange is the event type for a change to an existing alarm, such as its severity.
+	AlarmEventChange
+	// AlarmEventClear is the event type for an alarm that has been cleared.
+	AlarmEventClear
+	// AlarmEventAcknowledge is the event type for an alarm that has been acknowledged.
+	AlarmEventAcknowledge
+)
+
+// String returns the name of the event type as it is used in alarm subscription filters.
+func (eventType AlarmEventType) String() string {
+	switch eventType {
+	case AlarmEventNew:
+		return string(AlarmFilterNew)
+	case AlarmEventChange:
+		return string(AlarmFilterChange)
+	case AlarmEventClear:
+		return string(AlarmFilterClear)
+	case AlarmEventAcknowledge:
+		return string(AlarmFilterAcknowledge)
+	default:
+		return fmt.Sprintf("UNKNOWN(%d)", int(eventType))
+	}
+}
+
+// AlarmSubscriptionFilter is the type of alarm event an alarm subscription excludes from its notifications.
+type AlarmSubscriptionFilter string
+
+const (
+	// AlarmFilterNew excludes notifications for new alarms.
+	AlarmFilterNew AlarmSubscriptionFilter = "NEW"
+	// AlarmFilterChange excludes notifications for changed alarms.
+	AlarmFilterChange AlarmSubscriptionFilter = "CHANGE"
+	// AlarmFilterClear excludes notifications for cleared alarms.
+	AlarmFilterClear AlarmSubscriptionFilter = "CLEAR"
+	// AlarmFilterAcknowledge excludes notifications for acknowledged alarms.
+	AlarmFilterAcknowledge AlarmSubscriptionFilter = "ACKNOWLEDGE"
+)
+
+// AlarmEventRecord is a single alarm as returned by the O2IMS API.
+type AlarmEventRecord struct {
+	AlarmEventRecordID    string            `json:"alarmEventRecordId"`
+	AlarmDefinitionID     string            `json:"alarmDefinitionID"`
+	ProbableCauseID       string            `json:"probableCauseID"`
+	ResourceTypeID        string            `json:"resourceTypeID"`
+	ResourceID            string            `json:"resourceID"`
+	AlarmRaisedTime       time.Time         `json:"alarmRaisedTime"`
+	AlarmChangedTime      *time.Time        `json:"alarmChangedTime,omitempty"`
+	AlarmClearedTime      *time.Time        `json:"alarmClearedTime,omitempty"`
+	AlarmAcknowledgedTime *time.Time        `json:"alarmAcknowledgedTime,omitempty"`
+	AlarmAcknowledged     bool              `json:"alarmAcknowledged"`
+	PerceivedSeverity     PerceivedSeverity `json:"perceivedSeverity"`
+	Extensions            map[string]string `json:"extensions,omitempty"`
+}
+
+// AlarmEventRecordModifications is the body of a request to modify an alarm. Only acknowledging alarms is supported.
+type AlarmEventRecordModifications struct {
+	AlarmAcknowledged *bool              `json:"alarmAcknowledged,omitempty"`
+	PerceivedSeverity *PerceivedSeverity `json:"perceivedSeverity,omitempty"`
+}
+
+// AlarmDefinition is a single alarm that may be raised for a resource type.
+type AlarmDefinition struct {
+	AlarmDefinitionID     string            `json:"alarmDefinitionId"`
+	AlarmName             string            `json:"alarmName"`
+	AlarmLastChange       string            `json:"alarmLastChange"`
+	AlarmChangeType       int               `json:"alarmChangeType"`
+	AlarmDescription      string            `json:"alarmDescription"`
+	ProposedRepairActions string            `json:"proposedRepairActions"`
+	ClearingType          int               `json:"clearingType"`
+	ManagementInterfaceID []string          `json:"managementInterfaceId,omitempty"`
+	PKNotificationField   []string          `json:"pkNotificationField,omitempty"`
+	AlarmAdditionalFields map[string]string `json:"alarmAdditionalFields,omitempty"`
+}
+
+// AlarmDictionary is the set of alarms that may be raised for a resource type.
+type AlarmDictionary struct {
+	AlarmDictionaryID            string            `json:"alarmDictionaryId"`
+	AlarmDictionaryVersion       string            `json:"alarmDictionaryVersion"`
+	AlarmDictionarySchemaVersion string            `json:"alarmDictionarySchemaVersion"`
+	EntityType                   string            `json:"entityType"`
+	Vendor                       string            `json:"vendor"`
+	ManagementInterfaceID        []string          `json:"managementInterfaceId,omitempty"`
+	PKNotificationField          []string          `json:"pkNotificationField,omitempty"`
+	AlarmDefinition              []AlarmDefinition `json:"alarmDefinition"`
+}
+
+// Definition returns the alarm definition with the provided ID and whether it was found.
+func (dictionary *AlarmDictionary) Definition(alarmDefinitionID string) (*AlarmDefinition, bool) {
+	for index := range dictionary.AlarmDefinition {
+		if dictionary.AlarmDefinition[index].AlarmDefinitionID == alarmDefinitionID {
+			return &dictionary.AlarmDefinition[index], true
+		}
+	}
+
+	return nil, false
+}
+
+// AlarmSubscriptionInfo is an alarm subscription. When creating a subscription, AlarmSubscriptionID should be left
+// empty since it is assigned by the server.
+type AlarmSubscriptionInfo struct {
+	AlarmSubscriptionID    string                   `json:"alarmSubscriptionId,omitempty"`
+	ConsumerSubscriptionID string                   `json:"consumerSubscriptionId,omitempty"`
+	Callback               string                   `json:"callback"`
+	Filter                 *AlarmSubscriptionFilter `json:"filter,omitempty"`
+}
+
+// AlarmEventNotification is the body of the notifications sent to alarm subscription callbacks.
+type AlarmEventNotification struct {
+	GlobalCloudID          string            `json:"globalCloudID"`
+	ConsumerSubscriptionID string            `json:"consumerSubscriptionId,omitempty"`
+	NotificationEventType  AlarmEventType    `json:"notificationEventType"`
+	ObjectRef              string            `json:"objectRef,omitempty"`
+	AlarmEventRecordID     string            `json:"alarmEventRecordId"`
+	AlarmDefinitionID      string            `json:"alarmDefinitionID"`
+	ProbableCauseID        string            `json:"probableCauseID"`
+	ResourceTypeID         string            `json:"resourceTypeID"`
+	ResourceID             string            `json:"resourceID"`
+	AlarmRaisedTime        time.Time         `json:"alarmRaisedTime"`
+	AlarmChangedTime       *time.Time        `json:"alarmChangedTime,omitempty"`
+	AlarmAcknowledgeTime   *time.Time        `json:"alarmAcknowledgeTime,omitempty"`
+	AlarmAcknowledged      bool              `json:"alarmAcknowledged"`
+	PerceivedSeverity      PerceivedSeverity `json:"perceivedSeverity"`
+	Extensions             map[string]string `json:"extensions,omitempty"`
+}
+
+// ListAlarms lists all alarms. Optionally, a filter can be provided to filter the list of alarms. If more than one
+// filter is provided, only the first one is used.
+func (client *Client) ListAlarms(filters ...filter.Filter) ([]AlarmEventRecord, error) {
+	var alarms []AlarmEventRecord
+
+	err := client.do(http.MethodGet, monitoringPath+"/alarms", filters, "", nil, &alarms)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alarms: %w", err)
+	}
+
+	return alarms, nil
+}
+
+// GetAlarm gets the alarm with the provided alarmEventRecordId.
+func (client *Client) GetAlarm(id string) (*AlarmEventRecord, error) {
+	alarm := &AlarmEventRecord{}
+
+	err := client.do(http.MethodGet, monitoringPath+"/alarms/"+id, nil, "", nil, alarm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alarm %s: %w", id, err)
+	}
+
+	return alarm, nil
+}
+
+// AcknowledgeAlarm acknowledges the alarm with the provided alarmEventRecordId and returns the modifications
+// accepted by the server.
+func (client *Client) AcknowledgeAlarm(id string) (*AlarmEventRecordModifications, error) {
+	acknowledged := true
+	modifications := &AlarmEventRecordModifications{}
+
+	err := client.do(http.MethodPatch, monitoringPath+"/alarms/"+id, nil, mergePatchContentType,
+		&AlarmEventRecordModifications{AlarmAcknowledged: &acknowledged}, modifications)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acknowledge alarm %s: %w", id, err)
+	}
+
+	return modifications, nil
+}
+
+// ListAlarmDictionaries lists the alarm dictionaries of all resource types.
+func (client *Client) ListAlarmDictionaries(filters ...filter.Filter) ([]AlarmDictionary, error) {
+	var dictionaries []AlarmDictionary
+
+	err := client.do(http.MethodGet, inventoryPath+"/alarmDictionaries", filters, "", nil, &dictionaries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alarm dictionaries: %w", err)
+	}
+
+	return dictionaries, nil
+}
+
+// GetAlarmDictionary gets the alarm dictionary with the provided ID.
+func (client *Client) GetAlarmDictionary(id string) (*AlarmDictionary, error) {
+	dictionary := &AlarmDictionary{}
+
+	err := client.do(http.MethodGet, inventoryPath+"/alarmDictionaries/"+id, nil, "", nil, dictionary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alarm dictionary %s: %w", id, err)
+	}
+
+	return dictionary, nil
+}
+
+// ListAlarmSubscriptions lists all alarm subscriptions.
+func (client *Client) ListAlarmSubscriptions(filters ...filter.Filter) ([]AlarmSubscriptionInfo, error) {
+	var subscriptions []AlarmSubscriptionInfo
+
+	err := client.do(http.MethodGet, monitoringPath+"/alarmSubscriptions", filters, "", nil, &subscriptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alarm subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// CreateAlarmSubscription creates the provided alarm subscription and returns it with the ID assigned by the server.
+func (client *Client) CreateAlarmSubscription(subscription AlarmSubscriptionInfo) (*AlarmSubscriptionInfo, error) {
+	created := &AlarmSubscriptionInfo{}
+
+	err := client.do(http.MethodPost, monitoringPath+"/alarmSubscriptions", nil, "", subscription, created)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create alarm subscription: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetAlarmSubscription gets the alarm subscription with the provided ID.
+func (client *Client) GetAlarmSubscription(id string) (*AlarmSubscriptionInfo, error) {
+	subscription := &AlarmSubscriptionInfo{}
+
+	err := client.do(http.MethodGet, monitoringPath+"/alarmSubscriptions/"+id, nil, "", nil, subscription)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alarm subscription %s: %w", id, err)
+	}
+
+	return subscription, nil
+}
+
+// DeleteAlarmSubscription deletes the alarm subscription with the provided ID.
+func (client *Client) DeleteAlarmSubscription(id string) error {
+	err := client.do(http.MethodDelete, monitoringPath+"/alarmSubscriptions/"+id, nil, "", nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete alarm subscription %s: %w", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package o2ims
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/golang/glog"
+	oranapi "github.com/rh-ecosystem-edge/eco-goinfra/pkg/oran/api"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/oran/api/filter"
+)
+
+const (
+	// monitoringPath is the base path of the O2IMS infrastructure monitoring API, which serves alarms and alarm
+	// subscriptions.
+	monitoringPath = "/o2ims-infrastructureMonitoring/v1"
+	// inventoryPath is the base path of the O2IMS infrastructure inventory API, which serves resource types, alarm
+	// dictionaries, and inventory subscriptions.
+	inventoryPath = "/o2ims-infrastructureInventory/v1"
+
+	// mergePatchContentType is the content type for JSON merge patches, which is what the O2IMS API expects for
+	// PATCH requests.
+	mergePatchContentType = "application/merge-patch+json"
+
+	// logLevel is the glog verbosity level used by this package. It matches the oran suite.
+	logLevel glog.Level = 80
+)
+
+// Client provides access to the parts of the O2IMS API not covered by the eco-goinfra oranapi clients: alarms, alarm
+// dictionaries, and alarm and inventory subscriptions. Errors returned by the API are returned as *oranapi.Error so
+// they can be checked using oranapi.AsAPIError.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient returns a client for the O2IMS API at baseURL. The baseURL should include the scheme, similar to
+// oranapi.NewClientBuilder. If token is not empty, it is used as the bearer token. If tlsConfig is not nil, it is used
+// for all requests.
+func NewClient(baseURL, token string, tlsConfig *tls.Config) *Client {
+	httpClient := &http.Client{}
+
+	if tlsConfig != nil {
+		httpClient.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+// do sends a request with the provided method to path, which is relative to the base URL. If filters is not empty,
+// the first one is used as the filter query parameter. If body is not nil, it is marshaled as JSON with the provided
+// content type, defaulting to application/json. If out is not nil, the response body is unmarshaled into it. Responses
+// with a status outside of 2xx are returned as *oranapi.Error.
+func (client *Client) do(
+	method, path string, filters []filter.Filter, contentType string, body, out any) error {
+	requestURL := client.baseURL + path
+
+	if len(filters) > 0 {
+		requestURL += "?filter=" + url.QueryEscape(filters[0].Filter())
+	}
+
+	var bodyReader io.Reader
+
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+
+	request, err := http.NewRequestWithContext(context.TODO(), method, requestURL, bodyReader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		if contentType == "" {
+			contentType = "application/json"
+		}
+
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	if client.token != "" {
+		request.Header.Set("Authorization", "Bearer "+client.token)
+	}
+
+	glog.V(logLevel).Infof("Sending O2IMS API request %s %s", method, requestURL)
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("error contacting api: %w", err)
+	}
+
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return apiErrorFromResponse(response.StatusCode, responseBody)
+	}
+
+	if out == nil || len(responseBody) == 0 {
+		return nil
+	}
+
+	err = json.Unmarshal(responseBody, out)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return nil
+}
+
+// apiErrorFromResponse converts a failed response to an *oranapi.Error. If the body is not a ProblemDetails, the
+// error uses the body as its detail.
+func apiErrorFromResponse(statusCode int, body []byte) error {
+	apiError := &oranapi.Error{}
+
+	err := json.Unmarshal(body, apiError)
+	if err != nil || apiError.Status == 0 {
+		apiError = &oranapi.Error{Detail: strings.TrimSpace(string(body))}
+	}
+
+	apiError.Status = statusCode
+
+	return fmt.Errorf("received error from api: %w", apiError)
+}
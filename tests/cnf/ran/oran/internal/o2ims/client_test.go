@@ -0,0 +1,120 @@
+package o2ims
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	oranapi "github.com/rh-ecosystem-edge/eco-goinfra/pkg/oran/api"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/oran/api/filter"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testToken   = "test-token"
+	testAlarmID = "8c1c151d-2899-4c96-b76f-4fe92064b57b"
+)
+
+func TestClientAlarms(t *testing.T) {
+	var lastRequest struct {
+		method      string
+		path        string
+		filter      string
+		contentType string
+		token       string
+		body        string
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+
+		lastRequest.method = request.Method
+		lastRequest.path = request.URL.Path
+		lastRequest.filter = request.URL.Query().Get("filter")
+		lastRequest.contentType = request.Header.Get("Content-Type")
+		lastRequest.token = request.Header.Get("Authorization")
+		lastRequest.body = string(body)
+
+		switch {
+		case request.URL.Path == monitoringPath+"/alarms":
+			_ = json.NewEncoder(writer).Encode([]AlarmEventRecord{{AlarmEventRecordID: testAlarmID}})
+		case request.URL.Path == monitoringPath+"/alarms/"+testAlarmID && request.Method == http.MethodPatch:
+			_, _ = writer.Write(body)
+		case request.URL.Path == monitoringPath+"/alarmSubscriptions/missing":
+			writer.Header().Set("Content-Type", "application/problem+json")
+			writer.WriteHeader(http.StatusNotFound)
+			_, _ = writer.Write([]byte(`{"status":404,"title":"Not Found","detail":"subscription not found"}`))
+		case request.URL.Path == monitoringPath+"/alarmSubscriptions/plain":
+			writer.WriteHeader(http.StatusInternalServerError)
+			_, _ = writer.Write([]byte("internal error\n"))
+		default:
+			writer.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", testToken, server.Client().Transport.(*http.Transport).TLSClientConfig)
+
+	alarms, err := client.ListAlarms(filter.Equals("alarmAcknowledged", "false"))
+	assert.Nil(t, err)
+	assert.Len(t, alarms, 1)
+	assert.Equal(t, testAlarmID, alarms[0].AlarmEventRecordID)
+	assert.Equal(t, "(eq,alarmAcknowledged,false)", lastRequest.filter)
+	assert.Equal(t, "Bearer "+testToken, lastRequest.token)
+
+	modifications, err := client.AcknowledgeAlarm(testAlarmID)
+	assert.Nil(t, err)
+	assert.NotNil(t, modifications.AlarmAcknowledged)
+	assert.True(t, *modifications.AlarmAcknowledged)
+	assert.Equal(t, http.MethodPatch, lastRequest.method)
+	assert.Equal(t, mergePatchContentType, lastRequest.contentType)
+	assert.JSONEq(t, `{"alarmAcknowledged":true}`, lastRequest.body)
+
+	err = client.DeleteAlarmSubscription("deleted")
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodDelete, lastRequest.method)
+	assert.Equal(t, monitoringPath+"/alarmSubscriptions/deleted", lastRequest.path)
+
+	_, err = client.GetAlarmSubscription("missing")
+	apiError := oranapi.AsAPIError(err)
+	assert.NotNil(t, apiError)
+	assert.Equal(t, http.StatusNotFound, apiError.Status)
+	assert.Equal(t, "subscription not found", apiError.Detail)
+
+	_, err = client.GetAlarmSubscription("plain")
+	apiError = oranapi.AsAPIError(err)
+	assert.NotNil(t, apiError)
+	assert.Equal(t, http.StatusInternalServerError, apiError.Status)
+	assert.Equal(t, "internal error", apiError.Detail)
+}
+
+func TestAlarmDictionaryDefinition(t *testing.T) {
+	dictionary := &AlarmDictionary{AlarmDefinition: []AlarmDefinition{
+		{AlarmDefinitionID: "first", AlarmName: "First"},
+		{AlarmDefinitionID: "second", AlarmName: "Second"},
+	}}
+
+	testCases := []struct {
+		id           string
+		expectedName string
+		expectedOk   bool
+	}{
+		{id: "first", expectedName: "First", expectedOk: true},
+		{id: "second", expectedName: "Second", expectedOk: true},
+		{id: "third", expectedOk: false},
+	}
+
+	for _, testCase := range testCases {
+		definition, ok := dictionary.Definition(testCase.id)
+		assert.Equal(t, testCase.expectedOk, ok)
+
+		if testCase.expectedOk {
+			assert.Equal(t, testCase.expectedName, definition.AlarmName)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package o2ims
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/oran/api/filter"
+)
+
+// InventoryEventType is the type of an inventory change notification.
+type InventoryEventType int
+
+const (
+	// InventoryEventCreate is the event type for a newly created inventory object.
+	InventoryEventCreate InventoryEventType = iota
+	// InventoryEventModify is the event type for a modified inventory object.
+	InventoryEventModify
+	// InventoryEventDelete is the event type for a deleted inventory object.
+	InventoryEventDelete
+)
+
+// String returns a human readable name for the event type.
+func (eventType InventoryEventType) String() string {
+	switch eventType {
+	case InventoryEventCreate:
+		return "CREATE"
+	case InventoryEventModify:
+		return "MODIFY"
+	case InventoryEventDelete:
+		return "DELETE"
+	default:
+		return fmt.Sprintf("UNKNOWN(%d)", int(eventType))
+	}
+}
+
+// ResourceType is a type of resource in the O2IMS inventory. Only the fields used by the tests are included.
+type ResourceType struct {
+	ResourceTypeID  string           `json:"resourceTypeId"`
+	Name            string           `json:"name"`
+	Vendor          string           `json:"vendor"`
+	Model           string           `json:"model"`
+	Version         string           `json:"version"`
+	AlarmDictionary *AlarmDictionary `json:"alarmDictionary,omitempty"`
+}
+
+// InventorySubscription is an inventory subscription. When creating a subscription, SubscriptionID should be left empty
+// since it is assigned by the server. The Filter uses the same syntax as the filter query parameter.
+type InventorySubscription struct {
+	SubscriptionID         string `json:"subscriptionId,omitempty"`
+	ConsumerSubscriptionID string `json:"consumerSubscriptionId,omitempty"`
+	Callback               string `json:"callback"`
+	Filter                 string `json:"filter,omitempty"`
+}
+
+// InventoryChangeNotification is the body of the notifications sent to inventory subscription callbacks. The prior
+// and post object states are left as raw JSON since their type depends on the object reference.
+type InventoryChangeNotification struct {
+	ConsumerSubscriptionID string             `json:"consumerSubscriptionId,omitempty"`
+	NotificationEventType  InventoryEventType `json:"notificationEventType"`
+	ObjectRef              string             `json:"objectRef"`
+	PriorObjectState       json.RawMessage    `json:"priorObjectState,omitempty"`
+	PostObjectState        json.RawMessage    `json:"postObjectState,omitempty"`
+}
+
+// ListResourceTypes lists all resource types in the inventory.
+func (client *Client) ListResourceTypes(filters ...filter.Filter) ([]ResourceType, error) {
+	var resourceTypes []ResourceType
+
+	err := client.do(http.MethodGet, inventoryPath+"/resourceTypes", filters, "", nil, &resourceTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource types: %w", err)
+	}
+
+	return resourceTypes, nil
+}
+
+// GetResourceTypeAlarmDictionary gets the alarm dictionary for the resource type with the provided ID.
+func (client *Client) GetResourceTypeAlarmDictionary(resourceTypeID string) (*AlarmDictionary, error) {
+	dictionary := &AlarmDictionary{}
+
+	err := client.do(
+		http.MethodGet, inventoryPath+"/resourceTypes/"+resourceTypeID+"/alarmDictionary", nil, "", nil, dictionary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alarm dictionary for resource type %s: %w", resourceTypeID, err)
+	}
+
+	return dictionary, nil
+}
+
+// ListInventorySubscriptions lists all inventory subscriptions.
+func (client *Client) ListInventorySubscriptions(filters ...filter.Filter) ([]InventorySubscription, error) {
+	var subscriptions []InventorySubscription
+
+	err := client.do(http.MethodGet, inventoryPath+"/subscriptions", filters, "", nil, &subscriptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inventory subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// CreateInventorySubscription creates the provided inventory subscription and returns it with the ID assigned by the
+// server.
+func (client *Client) CreateInventorySubscription(subscription InventorySubscription) (*InventorySubscription, error) {
+	created := &InventorySubscription{}
+
+	err := client.do(http.MethodPost, inventoryPath+"/subscriptions", nil, "", subscription, created)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create inventory subscription: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetInventorySubscription gets the inventory subscription with the provided ID.
+func (client *Client) GetInventorySubscription(id string) (*InventorySubscription, error) {
+	subscription := &InventorySubscription{}
+
+	err := client.do(http.MethodGet, inventoryPath+"/subscriptions/"+id, nil, "", nil, subscription)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory subscription %s: %w", id, err)
+	}
+
+	return subscription, nil
+}
+
+// DeleteInventorySubscription deletes the inventory subscription with the provided ID.
+func (client *Client) DeleteInventorySubscription(id string) error {
+	err := client.do(http.MethodDelete, inventoryPath+"/subscriptions/"+id, nil, "", nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete inventory subscription %s: %w", id, err)
+	}
+
+	return nil
+}
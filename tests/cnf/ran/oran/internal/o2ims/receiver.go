@@ -0,0 +1,346 @@
+package o2ims
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// NotificationKind is the kind of subscription a notification was sent for, based on the callback path it was received
+// on.
+type NotificationKind string
+
+const (
+	// NotificationKindAlarm is the kind of notifications sent to alarm subscription callbacks.
+	NotificationKindAlarm NotificationKind = "alarm"
+	// NotificationKindInventory is the kind of notifications sent to inventory subscription callbacks.
+	NotificationKindInventory NotificationKind = "inventory"
+)
+
+const (
+	// alarmCallbackPath is the path on the receiver that alarm subscription callbacks should use.
+	alarmCallbackPath = "/alarms"
+	// inventoryCallbackPath is the path on the receiver that inventory subscription callbacks should use.
+	inventoryCallbackPath = "/inventory"
+	// receiverPollInterval is how often to check for new notifications when waiting.
+	receiverPollInterval = 500 * time.Millisecond
+)
+
+// Notification is a single notification received by the Receiver, before being unmarshaled.
+type Notification struct {
+	Kind       NotificationKind
+	ReceivedAt time.Time
+	Body       json.RawMessage
+}
+
+// Receiver is a local HTTP server that acts as the callback for O2IMS subscriptions, recording every notification it
+// receives in order. It must be reachable from the O2IMS server at its callback URL.
+type Receiver struct {
+	callbackURL   string
+	listener      net.Listener
+	server        *http.Server
+	mutex         sync.Mutex
+	notifications []Notification
+}
+
+// NewReceiver starts a receiver listening on listenAddress. The callbackURL is the externally reachable URL of the
+// receiver that subscriptions use as their callback. When it uses https, the receiver serves TLS using a self-signed
+// certificate for the host of the callbackURL. If callbackURL is empty, it defaults to plain http on the address the
+// receiver listens on.
+func NewReceiver(listenAddress, callbackURL string) (*Receiver, error) {
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", listenAddress, err)
+	}
+
+	if callbackURL == "" {
+		callbackURL = "http://" + listener.Addr().String()
+	}
+
+	parsedURL, err := url.Parse(callbackURL)
+	if err != nil {
+		_ = listener.Close()
+
+		return nil, fmt.Errorf("failed to parse callback URL %s: %w", callbackURL, err)
+	}
+
+	receiver := &Receiver{
+		callbackURL: strings.TrimSuffix(callbackURL, "/"),
+		listener:    listener,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(alarmCallbackPath, receiver.handlerFor(NotificationKindAlarm))
+	mux.HandleFunc(inventoryCallbackPath, receiver.handlerFor(NotificationKindInventory))
+
+	receiver.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	if parsedURL.Scheme == "https" {
+		certificate, err := newSelfSignedCertificate(parsedURL.Hostname())
+		if err != nil {
+			_ = listener.Close()
+
+			return nil, err
+		}
+
+		receiver.server.TLSConfig = &tls.Config{Certificates: []tls.Certificate{certificate}, MinVersion: tls.VersionTLS12}
+		receiver.listener = tls.NewListener(listener, receiver.server.TLSConfig)
+	}
+
+	go func() {
+		err := receiver.server.Serve(receiver.listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			glog.V(logLevel).Infof("O2IMS notification receiver stopped unexpectedly: %v", err)
+		}
+	}()
+
+	glog.V(logLevel).Infof("Started O2IMS notification receiver on %s with callback URL %s", listener.Addr(), callbackURL)
+
+	return receiver, nil
+}
+
+// Close stops the receiver. Notifications already received are kept.
+func (receiver *Receiver) Close() error {
+	return receiver.server.Shutdown(context.TODO())
+}
+
+// Address returns the address the receiver is listening on.
+func (receiver *Receiver) Address() string {
+	return receiver.listener.Addr().String()
+}
+
+// AlarmCallback returns the callback URL to use for alarm subscriptions.
+func (receiver *Receiver) AlarmCallback() string {
+	return receiver.callbackURL + alarmCallbackPath
+}
+
+// InventoryCallback returns the callback URL to use for inventory subscriptions.
+func (receiver *Receiver) InventoryCallback() string {
+	return receiver.callbackURL + inventoryCallbackPath
+}
+
+// Notifications returns a copy of every notification received so far in the order they were received.
+func (receiver *Receiver) Notifications() []Notification {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	return append([]Notification{}, receiver.notifications...)
+}
+
+// Reset discards all notifications received so far.
+func (receiver *Receiver) Reset() {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	receiver.notifications = nil
+}
+
+// AlarmNotifications returns the alarm notifications for the provided consumerSubscriptionId in the order they were
+// received. If consumerSubscriptionID is empty, all alarm notifications are returned.
+func (receiver *Receiver) AlarmNotifications(consumerSubscriptionID string) ([]AlarmEventNotification, error) {
+	var alarmNotifications []AlarmEventNotification
+
+	for _, notification := range receiver.Notifications() {
+		if notification.Kind != NotificationKindAlarm {
+			continue
+		}
+
+		alarmNotification := AlarmEventNotification{}
+
+		err := json.Unmarshal(notification.Body, &alarmNotification)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal alarm notification %s: %w", string(notification.Body), err)
+		}
+
+		if consumerSubscriptionID == "" || alarmNotification.ConsumerSubscriptionID == consumerSubscriptionID {
+			alarmNotifications = append(alarmNotifications, alarmNotification)
+		}
+	}
+
+	return alarmNotifications, nil
+}
+
+// InventoryNotifications returns the inventory notifications for the provided consumerSubscriptionId in the order
+// they were received. If consumerSubscriptionID is empty, all inventory notifications are returned.
+func (receiver *Receiver) InventoryNotifications(consumerSubscriptionID string) ([]InventoryChangeNotification, error) {
+	var inventoryNotifications []InventoryChangeNotification
+
+	for _, notification := range receiver.Notifications() {
+		if notification.Kind != NotificationKindInventory {
+			continue
+		}
+
+		inventoryNotification := InventoryChangeNotification{}
+
+		err := json.Unmarshal(notification.Body, &inventoryNotification)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal inventory notification %s: %w", string(notification.Body), err)
+		}
+
+		if consumerSubscriptionID == "" || inventoryNotification.ConsumerSubscriptionID == consumerSubscriptionID {
+			inventoryNotifications = append(inventoryNotifications, inventoryNotification)
+		}
+	}
+
+	return inventoryNotifications, nil
+}
+
+// WaitForAlarmNotification waits up to timeout for an alarm notification for consumerSubscriptionID that matches
+// condition and returns it.
+func (receiver *Receiver) WaitForAlarmNotification(
+	consumerSubscriptionID string,
+	condition func(AlarmEventNotification) bool,
+	timeout time.Duration) (*AlarmEventNotification, error) {
+	var matched *AlarmEventNotification
+
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), receiverPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+			alarmNotifications, err := receiver.AlarmNotifications(consumerSubscriptionID)
+			if err != nil {
+				return false, err
+			}
+
+			for index := range alarmNotifications {
+				if condition(alarmNotifications[index]) {
+					matched = &alarmNotifications[index]
+
+					return true, nil
+				}
+			}
+
+			return false, nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for alarm notification for subscription %s: %w",
+			consumerSubscriptionID, err)
+	}
+
+	return matched, nil
+}
+
+// WaitForInventoryNotification waits up to timeout for an inventory notification for consumerSubscriptionID that
+// matches condition and returns it.
+func (receiver *Receiver) WaitForInventoryNotification(
+	consumerSubscriptionID string,
+	condition func(InventoryChangeNotification) bool,
+	timeout time.Duration) (*InventoryChangeNotification, error) {
+	var matched *InventoryChangeNotification
+
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), receiverPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+			inventoryNotifications, err := receiver.InventoryNotifications(consumerSubscriptionID)
+			if err != nil {
+				return false, err
+			}
+
+			for index := range inventoryNotifications {
+				if condition(inventoryNotifications[index]) {
+					matched = &inventoryNotifications[index]
+
+					return true, nil
+				}
+			}
+
+			return false, nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for inventory notification for subscription %s: %w",
+			consumerSubscriptionID, err)
+	}
+
+	return matched, nil
+}
+
+// AlarmEventTypes returns the event types of the notifications for the alarm with the provided alarmEventRecordId, in
+// the order they were received. It is used to assert on the order of notifications for a single alarm.
+func AlarmEventTypes(alarmNotifications []AlarmEventNotification, alarmEventRecordID string) []AlarmEventType {
+	var eventTypes []AlarmEventType
+
+	for _, notification := range alarmNotifications {
+		if notification.AlarmEventRecordID == alarmEventRecordID {
+			eventTypes = append(eventTypes, notification.NotificationEventType)
+		}
+	}
+
+	return eventTypes
+}
+
+// handlerFor returns the handler that records notifications of the provided kind. Only POST requests are recorded
+// but other methods still succeed so that any reachability check of the callback passes.
+func (receiver *Receiver) handlerFor(kind NotificationKind) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost {
+			writer.WriteHeader(http.StatusOK)
+
+			return
+		}
+
+		body, err := io.ReadAll(request.Body)
+		if err != nil || !json.Valid(body) {
+			glog.V(logLevel).Infof("Received invalid %s notification: %v", kind, err)
+			writer.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		glog.V(logLevel).Infof("Received %s notification: %s", kind, string(body))
+
+		receiver.mutex.Lock()
+		receiver.notifications = append(receiver.notifications, Notification{
+			Kind:       kind,
+			ReceivedAt: time.Now(),
+			Body:       body,
+		})
+		receiver.mutex.Unlock()
+
+		writer.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// newSelfSignedCertificate returns a self-signed certificate valid for host, which may be either an IP or DNS name.
+func newSelfSignedCertificate(host string) (tls.Certificate, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to generate receiver private key: %w", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: host},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		template.IPAddresses = []net.IP{ip}
+	} else {
+		template.DNSNames = []string{host}
+	}
+
+	certificateDER, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to create receiver certificate: %w", err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{certificateDER}, PrivateKey: privateKey}, nil
+}
@@ -0,0 +1,145 @@
+package o2ims
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiver(t *testing.T) {
+	testCases := []struct {
+		name        string
+		callbackURL string
+	}{
+		{
+			name:        "plain http",
+			callbackURL: "",
+		},
+		{
+			name:        "self-signed https",
+			callbackURL: "https://127.0.0.1:8443/",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			receiver, err := NewReceiver("127.0.0.1:0", testCase.callbackURL)
+			assert.Nil(t, err)
+
+			defer receiver.Close()
+
+			scheme := "http://"
+			if testCase.callbackURL != "" {
+				scheme = "https://"
+				assert.Equal(t, "https://127.0.0.1:8443/alarms", receiver.AlarmCallback())
+			}
+
+			assert.True(t, strings.HasSuffix(receiver.InventoryCallback(), inventoryCallbackPath))
+
+			// The receiver certificate is self-signed so verification must be skipped.
+			httpClient := &http.Client{Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			}}
+			address := scheme + receiver.Address()
+
+			alarmNotifications := []AlarmEventNotification{
+				{ConsumerSubscriptionID: "first", AlarmEventRecordID: "alarm", NotificationEventType: AlarmEventNew},
+				{ConsumerSubscriptionID: "second", AlarmEventRecordID: "alarm", NotificationEventType: AlarmEventNew},
+				{ConsumerSubscriptionID: "first", AlarmEventRecordID: "other", NotificationEventType: AlarmEventNew},
+				{ConsumerSubscriptionID: "first", AlarmEventRecordID: "alarm", NotificationEventType: AlarmEventAcknowledge},
+				{ConsumerSubscriptionID: "first", AlarmEventRecordID: "alarm", NotificationEventType: AlarmEventClear},
+			}
+
+			for _, notification := range alarmNotifications {
+				assert.Equal(t, http.StatusNoContent, postJSON(t, httpClient, address+alarmCallbackPath, notification))
+			}
+
+			inventoryNotification := InventoryChangeNotification{
+				ConsumerSubscriptionID: "inventory",
+				NotificationEventType:  InventoryEventModify,
+				ObjectRef:              "/o2ims-infrastructureInventory/v1/resourcePools/pool",
+			}
+			assert.Equal(t, http.StatusNoContent,
+				postJSON(t, httpClient, address+inventoryCallbackPath, inventoryNotification))
+
+			response, err := httpClient.Post(address+alarmCallbackPath, "application/json", strings.NewReader("{"))
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			_ = response.Body.Close()
+
+			response, err = httpClient.Get(address + alarmCallbackPath)
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+			_ = response.Body.Close()
+
+			assert.Len(t, receiver.Notifications(), 6)
+
+			first, err := receiver.AlarmNotifications("first")
+			assert.Nil(t, err)
+			assert.Len(t, first, 4)
+			assert.Equal(t,
+				[]AlarmEventType{AlarmEventNew, AlarmEventAcknowledge, AlarmEventClear}, AlarmEventTypes(first, "alarm"))
+
+			all, err := receiver.AlarmNotifications("")
+			assert.Nil(t, err)
+			assert.Len(t, all, 5)
+
+			inventory, err := receiver.InventoryNotifications("inventory")
+			assert.Nil(t, err)
+			assert.Len(t, inventory, 1)
+			assert.Equal(t, InventoryEventModify, inventory[0].NotificationEventType)
+
+			cleared, err := receiver.WaitForAlarmNotification("first", func(notification AlarmEventNotification) bool {
+				return notification.NotificationEventType == AlarmEventClear
+			}, time.Second)
+			assert.Nil(t, err)
+			assert.Equal(t, "alarm", cleared.AlarmEventRecordID)
+
+			_, err = receiver.WaitForAlarmNotification("second", func(notification AlarmEventNotification) bool {
+				return notification.NotificationEventType == AlarmEventClear
+			}, time.Second)
+			assert.NotNil(t, err)
+
+			receiver.Reset()
+			assert.Empty(t, receiver.Notifications())
+		})
+	}
+}
+
+func TestAlarmEventTypeString(t *testing.T) {
+	testCases := []struct {
+		eventType AlarmEventType
+		expected  string
+	}{
+		{eventType: AlarmEventNew, expected: "NEW"},
+		{eventType: AlarmEventChange, expected: "CHANGE"},
+		{eventType: AlarmEventClear, expected: "CLEAR"},
+		{eventType: AlarmEventAcknowledge, expected: "ACKNOWLEDGE"},
+		{eventType: AlarmEventType(7), expected: "UNKNOWN(7)"},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, testCase.eventType.String())
+	}
+}
+
+// postJSON marshals body and posts it to url, returning the status code of the response.
+func postJSON(t *testing.T, httpClient *http.Client, url string, body any) int {
+	t.Helper()
+
+	bodyBytes, err := json.Marshal(body)
+	assert.Nil(t, err)
+
+	response, err := httpClient.Post(url, "application/json", bytes.NewReader(bodyBytes))
+	assert.Nil(t, err)
+
+	defer response.Body.Close()
+
+	return response.StatusCode
+}
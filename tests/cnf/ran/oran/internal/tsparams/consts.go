@@ -1,6 +1,10 @@
 package tsparams
 
-import "github.com/golang/glog"
+import (
+	"time"
+
+	"github.com/golang/glog"
+)
 
 const (
 	// LabelSuite is the label applied to all cases in the oran suite.
@@ -14,6 +18,10 @@ const (
 	LabelPostProvision = "post-provision"
 	// LabelTemplateInventory is the label applied to just the template inventory test cases.
 	LabelTemplateInventory = "template-inventory"
+	// LabelAlarms is the label applied to just the alarm test cases.
+	LabelAlarms = "alarms"
+	// LabelInventorySubscriptions is the label applied to just the inventory subscription test cases.
+	LabelInventorySubscriptions = "inventory-subscriptions"
 )
 
 const (
@@ -84,5 +92,24 @@ const (
 	TestBase64Credential = "d3JvbmdwYXNzd29yZA=="
 )
 
+const (
+	// AlarmNamespace is the namespace on the spoke for the workload and PrometheusRule used to raise alarms. It is
+	// separate from TestName since it must be labeled for cluster monitoring.
+	AlarmNamespace = "oran-alarm-test"
+	// AlertName is the name of the alert in the test PrometheusRule on the spoke. It fires when the test Deployment
+	// has no available replicas.
+	AlertName = "OranTestWorkloadUnavailable"
+	// AlertFor is how long the test Deployment must be unavailable before the alert fires.
+	AlertFor = "1m"
+	// AlertSeverity is the severity label of the test alert. It maps to a critical O2IMS alarm.
+	AlertSeverity = "critical"
+	// AlarmTimeout is how long to wait for the O2IMS server to send an alarm notification after the condition for it
+	// changes. It includes the alert for duration and the delay in alerts reaching the hub.
+	AlarmTimeout = 10 * time.Minute
+	// NotificationTimeout is how long to wait for a notification caused directly by an API call, such as
+	// acknowledging an alarm.
+	NotificationTimeout = 2 * time.Minute
+)
+
 // LogLevel is the glog verbosity level to use for logs in this suite or its helpers.
 const LogLevel glog.Level = 80
@@ -26,7 +26,8 @@ var (
 
 	// ReporterSpokeNamespacesToDump tells the reporter which namespaces on the spoke to collect pod logs from.
 	ReporterSpokeNamespacesToDump = map[string]string{
-		TestName: "",
+		TestName:       "",
+		AlarmNamespace: "",
 	}
 
 	// ReporterHubCRsToDump is the CRs the reporter should dump on the hub.
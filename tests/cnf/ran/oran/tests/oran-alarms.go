@@ -0,0 +1,255 @@
+package tests
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	oranapi "github.com/rh-ecosystem-edge/eco-goinfra/pkg/oran/api"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/oran/api/filter"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/oran/internal/helper"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/oran/internal/o2ims"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/oran/internal/tsparams"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("ORAN Alarms Tests", Label(tsparams.LabelAlarms), func() {
+	var o2imsClient *o2ims.Client
+
+	BeforeEach(func() {
+		By("creating the O2IMS alarms client")
+		o2imsClient = helper.NewO2IMSClient()
+	})
+
+	// 82943 - Successfully list alarm dictionaries for all resource types
+	It("successfully lists alarm dictionaries for all resource types", reportxml.ID("82943"), func() {
+		By("listing all alarm dictionaries")
+		dictionaries, err := o2imsClient.ListAlarmDictionaries()
+		Expect(err).ToNot(HaveOccurred(), "Failed to list alarm dictionaries")
+		Expect(dictionaries).ToNot(BeEmpty(), "Expected at least one alarm dictionary")
+
+		By("verifying every alarm dictionary has uniquely identified alarm definitions")
+		for _, dictionary := range dictionaries {
+			Expect(dictionary.AlarmDefinition).ToNot(BeEmpty(),
+				"Alarm dictionary %s has no alarm definitions", dictionary.AlarmDictionaryID)
+
+			definitionIDs := make(map[string]bool)
+
+			for _, definition := range dictionary.AlarmDefinition {
+				Expect(definition.AlarmDefinitionID).ToNot(BeEmpty(),
+					"Alarm definition %s in dictionary %s has no ID", definition.AlarmName, dictionary.AlarmDictionaryID)
+				Expect(definition.AlarmName).ToNot(BeEmpty(),
+					"Alarm definition %s in dictionary %s has no name",
+					definition.AlarmDefinitionID, dictionary.AlarmDictionaryID)
+				Expect(definitionIDs).ToNot(HaveKey(definition.AlarmDefinitionID),
+					"Alarm definition %s is duplicated in dictionary %s",
+					definition.AlarmDefinitionID, dictionary.AlarmDictionaryID)
+
+				definitionIDs[definition.AlarmDefinitionID] = true
+			}
+		}
+
+		By("verifying the alarm dictionary of each resource type is listed")
+		resourceTypes, err := o2imsClient.ListResourceTypes()
+		Expect(err).ToNot(HaveOccurred(), "Failed to list resource types")
+
+		for _, resourceType := range resourceTypes {
+			if resourceType.AlarmDictionary == nil {
+				continue
+			}
+
+			dictionary, err := o2imsClient.GetResourceTypeAlarmDictionary(resourceType.ResourceTypeID)
+			Expect(err).ToNot(HaveOccurred(),
+				"Failed to get alarm dictionary for resource type %s", resourceType.ResourceTypeID)
+			Expect(dictionary.AlarmDictionaryID).To(Equal(resourceType.AlarmDictionary.AlarmDictionaryID),
+				"Alarm dictionary of resource type %s does not match", resourceType.ResourceTypeID)
+
+			found := slices.ContainsFunc(dictionaries, func(listed o2ims.AlarmDictionary) bool {
+				return listed.AlarmDictionaryID == dictionary.AlarmDictionaryID
+			})
+			Expect(found).To(BeTrue(), "Alarm dictionary %s of resource type %s was not listed",
+				dictionary.AlarmDictionaryID, resourceType.ResourceTypeID)
+		}
+	})
+
+	// 82944 - Successfully create, get, and delete an alarm subscription
+	It("successfully creates, gets, and deletes an alarm subscription", reportxml.ID("82944"), func() {
+		By("starting the notification receiver")
+		receiver, err := helper.NewO2IMSReceiver()
+		Expect(err).ToNot(HaveOccurred(), "Failed to start the notification receiver")
+
+		DeferCleanup(receiver.Close)
+
+		By("creating an alarm subscription")
+		consumerSubscriptionID := string(uuid.NewUUID())
+		subscription, err := o2imsClient.CreateAlarmSubscription(o2ims.AlarmSubscriptionInfo{
+			ConsumerSubscriptionID: consumerSubscriptionID,
+			Callback:               receiver.AlarmCallback(),
+			Filter:                 ptr.To(o2ims.AlarmFilterChange),
+		})
+		Expect(err).ToNot(HaveOccurred(), "Failed to create alarm subscription")
+		Expect(subscription.AlarmSubscriptionID).ToNot(BeEmpty(), "Created alarm subscription has no ID")
+
+		DeferCleanup(deleteAlarmSubscriptionIfExists, o2imsClient, subscription.AlarmSubscriptionID)
+
+		By("verifying the alarm subscription can be retrieved")
+		retrieved, err := o2imsClient.GetAlarmSubscription(subscription.AlarmSubscriptionID)
+		Expect(err).ToNot(HaveOccurred(), "Failed to get alarm subscription")
+		Expect(retrieved.ConsumerSubscriptionID).To(Equal(consumerSubscriptionID))
+		Expect(retrieved.Callback).To(Equal(receiver.AlarmCallback()))
+		Expect(retrieved.Filter).To(HaveValue(Equal(o2ims.AlarmFilterChange)))
+
+		By("verifying the alarm subscription is listed")
+		subscriptions, err := o2imsClient.ListAlarmSubscriptions()
+		Expect(err).ToNot(HaveOccurred(), "Failed to list alarm subscriptions")
+		Expect(subscriptions).To(ContainElement(HaveField("AlarmSubscriptionID", subscription.AlarmSubscriptionID)),
+			"Alarm subscription %s was not listed", subscription.AlarmSubscriptionID)
+
+		By("deleting the alarm subscription")
+		err = o2imsClient.DeleteAlarmSubscription(subscription.AlarmSubscriptionID)
+		Expect(err).ToNot(HaveOccurred(), "Failed to delete alarm subscription")
+
+		By("verifying the alarm subscription no longer exists")
+		_, err = o2imsClient.GetAlarmSubscription(subscription.AlarmSubscriptionID)
+		apiError := oranapi.AsAPIError(err)
+		Expect(apiError).ToNot(BeNil(), "Expected an API error getting the deleted alarm subscription, got %v", err)
+		Expect(apiError.Status).To(Equal(http.StatusNotFound), "Expected deleted alarm subscription to not be found")
+	})
+
+	// 82945 - Successfully notify subscribers in order when an alarm is raised, acknowledged, and cleared
+	It("notifies subscribers in order when an alarm is raised, acknowledged, and cleared",
+		reportxml.ID("82945"), func() {
+			By("starting the notification receiver")
+			receiver, err := helper.NewO2IMSReceiver()
+			Expect(err).ToNot(HaveOccurred(), "Failed to start the notification receiver")
+
+			DeferCleanup(receiver.Close)
+
+			By("creating an alarm subscription for all events")
+			allEventsID := string(uuid.NewUUID())
+			allEvents, err := o2imsClient.CreateAlarmSubscription(o2ims.AlarmSubscriptionInfo{
+				ConsumerSubscriptionID: allEventsID,
+				Callback:               receiver.AlarmCallback(),
+			})
+			Expect(err).ToNot(HaveOccurred(), "Failed to create alarm subscription for all events")
+
+			DeferCleanup(deleteAlarmSubscriptionIfExists, o2imsClient, allEvents.AlarmSubscriptionID)
+
+			By("creating an alarm subscription that filters out acknowledge events")
+			filteredID := string(uuid.NewUUID())
+			filtered, err := o2imsClient.CreateAlarmSubscription(o2ims.AlarmSubscriptionInfo{
+				ConsumerSubscriptionID: filteredID,
+				Callback:               receiver.AlarmCallback(),
+				Filter:                 ptr.To(o2ims.AlarmFilterAcknowledge),
+			})
+			Expect(err).ToNot(HaveOccurred(), "Failed to create alarm subscription filtering acknowledge events")
+
+			DeferCleanup(deleteAlarmSubscriptionIfExists, o2imsClient, filtered.AlarmSubscriptionID)
+
+			// Cleanup is registered first so a partially created workload is still deleted.
+			DeferCleanup(func() {
+				By("deleting the monitored workload and alert on spoke 1")
+				err := helper.DeleteAlarmWorkload(Spoke1APIClient, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred(), "Failed to delete the monitored workload on spoke 1")
+			})
+
+			By("creating the monitored workload and alert on spoke 1")
+			workload, err := helper.CreateAlarmWorkload(Spoke1APIClient, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred(), "Failed to create the monitored workload on spoke 1")
+
+			By("stopping the monitored workload to raise the alarm")
+			workload, err = workload.WithReplicas(0).Update()
+			Expect(err).ToNot(HaveOccurred(), "Failed to scale the monitored workload to zero")
+
+			By("waiting for the new alarm notification")
+			raised, err := receiver.WaitForAlarmNotification(allEventsID, func(notification o2ims.AlarmEventNotification) bool {
+				return helper.IsTestAlarm(notification) && notification.NotificationEventType == o2ims.AlarmEventNew
+			}, tsparams.AlarmTimeout)
+			Expect(err).ToNot(HaveOccurred(), "Failed to receive the new alarm notification")
+
+			alarmID := raised.AlarmEventRecordID
+
+			By("verifying the new alarm notification payload")
+			Expect(alarmID).ToNot(BeEmpty(), "New alarm notification has no alarm ID")
+			Expect(raised.AlarmDefinitionID).ToNot(BeEmpty(), "New alarm notification has no alarm definition")
+			Expect(raised.ResourceTypeID).ToNot(BeEmpty(), "New alarm notification has no resource type")
+			Expect(raised.AlarmRaisedTime).ToNot(BeZero(), "New alarm notification has no raised time")
+			Expect(raised.PerceivedSeverity).To(Equal(o2ims.SeverityCritical), "New alarm has the wrong severity")
+			Expect(raised.AlarmAcknowledged).To(BeFalse(), "New alarm is already acknowledged")
+
+			By("verifying the alarm is returned by the API")
+			alarm, err := o2imsClient.GetAlarm(alarmID)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get alarm %s", alarmID)
+			Expect(alarm.AlarmDefinitionID).To(Equal(raised.AlarmDefinitionID))
+			Expect(alarm.ResourceID).To(Equal(raised.ResourceID))
+			Expect(alarm.AlarmAcknowledged).To(BeFalse(), "Alarm %s is already acknowledged", alarmID)
+
+			alarms, err := o2imsClient.ListAlarms(filter.Equals("alarmEventRecordId", alarmID))
+			Expect(err).ToNot(HaveOccurred(), "Failed to list alarms filtered by ID")
+			Expect(alarms).To(HaveLen(1), "Expected exactly one alarm with ID %s", alarmID)
+
+			By("acknowledging the alarm")
+			modifications, err := o2imsClient.AcknowledgeAlarm(alarmID)
+			Expect(err).ToNot(HaveOccurred(), "Failed to acknowledge alarm %s", alarmID)
+			Expect(modifications.AlarmAcknowledged).To(HaveValue(BeTrue()), "Alarm acknowledgement was not accepted")
+
+			By("waiting for the acknowledge alarm notification")
+			_, err = receiver.WaitForAlarmNotification(allEventsID, func(notification o2ims.AlarmEventNotification) bool {
+				return notification.AlarmEventRecordID == alarmID &&
+					notification.NotificationEventType == o2ims.AlarmEventAcknowledge
+			}, tsparams.NotificationTimeout)
+			Expect(err).ToNot(HaveOccurred(), "Failed to receive the acknowledge alarm notification")
+
+			alarm, err = o2imsClient.GetAlarm(alarmID)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get alarm %s", alarmID)
+			Expect(alarm.AlarmAcknowledged).To(BeTrue(), "Alarm %s is not acknowledged", alarmID)
+			Expect(alarm.AlarmAcknowledgedTime).ToNot(BeNil(), "Alarm %s has no acknowledged time", alarmID)
+
+			By("restarting the monitored workload to clear the alarm")
+			_, err = workload.WithReplicas(1).Update()
+			Expect(err).ToNot(HaveOccurred(), "Failed to scale the monitored workload to one")
+
+			By("waiting for the clear alarm notification")
+			_, err = receiver.WaitForAlarmNotification(allEventsID, func(notification o2ims.AlarmEventNotification) bool {
+				return notification.AlarmEventRecordID == alarmID &&
+					notification.NotificationEventType == o2ims.AlarmEventClear
+			}, tsparams.AlarmTimeout)
+			Expect(err).ToNot(HaveOccurred(), "Failed to receive the clear alarm notification")
+
+			alarm, err = o2imsClient.GetAlarm(alarmID)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get alarm %s", alarmID)
+			Expect(alarm.AlarmClearedTime).ToNot(BeNil(), "Alarm %s has no cleared time", alarmID)
+
+			By("verifying the order of notifications for the subscription with all events")
+			allEventsNotifications, err := receiver.AlarmNotifications(allEventsID)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get notifications for subscription %s", allEventsID)
+			Expect(o2ims.AlarmEventTypes(allEventsNotifications, alarmID)).To(Equal([]o2ims.AlarmEventType{
+				o2ims.AlarmEventNew, o2ims.AlarmEventAcknowledge, o2ims.AlarmEventClear,
+			}), "Unexpected notifications for alarm %s", alarmID)
+
+			By("verifying the filtered subscription did not receive the acknowledge notification")
+			filteredNotifications, err := receiver.AlarmNotifications(filteredID)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get notifications for subscription %s", filteredID)
+			Expect(o2ims.AlarmEventTypes(filteredNotifications, alarmID)).To(Equal([]o2ims.AlarmEventType{
+				o2ims.AlarmEventNew, o2ims.AlarmEventClear,
+			}), "Unexpected notifications for alarm %s with acknowledge events filtered", alarmID)
+		})
+})
+
+// deleteAlarmSubscriptionIfExists deletes the alarm subscription with the provided ID, ignoring the error if it has
+// already been deleted.
+func deleteAlarmSubscriptionIfExists(o2imsClient *o2ims.Client, id string) {
+	By("deleting alarm subscription " + id)
+
+	err := o2imsClient.DeleteAlarmSubscription(id)
+	if apiError := oranapi.AsAPIError(err); apiError != nil && apiError.Status == http.StatusNotFound {
+		return
+	}
+
+	Expect(err).ToNot(HaveOccurred(), "Failed to delete alarm subscription %s", id)
+}
@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/ocm"
+	oranapi "github.com/rh-ecosystem-edge/eco-goinfra/pkg/oran/api"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/oran/internal/helper"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/oran/internal/o2ims"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/oran/internal/tsparams"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+var _ = Describe("ORAN Inventory Subscriptions Tests", Label(tsparams.LabelInventorySubscriptions), func() {
+	var (
+		o2imsClient *o2ims.Client
+		receiver    *o2ims.Receiver
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		By("creating the O2IMS inventory client")
+		o2imsClient = helper.NewO2IMSClient()
+
+		By("starting the notification receiver")
+		receiver, err = helper.NewO2IMSReceiver()
+		Expect(err).ToNot(HaveOccurred(), "Failed to start the notification receiver")
+
+		DeferCleanup(receiver.Close)
+	})
+
+	// 82946 - Successfully create, get, and delete an inventory subscription
+	It("successfully creates, gets, and deletes an inventory subscription", reportxml.ID("82946"), func() {
+		By("creating an inventory subscription")
+		consumerSubscriptionID := string(uuid.NewUUID())
+		subscription, err := o2imsClient.CreateInventorySubscription(o2ims.InventorySubscription{
+			ConsumerSubscriptionID: consumerSubscriptionID,
+			Callback:               receiver.InventoryCallback(),
+		})
+		Expect(err).ToNot(HaveOccurred(), "Failed to create inventory subscription")
+		Expect(subscription.SubscriptionID).ToNot(BeEmpty(), "Created inventory subscription has no ID")
+
+		DeferCleanup(deleteInventorySubscriptionIfExists, o2imsClient, subscription.SubscriptionID)
+
+		By("verifying the inventory subscription can be retrieved")
+		retrieved, err := o2imsClient.GetInventorySubscription(subscription.SubscriptionID)
+		Expect(err).ToNot(HaveOccurred(), "Failed to get inventory subscription")
+		Expect(retrieved.ConsumerSubscriptionID).To(Equal(consumerSubscriptionID))
+		Expect(retrieved.Callback).To(Equal(receiver.InventoryCallback()))
+
+		By("verifying the inventory subscription is listed")
+		subscriptions, err := o2imsClient.ListInventorySubscriptions()
+		Expect(err).ToNot(HaveOccurred(), "Failed to list inventory subscriptions")
+		Expect(subscriptions).To(ContainElement(HaveField("SubscriptionID", subscription.SubscriptionID)),
+			"Inventory subscription %s was not listed", subscription.SubscriptionID)
+
+		By("deleting the inventory subscription")
+		err = o2imsClient.DeleteInventorySubscription(subscription.SubscriptionID)
+		Expect(err).ToNot(HaveOccurred(), "Failed to delete inventory subscription")
+
+		By("verifying the inventory subscription no longer exists")
+		_, err = o2imsClient.GetInventorySubscription(subscription.SubscriptionID)
+		apiError := oranapi.AsAPIError(err)
+		Expect(apiError).ToNot(BeNil(), "Expected an API error getting the deleted inventory subscription, got %v", err)
+		Expect(apiError.Status).To(Equal(http.StatusNotFound), "Expected deleted inventory subscription to not be found")
+	})
+
+	// 82947 - Successfully notify inventory subscribers when the spoke cluster changes
+	It("notifies inventory subscribers when the spoke cluster changes", reportxml.ID("82947"), func() {
+		By("creating an inventory subscription")
+		consumerSubscriptionID := string(uuid.NewUUID())
+		subscription, err := o2imsClient.CreateInventorySubscription(o2ims.InventorySubscription{
+			ConsumerSubscriptionID: consumerSubscriptionID,
+			Callback:               receiver.InventoryCallback(),
+		})
+		Expect(err).ToNot(HaveOccurred(), "Failed to create inventory subscription")
+
+		DeferCleanup(deleteInventorySubscriptionIfExists, o2imsClient, subscription.SubscriptionID)
+
+		By("adding the test label to the spoke 1 ManagedCluster")
+		mcl, err := ocm.PullManagedCluster(HubAPIClient, RANConfig.Spoke1Name)
+		Expect(err).ToNot(HaveOccurred(), "Failed to pull spoke 1 ManagedCluster")
+
+		DeferCleanup(removeTestLabelIfExists)
+
+		mcl.Definition.Labels[tsparams.TestName] = tsparams.TestNewValue
+
+		_, err = mcl.Update()
+		Expect(err).ToNot(HaveOccurred(), "Failed to update spoke 1 ManagedCluster to add test label")
+
+		By("waiting for the modify inventory notification")
+		notification, err := receiver.WaitForInventoryNotification(consumerSubscriptionID,
+			func(notification o2ims.InventoryChangeNotification) bool {
+				return notification.NotificationEventType == o2ims.InventoryEventModify
+			}, tsparams.NotificationTimeout)
+		Expect(err).ToNot(HaveOccurred(), "Failed to receive the modify inventory notification")
+
+		By("verifying the modify inventory notification payload")
+		Expect(notification.ObjectRef).ToNot(BeEmpty(), "Modify inventory notification has no object reference")
+		Expect(notification.PriorObjectState).ToNot(BeEmpty(), "Modify inventory notification has no prior state")
+		Expect(notification.PostObjectState).ToNot(BeEmpty(), "Modify inventory notification has no post state")
+		Expect(notification.PostObjectState).ToNot(MatchJSON(notification.PriorObjectState),
+			"Modify inventory notification has the same prior and post state")
+	})
+})
+
+// deleteInventorySubscriptionIfExists deletes the inventory subscription with the provided ID, ignoring the error if
+// it has already been deleted.
+func deleteInventorySubscriptionIfExists(o2imsClient *o2ims.Client, id string) {
+	By("deleting inventory subscription " + id)
+
+	err := o2imsClient.DeleteInventorySubscription(id)
+	if apiError := oranapi.AsAPIError(err); apiError != nil && apiError.Status == http.StatusNotFound {
+		return
+	}
+
+	Expect(err).ToNot(HaveOccurred(), "Failed to delete inventory subscription %s", id)
+}
@@ -18,33 +18,8 @@ import (
 	"k8s.io/utils/cpuset"
 )
 
-// GetPowerState determines the power state from the workloadHints object of the PerformanceProfile.
-func GetPowerState(perfProfile *nto.Builder) (string, error) {
-	workloadHints := perfProfile.Definition.Spec.WorkloadHints
-
-	if workloadHints == nil {
-		// No workloadHints object -> default is Performance mode
-		return tsparams.PerformanceMode, nil
-	}
-
-	realTime := *workloadHints.RealTime
-	highPowerConsumption := *workloadHints.HighPowerConsumption
-	perPodPowerManagement := *workloadHints.PerPodPowerManagement
-
-	switch {
-	case realTime && !highPowerConsumption && !perPodPowerManagement:
-		return tsparams.PerformanceMode, nil
-	case realTime && highPowerConsumption && !perPodPowerManagement:
-		return tsparams.HighPerformanceMode, nil
-	case realTime && !highPowerConsumption && perPodPowerManagement:
-		return tsparams.PowerSavingMode, nil
-	default:
-		return "", fmt.Errorf("unknown workloadHints power state configuration")
-	}
-}
-
 // CollectPowerMetricsWithNoWorkload collects metrics with no workload.
-func CollectPowerMetricsWithNoWorkload(duration, interval time.Duration, tag string) (map[string]string, error) {
+func CollectPowerMetricsWithNoWorkload(duration, interval time.Duration, tag string) (*Series, error) {
 	glog.V(tsparams.LogLevel).Infof("Wait for %s for noworkload scenario", duration)
 
 	return collectPowerUsageMetrics(duration, interval, "noworkload", tag)
@@ -52,7 +27,7 @@ func CollectPowerMetricsWithNoWorkload(duration, interval time.Duration, tag str
 
 // CollectPowerMetricsWithSteadyWorkload collects power metrics with steady workload scenario.
 func CollectPowerMetricsWithSteadyWorkload(
-	duration, interval time.Duration, tag string, perfProfile *nto.Builder, nodeName string) (map[string]string, error) {
+	duration, interval time.Duration, tag string, perfProfile *nto.Builder, nodeName string) (*Series, error) {
 	// stressNg cpu count is roughly 75% of total isolated cores.
 	// 1 cpu will be used by other consumer pods, such as process-exporter, cnf-ran-gotests-priv.
 	isolatedCPUSet, err := cpuset.Parse(string(*perfProfile.Object.Spec.CPU.Isolated))
@@ -73,7 +48,7 @@ func CollectPowerMetricsWithSteadyWorkload(
 	}
 
 	glog.V(tsparams.LogLevel).Infof("Wait for %s for steadyworkload scenario", duration.String())
-	result, collectErr := collectPowerUsageMetrics(duration, interval, "steadyworkload", tag)
+	series, collectErr := collectPowerUsageMetrics(duration, interval, "steadyworkload", tag)
 
 	// Delete stress-ng pods regardless of whether collectPowerUsageMetrics failed.
 	for _, stressPod := range stressNgPods {
@@ -84,35 +59,67 @@ func CollectPowerMetricsWithSteadyWorkload(
 	}
 
 	// If deleting stress-ng pods was successful, still return error from collectPowerUsageMetrics.
-	return result, collectErr
+	return series, collectErr
+}
+
+// Statistics computes the power usage summary statistics of the series, keyed by metric name, scenario, and tag.
+func (series *Series) Statistics() (map[string]string, error) {
+	compMap, err := computePowerUsageStatistics(series.Watts(), series.Interval, series.Scenario, series.Tag)
+	if err != nil {
+		return compMap, err
+	}
+
+	compMap[fmt.Sprintf("%s_%s_%s", tsparams.RanPowerMetricFailedSamples, series.Scenario, series.Tag)] =
+		fmt.Sprintf("%d", series.FailedSamples())
+
+	return compMap, nil
 }
 
-// collectPowerUsageMetrics collects power usage metrics.
-func collectPowerUsageMetrics(duration, interval time.Duration, scenario, tag string) (map[string]string, error) {
-	var powerMeasurements []float64
+// collectPowerUsageMetrics collects a power usage sample every interval for duration. Failed samples are kept in the
+// series with their error. If enabled in the config, the CPU frequency and C-state residency of the node are sampled
+// alongside the power usage.
+func collectPowerUsageMetrics(duration, interval time.Duration, scenario, tag string) (*Series, error) {
+	series := NewSeries(scenario, tag, interval)
+
+	var sampler *nodeStatsSampler
+	if RANConfig.PowerNodeStats {
+		sampler = newNodeStatsSampler(Spoke1APIClient)
+	}
 
 	endTime := time.Now().Add(duration)
 	for time.Now().Before(endTime) {
+		sample := Sample{Time: time.Now(), Scenario: scenario, Tag: tag}
+
 		power, err := BMCClient.PowerUsage()
 		if err != nil {
-			glog.V(tsparams.LogLevel).Infof("error getting power usage: %w", err)
+			glog.V(tsparams.LogLevel).Infof("error getting power usage: %v", err)
+
+			sample.Error = err.Error()
+		} else {
+			sample.Watts = float64(power)
+		}
+
+		if sampler != nil {
+			err = sampler.Observe(&sample)
+			if err != nil {
+				glog.V(tsparams.LogLevel).Infof("error getting node stats: %v", err)
 
-			continue
+				sample.NodeStatsError = err.Error()
+			}
 		}
 
-		powerMeasurements = append(powerMeasurements, float64(power))
+		series.Samples = append(series.Samples, sample)
 
 		time.Sleep(interval)
 	}
 
 	glog.V(tsparams.LogLevel).Info("Finished collecting power usage, waiting for results")
 
-	if len(powerMeasurements) < 1 {
-		return nil, fmt.Errorf("no power usage metrics were retrieved")
+	if len(series.Watts()) < 1 {
+		return series, fmt.Errorf("no power usage metrics were retrieved out of %d samples", len(series.Samples))
 	}
 
-	// Compute power metrics.
-	return computePowerUsageStatistics(powerMeasurements, interval, scenario, tag)
+	return series, nil
 }
 
 // deployStressNgPods deploys the stress-ng workload pods.
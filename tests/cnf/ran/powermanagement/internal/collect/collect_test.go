@@ -0,0 +1,45 @@
+package collect
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/redfishsim"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectPowerUsageMetrics(t *testing.T) {
+	simulator := redfishsim.New()
+	defer simulator.Close()
+
+	originalBMCClient := BMCClient
+	BMCClient = simulator.BMC()
+
+	defer func() { BMCClient = originalBMCClient }()
+
+	simulator.SetPowerWatts(300)
+	simulator.FailNext(redfishsim.EndpointPower, 2, http.StatusInternalServerError)
+
+	series, err := collectPowerUsageMetrics(500*time.Millisecond, 50*time.Millisecond, "noworkload", "performance")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, series.FailedSamples())
+	assert.Greater(t, len(series.Watts()), 0)
+
+	for _, watts := range series.Watts() {
+		assert.Equal(t, 300.0, watts)
+	}
+
+	compMap, err := series.Statistics()
+	assert.Nil(t, err)
+	assert.Equal(t, "2", compMap["ranmetrics_power_failed_samples_noworkload_performance"])
+
+	// When every sample fails, the series is still returned so the errors can be exported.
+	simulator.FailNext(redfishsim.EndpointPower, -1, http.StatusInternalServerError)
+
+	series, err = collectPowerUsageMetrics(200*time.Millisecond, 50*time.Millisecond, "noworkload", "performance")
+	assert.NotNil(t, err)
+	assert.NotNil(t, series)
+	assert.Equal(t, len(series.Samples), series.FailedSamples())
+}
@@ -0,0 +1,124 @@
+package collect
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/stats"
+)
+
+// currentSource is the source shown in comparisons for series collected in the current run.
+const currentSource = "current"
+
+// ComparisonRow is the summary of a single series in a comparison.
+type ComparisonRow struct {
+	Source   string
+	Scenario string
+	Tag      string
+	Samples  int
+	Failed   int
+	Min      float64
+	Max      float64
+	Mean     float64
+	Median   float64
+	P95      float64
+	// MeanDeltaPercent is the difference between the mean of this row and the first row as a percentage of the
+	// first row.
+	MeanDeltaPercent float64
+}
+
+// Comparison summarizes the power usage of multiple series, such as the same scenario across runs or power modes.
+// The first row is the baseline that the others are compared to.
+type Comparison struct {
+	Rows []ComparisonRow
+}
+
+// Compare returns a comparison of all the provided series with the first one as the baseline. Series without any
+// successful samples are included with only their sample counts.
+func Compare(allSeries ...*Series) (*Comparison, error) {
+	if len(allSeries) == 0 {
+		return nil, fmt.Errorf("at least one power series is required for a comparison")
+	}
+
+	comparison := &Comparison{}
+
+	for _, series := range allSeries {
+		row := ComparisonRow{
+			Source:   series.Source,
+			Scenario: series.Scenario,
+			Tag:      series.Tag,
+			Samples:  len(series.Samples),
+			Failed:   series.FailedSamples(),
+		}
+
+		if row.Source == "" {
+			row.Source = currentSource
+		}
+
+		watts := series.Watts()
+		if len(watts) > 0 {
+			// Errors are impossible since there is at least one sample.
+			row.Min = slices.Min(watts)
+			row.Max = slices.Max(watts)
+			row.Mean, _ = stats.Mean(watts)
+			row.Median, _ = stats.Median(watts)
+			row.P95, _ = stats.Percentile(watts, 95)
+		}
+
+		if baseline := comparison.baseline(); baseline != nil && baseline.Mean != 0 {
+			row.MeanDeltaPercent = (row.Mean - baseline.Mean) / baseline.Mean * 100
+		}
+
+		comparison.Rows = append(comparison.Rows, row)
+	}
+
+	return comparison, nil
+}
+
+// CompareWithBaseline compares series with every series for the same scenario saved in baselineDir, such as from a
+// previous run or a run with a different power mode.
+func CompareWithBaseline(series *Series, baselineDir string) (*Comparison, error) {
+	baselines, err := LoadSeries(baselineDir)
+	if err != nil {
+		return nil, err
+	}
+
+	allSeries := []*Series{series}
+
+	for _, baseline := range baselines {
+		if baseline.Scenario == series.Scenario {
+			allSeries = append(allSeries, baseline)
+		}
+	}
+
+	return Compare(allSeries...)
+}
+
+// String returns the comparison as a table with one row per series.
+func (comparison *Comparison) String() string {
+	var builder strings.Builder
+
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "SOURCE\tSCENARIO\tTAG\tSAMPLES\tFAILED\tMIN\tMAX\tMEAN\tMEDIAN\tP95\tMEAN DELTA")
+
+	for _, row := range comparison.Rows {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%d\t%d\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\t%+.1f%%\n",
+			row.Source, row.Scenario, row.Tag, row.Samples, row.Failed,
+			row.Min, row.Max, row.Mean, row.Median, row.P95, row.MeanDeltaPercent)
+	}
+
+	_ = writer.Flush()
+
+	return builder.String()
+}
+
+// baseline returns the first row of the comparison, or nil if there are none.
+func (comparison *Comparison) baseline() *ComparisonRow {
+	if len(comparison.Rows) == 0 {
+		return nil
+	}
+
+	return &comparison.Rows[0]
+}
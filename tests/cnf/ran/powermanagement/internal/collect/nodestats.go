@@ -0,0 +1,147 @@
+package collect
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+)
+
+// nodeStatsCommand prints the current frequency of every CPU in kHz, one per line, followed by the name and cumulative
+// residency in microseconds of every idle state of every CPU, prefixed by their paths.
+const nodeStatsCommand = "cat /sys/devices/system/cpu/cpu[0-9]*/cpufreq/scaling_cur_freq; " +
+	"grep -H . /sys/devices/system/cpu/cpu[0-9]*/cpuidle/state[0-9]*/name " +
+	"/sys/devices/system/cpu/cpu[0-9]*/cpuidle/state[0-9]*/time"
+
+// nodeStatsSnapshot is the CPU frequency and cumulative C-state residency of a node at a single point in time.
+type nodeStatsSnapshot struct {
+	time           time.Time
+	frequenciesKHz []float64
+	// residencyMicroseconds maps each C-state name to its residency summed across all CPUs.
+	residencyMicroseconds map[string]uint64
+	cpus                  int
+}
+
+// nodeStatsSampler samples the CPU frequency and C-state residency of a single node from /sys. Since residency
+// is cumulative, each sample reports the residency since the previous one.
+type nodeStatsSampler struct {
+	client   *clients.Settings
+	previous *nodeStatsSnapshot
+}
+
+// newNodeStatsSampler returns a sampler for the single node cluster of client.
+func newNodeStatsSampler(client *clients.Settings) *nodeStatsSampler {
+	return &nodeStatsSampler{client: client}
+}
+
+// Observe samples the node stats and adds them to sample. The first sample only has the CPU frequency.
+func (sampler *nodeStatsSampler) Observe(sample *Sample) error {
+	output, err := cluster.ExecCommandOnSNOWithRetries(
+		sampler.client, ranparam.RetryCount, ranparam.RetryInterval, nodeStatsCommand)
+	if err != nil {
+		return fmt.Errorf("failed to get node stats: %w", err)
+	}
+
+	snapshot, err := parseNodeStats(output, time.Now())
+	if err != nil {
+		return err
+	}
+
+	applyNodeStats(sample, sampler.previous, snapshot)
+	sampler.previous = snapshot
+
+	return nil
+}
+
+// applyNodeStats sets the CPU frequency of sample from current and, if previous is not nil, the C-state residency as
+// the fraction of the elapsed CPU time spent in each state.
+func applyNodeStats(sample *Sample, previous, current *nodeStatsSnapshot) {
+	if len(current.frequenciesKHz) > 0 {
+		total := 0.0
+		for _, frequency := range current.frequenciesKHz {
+			total += frequency
+		}
+
+		sample.CPUFrequencyMHz = total / float64(len(current.frequenciesKHz)) / 1000
+	}
+
+	if previous == nil || current.cpus == 0 {
+		return
+	}
+
+	elapsed := float64(current.time.Sub(previous.time).Microseconds()) * float64(current.cpus)
+	if elapsed <= 0 {
+		return
+	}
+
+	sample.CStateResidency = make(map[string]float64)
+
+	for name, residency := range current.residencyMicroseconds {
+		// Residency is cumulative so it only decreases if the counters were reset, in which case this interval is
+		// skipped.
+		if residency < previous.residencyMicroseconds[name] {
+			continue
+		}
+
+		sample.CStateResidency[name] = float64(residency-previous.residencyMicroseconds[name]) / elapsed
+	}
+}
+
+// parseNodeStats parses the output of nodeStatsCommand.
+func parseNodeStats(output string, observedAt time.Time) (*nodeStatsSnapshot, error) {
+	snapshot := &nodeStatsSnapshot{time: observedAt, residencyMicroseconds: make(map[string]uint64)}
+
+	// Maps cpuN/stateM to the name and residency of that idle state.
+	stateNames := make(map[string]string)
+	stateTimes := make(map[string]uint64)
+	cpus := make(map[string]bool)
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		filePath, value, found := strings.Cut(line, ":")
+		if !found {
+			frequency, err := strconv.ParseFloat(line, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse cpu frequency %q: %w", line, err)
+			}
+
+			snapshot.frequenciesKHz = append(snapshot.frequenciesKHz, frequency)
+
+			continue
+		}
+
+		stateDir := path.Dir(filePath)
+		cpu := path.Base(path.Dir(path.Dir(stateDir)))
+		state := cpu + "/" + path.Base(stateDir)
+		cpus[cpu] = true
+
+		switch path.Base(filePath) {
+		case "name":
+			stateNames[state] = value
+		case "time":
+			residency, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse c-state residency %q: %w", line, err)
+			}
+
+			stateTimes[state] = residency
+		}
+	}
+
+	for state, name := range stateNames {
+		snapshot.residencyMicroseconds[name] += stateTimes[state]
+	}
+
+	snapshot.cpus = len(cpus)
+
+	return snapshot, nil
+}
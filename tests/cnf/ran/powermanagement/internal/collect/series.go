@@ -0,0 +1,226 @@
+package collect
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Sample is a single power usage measurement along with the node stats sampled at the same time, if enabled.
+type Sample struct {
+	Time     time.Time `json:"time"`
+	Scenario string    `json:"scenario"`
+	Tag      string    `json:"tag"`
+	// Watts is the instantaneous power usage reported by the BMC. It is zero when Error is set.
+	Watts float64 `json:"watts"`
+	// Error is the error from getting the power usage, if any.
+	Error string `json:"error,omitempty"`
+	// NodeStatsError is the error from getting the node stats, if any. The power usage is still valid when only
+	// this is set.
+	NodeStatsError string `json:"nodeStatsError,omitempty"`
+	// CPUFrequencyMHz is the mean current frequency across all CPUs on the node.
+	CPUFrequencyMHz float64 `json:"cpuFrequencyMHz,omitempty"`
+	// CStateResidency maps each C-state name to the fraction of time all CPUs spent in it since the previous sample.
+	CStateResidency map[string]float64 `json:"cStateResidency,omitempty"`
+}
+
+// Failed returns whether getting the power usage for this sample failed.
+func (sample Sample) Failed() bool {
+	return sample.Error != ""
+}
+
+// Series is every sample collected for a single scenario and tag.
+type Series struct {
+	Scenario string        `json:"scenario"`
+	Tag      string        `json:"tag"`
+	Interval time.Duration `json:"interval"`
+	Samples  []Sample      `json:"samples"`
+	// Source is where the series was loaded from. It is empty for series collected in the current run.
+	Source string `json:"-"`
+}
+
+// NewSeries returns an empty series for the provided scenario and tag.
+func NewSeries(scenario, tag string, interval time.Duration) *Series {
+	return &Series{Scenario: scenario, Tag: tag, Interval: interval}
+}
+
+// Watts returns the power usage of every sample that did not fail.
+func (series *Series) Watts() []float64 {
+	var watts []float64
+
+	for _, sample := range series.Samples {
+		if !sample.Failed() {
+			watts = append(watts, sample.Watts)
+		}
+	}
+
+	return watts
+}
+
+// FailedSamples returns the number of samples where getting the power usage failed.
+func (series *Series) FailedSamples() int {
+	failed := 0
+
+	for _, sample := range series.Samples {
+		if sample.Failed() {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+// FileName returns the name of the file for this series without an extension.
+func (series *Series) FileName() string {
+	return fmt.Sprintf("power_%s_%s", series.Scenario, series.Tag)
+}
+
+// WriteCSV writes the series to writer as CSV with a header row. Every C-state seen in any sample gets its own column.
+func (series *Series) WriteCSV(writer io.Writer) error {
+	cStates := make(map[string]bool)
+
+	for _, sample := range series.Samples {
+		for name := range sample.CStateResidency {
+			cStates[name] = true
+		}
+	}
+
+	cStateNames := slices.Sorted(maps.Keys(cStates))
+	header := []string{"time", "scenario", "tag", "watts", "error", "node_stats_error", "cpu_frequency_mhz"}
+
+	for _, name := range cStateNames {
+		header = append(header, "cstate_"+name)
+	}
+
+	csvWriter := csv.NewWriter(writer)
+
+	err := csvWriter.Write(header)
+	if err != nil {
+		return err
+	}
+
+	for _, sample := range series.Samples {
+		record := []string{
+			sample.Time.Format(time.RFC3339Nano),
+			sample.Scenario,
+			sample.Tag,
+			strconv.FormatFloat(sample.Watts, 'f', -1, 64),
+			sample.Error,
+			sample.NodeStatsError,
+			strconv.FormatFloat(sample.CPUFrequencyMHz, 'f', -1, 64),
+		}
+
+		for _, name := range cStateNames {
+			residency, ok := sample.CStateResidency[name]
+			if !ok {
+				record = append(record, "")
+
+				continue
+			}
+
+			record = append(record, strconv.FormatFloat(residency, 'f', 6, 64))
+		}
+
+		err = csvWriter.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+
+	return csvWriter.Error()
+}
+
+// WriteJSON writes the series to writer as indented JSON.
+func (series *Series) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(series)
+}
+
+// Save writes the series as both CSV and JSON to dir, creating it if necessary. It returns the paths of the files
+// written.
+func (series *Series) Save(dir string) ([]string, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create power series directory %s: %w", dir, err)
+	}
+
+	writers := []struct {
+		extension string
+		write     func(io.Writer) error
+	}{
+		{extension: ".csv", write: series.WriteCSV},
+		{extension: ".json", write: series.WriteJSON},
+	}
+
+	var paths []string
+
+	for _, writer := range writers {
+		path := filepath.Join(dir, series.FileName()+writer.extension)
+
+		err = writeFile(path, writer.write)
+		if err != nil {
+			return paths, err
+		}
+
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// LoadSeries reads every series saved as JSON by Save in dir. The Source of each series is set to its path.
+func LoadSeries(dir string) ([]*Series, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "power_*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var allSeries []*Series
+
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read power series %s: %w", path, err)
+		}
+
+		series := &Series{}
+
+		err = json.Unmarshal(content, series)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal power series %s: %w", path, err)
+		}
+
+		series.Source = path
+		allSeries = append(allSeries, series)
+	}
+
+	return allSeries, nil
+}
+
+// writeFile creates the file at path and writes to it using write.
+func writeFile(path string, write func(io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+
+	err = write(file)
+	if err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,133 @@
+package collect
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const nodeStatsOutput = `2000000
+3000000
+/sys/devices/system/cpu/cpu0/cpuidle/state0/name:POLL
+/sys/devices/system/cpu/cpu0/cpuidle/state1/name:C1
+/sys/devices/system/cpu/cpu1/cpuidle/state0/name:POLL
+/sys/devices/system/cpu/cpu1/cpuidle/state1/name:C1
+/sys/devices/system/cpu/cpu0/cpuidle/state0/time:100
+/sys/devices/system/cpu/cpu0/cpuidle/state1/time:1000
+/sys/devices/system/cpu/cpu1/cpuidle/state0/time:200
+/sys/devices/system/cpu/cpu1/cpuidle/state1/time:2000
+`
+
+func TestSeriesWriteCSV(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	series := buildSeries("noworkload", "performance", start, 100, 0, 110)
+	series.Samples[0].CPUFrequencyMHz = 2500
+	series.Samples[2].CStateResidency = map[string]float64{"C1": 0.25}
+
+	var buffer bytes.Buffer
+
+	err := series.WriteCSV(&buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, `time,scenario,tag,watts,error,node_stats_error,cpu_frequency_mhz,cstate_C1
+2025-01-01T00:00:00Z,noworkload,performance,100,,,2500,
+2025-01-01T00:00:30Z,noworkload,performance,0,bmc unavailable,,0,
+2025-01-01T00:01:00Z,noworkload,performance,110,,,0,0.250000
+`, buffer.String())
+}
+
+func TestSeriesSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	series := buildSeries("noworkload", "performance", start, 100, 0, 110)
+
+	paths, err := series.Save(dir)
+	assert.Nil(t, err)
+	assert.Len(t, paths, 2)
+
+	loaded, err := LoadSeries(dir)
+	assert.Nil(t, err)
+	assert.Len(t, loaded, 1)
+	assert.Equal(t, paths[1], loaded[0].Source)
+	assert.Equal(t, []float64{100, 110}, loaded[0].Watts())
+	assert.Equal(t, 1, loaded[0].FailedSamples())
+	assert.True(t, loaded[0].Samples[0].Time.Equal(start))
+}
+
+func TestNodeStats(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	previous, err := parseNodeStats(nodeStatsOutput, start)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, previous.cpus)
+	assert.Equal(t, []float64{2000000, 3000000}, previous.frequenciesKHz)
+	assert.Equal(t, map[string]uint64{"POLL": 300, "C1": 3000}, previous.residencyMicroseconds)
+
+	// Over one second, both CPUs spend half their time in C1 and a tenth in POLL.
+	current := &nodeStatsSnapshot{
+		time:                  start.Add(time.Second),
+		frequenciesKHz:        []float64{1000000, 1000000},
+		residencyMicroseconds: map[string]uint64{"POLL": 200300, "C1": 1003000},
+		cpus:                  2,
+	}
+
+	sample := Sample{}
+	applyNodeStats(&sample, nil, previous)
+	assert.Equal(t, 2500.0, sample.CPUFrequencyMHz)
+	assert.Nil(t, sample.CStateResidency)
+
+	sample = Sample{}
+	applyNodeStats(&sample, previous, current)
+	assert.Equal(t, 1000.0, sample.CPUFrequencyMHz)
+	assert.InDeltaMapValues(t, map[string]float64{"POLL": 0.1, "C1": 0.5}, sample.CStateResidency, 1e-9)
+
+	_, err = parseNodeStats("not a frequency\n", start)
+	assert.NotNil(t, err)
+}
+
+func TestCompare(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	current := buildSeries("noworkload", "powersaving", start, 90, 90, 0)
+	baseline := buildSeries("noworkload", "performance", start, 100, 100, 100)
+	baseline.Source = "baseline/power_noworkload_performance.json"
+
+	comparison, err := Compare(current, baseline)
+	assert.Nil(t, err)
+	assert.Len(t, comparison.Rows, 2)
+
+	assert.Equal(t, currentSource, comparison.Rows[0].Source)
+	assert.Equal(t, 3, comparison.Rows[0].Samples)
+	assert.Equal(t, 1, comparison.Rows[0].Failed)
+	assert.Equal(t, 90.0, comparison.Rows[0].Mean)
+	assert.Equal(t, 0.0, comparison.Rows[0].MeanDeltaPercent)
+
+	assert.Equal(t, baseline.Source, comparison.Rows[1].Source)
+	assert.Equal(t, 100.0, comparison.Rows[1].P95)
+	assert.InDelta(t, 100.0/9, comparison.Rows[1].MeanDeltaPercent, 1e-9)
+
+	_, err = Compare()
+	assert.NotNil(t, err)
+}
+
+// buildSeries returns a series with a sample every 30 seconds for each of watts. A value of zero is a failed sample.
+func buildSeries(scenario, tag string, start time.Time, watts ...float64) *Series {
+	series := NewSeries(scenario, tag, 30*time.Second)
+
+	for index, value := range watts {
+		sample := Sample{
+			Time:     start.Add(time.Duration(index) * series.Interval),
+			Scenario: scenario,
+			Tag:      tag,
+			Watts:    value,
+		}
+
+		if value == 0 {
+			sample.Error = "bmc unavailable"
+		}
+
+		series.Samples = append(series.Samples, sample)
+	}
+
+	return series
+}
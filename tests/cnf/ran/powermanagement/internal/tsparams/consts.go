@@ -15,16 +15,11 @@ const (
 	LabelCPUFrequency = "cpu-frequency"
 	// PowerSaveTimeout is the timeout value for power save tests.
 	PowerSaveTimeout = 10 * time.Minute
+	// PowerSeriesDir is the directory under the reports directory where every power usage sample is saved.
+	PowerSeriesDir = "power"
 	// TestingNamespace is the tests namespace.
 	TestingNamespace = "ran-test"
 
-	// PowerSavingMode is the name of the power saving power state.
-	PowerSavingMode = "powersaving"
-	// PerformanceMode is the name of the performance power state.
-	PerformanceMode = "performance"
-	// HighPerformanceMode is the name of the high performance power state.
-	HighPerformanceMode = "highperformance"
-
 	// IpmiDcmiPowerMinimumDuringSampling is the minimum power metric.
 	IpmiDcmiPowerMinimumDuringSampling = "minPower"
 	// IpmiDcmiPowerMaximumDuringSampling is the maximum power metric.
@@ -36,6 +31,8 @@ const (
 
 	// RanPowerMetricTotalSamples is the metric for total samples.
 	RanPowerMetricTotalSamples = "ranmetrics_power_total_samples"
+	// RanPowerMetricFailedSamples is the metric for samples where getting the power usage failed.
+	RanPowerMetricFailedSamples = "ranmetrics_power_failed_samples"
 	// RanPowerMetricSamplingIntervalSeconds is the metric for sampling interval.
 	RanPowerMetricSamplingIntervalSeconds = "ranmetrics_power_sampling_interval_seconds"
 	// RanPowerMetricMinInstantPower is the metric for minimum instantaneous power.
@@ -11,6 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 	performancev2 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/performanceprofile/v2"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nto"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranhelper"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/version"
@@ -33,7 +34,7 @@ var _ = Describe("CPU frequency tuning tests change the core frequencies of isol
 		)
 
 		BeforeEach(func() {
-			perfProfile, err = helper.GetPerformanceProfileWithCPUSet()
+			perfProfile, err = ranhelper.GetPerformanceProfileWithCPUSet(Spoke1APIClient)
 			Expect(err).ToNot(HaveOccurred(), "Failed to get performance profile")
 
 			By("getting isolated core ID")
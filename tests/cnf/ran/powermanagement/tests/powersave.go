@@ -2,6 +2,7 @@ package tests
 
 import (
 	"fmt"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
@@ -18,6 +19,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nto"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranhelper"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/powermanagement/internal/collect"
@@ -51,7 +53,7 @@ var _ = Describe("Per-core runtime power states tuning", Label(tsparams.LabelPow
 		}
 
 		nodeName = nodeList[0].Object.Name
-		perfProfile, err = helper.GetPerformanceProfileWithCPUSet()
+		perfProfile, err = ranhelper.GetPerformanceProfileWithCPUSet(Spoke1APIClient)
 		Expect(err).ToNot(HaveOccurred(), "Failed to get performance profile")
 
 		originalPerfProfileSpec = perfProfile.Object.Spec
@@ -62,7 +64,7 @@ var _ = Describe("Per-core runtime power states tuning", Label(tsparams.LabelPow
 			return
 		}
 
-		perfProfile, err = helper.GetPerformanceProfileWithCPUSet()
+		perfProfile, err = ranhelper.GetPerformanceProfileWithCPUSet(Spoke1APIClient)
 		Expect(err).ToNot(HaveOccurred(), "Failed to get performance profile")
 
 		if reflect.DeepEqual(perfProfile.Object.Spec, originalPerfProfileSpec) {
@@ -202,7 +204,7 @@ var _ = Describe("Per-core runtime power states tuning", Label(tsparams.LabelPow
 	Context("Collect power usage metrics", Ordered, func() {
 		var (
 			samplingInterval time.Duration
-			powerState       string
+			powerState       ranparam.PowerMode
 		)
 
 		BeforeAll(func() {
@@ -214,7 +216,7 @@ var _ = Describe("Per-core runtime power states tuning", Label(tsparams.LabelPow
 			Expect(err).ToNot(HaveOccurred(), "Failed to parse metric sampling interval")
 
 			// Determine power state to be used as a tag for the metric
-			powerState, err = collect.GetPowerState(perfProfile)
+			powerState, err = ranhelper.GetPowerMode(perfProfile)
 			Expect(err).ToNot(HaveOccurred(), "Failed to get power state for the performance profile")
 		})
 
@@ -222,9 +224,13 @@ var _ = Describe("Per-core runtime power states tuning", Label(tsparams.LabelPow
 			duration, err := time.ParseDuration(RANConfig.NoWorkloadDuration)
 			Expect(err).ToNot(HaveOccurred(), "Failed to parse no workload duration")
 
-			compMap, err := collect.CollectPowerMetricsWithNoWorkload(duration, samplingInterval, powerState)
+			series, err := collect.CollectPowerMetricsWithNoWorkload(duration, samplingInterval, string(powerState))
+			exportPowerSeries(series)
 			Expect(err).ToNot(HaveOccurred(), "Failed to collect power metrics with no workload")
 
+			compMap, err := series.Statistics()
+			Expect(err).ToNot(HaveOccurred(), "Failed to compute power usage statistics with no workload")
+
 			// Persist power usage metric to ginkgo report for further processing in pipeline.
 			for metricName, metricValue := range compMap {
 				GinkgoWriter.Printf("%s: %s\n", metricName, metricValue)
@@ -235,10 +241,14 @@ var _ = Describe("Per-core runtime power states tuning", Label(tsparams.LabelPow
 			duration, err := time.ParseDuration(RANConfig.WorkloadDuration)
 			Expect(err).ToNot(HaveOccurred(), "Failed to parse steady workload duration")
 
-			compMap, err := collect.CollectPowerMetricsWithSteadyWorkload(
-				duration, samplingInterval, powerState, perfProfile, nodeName)
+			series, err := collect.CollectPowerMetricsWithSteadyWorkload(
+				duration, samplingInterval, string(powerState), perfProfile, nodeName)
+			exportPowerSeries(series)
 			Expect(err).ToNot(HaveOccurred(), "Failed to collect power metrics with steady workload")
 
+			compMap, err := series.Statistics()
+			Expect(err).ToNot(HaveOccurred(), "Failed to compute power usage statistics with steady workload")
+
 			// Persist power usage metric to ginkgo report for further processing in pipeline.
 			for metricName, metricValue := range compMap {
 				GinkgoWriter.Printf("%s: %s\n", metricName, metricValue)
@@ -247,6 +257,30 @@ var _ = Describe("Per-core runtime power states tuning", Label(tsparams.LabelPow
 	})
 })
 
+// exportPowerSeries saves every sample of series under the reports directory and, if a baseline directory is
+// configured, reports a comparison with the series for the same scenario from the baseline. It does nothing when
+// series is nil, such as when the stress-ng pods could not be deployed.
+func exportPowerSeries(series *collect.Series) {
+	if series == nil {
+		return
+	}
+
+	paths, err := series.Save(filepath.Join(RANConfig.ReportsDirAbsPath, tsparams.PowerSeriesDir))
+	Expect(err).ToNot(HaveOccurred(), "Failed to save power series for %s", series.Scenario)
+
+	GinkgoWriter.Printf("Saved power series for %s to %v\n", series.Scenario, paths)
+
+	if RANConfig.PowerBaselineDir == "" {
+		return
+	}
+
+	comparison, err := collect.CompareWithBaseline(series, RANConfig.PowerBaselineDir)
+	Expect(err).ToNot(HaveOccurred(), "Failed to compare power series for %s with baseline", series.Scenario)
+
+	GinkgoWriter.Print(comparison.String())
+	AddReportEntry("power-comparison-"+series.Scenario, comparison.String())
+}
+
 // checkCPUGovernorsAndResumeLatency checks power and latency settings of the cpus.
 func checkCPUGovernorsAndResumeLatency(cpus []int, pmQos, governor string) {
 	for _, cpu := range cpus {
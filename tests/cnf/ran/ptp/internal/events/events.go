@@ -0,0 +1,338 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogPrefix is the prefix of every line in the consumer pod logs that contains an event. The rest of the line is the
+// event as JSON.
+const LogPrefix = "EVENT "
+
+// EventType is the type of a PTP cloud event.
+type EventType string
+
+const (
+	// TypeLockState is the type of events for changes in the lock state of ptp4l.
+	TypeLockState EventType = "event.sync.ptp-status.ptp-state-change"
+	// TypeClockClass is the type of events for changes in the clock class advertised by the node.
+	TypeClockClass EventType = "event.sync.ptp-status.ptp-clock-class-change"
+	// TypeOsClockSyncState is the type of events for changes in the sync state of the system clock.
+	TypeOsClockSyncState EventType = "event.sync.sync-status.os-clock-sync-state-change"
+	// TypeGNSSSyncState is the type of events for changes in the sync state of the GNSS receiver.
+	TypeGNSSSyncState EventType = "event.sync.gnss-status.gnss-state-change"
+	// TypeSyncState is the type of events for changes in the overall sync state of the node.
+	TypeSyncState EventType = "event.sync.sync-status.synchronization-state-change"
+)
+
+// Resource is the resource address of an event, without the /cluster/node/<node> prefix.
+type Resource string
+
+const (
+	// ResourceLockState is the resource for TypeLockState events.
+	ResourceLockState Resource = "/sync/ptp-status/lock-state"
+	// ResourceClockClass is the resource for TypeClockClass events.
+	ResourceClockClass Resource = "/sync/ptp-status/clock-class"
+	// ResourceOsClockSyncState is the resource for TypeOsClockSyncState events.
+	ResourceOsClockSyncState Resource = "/sync/sync-status/os-clock-sync-state"
+	// ResourceGNSSSyncState is the resource for TypeGNSSSyncState events.
+	ResourceGNSSSyncState Resource = "/sync/gnss-status/gnss-sync-status"
+	// ResourceSyncState is the resource for TypeSyncState events.
+	ResourceSyncState Resource = "/sync/sync-status/sync-state"
+)
+
+// Resources is every resource the PTP events tests subscribe to.
+var Resources = []Resource{
+	ResourceLockState, ResourceClockClass, ResourceOsClockSyncState, ResourceGNSSSyncState, ResourceSyncState,
+}
+
+// Address returns the full resource address of resource on nodeName, as used in subscriptions.
+func (resource Resource) Address(nodeName string) string {
+	return fmt.Sprintf("/cluster/node/%s%s", nodeName, resource)
+}
+
+const (
+	// StateLocked is the value of sync state events when the clock is locked.
+	StateLocked = "LOCKED"
+	// StateHoldover is the value of sync state events when the clock has lost its source but is within holdover.
+	StateHoldover = "HOLDOVER"
+	// StateFreerun is the value of sync state events when the clock is not synchronized.
+	StateFreerun = "FREERUN"
+	// StateSynchronized is the value of GNSS sync state events when the receiver is synchronized.
+	StateSynchronized = "SYNCHRONIZED"
+)
+
+const (
+	// dataTypeNotification is the data type of event values that hold a state.
+	dataTypeNotification = "notification"
+	// dataTypeMetric is the data type of event values that hold a number.
+	dataTypeMetric = "metric"
+)
+
+// Event is a PTP cloud event as received by the consumer.
+type Event struct {
+	ID     string    `json:"id"`
+	Type   EventType `json:"type"`
+	Source string    `json:"source"`
+	Time   time.Time `json:"time"`
+	Data   Data      `json:"data"`
+}
+
+// Data is the data of a PTP cloud event.
+type Data struct {
+	Version string  `json:"version"`
+	Values  []Value `json:"values"`
+}
+
+// Value is a single value in the data of a PTP cloud event. State events have a notification value and usually a
+// metric value with the offset, while clock class events only have a metric value.
+type Value struct {
+	ResourceAddress string `json:"ResourceAddress"`
+	DataType        string `json:"data_type"`
+	ValueType       string `json:"value_type"`
+	// Value is the value as a string, even when it is a number in the event.
+	Value string `json:"-"`
+}
+
+// UnmarshalJSON unmarshals a Value, accepting either a string or a number for its value.
+func (value *Value) UnmarshalJSON(data []byte) error {
+	type plainValue Value
+
+	var raw struct {
+		*plainValue
+		Value json.RawMessage `json:"value"`
+	}
+
+	raw.plainValue = (*plainValue)(value)
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	var text string
+
+	err = json.Unmarshal(raw.Value, &text)
+	if err == nil {
+		value.Value = text
+
+		return nil
+	}
+
+	value.Value = strings.TrimSpace(string(raw.Value))
+
+	return nil
+}
+
+// State returns the value of the first notification value of the event, or, if there is none, the value of the first
+// metric. For clock class events this is the clock class.
+func (event Event) State() string {
+	for _, value := range event.Data.Values {
+		if value.DataType == dataTypeNotification {
+			return value.Value
+		}
+	}
+
+	for _, value := range event.Data.Values {
+		if value.DataType == dataTypeMetric {
+			return value.Value
+		}
+	}
+
+	return ""
+}
+
+// Metric returns the first metric value of the event as a number.
+func (event Event) Metric() (float64, error) {
+	for _, value := range event.Data.Values {
+		if value.DataType == dataTypeMetric {
+			return strconv.ParseFloat(value.Value, 64)
+		}
+	}
+
+	return 0, fmt.Errorf("event %s of type %s has no metric value", event.ID, event.Type)
+}
+
+// String returns the time, type, and state of the event.
+func (event Event) String() string {
+	return fmt.Sprintf("%s %s %s", event.Time.Format(time.RFC3339Nano), event.Type, event.State())
+}
+
+// ParseLog returns every event in the logs of the consumer pod, in the order they were received. Lines without
+// LogPrefix are ignored.
+func ParseLog(log string) ([]Event, error) {
+	var events []Event
+
+	scanner := bufio.NewScanner(strings.NewReader(log))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		eventJSON, found := strings.CutPrefix(line, LogPrefix)
+		if !found {
+			continue
+		}
+
+		var event Event
+
+		err := json.Unmarshal([]byte(eventJSON), &event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event %q: %w", eventJSON, err)
+		}
+
+		events = append(events, event)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read consumer logs: %w", err)
+	}
+
+	return events, nil
+}
+
+// Since returns the events sent at or after since.
+func Since(events []Event, since time.Time) []Event {
+	var filtered []Event
+
+	for _, event := range events {
+		if !event.Time.Before(since) {
+			filtered = append(filtered, event)
+		}
+	}
+
+	return filtered
+}
+
+// Filter returns the events of eventType sent at or after since.
+func Filter(events []Event, eventType EventType, since time.Time) []Event {
+	var filtered []Event
+
+	for _, event := range Since(events, since) {
+		if event.Type == eventType {
+			filtered = append(filtered, event)
+		}
+	}
+
+	return filtered
+}
+
+// Transition is an expected event in a sequence of events.
+type Transition struct {
+	Type EventType
+	// States are the states, as returned by Event.State, the event may have.
+	States []string
+	// Not inverts States so the event must have a state not in States.
+	Not bool
+}
+
+// To returns a Transition to an event of eventType with any of states.
+func To(eventType EventType, states ...string) Transition {
+	return Transition{Type: eventType, States: states}
+}
+
+// AwayFrom returns a Transition to an event of eventType with a state that is not any of states.
+func AwayFrom(eventType EventType, states ...string) Transition {
+	return Transition{Type: eventType, States: states, Not: true}
+}
+
+// Matches returns whether event satisfies the transition.
+func (transition Transition) Matches(event Event) bool {
+	if event.Type != transition.Type {
+		return false
+	}
+
+	state := event.State()
+	matched := slices.ContainsFunc(transition.States, func(expected string) bool {
+		return sameState(state, expected)
+	})
+
+	return matched != transition.Not
+}
+
+// String returns the expected type and states of the transition.
+func (transition Transition) String() string {
+	if transition.Not {
+		return fmt.Sprintf("%s not in %v", transition.Type, transition.States)
+	}
+
+	return fmt.Sprintf("%s in %v", transition.Type, transition.States)
+}
+
+// FindSequence returns the events matching each transition, where the events are in the same order as the
+// transitions but other events may come between them. It returns an error listing the events received if the
+// sequence is not found.
+func FindSequence(events []Event, transitions ...Transition) ([]Event, error) {
+	var matched []Event
+
+	next := 0
+
+	for _, event := range events {
+		if next == len(transitions) {
+			break
+		}
+
+		if transitions[next].Matches(event) {
+			matched = append(matched, event)
+			next++
+		}
+	}
+
+	if next < len(transitions) {
+		return matched, fmt.Errorf("did not find event %s after %d matched events, received events:\n%s",
+			transitions[next], next, Describe(events))
+	}
+
+	return matched, nil
+}
+
+// RecoveryTime returns the time between the first event of eventType at or after since that does not have state and
+// the first following event of eventType that does. It returns an error if the clock never left state or never
+// returned to it.
+func RecoveryTime(events []Event, eventType EventType, since time.Time, state string) (time.Duration, error) {
+	matched, err := FindSequence(Filter(events, eventType, since), AwayFrom(eventType, state), To(eventType, state))
+	if err != nil {
+		return 0, fmt.Errorf("failed to find recovery to %s: %w", state, err)
+	}
+
+	return matched[1].Time.Sub(matched[0].Time), nil
+}
+
+// Describe returns each event on its own line, for use in failure messages.
+func Describe(events []Event) string {
+	if len(events) == 0 {
+		return "\tno events"
+	}
+
+	var lines []string
+	for _, event := range events {
+		lines = append(lines, "\t"+event.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// sameState returns whether two states are equal. States that are both numbers, such as clock classes, are compared
+// numerically so 6 and 6.000 are the same.
+func sameState(first, second string) bool {
+	if first == second {
+		return true
+	}
+
+	firstNumber, err := strconv.ParseFloat(first, 64)
+	if err != nil {
+		return false
+	}
+
+	secondNumber, err := strconv.ParseFloat(second, 64)
+	if err != nil {
+		return false
+	}
+
+	return firstNumber == secondNumber
+}
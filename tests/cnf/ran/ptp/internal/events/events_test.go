@@ -0,0 +1,175 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const consumerLog = `Listening on port 8989
+EVENT {"id":"1","type":"event.sync.ptp-status.ptp-state-change","source":"/sync/ptp-status/lock-state",` +
+	`"time":"2024-01-01T00:00:00Z","data":{"version":"1.0","values":[{"ResourceAddress":` +
+	`"/cluster/node/sno/ens1f0/master","data_type":"notification","value_type":"enumeration","value":"LOCKED"},` +
+	`{"ResourceAddress":"/cluster/node/sno/ens1f0/master","data_type":"metric","value_type":"decimal64.3",` +
+	`"value":"-3"}]}}
+EVENT {"id":"2","type":"event.sync.ptp-status.ptp-clock-class-change","source":"/sync/ptp-status/clock-class",` +
+	`"time":"2024-01-01T00:00:10Z","data":{"version":"1.0","values":[{"ResourceAddress":` +
+	`"/cluster/node/sno/sync/ptp-status/clock-class","data_type":"metric","value_type":"decimal64.3","value":6}]}}
+EVENT {"id":"3","type":"event.sync.ptp-status.ptp-state-change","source":"/sync/ptp-status/lock-state",` +
+	`"time":"2024-01-01T00:01:00Z","data":{"version":"1.0","values":[{"ResourceAddress":` +
+	`"/cluster/node/sno/ens1f0/master","data_type":"notification","value_type":"enumeration","value":"HOLDOVER"}]}}
+EVENT {"id":"4","type":"event.sync.ptp-status.ptp-state-change","source":"/sync/ptp-status/lock-state",` +
+	`"time":"2024-01-01T00:01:30Z","data":{"version":"1.0","values":[{"ResourceAddress":` +
+	`"/cluster/node/sno/ens1f0/master","data_type":"notification","value_type":"enumeration","value":"FREERUN"}]}}
+EVENT {"id":"5","type":"event.sync.ptp-status.ptp-state-change","source":"/sync/ptp-status/lock-state",` +
+	`"time":"2024-01-01T00:02:15Z","data":{"version":"1.0","values":[{"ResourceAddress":` +
+	`"/cluster/node/sno/ens1f0/master","data_type":"notification","value_type":"enumeration","value":"LOCKED"}]}}
+`
+
+func TestParseLog(t *testing.T) {
+	testCases := []struct {
+		name           string
+		log            string
+		expectedStates []string
+		expectedError  bool
+	}{
+		{
+			name:           "consumer log",
+			log:            consumerLog,
+			expectedStates: []string{"LOCKED", "6", "HOLDOVER", "FREERUN", "LOCKED"},
+		},
+		{
+			name:           "no events",
+			log:            "Listening on port 8989\n",
+			expectedStates: nil,
+		},
+		{
+			name:          "invalid event",
+			log:           "EVENT {\"id\":\n",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			events, err := ParseLog(testCase.log)
+			if testCase.expectedError {
+				assert.NotNil(t, err)
+
+				return
+			}
+
+			assert.Nil(t, err)
+
+			var states []string
+			for _, event := range events {
+				states = append(states, event.State())
+			}
+
+			assert.Equal(t, testCase.expectedStates, states)
+		})
+	}
+}
+
+func TestEventValues(t *testing.T) {
+	events, err := ParseLog(consumerLog)
+	assert.Nil(t, err)
+
+	offset, err := events[0].Metric()
+	assert.Nil(t, err)
+	assert.Equal(t, -3.0, offset)
+
+	clockClass, err := events[1].Metric()
+	assert.Nil(t, err)
+	assert.Equal(t, 6.0, clockClass)
+
+	_, err = events[2].Metric()
+	assert.NotNil(t, err)
+
+	assert.Equal(t, "/cluster/node/sno/sync/ptp-status/lock-state", ResourceLockState.Address("sno"))
+	assert.Len(t, Since(events, events[2].Time), 3)
+	assert.Len(t, Filter(events, TypeLockState, events[1].Time), 3)
+}
+
+func TestFindSequence(t *testing.T) {
+	events, err := ParseLog(consumerLog)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		name          string
+		transitions   []Transition
+		expectedIDs   []string
+		expectedError bool
+	}{
+		{
+			name: "holdover then locked",
+			transitions: []Transition{
+				To(TypeLockState, StateHoldover, StateFreerun),
+				To(TypeLockState, StateLocked),
+			},
+			expectedIDs: []string{"3", "5"},
+		},
+		{
+			name: "away from locked across types",
+			transitions: []Transition{
+				To(TypeClockClass, "6"),
+				AwayFrom(TypeLockState, StateLocked),
+				To(TypeLockState, StateLocked),
+			},
+			expectedIDs: []string{"2", "3", "5"},
+		},
+		{
+			name:        "numeric clock class",
+			transitions: []Transition{To(TypeClockClass, "6.000"), AwayFrom(TypeLockState, StateLocked)},
+			expectedIDs: []string{"2", "3"},
+		},
+		{
+			name:          "out of order",
+			transitions:   []Transition{To(TypeLockState, StateFreerun), To(TypeLockState, StateHoldover)},
+			expectedError: true,
+		},
+		{
+			name:          "missing type",
+			transitions:   []Transition{To(TypeGNSSSyncState, StateSynchronized)},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			matched, err := FindSequence(events, testCase.transitions...)
+			if testCase.expectedError {
+				assert.NotNil(t, err)
+
+				return
+			}
+
+			assert.Nil(t, err)
+
+			var ids []string
+			for _, event := range matched {
+				ids = append(ids, event.ID)
+			}
+
+			assert.Equal(t, testCase.expectedIDs, ids)
+		})
+	}
+}
+
+func TestRecoveryTime(t *testing.T) {
+	events, err := ParseLog(consumerLog)
+	assert.Nil(t, err)
+
+	start := time.Date(2024, 1, 1, 0, 0, 30, 0, time.UTC)
+
+	recovery, err := RecoveryTime(events, TypeLockState, start, StateLocked)
+	assert.Nil(t, err)
+	assert.Equal(t, 75*time.Second, recovery)
+
+	_, err = RecoveryTime(events, TypeLockState, start.Add(2*time.Minute), StateLocked)
+	assert.NotNil(t, err)
+
+	_, err = RecoveryTime(events, TypeClockClass, time.Time{}, "6")
+	assert.NotNil(t, err)
+}
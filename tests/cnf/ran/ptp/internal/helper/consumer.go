@@ -0,0 +1,155 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/events"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
+)
+
+// consumerScript is run by the consumer pod to receive events. It prints each event on its own line with
+// events.LogPrefix. Events sent in binary mode have their attributes in ce- headers, so they are combined with the body
+// to produce the same JSON as events sent in structured mode.
+const consumerScript = `
+import http.server, json, sys
+
+class Handler(http.server.BaseHTTPRequestHandler):
+    def do_POST(self):
+        body = self.rfile.read(int(self.headers.get("Content-Length", 0)) or 0)
+        try:
+            event = json.loads(body) if body else {}
+        except ValueError:
+            self.send_response(400)
+            self.end_headers()
+            return
+        if "ce-type" in self.headers:
+            event = {"id": self.headers.get("ce-id"), "type": self.headers.get("ce-type"),
+                     "source": self.headers.get("ce-source"), "time": self.headers.get("ce-time"), "data": event}
+        if event:
+            print("EVENT " + json.dumps(event), flush=True)
+        self.send_response(204)
+        self.end_headers()
+
+    def do_GET(self):
+        self.send_response(200)
+        self.end_headers()
+
+    def log_message(self, format, *args):
+        pass
+
+print("Listening on port %s" % sys.argv[1], flush=True)
+http.server.ThreadingHTTPServer(("", int(sys.argv[1])), Handler).serve_forever()
+`
+
+// subscription is the body used to create a subscription with the cloud event proxy.
+type subscription struct {
+	EndpointURI     string `json:"EndpointUri"`
+	ResourceAddress string `json:"ResourceAddress"`
+}
+
+// CreateConsumerPod creates a pod on nodeName that receives PTP events over HTTP and prints them to its logs, then
+// subscribes it to every resource in events.Resources on the cloud event proxy for nodeName.
+func CreateConsumerPod(client *clients.Settings, nodeName string) (*pod.Builder, error) {
+	glog.V(tsparams.LogLevel).Infof("Creating PTP event consumer pod on node %s", nodeName)
+
+	consumerPod, err := pod.NewBuilder(
+		client, tsparams.ConsumerPodName, tsparams.TestingNamespace, RANConfig.PtpEventConsumerImage).
+		DefineOnNode(nodeName).
+		RedefineDefaultCMD([]string{"python3", "-c", consumerScript, strconv.Itoa(tsparams.ConsumerPort)}).
+		CreateAndWaitUntilRunning(tsparams.ConsumerTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create PTP event consumer pod: %w", err)
+	}
+
+	for _, resource := range events.Resources {
+		err = subscribe(consumerPod, nodeName, resource)
+		if err != nil {
+			return consumerPod, err
+		}
+	}
+
+	return consumerPod, nil
+}
+
+// GetConsumerEvents returns every event received by the consumer pod so far.
+func GetConsumerEvents(consumerPod *pod.Builder) ([]events.Event, error) {
+	log, err := consumerPod.GetFullLog(consumerPod.Definition.Spec.Containers[0].Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs of PTP event consumer pod: %w", err)
+	}
+
+	return events.ParseLog(log)
+}
+
+// WaitForSequence waits up to timeout for the events received by the consumer pod after the first after events to
+// match transitions, as in events.FindSequence. Using a count rather than a time avoids depending on the clock of the
+// node agreeing with the local clock. It returns the matched events along with every event received after the first
+// after events.
+func WaitForSequence(
+	consumerPod *pod.Builder,
+	after int,
+	timeout time.Duration,
+	transitions ...events.Transition) ([]events.Event, []events.Event, error) {
+	var (
+		received []events.Event
+		matched  []events.Event
+		err      error
+	)
+
+	for start := time.Now(); time.Since(start) < timeout; time.Sleep(tsparams.PollingInterval) {
+		received, err = GetConsumerEvents(consumerPod)
+		if err != nil {
+			glog.V(tsparams.LogLevel).Infof("Failed to get PTP events, retrying: %v", err)
+
+			continue
+		}
+
+		received = received[min(after, len(received)):]
+
+		matched, err = events.FindSequence(received, transitions...)
+		if err == nil {
+			return matched, received, nil
+		}
+	}
+
+	return matched, received, fmt.Errorf("timed out waiting for PTP events: %w", err)
+}
+
+// subscribe subscribes the consumer pod to resource on the cloud event proxy for nodeName. The request is sent from
+// the consumer pod since the publisher service is only reachable from inside the cluster.
+func subscribe(consumerPod *pod.Builder, nodeName string, resource events.Resource) error {
+	body, err := json.Marshal(subscription{
+		EndpointURI:     fmt.Sprintf("http://%s:%d/event", consumerPod.Object.Status.PodIP, tsparams.ConsumerPort),
+		ResourceAddress: resource.Address(nodeName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal subscription to %s: %w", resource, err)
+	}
+
+	url := fmt.Sprintf("http://ptp-event-publisher-service-%s.%s.svc.cluster.local:%d%s",
+		nodeName, RANConfig.PtpOperatorNamespace, tsparams.PublisherPort, tsparams.SubscriptionsPath)
+
+	glog.V(tsparams.LogLevel).Infof("Subscribing PTP event consumer to %s at %s", resource, url)
+
+	output, err := consumerPod.ExecCommand([]string{
+		"curl", "-s", "-o", "/dev/null", "-w", "%{http_code}", "-X", "POST",
+		"-H", "Content-Type: application/json", "-d", string(body), url,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w: %s", resource, err, output.String())
+	}
+
+	// The proxy returns 201 for new subscriptions and 409 when the endpoint is already subscribed to the resource.
+	if code := output.String(); code != "201" && code != "409" {
+		return fmt.Errorf("failed to subscribe to %s: received status code %s", resource, code)
+	}
+
+	return nil
+}
@@ -0,0 +1,232 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/metrics"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ptpConfigListGVK is the GroupVersionKind of PtpConfigList. PtpConfigs are used as unstructured since neither
+// eco-goinfra nor the vendored dependencies provide their types.
+var ptpConfigListGVK = schema.GroupVersionKind{Group: "ptp.openshift.io", Version: "v1", Kind: "PtpConfigList"}
+
+// nmeaSerialPortRegexp matches the line of a ts2phc config that sets the serial port the GNSS receiver sends NMEA
+// sentences on. The first group is everything before the port itself.
+var nmeaSerialPortRegexp = regexp.MustCompile(`(?m)^(\s*ts2phc\.nmea_serialport\s+)(\S+)`)
+
+// GetDaemonPod returns the linuxptp-daemon pod running on nodeName.
+func GetDaemonPod(client *clients.Settings, nodeName string) (*pod.Builder, error) {
+	daemonPods, err := pod.List(client, RANConfig.PtpOperatorNamespace, metav1.ListOptions{
+		LabelSelector: ranparam.PtpDaemonsetLabelSelector,
+		FieldSelector: "spec.nodeName=" + nodeName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PTP daemon pods on node %s: %w", nodeName, err)
+	}
+
+	if len(daemonPods) != 1 {
+		return nil, fmt.Errorf("expected one PTP daemon pod on node %s but found %d", nodeName, len(daemonPods))
+	}
+
+	return daemonPods[0], nil
+}
+
+// GetDaemonMetrics returns the metrics of the linuxptp-daemon pod.
+func GetDaemonMetrics(daemonPod *pod.Builder) (*metrics.Metrics, error) {
+	return metrics.ScrapePod(daemonPod, tsparams.DaemonMetricsURL, ranparam.PtpContainerName)
+}
+
+// GetClockType returns the type of clock the node is configured as based on the roles of the ptp4l ports. Nodes with
+// no slave ports but ts2phc running are grandmasters.
+func GetClockType(daemonMetrics *metrics.Metrics) (tsparams.ClockType, error) {
+	var roles []tsparams.PortRole
+
+	for _, sample := range daemonMetrics.Samples("openshift_ptp_interface_role", metrics.Labels{"process": "ptp4l"}) {
+		roles = append(roles, tsparams.PortRole(sample.Value))
+	}
+
+	hasSlave := slices.Contains(roles, tsparams.PortRoleSlave)
+	hasMaster := slices.Contains(roles, tsparams.PortRoleMaster)
+
+	switch {
+	case hasSlave && hasMaster:
+		return tsparams.ClockTypeBC, nil
+	case hasSlave:
+		return tsparams.ClockTypeOC, nil
+	case len(daemonMetrics.Samples("openshift_ptp_clock_state", metrics.Labels{"process": "ts2phc"})) > 0:
+		return tsparams.ClockTypeGM, nil
+	default:
+		return "", fmt.Errorf("failed to determine clock type from ptp4l port roles %v", roles)
+	}
+}
+
+// GetSlaveInterface returns the name of the ptp4l port in the slave role. It returns an error if there is not exactly
+// one such port.
+func GetSlaveInterface(daemonMetrics *metrics.Metrics) (string, error) {
+	var interfaces []string
+
+	for _, sample := range daemonMetrics.Samples("openshift_ptp_interface_role", metrics.Labels{"process": "ptp4l"}) {
+		if tsparams.PortRole(sample.Value) == tsparams.PortRoleSlave {
+			interfaces = append(interfaces, sample.Labels["iface"])
+		}
+	}
+
+	if len(interfaces) != 1 {
+		return "", fmt.Errorf("expected one slave interface but found %v", interfaces)
+	}
+
+	return interfaces[0], nil
+}
+
+// GetClockClass returns the clock class currently advertised by ptp4l.
+func GetClockClass(daemonMetrics *metrics.Metrics) (float64, error) {
+	return daemonMetrics.Value("openshift_ptp_clock_class", metrics.Labels{"process": "ptp4l"})
+}
+
+// IsLocked returns whether every clock state reported by the daemon is locked.
+func IsLocked(daemonMetrics *metrics.Metrics) bool {
+	samples := daemonMetrics.Samples("openshift_ptp_clock_state", nil)
+	if len(samples) == 0 {
+		return false
+	}
+
+	for _, sample := range samples {
+		// A clock state of 1 is LOCKED, 0 is FREERUN, and 2 is HOLDOVER.
+		if sample.Value != 1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SetInterfaceUp sets the link of iface on the node up or down.
+func SetInterfaceUp(client *clients.Settings, iface string, up bool) error {
+	state := "down"
+	if up {
+		state = "up"
+	}
+
+	glog.V(tsparams.LogLevel).Infof("Setting interface %s %s", iface, state)
+
+	_, err := cluster.ExecCommandOnSNOWithRetries(client, ranparam.RetryCount, ranparam.RetryInterval,
+		fmt.Sprintf("sudo ip link set %s %s", iface, state))
+	if err != nil {
+		return fmt.Errorf("failed to set interface %s %s: %w", iface, state, err)
+	}
+
+	return nil
+}
+
+// KillPtp4l kills every ptp4l process on the node. The daemon restarts the processes it manages, so this simulates a
+// crash rather than stopping PTP.
+func KillPtp4l(client *clients.Settings) error {
+	glog.V(tsparams.LogLevel).Info("Killing ptp4l processes")
+
+	_, err := cluster.ExecCommandOnSNOWithRetries(client, ranparam.RetryCount, ranparam.RetryInterval,
+		"sudo pkill -9 -x ptp4l")
+	if err != nil {
+		return fmt.Errorf("failed to kill ptp4l: %w", err)
+	}
+
+	return nil
+}
+
+// SetNMEASerialPort sets the ts2phc.nmea_serialport of every PtpConfig profile that has one to serialPort and returns
+// the previous port. Setting it to a device that does not exist simulates losing the GNSS signal and setting it back
+// to the previous port restores it. It returns an error if no profile has a serial port or if profiles have different
+// ports.
+func SetNMEASerialPort(client *clients.Settings, serialPort string) (string, error) {
+	ptpConfigs := &unstructured.UnstructuredList{}
+	ptpConfigs.SetGroupVersionKind(ptpConfigListGVK)
+
+	err := client.List(context.TODO(), ptpConfigs, runtimeclient.InNamespace(RANConfig.PtpOperatorNamespace))
+	if err != nil {
+		return "", fmt.Errorf("failed to list PtpConfigs: %w", err)
+	}
+
+	previous := ""
+
+	for _, ptpConfig := range ptpConfigs.Items {
+		port, err := setProfileSerialPorts(&ptpConfig, serialPort)
+		if err != nil {
+			return "", err
+		}
+
+		if port == "" {
+			continue
+		}
+
+		if previous != "" && port != previous {
+			return "", fmt.Errorf("PtpConfig %s uses NMEA serial port %s but another uses %s",
+				ptpConfig.GetName(), port, previous)
+		}
+
+		previous = port
+
+		glog.V(tsparams.LogLevel).Infof("Setting NMEA serial port of PtpConfig %s from %s to %s",
+			ptpConfig.GetName(), port, serialPort)
+
+		err = client.Update(context.TODO(), &ptpConfig)
+		if err != nil {
+			return "", fmt.Errorf("failed to update PtpConfig %s: %w", ptpConfig.GetName(), err)
+		}
+	}
+
+	if previous == "" {
+		return "", fmt.Errorf("failed to find a PtpConfig with ts2phc.nmea_serialport")
+	}
+
+	return previous, nil
+}
+
+// setProfileSerialPorts sets the NMEA serial port in the ts2phcConf of every profile of ptpConfig and returns the
+// previous port, or an empty string if no profile has one.
+func setProfileSerialPorts(ptpConfig *unstructured.Unstructured, serialPort string) (string, error) {
+	profiles, found, err := unstructured.NestedSlice(ptpConfig.Object, "spec", "profile")
+	if err != nil || !found {
+		return "", err
+	}
+
+	previous := ""
+
+	for _, profile := range profiles {
+		profileMap, ok := profile.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		ts2phcConf, ok := profileMap["ts2phcConf"].(string)
+		if !ok {
+			continue
+		}
+
+		match := nmeaSerialPortRegexp.FindStringSubmatch(ts2phcConf)
+		if match == nil {
+			continue
+		}
+
+		previous = match[2]
+		profileMap["ts2phcConf"] = nmeaSerialPortRegexp.ReplaceAllString(ts2phcConf, "${1}"+serialPort)
+	}
+
+	if previous == "" {
+		return "", nil
+	}
+
+	return previous, unstructured.SetNestedSlice(ptpConfig.Object, profiles, "spec", "profile")
+}
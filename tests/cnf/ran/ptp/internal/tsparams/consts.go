@@ -0,0 +1,86 @@
+package tsparams
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// LabelSuite is the label for all the tests in this suite.
+	LabelSuite = "ptp"
+	// LabelEvents is the label for the PTP event test cases.
+	LabelEvents = "ptp-events"
+	// LabelInterfaceDown is the label for the slave interface down test case.
+	LabelInterfaceDown = "interface-down"
+	// LabelPtp4lKill is the label for the ptp4l kill test case.
+	LabelPtp4lKill = "ptp4l-kill"
+	// LabelGNSSLoss is the label for the GNSS loss test case.
+	LabelGNSSLoss = "gnss-loss"
+	// TestingNamespace is the tests namespace.
+	TestingNamespace = "ran-ptp-test"
+
+	// ConsumerPodName is the name of the pod receiving PTP events.
+	ConsumerPodName = "ptp-event-consumer"
+	// ConsumerPort is the port the consumer pod listens for events on.
+	ConsumerPort = 8989
+	// ConsumerTimeout is the timeout for creating and deleting the consumer pod.
+	ConsumerTimeout = 5 * time.Minute
+
+	// PublisherPort is the port of the cloud event proxy publisher service for each node.
+	PublisherPort = 9043
+	// SubscriptionsPath is the path of the subscriptions endpoint of the cloud event proxy publisher.
+	SubscriptionsPath = "/api/ocloudNotifications/v2/subscriptions"
+	// DaemonMetricsURL is the URL of the linuxptp-daemon metrics, from inside the daemon pod.
+	DaemonMetricsURL = "http://localhost:9091/metrics"
+
+	// InvalidSerialPort is the NMEA serial port set in the ts2phc config to simulate losing the GNSS signal.
+	InvalidSerialPort = "/dev/eco-gotests-no-gnss"
+
+	// FaultTimeout is the time to wait for a fault to be reported through events.
+	FaultTimeout = 3 * time.Minute
+	// LockedTimeout is the time to wait for the clock to be locked before inducing a fault.
+	LockedTimeout = 10 * time.Minute
+	// PollingInterval is the interval between checks of the consumer pod logs and daemon metrics.
+	PollingInterval = 5 * time.Second
+
+	// LogLevel is the verbosity of glog statements in this test suite.
+	LogLevel glog.Level = 90
+)
+
+// ClockType is the role of the node in the PTP network, as determined from its port roles.
+type ClockType string
+
+const (
+	// ClockTypeGM is a grandmaster clock, which has no slave ports and is synchronized to GNSS.
+	ClockTypeGM ClockType = "T-GM"
+	// ClockTypeBC is a boundary clock, which has both slave and master ports.
+	ClockTypeBC ClockType = "T-BC"
+	// ClockTypeOC is an ordinary clock, which has only slave ports.
+	ClockTypeOC ClockType = "T-TSC"
+)
+
+const (
+	// ClockClassGMLocked is the clock class of a grandmaster locked to GNSS.
+	ClockClassGMLocked = "6"
+	// ClockClassGMHoldover is the clock class of a grandmaster in holdover within specification.
+	ClockClassGMHoldover = "7"
+)
+
+// PortRole is the value of the openshift_ptp_interface_role metric.
+type PortRole int
+
+const (
+	// PortRolePassive is the role of a passive port.
+	PortRolePassive PortRole = iota
+	// PortRoleSlave is the role of a port synchronized to a master.
+	PortRoleSlave
+	// PortRoleMaster is the role of a port providing time to slaves.
+	PortRoleMaster
+	// PortRoleFaulty is the role of a faulty port.
+	PortRoleFaulty
+	// PortRoleUnknown is the role of a port in an unknown state.
+	PortRoleUnknown
+	// PortRoleListening is the role of a port listening for announce messages.
+	PortRoleListening
+)
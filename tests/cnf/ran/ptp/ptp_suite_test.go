@@ -0,0 +1,67 @@
+package ptp
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/golang/glog"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/rancluster"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
+	_ "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/tests"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/reporter"
+)
+
+var _, currentFile, _, _ = runtime.Caller(0)
+
+func TestPTP(t *testing.T) {
+	_, reporterConfig := GinkgoConfiguration()
+	reporterConfig.JUnitReport = RANConfig.GetJunitReportPath(currentFile)
+
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RAN PTP Test Suite", Label(tsparams.Labels...), reporterConfig)
+}
+
+var _ = BeforeSuite(func() {
+	By("checking that the required clusters are present")
+	if !rancluster.AreClustersPresent([]*clients.Settings{Spoke1APIClient}) {
+		Skip("not all of the required clusters are present")
+	}
+
+	testNamespace := namespace.NewBuilder(Spoke1APIClient, tsparams.TestingNamespace)
+
+	glog.V(tsparams.LogLevel).Infof("Deleting test namespace %s", tsparams.TestingNamespace)
+	err := testNamespace.DeleteAndWait(tsparams.ConsumerTimeout)
+	Expect(err).ToNot(HaveOccurred(), "Failed to delete namespace %s", tsparams.TestingNamespace)
+
+	glog.V(tsparams.LogLevel).Infof("Creating test namespace %s", tsparams.TestingNamespace)
+	_, err = testNamespace.Create()
+	Expect(err).ToNot(HaveOccurred(), "Failed to create namespace %s", tsparams.TestingNamespace)
+})
+
+var _ = AfterSuite(func() {
+	// The BeforeSuite skips without creating the test namespace when spoke 1 is not present.
+	if !rancluster.AreClustersPresent([]*clients.Settings{Spoke1APIClient}) {
+		return
+	}
+
+	testNamespace := namespace.NewBuilder(Spoke1APIClient, tsparams.TestingNamespace)
+
+	glog.V(tsparams.LogLevel).Infof("Deleting test namespace %s", tsparams.TestingNamespace)
+	err := testNamespace.DeleteAndWait(tsparams.ConsumerTimeout)
+	Expect(err).ToNot(HaveOccurred(), "Failed to delete namespace %s", tsparams.TestingNamespace)
+})
+
+var _ = JustAfterEach(func() {
+	reporter.ReportIfFailed(
+		CurrentSpecReport(), currentFile, tsparams.ReporterNamespacesToDump, tsparams.ReporterCRsToDump)
+})
+
+var _ = ReportAfterSuite("", func(report Report) {
+	reportxml.Create(report, RANConfig.GetReportPath(), RANConfig.TCPrefix)
+})
@@ -0,0 +1,240 @@
+package tests
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/events"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/helper"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
+)
+
+var _ = Describe("PTP events during faults", Ordered, Label(tsparams.LabelEvents), func() {
+	var (
+		nodeName    string
+		daemonPod   *pod.Builder
+		consumerPod *pod.Builder
+		clockType   tsparams.ClockType
+		clockClass  string
+	)
+
+	BeforeAll(func() {
+		if RANConfig.PtpEventConsumerImage == "" {
+			Skip("PTP event consumer image must be provided to run PTP event tests")
+		}
+
+		nodeList, err := nodes.List(Spoke1APIClient)
+		Expect(err).ToNot(HaveOccurred(), "Failed to list cluster nodes")
+
+		if len(nodeList) != 1 {
+			Skip("PTP event tests only support SNO clusters")
+		}
+
+		nodeName = nodeList[0].Object.Name
+
+		By("getting the PTP daemon pod and waiting for the clock to lock")
+		daemonPod, err = helper.GetDaemonPod(Spoke1APIClient, nodeName)
+		Expect(err).ToNot(HaveOccurred(), "Failed to get PTP daemon pod")
+
+		waitForLocked(daemonPod)
+
+		daemonMetrics, err := helper.GetDaemonMetrics(daemonPod)
+		Expect(err).ToNot(HaveOccurred(), "Failed to get PTP daemon metrics")
+
+		clockType, err = helper.GetClockType(daemonMetrics)
+		Expect(err).ToNot(HaveOccurred(), "Failed to get clock type")
+
+		lockedClockClass, err := helper.GetClockClass(daemonMetrics)
+		Expect(err).ToNot(HaveOccurred(), "Failed to get clock class")
+
+		clockClass = strconv.FormatFloat(lockedClockClass, 'f', -1, 64)
+
+		GinkgoWriter.Printf("Node %s is a %s with clock class %s\n", nodeName, clockType, clockClass)
+
+		By("creating the PTP event consumer pod")
+		consumerPod, err = helper.CreateConsumerPod(Spoke1APIClient, nodeName)
+		Expect(err).ToNot(HaveOccurred(), "Failed to create PTP event consumer pod")
+	})
+
+	AfterAll(func() {
+		if consumerPod == nil || !consumerPod.Exists() {
+			return
+		}
+
+		By("deleting the PTP event consumer pod")
+		_, err := consumerPod.DeleteAndWait(tsparams.ConsumerTimeout)
+		Expect(err).ToNot(HaveOccurred(), "Failed to delete PTP event consumer pod")
+	})
+
+	BeforeEach(func() {
+		if daemonPod == nil {
+			return
+		}
+
+		By("waiting for the clock to be locked before inducing a fault")
+		waitForLocked(daemonPod)
+	})
+
+	// induceAndCheck induces a fault and checks that each of faultEvents is received. It then removes the fault, if
+	// remove is not nil, and checks that the event type of each of recovered leaves and returns to its state within the
+	// recovery timeout. Events of different types may arrive in any order.
+	induceAndCheck := func(
+		induce, remove func() error, faultEvents []events.Transition, recovered []events.Transition) {
+		received, err := helper.GetConsumerEvents(consumerPod)
+		Expect(err).ToNot(HaveOccurred(), "Failed to get PTP events before inducing fault")
+
+		before := len(received)
+
+		By("inducing the fault")
+		Expect(induce()).To(Succeed(), "Failed to induce fault")
+
+		// Removing a fault is idempotent, so it is also deferred in case the test fails before removing it.
+		if remove != nil {
+			DeferCleanup(remove)
+		}
+
+		By("waiting for the fault events")
+
+		for _, faultEvent := range faultEvents {
+			_, _, err = helper.WaitForSequence(consumerPod, before, tsparams.FaultTimeout, faultEvent)
+			Expect(err).ToNot(HaveOccurred(), "Failed to receive fault event %s", faultEvent)
+		}
+
+		if remove != nil {
+			By("removing the fault")
+			Expect(remove()).To(Succeed(), "Failed to remove fault")
+		}
+
+		// Without a fault to remove, recovery time is measured from when the fault is reported.
+		By("waiting for recovery")
+
+		recoveryStart := time.Now()
+
+		for _, recovery := range recovered {
+			timeout := max(RANConfig.PtpRecoveryTimeout-time.Since(recoveryStart), 0)
+
+			_, received, err = helper.WaitForSequence(consumerPod, before, timeout,
+				events.AwayFrom(recovery.Type, recovery.States...), recovery)
+			Expect(err).ToNot(HaveOccurred(), "Failed to recover within %s", RANConfig.PtpRecoveryTimeout)
+		}
+
+		report := fmt.Sprintf("recovered in %s", time.Since(recoveryStart))
+
+		// The time out of lock is measured using the event timestamps, so it does not depend on the clock of the node
+		// agreeing with the local clock.
+		outOfLock, err := events.RecoveryTime(received, events.TypeLockState, time.Time{}, events.StateLocked)
+		if err == nil {
+			report = fmt.Sprintf("%s, out of lock for %s", report, outOfLock)
+		}
+
+		report = fmt.Sprintf("%s\n%s", report, events.Describe(received))
+
+		GinkgoWriter.Println(report)
+		AddReportEntry(string(clockType), report)
+	}
+
+	// 82951 - Verify events when the slave interface goes down
+	It("verifies events when the slave interface goes down", Label(tsparams.LabelInterfaceDown),
+		reportxml.ID("82951"), func() {
+			if clockType == tsparams.ClockTypeGM {
+				Skip("Grandmaster clocks have no slave interface")
+			}
+
+			daemonMetrics, err := helper.GetDaemonMetrics(daemonPod)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get PTP daemon metrics")
+
+			slaveInterface, err := helper.GetSlaveInterface(daemonMetrics)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get slave interface")
+
+			faultEvents := []events.Transition{events.AwayFrom(events.TypeLockState, events.StateLocked)}
+			recovered := []events.Transition{
+				events.To(events.TypeLockState, events.StateLocked),
+				events.To(events.TypeSyncState, events.StateLocked),
+			}
+
+			// Boundary clocks must stop advertising the clock class of the grandmaster once they lose it.
+			if clockType == tsparams.ClockTypeBC {
+				faultEvents = append(faultEvents, events.AwayFrom(events.TypeClockClass, clockClass))
+				recovered = append(recovered, events.To(events.TypeClockClass, clockClass))
+			}
+
+			induceAndCheck(
+				func() error { return helper.SetInterfaceUp(Spoke1APIClient, slaveInterface, false) },
+				func() error { return helper.SetInterfaceUp(Spoke1APIClient, slaveInterface, true) },
+				faultEvents, recovered)
+		})
+
+	// 82952 - Verify events when ptp4l is killed
+	It("verifies events when ptp4l is killed", Label(tsparams.LabelPtp4lKill), reportxml.ID("82952"), func() {
+		if clockType == tsparams.ClockTypeGM {
+			Skip("The lock state of grandmaster clocks does not depend on ptp4l")
+		}
+
+		// The daemon restarts ptp4l on its own, so there is no fault to remove.
+		induceAndCheck(
+			func() error { return helper.KillPtp4l(Spoke1APIClient) },
+			nil,
+			[]events.Transition{events.AwayFrom(events.TypeLockState, events.StateLocked)},
+			[]events.Transition{events.To(events.TypeLockState, events.StateLocked)})
+	})
+
+	// 82953 - Verify grandmaster holdover when GNSS is lost
+	It("verifies grandmaster holdover when GNSS is lost", Label(tsparams.LabelGNSSLoss), reportxml.ID("82953"), func() {
+		if clockType != tsparams.ClockTypeGM {
+			Skip("Only grandmaster clocks use GNSS")
+		}
+
+		var serialPort string
+
+		// Grandmasters must go into holdover, advertising the holdover clock class, rather than straight to freerun.
+		induceAndCheck(
+			func() error {
+				var err error
+
+				serialPort, err = helper.SetNMEASerialPort(Spoke1APIClient, tsparams.InvalidSerialPort)
+
+				return err
+			},
+			func() error {
+				if serialPort == "" {
+					return nil
+				}
+
+				_, err := helper.SetNMEASerialPort(Spoke1APIClient, serialPort)
+
+				return err
+			},
+			[]events.Transition{
+				events.AwayFrom(events.TypeGNSSSyncState, events.StateLocked, events.StateSynchronized),
+				events.To(events.TypeLockState, events.StateHoldover),
+				events.To(events.TypeClockClass, tsparams.ClockClassGMHoldover),
+			},
+			[]events.Transition{
+				events.To(events.TypeLockState, events.StateLocked),
+				events.To(events.TypeGNSSSyncState, events.StateLocked, events.StateSynchronized),
+				events.To(events.TypeClockClass, tsparams.ClockClassGMLocked),
+			})
+	})
+})
+
+// waitForLocked waits for every clock state reported by the PTP daemon to be locked.
+func waitForLocked(daemonPod *pod.Builder) {
+	Eventually(func() bool {
+		daemonMetrics, err := helper.GetDaemonMetrics(daemonPod)
+		if err != nil {
+			GinkgoWriter.Printf("Failed to get PTP daemon metrics: %v\n", err)
+
+			return false
+		}
+
+		return helper.IsLocked(daemonMetrics)
+	}).WithTimeout(tsparams.LockedTimeout).WithPolling(tsparams.PollingInterval).
+		Should(BeTrue(), "PTP clock did not lock within %s", tsparams.LockedTimeout)
+}
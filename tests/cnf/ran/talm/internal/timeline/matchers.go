@@ -0,0 +1,139 @@
+package timeline
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/onsi/gomega/types"
+)
+
+// HaveSequence succeeds if the actual *Timeline or *Recorder contains events matching every step in order. Other
+// events may come between the steps. Steps are described in Event.Matches, for example:
+//
+//	Expect(recorder).To(HaveSequence("Progressing", "BatchTimedOut(spoke2)", "Succeeded"))
+func HaveSequence(steps ...string) types.GomegaMatcher {
+	return &sequenceMatcher{steps: steps}
+}
+
+// HavePhasesWithin succeeds if every phase started by an event matching step in the actual *Timeline or *Recorder,
+// including ongoing phases, lasted at most maximum. It fails if there are no matching phases.
+func HavePhasesWithin(step string, maximum time.Duration) types.GomegaMatcher {
+	return &phaseMatcher{step: step, maximum: maximum}
+}
+
+type sequenceMatcher struct {
+	steps []string
+	// matched is the number of steps found before the first missing one.
+	matched int
+}
+
+// Match returns whether the timeline contains the steps in order.
+func (matcher *sequenceMatcher) Match(actual any) (bool, error) {
+	timeline, err := toTimeline(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.matched = 0
+	next := 0
+
+	for _, step := range matcher.steps {
+		index := timeline.Find(step, next)
+		if index < 0 {
+			return false, nil
+		}
+
+		matcher.matched++
+		next = index + 1
+	}
+
+	return true, nil
+}
+
+// FailureMessage returns the first missing step and the full timeline.
+func (matcher *sequenceMatcher) FailureMessage(actual any) string {
+	timeline, _ := toTimeline(actual)
+
+	return fmt.Sprintf("Expected sequence\n\t%s\nbut step %q was not found after %d matched steps in\n%s",
+		strings.Join(matcher.steps, " -> "), matcher.steps[matcher.matched], matcher.matched, timeline)
+}
+
+// NegatedFailureMessage returns the full timeline.
+func (matcher *sequenceMatcher) NegatedFailureMessage(actual any) string {
+	timeline, _ := toTimeline(actual)
+
+	return fmt.Sprintf("Expected timeline not to contain sequence\n\t%s\nin\n%s",
+		strings.Join(matcher.steps, " -> "), timeline)
+}
+
+type phaseMatcher struct {
+	step    string
+	maximum time.Duration
+	// failed is the first phase that was too long, if any.
+	failed *Phase
+}
+
+// Match returns whether all phases matching the step are within the maximum duration.
+func (matcher *phaseMatcher) Match(actual any) (bool, error) {
+	timeline, err := toTimeline(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.failed = nil
+
+	phases := timeline.Phases(matcher.step)
+	if len(phases) == 0 {
+		return false, nil
+	}
+
+	for _, phase := range phases {
+		if phase.Duration() > matcher.maximum {
+			matcher.failed = &phase
+
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// FailureMessage returns the phase that was too long, or that no phases were found, and the full timeline.
+func (matcher *phaseMatcher) FailureMessage(actual any) string {
+	timeline, _ := toTimeline(actual)
+
+	if matcher.failed == nil {
+		return fmt.Sprintf("Expected phases matching %q but found none in\n%s", matcher.step, timeline)
+	}
+
+	return fmt.Sprintf("Expected phases matching %q to last at most %s but %s lasted %s (ongoing: %t) in\n%s",
+		matcher.step, matcher.maximum, matcher.failed.Step, matcher.failed.Duration(), matcher.failed.Ongoing, timeline)
+}
+
+// NegatedFailureMessage returns the full timeline.
+func (matcher *phaseMatcher) NegatedFailureMessage(actual any) string {
+	timeline, _ := toTimeline(actual)
+
+	return fmt.Sprintf("Expected some phase matching %q to last longer than %s in\n%s",
+		matcher.step, matcher.maximum, timeline)
+}
+
+func toTimeline(actual any) (*Timeline, error) {
+	switch typed := actual.(type) {
+	case *Timeline:
+		if typed == nil {
+			return nil, fmt.Errorf("timeline matchers expect a non-nil *Timeline")
+		}
+
+		return typed, nil
+	case *Recorder:
+		if typed == nil {
+			return nil, fmt.Errorf("timeline matchers expect a non-nil *Recorder")
+		}
+
+		return typed.Timeline(), nil
+	default:
+		return nil, fmt.Errorf("timeline matchers expect a *Timeline or *Recorder, got %T", actual)
+	}
+}
@@ -0,0 +1,139 @@
+package timeline
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/cgu"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/tsparams"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// DefaultInterval is the polling interval used by Record. TALM reconciles much less often so this is enough to
+	// see every state.
+	DefaultInterval = 5 * time.Second
+	// ReportEntryPrefix is prepended to the CGU name to form the name of the report entry containing the timeline.
+	ReportEntryPrefix = "cgu-timeline-"
+)
+
+// Recorder polls a CGU in the background and adds every status change to its timeline.
+type Recorder struct {
+	apiClient *clients.Settings
+	interval  time.Duration
+
+	mutex    sync.Mutex
+	timeline *Timeline
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// NewRecorder returns a recorder for the CGU with the provided name and namespace. It does not start polling until
+// Start is called. The CGU does not need to exist yet.
+func NewRecorder(apiClient *clients.Settings, name, nsname string, interval time.Duration) *Recorder {
+	return &Recorder{
+		apiClient: apiClient,
+		interval:  interval,
+		timeline:  New(name, nsname),
+	}
+}
+
+// Start begins polling the CGU in a separate goroutine. Calling Start on a recorder that is already started does
+// nothing.
+func (recorder *Recorder) Start() {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	if recorder.cancel != nil {
+		return
+	}
+
+	glog.V(tsparams.LogLevel).Infof("Starting timeline recorder for CGU %s in namespace %s",
+		recorder.timeline.Name, recorder.timeline.Namespace)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	recorder.cancel = cancel
+	recorder.done = make(chan struct{})
+
+	go func() {
+		defer close(recorder.done)
+
+		// The error is only ever the context being canceled.
+		_ = wait.PollUntilContextCancel(ctx, recorder.interval, true, func(context.Context) (bool, error) {
+			recorder.poll()
+
+			return false, nil
+		})
+	}()
+}
+
+// Stop stops polling, takes one final snapshot, and returns a copy of the timeline. It is safe to call Stop multiple
+// times or without calling Start.
+func (recorder *Recorder) Stop() *Timeline {
+	recorder.mutex.Lock()
+	cancel, done := recorder.cancel, recorder.done
+	recorder.mutex.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+
+	recorder.poll()
+
+	return recorder.Timeline()
+}
+
+// Timeline returns a copy of the timeline recorded so far.
+func (recorder *Recorder) Timeline() *Timeline {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	timelineCopy := *recorder.timeline
+	timelineCopy.Events = append([]Event(nil), recorder.timeline.Events...)
+
+	return &timelineCopy
+}
+
+// poll pulls the CGU and observes its status. Errors are ignored since the CGU may not exist yet or may have already
+// been deleted.
+func (recorder *Recorder) poll() {
+	cguBuilder, err := cgu.Pull(recorder.apiClient, recorder.timeline.Name, recorder.timeline.Namespace)
+	if err != nil || cguBuilder.Object == nil {
+		return
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recorder.timeline.Observe(&cguBuilder.Object.Status, time.Now())
+}
+
+// Record starts a recorder for the CGU with the provided name and namespace that is stopped when the current spec
+// finishes. The timeline is always added as a report entry, so it is included in the JUnit report and printed when the
+// spec fails or is run with -v.
+func Record(apiClient *clients.Settings, name, nsname string) *Recorder {
+	ginkgo.GinkgoHelper()
+
+	recorder := NewRecorder(apiClient, name, nsname, DefaultInterval)
+	recorder.Start()
+
+	ginkgo.DeferCleanup(func() {
+		recordedTimeline := recorder.Stop()
+
+		if ginkgo.CurrentSpecReport().Failed() {
+			glog.V(tsparams.LogLevel).Infof("Spec failed with CGU timeline:\n%s", recordedTimeline)
+		}
+
+		ginkgo.AddReportEntry(
+			fmt.Sprintf("%s%s", ReportEntryPrefix, name), recordedTimeline.String(),
+			ginkgo.ReportEntryVisibilityFailureOrVerbose)
+	})
+
+	return recorder
+}
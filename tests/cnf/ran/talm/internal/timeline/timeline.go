@@ -0,0 +1,298 @@
+// Package timeline records every status change of a ClusterGroupUpgrade so tests can assert on the order and duration
+// of the intermediate states rather than only on the final condition.
+package timeline
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EventKind is the part of the CGU status that an event describes.
+type EventKind string
+
+const (
+	// KindCondition events are changes to the status, reason, or message of a condition.
+	KindCondition EventKind = "condition"
+	// KindBatch events are changes to the current batch.
+	KindBatch EventKind = "batch"
+	// KindCluster events are changes to the remediation state of a cluster, either in the current batch progress or
+	// in the final cluster states.
+	KindCluster EventKind = "cluster"
+	// KindPrecache events are changes to the pre-caching status of a cluster.
+	KindPrecache EventKind = "precache"
+)
+
+const (
+	// clusterTimedOut is the final state TALM uses for a cluster that did not finish remediating in its batch.
+	clusterTimedOut = "timedout"
+	// clusterComplete is the final state TALM uses for a cluster that finished remediating.
+	clusterComplete = "complete"
+)
+
+// Event is a single change in the status of a CGU.
+type Event struct {
+	Time time.Time
+	Kind EventKind
+	// Subject is the condition type for condition events, the batch number for batch events, and the cluster name
+	// for cluster and precache events.
+	Subject string
+	// State is the condition status, the cluster state, or the precache status. It is empty for batch events.
+	State   string
+	Reason  string
+	Message string
+}
+
+// Names returns every step name that this event can be matched with. Condition events match Type when True,
+// Type=Status, and their Reason. Batch events match Batch. Cluster events match Cluster followed by their state, such
+// as ClusterInProgress, and timed out clusters also match BatchTimedOut. Precache events match Precache followed by
+// their status, such as PrecacheSucceeded.
+func (event Event) Names() []string {
+	switch event.Kind {
+	case KindCondition:
+		names := []string{event.Subject + "=" + event.State}
+
+		if event.State == string(metav1.ConditionTrue) {
+			names = append(names, event.Subject)
+		}
+
+		if event.Reason != "" {
+			names = append(names, event.Reason)
+		}
+
+		return names
+	case KindBatch:
+		return []string{"Batch"}
+	case KindCluster:
+		switch event.State {
+		case clusterTimedOut:
+			return []string{"ClusterTimedOut", "BatchTimedOut"}
+		case clusterComplete:
+			return []string{"ClusterCompleted"}
+		default:
+			return []string{"Cluster" + event.State}
+		}
+	case KindPrecache:
+		return []string{"Precache" + event.State}
+	default:
+		return nil
+	}
+}
+
+// Step returns the primary name of the event and its subject in the form accepted by HaveSequence, for example
+// Progressing=True, Batch(2), or ClusterInProgress(spoke1).
+func (event Event) Step() string {
+	name := event.Names()[0]
+
+	if event.Kind == KindCondition {
+		return name
+	}
+
+	return fmt.Sprintf("%s(%s)", name, event.Subject)
+}
+
+// Matches returns whether the event matches step, which is a name returned by Names optionally followed by the
+// subject in parentheses, such as Progressing, Succeeded=False, TimedOut, Batch(2), or BatchTimedOut(spoke2).
+func (event Event) Matches(step string) bool {
+	name, subject := parseStep(step)
+
+	if subject != "" && subject != event.Subject {
+		return false
+	}
+
+	return slices.Contains(event.Names(), name)
+}
+
+// Phase is an interval between an event and the next event for the same subject, such as a condition being True or a
+// cluster being InProgress.
+type Phase struct {
+	// Step is the primary step of the event that started the phase.
+	Step    string
+	Start   time.Time
+	End     time.Time
+	Ongoing bool
+}
+
+// Duration returns the length of the phase.
+func (phase Phase) Duration() time.Duration {
+	return phase.End.Sub(phase.Start)
+}
+
+// Timeline is an ordered list of events for a single CGU. Add snapshots using Observe.
+type Timeline struct {
+	Name      string
+	Namespace string
+	Events    []Event
+	// LastObserved is the time of the most recent snapshot and is used to end ongoing phases.
+	LastObserved time.Time
+
+	conditions    map[string]metav1.Condition
+	batch         int
+	clusterStates map[string]string
+	finalStates   map[string]string
+	precache      map[string]string
+}
+
+// New returns an empty timeline for the CGU with the provided name and namespace.
+func New(name, nsname string) *Timeline {
+	return &Timeline{
+		Name:          name,
+		Namespace:     nsname,
+		conditions:    make(map[string]metav1.Condition),
+		clusterStates: make(map[string]string),
+		finalStates:   make(map[string]string),
+		precache:      make(map[string]string),
+	}
+}
+
+// Observe compares the provided status with the previously observed one and adds an event for every difference.
+// Events from the same snapshot are ordered batch, clusters, precaching, then conditions since the conditions usually
+// summarize the other changes.
+func (timeline *Timeline) Observe(status *v1alpha1.ClusterGroupUpgradeStatus, observedAt time.Time) {
+	timeline.LastObserved = observedAt
+
+	if status == nil {
+		return
+	}
+
+	if status.Status.CurrentBatch != timeline.batch {
+		timeline.batch = status.Status.CurrentBatch
+		timeline.Events = append(timeline.Events, Event{
+			Time: observedAt, Kind: KindBatch, Subject: strconv.Itoa(status.Status.CurrentBatch)})
+	}
+
+	clusterStates := make(map[string]string)
+
+	for cluster, progress := range status.Status.CurrentBatchRemediationProgress {
+		if progress != nil {
+			clusterStates[cluster] = progress.State
+		}
+	}
+
+	timeline.observeStates(KindCluster, timeline.clusterStates, clusterStates, observedAt)
+
+	finalStates := make(map[string]string)
+	for _, clusterState := range status.Clusters {
+		finalStates[clusterState.Name] = clusterState.State
+	}
+
+	timeline.observeStates(KindCluster, timeline.finalStates, finalStates, observedAt)
+
+	if status.Precaching != nil {
+		timeline.observeStates(KindPrecache, timeline.precache, status.Precaching.Status, observedAt)
+	}
+
+	for _, condition := range status.Conditions {
+		previous, found := timeline.conditions[condition.Type]
+		if found && previous.Status == condition.Status &&
+			previous.Reason == condition.Reason && previous.Message == condition.Message {
+			continue
+		}
+
+		timeline.conditions[condition.Type] = condition
+		timeline.Events = append(timeline.Events, Event{
+			Time:    observedAt,
+			Kind:    KindCondition,
+			Subject: condition.Type,
+			State:   string(condition.Status),
+			Reason:  condition.Reason,
+			Message: condition.Message,
+		})
+	}
+}
+
+// observeStates adds an event for every key in current with a different value than in previous, then updates
+// previous. Keys removed from current are not events since TALM clears the batch progress between batches.
+func (timeline *Timeline) observeStates(kind EventKind, previous, current map[string]string, observedAt time.Time) {
+	for _, subject := range slices.Sorted(maps.Keys(current)) {
+		if previous[subject] == current[subject] {
+			continue
+		}
+
+		previous[subject] = current[subject]
+		timeline.Events = append(timeline.Events, Event{
+			Time: observedAt, Kind: kind, Subject: subject, State: current[subject]})
+	}
+}
+
+// Find returns the index of the first event at or after start that matches step, or -1 if none match.
+func (timeline *Timeline) Find(step string, start int) int {
+	for index := max(start, 0); index < len(timeline.Events); index++ {
+		if timeline.Events[index].Matches(step) {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// Phases returns every phase started by an event matching step. A phase ends at the next event of the same kind and
+// subject, or at LastObserved if there is none.
+func (timeline *Timeline) Phases(step string) []Phase {
+	var phases []Phase
+
+	for index, event := range timeline.Events {
+		if !event.Matches(step) {
+			continue
+		}
+
+		phase := Phase{Step: event.Step(), Start: event.Time, End: timeline.LastObserved, Ongoing: true}
+
+		for _, next := range timeline.Events[index+1:] {
+			if next.Kind == event.Kind && next.Subject == event.Subject ||
+				event.Kind == KindBatch && next.Kind == KindBatch {
+				phase.End = next.Time
+				phase.Ongoing = false
+
+				break
+			}
+		}
+
+		phases = append(phases, phase)
+	}
+
+	return phases
+}
+
+// String returns the timeline as a table with one event per line and times relative to the first event.
+func (timeline *Timeline) String() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "Timeline for CGU %s/%s with %d events\n", timeline.Namespace, timeline.Name, len(timeline.Events))
+
+	if len(timeline.Events) == 0 {
+		return builder.String()
+	}
+
+	start := timeline.Events[0].Time
+	fmt.Fprintf(&builder, "Started at %s\n", start.Format(time.RFC3339))
+
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "OFFSET\tSTEP\tREASON\tMESSAGE")
+
+	for _, event := range timeline.Events {
+		fmt.Fprintf(writer, "+%s\t%s\t%s\t%s\n", event.Time.Sub(start).Round(time.Second), event.Step(),
+			event.Reason, event.Message)
+	}
+
+	_ = writer.Flush()
+
+	return builder.String()
+}
+
+// parseStep splits a step such as BatchTimedOut(spoke2) into its name and subject.
+func parseStep(step string) (string, string) {
+	name, subject, found := strings.Cut(strings.TrimSpace(step), "(")
+	if !found {
+		return name, ""
+	}
+
+	return name, strings.TrimSuffix(subject, ")")
+}
@@ -0,0 +1,160 @@
+package timeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestObserve(t *testing.T) {
+	timeline := buildTimeline()
+
+	var steps []string
+	for _, event := range timeline.Events {
+		steps = append(steps, event.Step())
+	}
+
+	assert.Equal(t, []string{
+		"Batch(1)",
+		"ClusterInProgress(spoke1)",
+		"ClusterInProgress(spoke2)",
+		"Progressing=True",
+		"ClusterCompleted(spoke1)",
+		"ClusterCompleted(spoke1)",
+		"ClusterTimedOut(spoke2)",
+		"Progressing=False",
+		"Succeeded=False",
+	}, steps)
+}
+
+func TestHaveSequence(t *testing.T) {
+	testCases := []struct {
+		steps         []string
+		expectedMatch bool
+	}{
+		{
+			steps:         []string{"Progressing", "BatchTimedOut(spoke2)", "Succeeded=False"},
+			expectedMatch: true,
+		},
+		{
+			steps:         []string{"Batch(1)", "ClusterCompleted(spoke1)", "TimedOut"},
+			expectedMatch: true,
+		},
+		{
+			steps:         []string{"Succeeded=False", "Progressing"},
+			expectedMatch: false,
+		},
+		{
+			steps:         []string{"BatchTimedOut(spoke1)"},
+			expectedMatch: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		matched, err := HaveSequence(testCase.steps...).Match(buildTimeline())
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedMatch, matched, "steps: %v", testCase.steps)
+	}
+
+	_, err := HaveSequence("Progressing").Match("not a timeline")
+	assert.NotNil(t, err)
+}
+
+func TestHavePhasesWithin(t *testing.T) {
+	testCases := []struct {
+		step          string
+		maximum       time.Duration
+		expectedMatch bool
+	}{
+		{
+			step:          "ClusterInProgress(spoke1)",
+			maximum:       5 * time.Minute,
+			expectedMatch: true,
+		},
+		{
+			step:          "ClusterInProgress",
+			maximum:       5 * time.Minute,
+			expectedMatch: false,
+		},
+		{
+			step:          "Progressing",
+			maximum:       10 * time.Minute,
+			expectedMatch: true,
+		},
+		{
+			step:          "Succeeded=False",
+			maximum:       time.Minute,
+			expectedMatch: true,
+		},
+		{
+			step:          "PrecacheSucceeded",
+			maximum:       time.Hour,
+			expectedMatch: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		matched, err := HavePhasesWithin(testCase.step, testCase.maximum).Match(buildTimeline())
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedMatch, matched, "step: %s", testCase.step)
+	}
+}
+
+// buildTimeline returns a timeline where spoke1 completes after 5 minutes and spoke2 times out after 10 minutes.
+func buildTimeline() *Timeline {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeline := New("cgu", "talm-test")
+
+	timeline.Observe(&v1alpha1.ClusterGroupUpgradeStatus{
+		Conditions: []metav1.Condition{{Type: "Progressing", Status: metav1.ConditionTrue, Reason: "InProgress"}},
+		Status: v1alpha1.UpgradeStatus{
+			CurrentBatch: 1,
+			CurrentBatchRemediationProgress: map[string]*v1alpha1.ClusterRemediationProgress{
+				"spoke1": {State: v1alpha1.InProgress},
+				"spoke2": {State: v1alpha1.InProgress},
+			},
+		},
+	}, start)
+
+	// A snapshot without changes should not add any events.
+	timeline.Observe(&v1alpha1.ClusterGroupUpgradeStatus{
+		Conditions: []metav1.Condition{{Type: "Progressing", Status: metav1.ConditionTrue, Reason: "InProgress"}},
+		Status: v1alpha1.UpgradeStatus{
+			CurrentBatch: 1,
+			CurrentBatchRemediationProgress: map[string]*v1alpha1.ClusterRemediationProgress{
+				"spoke1": {State: v1alpha1.InProgress},
+				"spoke2": {State: v1alpha1.InProgress},
+			},
+		},
+	}, start.Add(time.Minute))
+
+	timeline.Observe(&v1alpha1.ClusterGroupUpgradeStatus{
+		Conditions: []metav1.Condition{{Type: "Progressing", Status: metav1.ConditionTrue, Reason: "InProgress"}},
+		Status: v1alpha1.UpgradeStatus{
+			CurrentBatch: 1,
+			CurrentBatchRemediationProgress: map[string]*v1alpha1.ClusterRemediationProgress{
+				"spoke1": {State: v1alpha1.Completed},
+				"spoke2": {State: v1alpha1.InProgress},
+			},
+		},
+	}, start.Add(5*time.Minute))
+
+	timeline.Observe(&v1alpha1.ClusterGroupUpgradeStatus{
+		Conditions: []metav1.Condition{
+			{Type: "Progressing", Status: metav1.ConditionFalse, Reason: "TimedOut"},
+			{Type: "Succeeded", Status: metav1.ConditionFalse, Reason: "TimedOut"},
+		},
+		Clusters: []v1alpha1.ClusterState{
+			{Name: "spoke1", State: clusterComplete},
+			{Name: "spoke2", State: clusterTimedOut},
+		},
+		Status: v1alpha1.UpgradeStatus{CurrentBatch: 1},
+	}, start.Add(10*time.Minute))
+
+	timeline.Observe(nil, start.Add(11*time.Minute))
+
+	return timeline
+}
@@ -17,6 +17,8 @@ const (
 	LabelBlockingCRTestCases = "blockingcr"
 	// LabelCanaryTestCases is the label for a particular test file.
 	LabelCanaryTestCases = "canary"
+	// LabelFleetTestCases is the label for a particular test file.
+	LabelFleetTestCases = "fleet"
 	// LabelPreCacheTestCases is the label for a particular test file.
 	LabelPreCacheTestCases = "precache"
 	// LabelMissingSpokeTestCases is the label for a set of batching test cases.
@@ -28,6 +30,13 @@ const (
 	// LabelTempNamespaceTestCases is the label for a set of batching test cases.
 	LabelTempNamespaceTestCases = "tempnamespace"
 
+	// FleetMinimumSpokes is the minimum number of spokes required for the fleet tests.
+	FleetMinimumSpokes = 3
+	// FleetMaxConcurrency is the max concurrency used for CGUs in the fleet tests.
+	FleetMaxConcurrency = 2
+	// FleetCanarySelector is the label selector for the spokes used as canaries in the fleet tests.
+	FleetCanarySelector = "role=canary"
+
 	// TestNamespace is the testing namespace created on the hub.
 	TestNamespace = "talm-test"
 	// TemporaryNamespace is a temporary namespace for testing created on the spokes.
@@ -18,6 +18,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/version"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/helper"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/setup"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/timeline"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/tsparams"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -162,6 +163,9 @@ var _ = Describe("TALM Batching Tests", Label(tsparams.LabelBatchingTestCases),
 			_, err = namespace.NewBuilder(Spoke1APIClient, tsparams.TemporaryNamespace).Create()
 			Expect(err).ToNot(HaveOccurred(), "Failed to create temporary namespace on spoke 1")
 
+			By("recording the CGU timeline")
+			recorder := timeline.Record(HubAPIClient, tsparams.CguName, tsparams.TestNamespace)
+
 			By("creating the CGU and associated resources")
 			// This test uses a max concurrency of 2 so both spokes are in the same batch.
 			cguBuilder := cgu.NewCguBuilder(HubAPIClient, tsparams.CguName, tsparams.TestNamespace, 2).
@@ -182,6 +186,9 @@ var _ = Describe("TALM Batching Tests", Label(tsparams.LabelBatchingTestCases),
 			_, err = cguBuilder.WaitForCondition(tsparams.CguTimeoutReasonCondition, 16*time.Minute)
 			Expect(err).ToNot(HaveOccurred(), "Failed to wait for CGU to timeout")
 
+			// Stopping the recorder takes a final snapshot so the timeline includes the timed out condition.
+			cguTimeline := recorder.Stop()
+
 			By("validating that the policy succeeded on spoke1")
 			catSrcExistsOnSpoke1 := olm.NewCatalogSourceBuilder(
 				Spoke1APIClient, tsparams.CatalogSourceName, tsparams.TemporaryNamespace).Exists()
@@ -191,6 +198,14 @@ var _ = Describe("TALM Batching Tests", Label(tsparams.LabelBatchingTestCases),
 			catSrcExistsOnSpoke2 := olm.NewCatalogSourceBuilder(
 				Spoke2APIClient, tsparams.CatalogSourceName, tsparams.TemporaryNamespace).Exists()
 			Expect(catSrcExistsOnSpoke2).To(BeFalse(), "Catalog source exists on spoke 2")
+
+			By("validating that only spoke2 timed out in the batch")
+			Expect(cguTimeline).To(timeline.HaveSequence(
+				tsparams.ProgressingType,
+				fmt.Sprintf("ClusterCompleted(%s)", RANConfig.Spoke1Name),
+				fmt.Sprintf("BatchTimedOut(%s)", RANConfig.Spoke2Name),
+				tsparams.TimedOutReason))
+			Expect(cguTimeline).ToNot(timeline.HaveSequence(fmt.Sprintf("BatchTimedOut(%s)", RANConfig.Spoke1Name)))
 		})
 
 		// 74753 upgrade failure of first batch would not affect second batch
@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/cgu"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/rancluster"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranconfig"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/helper"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/setup"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/timeline"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/tsparams"
+)
+
+var _ = Describe("TALM Fleet Tests", Label(tsparams.LabelFleetTestCases), func() {
+	var (
+		spokes   []*ranconfig.SpokeConfig
+		canaries []*ranconfig.SpokeConfig
+	)
+
+	BeforeEach(func() {
+		By("checking that the hub is present")
+		Expect(HubAPIClient).ToNot(BeNil(), "Failed due to missing hub API client")
+
+		By(fmt.Sprintf("checking that at least %d spokes are present", tsparams.FleetMinimumSpokes))
+
+		var err error
+
+		spokes, err = rancluster.SelectSpokes("", 0)
+		Expect(err).ToNot(HaveOccurred(), "Failed to select spokes")
+
+		if len(spokes) < tsparams.FleetMinimumSpokes {
+			Skip(fmt.Sprintf("TALM fleet tests require at least %d spokes but found %d",
+				tsparams.FleetMinimumSpokes, len(spokes)))
+		}
+
+		By(fmt.Sprintf("selecting canaries using selector %s", tsparams.FleetCanarySelector))
+		canaries, err = rancluster.FilterSpokes(spokes, tsparams.FleetCanarySelector, 0)
+		Expect(err).ToNot(HaveOccurred(), "Failed to select canary spokes")
+
+		if len(canaries) == 0 {
+			canaries = spokes[:1]
+		}
+	})
+
+	AfterEach(func() {
+		By("cleaning up resources on hub")
+		errorList := setup.CleanupTestResourcesOnHub(HubAPIClient, tsparams.TestNamespace, "")
+		Expect(errorList).To(BeEmpty(), "Failed to clean up test resources on hub")
+
+		By("cleaning up resources on spokes")
+		errorList = setup.CleanupTestResourcesOnSpokes(rancluster.SpokeAPIClients(spokes), "")
+		Expect(errorList).To(BeEmpty(), "Failed to clean up test resources on spokes")
+	})
+
+	// 82954 - Complete the CGU across the fleet in batches after the canaries
+	It("should complete the CGU across the fleet in batches after the canaries", reportxml.ID("82954"), func() {
+		spokeNames := rancluster.SpokeNames(spokes)
+		canaryNames := rancluster.SpokeNames(canaries)
+
+		By("recording the CGU timeline")
+		recorder := timeline.Record(HubAPIClient, tsparams.CguName, tsparams.TestNamespace)
+
+		By("creating the CGU and associated resources")
+		cguBuilder := cgu.NewCguBuilder(
+			HubAPIClient, tsparams.CguName, tsparams.TestNamespace, tsparams.FleetMaxConcurrency).
+			WithManagedPolicy(tsparams.PolicyName)
+
+		for _, spokeName := range spokeNames {
+			cguBuilder = cguBuilder.WithCluster(spokeName)
+		}
+
+		for _, canaryName := range canaryNames {
+			cguBuilder = cguBuilder.WithCanary(canaryName)
+		}
+
+		cguBuilder.Definition.Spec.RemediationStrategy.Timeout = 5 * len(spokes)
+		cguTimeout := time.Duration(cguBuilder.Definition.Spec.RemediationStrategy.Timeout) * time.Minute
+
+		cguBuilder, err := helper.SetupCguWithNamespace(cguBuilder, "")
+		Expect(err).ToNot(HaveOccurred(), "Failed to setup CGU")
+
+		By("waiting for the CGU to finish successfully")
+		cguBuilder, err = cguBuilder.WaitForCondition(
+			tsparams.CguSuccessfulFinishCondition, cguTimeout+5*time.Minute)
+		Expect(err).ToNot(HaveOccurred(), "Failed to wait for CGU to finish successfully")
+
+		// Stopping the recorder takes a final snapshot so the timeline includes the last batch.
+		cguTimeline := recorder.Stop()
+
+		By("verifying the remediation plan respects canaries and max concurrency")
+		var plannedNames []string
+
+		remediationPlan := cguBuilder.Object.Status.RemediationPlan
+		for index, batch := range remediationPlan {
+			Expect(len(batch)).To(BeNumerically("<=", tsparams.FleetMaxConcurrency),
+				"Batch %d has more clusters than the max concurrency", index)
+
+			plannedNames = append(plannedNames, batch...)
+		}
+
+		Expect(plannedNames).To(ConsistOf(spokeNames), "Remediation plan does not contain every spoke exactly once")
+
+		for index, spokeName := range plannedNames {
+			if index < len(canaryNames) {
+				Expect(canaryNames).To(ContainElement(spokeName), "Non-canary %s was remediated before a canary", spokeName)
+			}
+		}
+
+		By("verifying the canaries completed before the other batches started")
+		for _, canaryName := range canaryNames {
+			Expect(cguTimeline).To(timeline.HaveSequence(
+				fmt.Sprintf("ClusterCompleted(%s)", canaryName), fmt.Sprintf("Batch(%d)", len(remediationPlan))))
+		}
+
+		By("verifying no batch took longer than the CGU timeout")
+		Expect(cguTimeline).To(timeline.HavePhasesWithin("Batch", cguTimeout))
+
+		By("verifying the temporary namespace exists on every spoke")
+		for _, spoke := range spokes {
+			Expect(namespace.NewBuilder(spoke.APIClient, tsparams.TemporaryNamespace).Exists()).
+				To(BeTrue(), "Temporary namespace does not exist on spoke %s", spoke.Name)
+		}
+	})
+})
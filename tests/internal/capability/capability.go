@@ -0,0 +1,177 @@
+// Package capability probes a cluster for the prerequisites of a suite or spec, such as installed operators, node
+// counts, topology, connectivity, NIC models, and BMC access. Missing prerequisites are turned into Ginkgo skips with
+// a machine-readable reason so reports can tell specs that do not apply to a cluster apart from specs that failed.
+package capability
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+)
+
+// SkipReasonPrefix starts the message of every skip caused by missing capabilities. It is followed by the JSON encoded
+// SkipReason so that dashboards can extract it from the JUnit and reportxml skip messages.
+const SkipReasonPrefix = "capability-missing: "
+
+// ReportEntryName is the name of the Ginkgo report entry containing the SkipReason for a skipped spec.
+const ReportEntryName = "capability-missing"
+
+// Status is the outcome of probing a single requirement.
+type Status string
+
+const (
+	// StatusSatisfied means the cluster has the capability.
+	StatusSatisfied Status = "satisfied"
+	// StatusMissing means the cluster was successfully probed and does not have the capability.
+	StatusMissing Status = "missing"
+	// StatusError means the cluster could not be probed, so whether it has the capability is unknown.
+	StatusError Status = "error"
+)
+
+// Requirement is a single capability a cluster must have. Requirements are created with the constructors in this
+// package, such as Operator or MinNodes, and can be checked together using Check or SkipUnless.
+type Requirement struct {
+	// Kind is the type of requirement, such as "operator" or "nodes".
+	Kind string
+	// Name identifies the requirement within its kind, such as the operator name.
+	Name string
+	// probe returns an empty string if the requirement is satisfied or a description of what is missing otherwise.
+	// Errors mean the cluster could not be probed.
+	probe func(apiClient *clients.Settings) (string, error)
+}
+
+// NewRequirement creates a custom Requirement. The probe returns an empty string if the requirement is satisfied or a
+// description of what is missing otherwise. It returns an error only if the cluster could not be probed.
+func NewRequirement(kind, name string, probe func(apiClient *clients.Settings) (string, error)) Requirement {
+	return Requirement{Kind: kind, Name: name, probe: probe}
+}
+
+// String returns the kind and name of the requirement.
+func (requirement Requirement) String() string {
+	return fmt.Sprintf("%s/%s", requirement.Kind, requirement.Name)
+}
+
+// Result is the outcome of probing a single requirement.
+type Result struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	// Reason describes what is missing or why probing failed. It is empty if the requirement is satisfied.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Results is the outcome of probing multiple requirements.
+type Results []Result
+
+// Check probes every requirement on the cluster of apiClient and returns the results in the same order. All the
+// requirements are always probed so the results describe every missing capability at once.
+func Check(apiClient *clients.Settings, requirements ...Requirement) Results {
+	var results Results
+
+	for _, requirement := range requirements {
+		result := Result{Kind: requirement.Kind, Name: requirement.Name, Status: StatusSatisfied}
+
+		switch {
+		case apiClient == nil:
+			result.Status = StatusError
+			result.Reason = "apiClient is nil"
+		case requirement.probe == nil:
+			result.Status = StatusError
+			result.Reason = "requirement has no probe"
+		default:
+			missing, err := requirement.probe(apiClient)
+			if err != nil {
+				result.Status = StatusError
+				result.Reason = err.Error()
+			} else if missing != "" {
+				result.Status = StatusMissing
+				result.Reason = missing
+			}
+		}
+
+		glog.V(90).Infof("Capability %s is %s: %s", requirement, result.Status, result.Reason)
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// Satisfied returns whether every requirement was satisfied.
+func (results Results) Satisfied() bool {
+	return len(results.Missing()) == 0 && results.Err() == nil
+}
+
+// Missing returns the results for requirements that the cluster does not have.
+func (results Results) Missing() Results {
+	return results.withStatus(StatusMissing)
+}
+
+// Err returns an error describing every requirement that could not be probed or nil if all were probed.
+func (results Results) Err() error {
+	var errs []error
+
+	for _, result := range results.withStatus(StatusError) {
+		errs = append(errs, fmt.Errorf("failed to probe %s/%s: %s", result.Kind, result.Name, result.Reason))
+	}
+
+	return errors.Join(errs...)
+}
+
+// SkipReason returns the machine-readable skip message for the missing requirements. It starts with SkipReasonPrefix
+// followed by the JSON encoded SkipReason.
+func (results Results) SkipReason() string {
+	missing := results.Missing()
+
+	reason := SkipReason{Missing: missing}
+	for _, result := range missing {
+		reason.Summary = append(reason.Summary, fmt.Sprintf("%s/%s: %s", result.Kind, result.Name, result.Reason))
+	}
+
+	encoded, err := json.Marshal(reason)
+	if err != nil {
+		return SkipReasonPrefix + strings.Join(reason.Summary, "; ")
+	}
+
+	return SkipReasonPrefix + string(encoded)
+}
+
+// SkipReason is the structured reason recorded when a spec is skipped because of missing capabilities.
+type SkipReason struct {
+	Missing Results  `json:"missing"`
+	Summary []string `json:"summary"`
+}
+
+// ParseSkipReason extracts the SkipReason from a skip message. It returns false if the message was not created by
+// Results.SkipReason, for example because the skip had a different cause.
+func ParseSkipReason(message string) (SkipReason, bool) {
+	var reason SkipReason
+
+	_, encoded, found := strings.Cut(message, SkipReasonPrefix)
+	if !found {
+		return reason, false
+	}
+
+	err := json.Unmarshal([]byte(encoded), &reason)
+	if err != nil {
+		return reason, false
+	}
+
+	return reason, true
+}
+
+func (results Results) withStatus(status Status) Results {
+	var filtered Results
+
+	for _, result := range results {
+		if result.Status == status {
+			filtered = append(filtered, result)
+		}
+	}
+
+	return filtered
+}
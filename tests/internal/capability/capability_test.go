@@ -0,0 +1,170 @@
+package capability
+
+import (
+	"errors"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	oplmV1alpha1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/olm/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const workerLabel = "node-role.kubernetes.io/worker"
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		requirement    Requirement
+		objects        []runtime.Object
+		expectedStatus Status
+	}{
+		{
+			requirement:    MinNodes(workerLabel, 2),
+			objects:        []runtime.Object{buildNode("worker-0", workerLabel), buildNode("worker-1", workerLabel)},
+			expectedStatus: StatusSatisfied,
+		},
+		{
+			requirement:    MinNodes(workerLabel, 2),
+			objects:        []runtime.Object{buildNode("worker-0", workerLabel), buildNode("master-0", "")},
+			expectedStatus: StatusMissing,
+		},
+		{
+			requirement:    SNO(),
+			objects:        []runtime.Object{buildInfrastructure(configv1.SingleReplicaTopologyMode)},
+			expectedStatus: StatusSatisfied,
+		},
+		{
+			requirement:    MultiNode(),
+			objects:        []runtime.Object{buildInfrastructure(configv1.SingleReplicaTopologyMode)},
+			expectedStatus: StatusMissing,
+		},
+		{
+			requirement:    SNO(),
+			expectedStatus: StatusError,
+		},
+		{
+			requirement:    OCPVersion(">= 4.16"),
+			objects:        []runtime.Object{buildClusterVersion("4.18.0-rc.1")},
+			expectedStatus: StatusSatisfied,
+		},
+		{
+			requirement:    OCPVersion(">= 4.19"),
+			objects:        []runtime.Object{buildClusterVersion("4.18.3")},
+			expectedStatus: StatusMissing,
+		},
+		{
+			requirement:    OCPVersion("not a constraint"),
+			objects:        []runtime.Object{buildClusterVersion("4.18.3")},
+			expectedStatus: StatusError,
+		},
+		{
+			requirement:    Operator("openshift-sriov", "sriov-network-operator", ">= 4.16"),
+			objects:        []runtime.Object{buildCSV("sriov-network-operator.v4.18.0", "4.18.0", "Succeeded")},
+			expectedStatus: StatusSatisfied,
+		},
+		{
+			requirement:    Operator("openshift-sriov", "sriov-network-operator", ""),
+			objects:        []runtime.Object{buildCSV("sriov-network-operator.v4.18.0", "4.18.0", "Installing")},
+			expectedStatus: StatusMissing,
+		},
+		{
+			requirement:    Operator("openshift-sriov", "sriov-network-operator", ">= 4.19"),
+			objects:        []runtime.Object{buildCSV("sriov-network-operator.v4.18.0", "4.18.0", "Succeeded")},
+			expectedStatus: StatusMissing,
+		},
+		{
+			requirement:    Operator("openshift-sriov", "sriov-network-operator", ""),
+			expectedStatus: StatusMissing,
+		},
+		{
+			requirement:    BMCAccess("", "", ""),
+			expectedStatus: StatusMissing,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testSettings := clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects:  testCase.objects,
+			SchemeAttachers: []clients.SchemeAttacher{configv1.Install, oplmV1alpha1.AddToScheme},
+		})
+
+		results := Check(testSettings, testCase.requirement)
+		assert.Len(t, results, 1)
+		assert.Equal(t, testCase.expectedStatus, results[0].Status, "%s: %s", testCase.requirement, results[0].Reason)
+		assert.Equal(t, testCase.requirement.Kind, results[0].Kind)
+		assert.Equal(t, testCase.requirement.Name, results[0].Name)
+		assert.Equal(t, testCase.expectedStatus == StatusSatisfied, results[0].Reason == "")
+	}
+}
+
+func TestCheckNilClient(t *testing.T) {
+	results := Check(nil, MinNodes(workerLabel, 1))
+	assert.Len(t, results, 1)
+	assert.Equal(t, StatusError, results[0].Status)
+	assert.NotNil(t, results.Err())
+	assert.False(t, results.Satisfied())
+}
+
+func TestSkipReason(t *testing.T) {
+	results := Check(clients.GetTestClients(clients.TestClientParams{}),
+		MinNodes(workerLabel, 0),
+		MinNodes(workerLabel, 3),
+		NewRequirement("custom", "probe-error", func(*clients.Settings) (string, error) {
+			return "", errors.New("probe failed")
+		}))
+
+	assert.False(t, results.Satisfied())
+	assert.Len(t, results.Missing(), 1)
+	assert.ErrorContains(t, results.Err(), "custom/probe-error: probe failed")
+
+	message := results.SkipReason()
+	assert.Contains(t, message, SkipReasonPrefix)
+
+	reason, ok := ParseSkipReason("skipped - " + message)
+	assert.True(t, ok)
+	assert.Equal(t, results.Missing(), reason.Missing)
+	assert.Equal(t, []string{"nodes/3 " + workerLabel + ": found 0 nodes, need at least 3"}, reason.Summary)
+
+	_, ok = ParseSkipReason("skipped - not applicable")
+	assert.False(t, ok)
+}
+
+func buildNode(name, label string) *corev1.Node {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{}}}
+	if label != "" {
+		node.Labels[label] = ""
+	}
+
+	return node
+}
+
+func buildInfrastructure(topology configv1.TopologyMode) *configv1.Infrastructure {
+	return &configv1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Status:     configv1.InfrastructureStatus{ControlPlaneTopology: topology},
+	}
+}
+
+func buildClusterVersion(version string) *configv1.ClusterVersion {
+	return &configv1.ClusterVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "version"},
+		Status:     configv1.ClusterVersionStatus{Desired: configv1.Release{Version: version}},
+	}
+}
+
+func buildCSV(name, version string, phase oplmV1alpha1.ClusterServiceVersionPhase) *oplmV1alpha1.ClusterServiceVersion {
+	csv := &oplmV1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openshift-sriov"},
+		Status:     oplmV1alpha1.ClusterServiceVersionStatus{Phase: phase},
+	}
+
+	err := csv.Spec.Version.UnmarshalJSON([]byte(`"` + version + `"`))
+	if err != nil {
+		panic(err)
+	}
+
+	return csv
+}
@@ -0,0 +1,24 @@
+package capability
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+)
+
+// SkipUnless checks every requirement and skips the current node if any are missing. The SkipReason is added as a
+// report entry and used as the skip message. If any requirement could not be probed, the node fails instead since the
+// cluster may be broken rather than lacking the capability. Calling it in BeforeSuite skips the entire suite.
+func SkipUnless(apiClient *clients.Settings, requirements ...Requirement) {
+	ginkgo.GinkgoHelper()
+
+	results := Check(apiClient, requirements...)
+
+	if err := results.Err(); err != nil {
+		ginkgo.Fail(err.Error())
+	}
+
+	if missing := results.Missing(); len(missing) > 0 {
+		ginkgo.AddReportEntry(ReportEntryName, missing, ginkgo.ReportEntryVisibilityFailureOrVerbose)
+		ginkgo.Skip(results.SkipReason())
+	}
+}
@@ -0,0 +1,236 @@
+package capability
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/infrastructure"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/olm"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/sriov"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Operator requires a ClusterServiceVersion whose name starts with csvPrefix to have succeeded in namespace. If
+// versionConstraint is not empty, the CSV version must also satisfy it, for example ">= 4.16" or "~> 4.18.0".
+func Operator(namespace, csvPrefix, versionConstraint string) Requirement {
+	name := csvPrefix
+	if versionConstraint != "" {
+		name = fmt.Sprintf("%s %s", csvPrefix, versionConstraint)
+	}
+
+	return NewRequirement("operator", name, func(apiClient *clients.Settings) (string, error) {
+		var constraints version.Constraints
+
+		if versionConstraint != "" {
+			var err error
+
+			constraints, err = version.NewConstraint(versionConstraint)
+			if err != nil {
+				return "", fmt.Errorf("invalid version constraint %q: %w", versionConstraint, err)
+			}
+		}
+
+		csvs, err := olm.ListClusterServiceVersion(apiClient, namespace)
+		if err != nil {
+			return "", fmt.Errorf("failed to list CSVs in namespace %s: %w", namespace, err)
+		}
+
+		var found []string
+
+		for _, csv := range csvs {
+			if !strings.HasPrefix(csv.Object.Name, csvPrefix) {
+				continue
+			}
+
+			csvVersion := csv.Object.Spec.Version.String()
+			found = append(found, fmt.Sprintf("%s (%s)", csv.Object.Name, csv.Object.Status.Phase))
+
+			if csv.Object.Status.Phase != "Succeeded" {
+				continue
+			}
+
+			if constraints == nil {
+				return "", nil
+			}
+
+			parsedVersion, err := version.NewVersion(csvVersion)
+			if err != nil {
+				continue
+			}
+
+			if constraints.Check(parsedVersion) {
+				return "", nil
+			}
+		}
+
+		if len(found) == 0 {
+			return fmt.Sprintf("no CSV with prefix %s found in namespace %s", csvPrefix, namespace), nil
+		}
+
+		return fmt.Sprintf("no succeeded CSV matching %s found in namespace %s, found: %s",
+			name, namespace, strings.Join(found, ", ")), nil
+	})
+}
+
+// OCPVersion requires the OCP version of the cluster to satisfy versionConstraint, for example ">= 4.16".
+func OCPVersion(versionConstraint string) Requirement {
+	return NewRequirement("ocp-version", versionConstraint, func(apiClient *clients.Settings) (string, error) {
+		constraints, err := version.NewConstraint(versionConstraint)
+		if err != nil {
+			return "", fmt.Errorf("invalid version constraint %q: %w", versionConstraint, err)
+		}
+
+		clusterVersion, err := cluster.GetOCPClusterVersion(apiClient)
+		if err != nil {
+			return "", err
+		}
+
+		ocpVersion, err := version.NewVersion(clusterVersion.Object.Status.Desired.Version)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse OCP version %q: %w", clusterVersion.Object.Status.Desired.Version, err)
+		}
+
+		// Prerelease versions such as 4.18.0-rc.1 never satisfy constraints without a prerelease, so only the core
+		// version is compared.
+		if constraints.Check(ocpVersion.Core()) {
+			return "", nil
+		}
+
+		return fmt.Sprintf("OCP version is %s", ocpVersion), nil
+	})
+}
+
+// MinNodes requires at least count nodes with the label roleLabel, for example "node-role.kubernetes.io/worker". An
+// empty roleLabel counts every node.
+func MinNodes(roleLabel string, count int) Requirement {
+	name := fmt.Sprintf("%d %s", count, roleLabel)
+	if roleLabel == "" {
+		name = fmt.Sprintf("%d", count)
+	}
+
+	return NewRequirement("nodes", name, func(apiClient *clients.Settings) (string, error) {
+		nodeList, err := nodes.List(apiClient, metav1.ListOptions{LabelSelector: roleLabel})
+		if err != nil {
+			return "", fmt.Errorf("failed to list nodes: %w", err)
+		}
+
+		if len(nodeList) >= count {
+			return "", nil
+		}
+
+		return fmt.Sprintf("found %d nodes, need at least %d", len(nodeList), count), nil
+	})
+}
+
+// SNO requires the cluster to be a single node OpenShift cluster.
+func SNO() Requirement {
+	return topology("sno", configv1.SingleReplicaTopologyMode)
+}
+
+// MultiNode requires the cluster to have a highly available control plane.
+func MultiNode() Requirement {
+	return topology("multi-node", configv1.HighlyAvailableTopologyMode)
+}
+
+// Connected requires the cluster to be able to retrieve updates.
+func Connected() Requirement {
+	return NewRequirement("network", "connected", func(apiClient *clients.Settings) (string, error) {
+		connected, err := cluster.Connected(apiClient)
+		if err != nil {
+			return "", err
+		}
+
+		if connected {
+			return "", nil
+		}
+
+		return "cluster is disconnected", nil
+	})
+}
+
+// Disconnected requires the cluster to be unable to retrieve updates.
+func Disconnected() Requirement {
+	return NewRequirement("network", "disconnected", func(apiClient *clients.Settings) (string, error) {
+		disconnected, err := cluster.Disconnected(apiClient)
+		if err != nil {
+			return "", err
+		}
+
+		if disconnected {
+			return "", nil
+		}
+
+		return "cluster is connected", nil
+	})
+}
+
+// NICModel requires at least one SR-IOV interface with the vendor ID, and one of the device IDs if any are provided,
+// in the SriovNetworkNodeStates in sriovNamespace. IDs are hexadecimal strings such as "8086" and "159b".
+func NICModel(sriovNamespace, vendorID string, deviceIDs ...string) Requirement {
+	name := vendorID
+	if len(deviceIDs) > 0 {
+		name = fmt.Sprintf("%s:%s", vendorID, strings.Join(deviceIDs, ","))
+	}
+
+	return NewRequirement("nic", name, func(apiClient *clients.Settings) (string, error) {
+		nodeStates, err := sriov.ListNetworkNodeState(apiClient, sriovNamespace)
+		if err != nil {
+			return "", fmt.Errorf("failed to list SriovNetworkNodeStates in namespace %s: %w", sriovNamespace, err)
+		}
+
+		for _, nodeState := range nodeStates {
+			for _, iface := range nodeState.Objects.Status.Interfaces {
+				if !strings.EqualFold(iface.Vendor, vendorID) {
+					continue
+				}
+
+				if len(deviceIDs) == 0 || slices.ContainsFunc(deviceIDs, func(deviceID string) bool {
+					return strings.EqualFold(iface.DeviceID, deviceID)
+				}) {
+					return "", nil
+				}
+			}
+		}
+
+		return fmt.Sprintf("no SR-IOV interface matching %s found on %d nodes", name, len(nodeStates)), nil
+	})
+}
+
+// BMCAccess requires the Redfish API of the BMC at host to be reachable with the provided credentials. An empty host
+// is reported as missing so suites can depend on optional BMC configuration.
+func BMCAccess(host, username, password string) Requirement {
+	return NewRequirement("bmc", host, func(*clients.Settings) (string, error) {
+		if host == "" {
+			return "BMC host is not configured", nil
+		}
+
+		_, err := bmc.New(host).WithRedfishUser(username, password).SystemPowerState()
+		if err != nil {
+			return fmt.Sprintf("failed to reach BMC Redfish API: %v", err), nil
+		}
+
+		return "", nil
+	})
+}
+
+func topology(name string, mode configv1.TopologyMode) Requirement {
+	return NewRequirement("topology", name, func(apiClient *clients.Settings) (string, error) {
+		infraConfig, err := infrastructure.Pull(apiClient)
+		if err != nil {
+			return "", err
+		}
+
+		if infraConfig.Object.Status.ControlPlaneTopology == mode {
+			return "", nil
+		}
+
+		return fmt.Sprintf("control plane topology is %s", infraConfig.Object.Status.ControlPlaneTopology), nil
+	})
+}
@@ -0,0 +1,202 @@
+package cluster
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	mcv1 "github.com/openshift/api/machineconfiguration/v1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/infrastructure"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/mco"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/olm"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/fingerprint"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+	// csvCopiedFromLabel is set by OLM on the copies of CSVs for operators watching all namespaces.
+	csvCopiedFromLabel = "olm.copiedFrom"
+)
+
+// GetClusterFingerprint captures the fingerprint of an arbitrary cluster: its OCP version and history, network type,
+// platform, nodes, installed operators, and machine config pools. Failing to capture a section is recorded in the
+// Errors of the fingerprint rather than returned, so only an invalid APIClient results in an error.
+func GetClusterFingerprint(clusterObj APIClientGetter) (*fingerprint.Fingerprint, error) {
+	apiClient, err := checkAPIClient(clusterObj)
+	if err != nil {
+		return nil, err
+	}
+
+	glog.V(90).Infof("Capturing fingerprint of cluster at %s", apiClient.KubeconfigPath)
+
+	clusterFingerprint := &fingerprint.Fingerprint{Captured: time.Now()}
+
+	addFingerprintVersion(clusterObj, clusterFingerprint)
+	addFingerprintInfrastructure(clusterObj, apiClient, clusterFingerprint)
+	addFingerprintNodes(apiClient, clusterFingerprint)
+	addFingerprintOperators(apiClient, clusterFingerprint)
+	addFingerprintMCPs(apiClient, clusterFingerprint)
+
+	clusterFingerprint.Sort()
+
+	return clusterFingerprint, nil
+}
+
+func addFingerprintVersion(clusterObj APIClientGetter, clusterFingerprint *fingerprint.Fingerprint) {
+	clusterVersion, err := GetOCPClusterVersion(clusterObj)
+	if err != nil {
+		clusterFingerprint.AddError("clusterversion", err)
+
+		return
+	}
+
+	clusterFingerprint.OCPVersion = clusterVersion.Object.Status.Desired.Version
+
+	for _, update := range clusterVersion.Object.Status.History {
+		history := fingerprint.VersionHistory{
+			Version: update.Version,
+			State:   string(update.State),
+			Image:   update.Image,
+			Started: update.StartedTime.Time,
+		}
+
+		if update.CompletionTime != nil {
+			history.Completed = &update.CompletionTime.Time
+		}
+
+		clusterFingerprint.VersionHistory = append(clusterFingerprint.VersionHistory, history)
+	}
+}
+
+func addFingerprintInfrastructure(
+	clusterObj APIClientGetter, apiClient *clients.Settings, clusterFingerprint *fingerprint.Fingerprint) {
+	clusterNetwork, err := GetOCPNetworkConfig(clusterObj)
+	if err != nil {
+		clusterFingerprint.AddError("network", err)
+	} else {
+		clusterFingerprint.NetworkType = clusterNetwork.Object.Status.NetworkType
+	}
+
+	clusterProxy, err := GetOCPProxy(clusterObj)
+	if err != nil {
+		clusterFingerprint.AddError("proxy", err)
+	} else {
+		clusterFingerprint.Proxied = clusterProxy.Object.Status.HTTPProxy != "" ||
+			clusterProxy.Object.Status.HTTPSProxy != ""
+	}
+
+	infraConfig, err := infrastructure.Pull(apiClient)
+	if err != nil {
+		clusterFingerprint.AddError("infrastructure", err)
+
+		return
+	}
+
+	clusterFingerprint.Platform = string(infraConfig.Object.Spec.PlatformSpec.Type)
+	clusterFingerprint.ControlPlaneTopology = string(infraConfig.Object.Status.ControlPlaneTopology)
+
+	clusterFingerprint.ClusterName, err = GetOCPClusterName(clusterObj)
+	if err != nil {
+		clusterFingerprint.AddError("cluster name", err)
+	}
+}
+
+func addFingerprintNodes(apiClient *clients.Settings, clusterFingerprint *fingerprint.Fingerprint) {
+	nodeList, err := nodes.List(apiClient)
+	if err != nil {
+		clusterFingerprint.AddError("nodes", err)
+
+		return
+	}
+
+	for _, node := range nodeList {
+		nodeInfo := node.Object.Status.NodeInfo
+		fingerprintNode := fingerprint.Node{
+			Name:             node.Object.Name,
+			KernelVersion:    nodeInfo.KernelVersion,
+			OSImage:          nodeInfo.OSImage,
+			KubeletVersion:   nodeInfo.KubeletVersion,
+			ContainerRuntime: nodeInfo.ContainerRuntimeVersion,
+			Architecture:     nodeInfo.Architecture,
+		}
+
+		for label := range node.Object.Labels {
+			if role, found := strings.CutPrefix(label, nodeRoleLabelPrefix); found {
+				fingerprintNode.Roles = append(fingerprintNode.Roles, role)
+			}
+		}
+
+		slices.Sort(fingerprintNode.Roles)
+
+		for _, condition := range node.Object.Status.Conditions {
+			if condition.Type == corev1.NodeReady {
+				fingerprintNode.Ready = condition.Status == corev1.ConditionTrue
+			}
+		}
+
+		clusterFingerprint.Nodes = append(clusterFingerprint.Nodes, fingerprintNode)
+	}
+}
+
+func addFingerprintOperators(apiClient *clients.Settings, clusterFingerprint *fingerprint.Fingerprint) {
+	csvList, err := olm.ListClusterServiceVersionInAllNamespaces(apiClient)
+	if err != nil {
+		clusterFingerprint.AddError("operators", err)
+
+		return
+	}
+
+	for _, csv := range csvList {
+		if _, copied := csv.Object.Labels[csvCopiedFromLabel]; copied {
+			continue
+		}
+
+		clusterFingerprint.Operators = append(clusterFingerprint.Operators, fingerprint.Operator{
+			Name:      csv.Object.Name,
+			Namespace: csv.Object.Namespace,
+			Version:   csv.Object.Spec.Version.String(),
+			Phase:     string(csv.Object.Status.Phase),
+		})
+	}
+}
+
+func addFingerprintMCPs(apiClient *clients.Settings, clusterFingerprint *fingerprint.Fingerprint) {
+	mcpList, err := mco.ListMCP(apiClient)
+	if err != nil {
+		clusterFingerprint.AddError("machineconfigpools", err)
+
+		return
+	}
+
+	for _, mcp := range mcpList {
+		status := mcp.Object.Status
+		pool := fingerprint.MachineConfigPool{
+			Name:                 mcp.Object.Name,
+			Configuration:        status.Configuration.Name,
+			MachineCount:         status.MachineCount,
+			ReadyMachineCount:    status.ReadyMachineCount,
+			UpdatedMachineCount:  status.UpdatedMachineCount,
+			DegradedMachineCount: status.DegradedMachineCount,
+		}
+
+		for _, condition := range status.Conditions {
+			isTrue := condition.Status == corev1.ConditionTrue
+
+			//nolint:exhaustive
+			switch condition.Type {
+			case mcv1.MachineConfigPoolUpdated:
+				pool.Updated = isTrue
+			case mcv1.MachineConfigPoolUpdating:
+				pool.Updating = isTrue
+			case mcv1.MachineConfigPoolDegraded:
+				pool.Degraded = isTrue
+			}
+		}
+
+		clusterFingerprint.MachineConfigPools = append(clusterFingerprint.MachineConfigPools, pool)
+	}
+}
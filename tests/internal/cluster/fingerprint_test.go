@@ -0,0 +1,112 @@
+package cluster
+
+import (
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/fingerprint"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetClusterFingerprint(t *testing.T) {
+	testCases := []struct {
+		runtimeObjs        []runtime.Object
+		expectedVersion    string
+		expectedPlatform   string
+		expectedNodes      []fingerprint.Node
+		expectedErrorCount int
+	}{
+		{
+			runtimeObjs: []runtime.Object{
+				generateFakeClusterVersion("4.18.3"),
+				generateFakeNetworkConfig(),
+				generateFakeConfigObject("cluster"),
+				generateFakeInfrastructure("kni-qe-12-p746q", configv1.BareMetalPlatformType),
+				generateFakeNode("worker-1", "worker", true),
+				generateFakeNode("master-0", "master", false),
+			},
+			expectedVersion:  "4.18.3",
+			expectedPlatform: "BareMetal",
+			expectedNodes: []fingerprint.Node{
+				{Name: "master-0", Roles: []string{"master"}, KernelVersion: "5.14.0", OSImage: "RHCOS"},
+				{Name: "worker-1", Roles: []string{"worker"}, Ready: true, KernelVersion: "5.14.0", OSImage: "RHCOS"},
+			},
+		},
+		{
+			runtimeObjs: []runtime.Object{
+				generateFakeNetworkConfig(),
+				generateFakeConfigObject("cluster"),
+				generateFakeInfrastructure("kni-qe-12-p746q", configv1.BareMetalPlatformType),
+			},
+			expectedPlatform:   "BareMetal",
+			expectedErrorCount: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		// The config clientset used by SetFakeOCPClient cannot hold nodes and is not needed by the fingerprint.
+		mockAPIClient := NewMockAPIClientGetter()
+		mockAPIClient.testClients = clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects:  testCase.runtimeObjs,
+			SchemeAttachers: []clients.SchemeAttacher{configv1.Install},
+		})
+
+		clusterFingerprint, err := GetClusterFingerprint(mockAPIClient)
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedVersion, clusterFingerprint.OCPVersion)
+		assert.Equal(t, testCase.expectedPlatform, clusterFingerprint.Platform)
+		assert.Equal(t, "kni-qe-12", clusterFingerprint.ClusterName)
+		assert.Equal(t, testCase.expectedNodes, clusterFingerprint.Nodes)
+		assert.Len(t, clusterFingerprint.Errors, testCase.expectedErrorCount, clusterFingerprint.Errors)
+	}
+
+	_, err := GetClusterFingerprint(NewMockAPIClientGetter())
+	assert.NotNil(t, err)
+}
+
+func generateFakeInfrastructure(infrastructureName string, platform configv1.PlatformType) runtime.Object {
+	return &configv1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+		},
+		Spec: configv1.InfrastructureSpec{
+			PlatformSpec: configv1.PlatformSpec{
+				Type: platform,
+			},
+		},
+		Status: configv1.InfrastructureStatus{
+			InfrastructureName:   infrastructureName,
+			ControlPlaneTopology: configv1.HighlyAvailableTopologyMode,
+		},
+	}
+}
+
+func generateFakeNode(name, role string, ready bool) runtime.Object {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{nodeRoleLabelPrefix + role: ""},
+		},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{
+				KernelVersion: "5.14.0",
+				OSImage:       "RHCOS",
+			},
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:   corev1.NodeReady,
+					Status: readyStatus,
+				},
+			},
+		},
+	}
+}
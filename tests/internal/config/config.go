@@ -7,36 +7,42 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
-
-	"github.com/kelseyhightower/envconfig"
-	"gopkg.in/yaml.v2"
 )
 
 const (
 	// PathToDefaultParamsFile path to config file with default parameters.
 	PathToDefaultParamsFile = "./default.yaml"
+	// EnvReportAttempt is the environment variable the test runner sets to the number of the current attempt when it
+	// reruns the failed specs of a suite. It must be kept in sync with the test runner in internal/testrunner.
+	EnvReportAttempt = "ECO_REPORT_ATTEMPT"
 )
 
 // GeneralConfig type keeps general configuration.
 type GeneralConfig struct {
-	ReportsDirAbsPath         string `yaml:"reports_dump_dir" envconfig:"ECO_REPORTS_DUMP_DIR"`
-	VerboseLevel              string `yaml:"verbose_level" envconfig:"ECO_VERBOSE_LEVEL"`
-	DumpFailedTests           bool   `yaml:"dump_failed_tests" envconfig:"ECO_DUMP_FAILED_TESTS"`
-	EnableReport              bool   `yaml:"enable_report" envconfig:"ECO_ENABLE_REPORT"`
-	DryRun                    bool   `yaml:"dry_run" envconfig:"ECO_DRY_RUN"`
-	SSHKeyPath                string `envconfig:"ECO_SSH_KEY_PATH"`
-	SSHUser                   string `yaml:"ssh_user" envconfig:"ECO_SSH_USER"`
-	KubernetesRolePrefix      string `yaml:"kubernetes_role_prefix" envconfig:"ECO_KUBERNETES_ROLE_PREFIX"`
-	WorkerLabelEnvVar         string `yaml:"worker_label" envconfig:"ECO_WORKER_LABEL"`
-	WorkerLabel               string
-	ControlPlaneLabel         string `yaml:"control_plane_label" envconfig:"ECO_CONTROL_PLANE_LABEL"`
-	TCPrefix                  string `yaml:"tc_prefix" envconfig:"ECO_TC_PREFIX"`
-	MCONamespace              string `yaml:"mco_namespace" envconfig:"ECO_MCO_NAMESPACE"`
-	LoggingOperatorNamespace  string `yaml:"logging_operator_namespace" envconfig:"ECO_LOGGING_OPERATOR_NAMESPACE"`
-	MCOConfigDaemonName       string `yaml:"mco_config_daemon_name" envconfig:"ECO_MCO_CONFIG_DAEMON_NAME"`
-	SriovOperatorNamespace    string `yaml:"sriov_operator_namespace" envconfig:"ECO_SRIOV_OPERATOR_NAMESPACE"`
-	NMStateOperatorNamespace  string `yaml:"nmstate_operator_namespace" envconfig:"ECO_NMSTATE_OPERATOR_NAMESPACE"`
-	SriovFecOperatorNamespace string `yaml:"sriov_fec_operator_namespace" envconfig:"ECO_SRIOV_FEC_OPERATOR_NAMESPACE"`
+	ReportsDirAbsPath string `yaml:"reports_dump_dir" envconfig:"ECO_REPORTS_DUMP_DIR" validate:"required"`
+	VerboseLevel      string `yaml:"verbose_level" envconfig:"ECO_VERBOSE_LEVEL"`
+	DumpFailedTests   bool   `yaml:"dump_failed_tests" envconfig:"ECO_DUMP_FAILED_TESTS"`
+	EnableReport      bool   `yaml:"enable_report" envconfig:"ECO_ENABLE_REPORT"`
+	DryRun            bool   `yaml:"dry_run" envconfig:"ECO_DRY_RUN"`
+	SSHKeyPath        string `envconfig:"ECO_SSH_KEY_PATH"`
+	SSHUser           string `yaml:"ssh_user" envconfig:"ECO_SSH_USER"`
+	//nolint:lll
+	KubernetesRolePrefix string `yaml:"kubernetes_role_prefix" envconfig:"ECO_KUBERNETES_ROLE_PREFIX" validate:"required"`
+	WorkerLabelEnvVar    string `yaml:"worker_label" envconfig:"ECO_WORKER_LABEL" validate:"required"`
+	WorkerLabel          string
+	ControlPlaneLabel    string `yaml:"control_plane_label" envconfig:"ECO_CONTROL_PLANE_LABEL" validate:"required"`
+	TCPrefix             string `yaml:"tc_prefix" envconfig:"ECO_TC_PREFIX"`
+	MCONamespace         string `yaml:"mco_namespace" envconfig:"ECO_MCO_NAMESPACE" validate:"required"`
+	//nolint:lll
+	LoggingOperatorNamespace string `yaml:"logging_operator_namespace" envconfig:"ECO_LOGGING_OPERATOR_NAMESPACE" validate:"required"`
+	MCOConfigDaemonName      string `yaml:"mco_config_daemon_name" envconfig:"ECO_MCO_CONFIG_DAEMON_NAME"`
+	//nolint:lll
+	SriovOperatorNamespace string `yaml:"sriov_operator_namespace" envconfig:"ECO_SRIOV_OPERATOR_NAMESPACE" validate:"required"`
+	//nolint:lll
+	NMStateOperatorNamespace string `yaml:"nmstate_operator_namespace" envconfig:"ECO_NMSTATE_OPERATOR_NAMESPACE" validate:"required"`
+	//nolint:lll
+	SriovFecOperatorNamespace string `yaml:"sriov_fec_operator_namespace" envconfig:"ECO_SRIOV_FEC_OPERATOR_NAMESPACE" validate:"required"`
+	ReportAttempt             int    `envconfig:"ECO_REPORT_ATTEMPT"`
 	WorkerLabelMap            map[string]string
 	ControlPlaneLabelMap      map[string]string
 }
@@ -50,21 +56,15 @@ func NewConfig() *GeneralConfig {
 	_, filename, _, _ := runtime.Caller(0)
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultParamsFile)
-	err := readFile(&conf, confFile)
+	err := Load(&conf, confFile)
 
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
+		log.Printf("Error to load general config: %v", err)
 
 		return nil
 	}
 
-	err = readEnv(&conf)
-
-	if err != nil {
-		log.Print("Error to read environment variables")
-
-		return nil
-	}
+	conf.setLabels()
 
 	err = deployReportDir(conf.ReportsDirAbsPath)
 
@@ -77,10 +77,15 @@ func NewConfig() *GeneralConfig {
 	return &conf
 }
 
-// GetJunitReportPath returns full path to the junit report file.
+// GetJunitReportPath returns full path to the junit report file. When the test runner reruns failed specs, the attempt
+// number from ECO_REPORT_ATTEMPT is added to the file name so a retry does not overwrite the report of the first run.
 func (cfg *GeneralConfig) GetJunitReportPath(file string) string {
 	reportFileName := strings.TrimSuffix(filepath.Base(file), filepath.Ext(filepath.Base(file)))
 
+	if cfg.ReportAttempt > 0 {
+		reportFileName = fmt.Sprintf("%s_attempt%d", reportFileName, cfg.ReportAttempt)
+	}
+
 	return fmt.Sprintf("%s_junit.xml", filepath.Join(cfg.ReportsDirAbsPath, reportFileName))
 }
 
@@ -113,38 +118,12 @@ func (cfg *GeneralConfig) GetDumpFailedTestReportLocation(file string) string {
 	return ""
 }
 
-func readFile(cfg *GeneralConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-	err = decoder.Decode(&cfg)
-
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(cfg *GeneralConfig) error {
-	err := envconfig.Process("", cfg)
-	if err != nil {
-		return err
-	}
-
+// setLabels builds the node role labels from the role prefix and the configured role names.
+func (cfg *GeneralConfig) setLabels() {
 	cfg.WorkerLabel = fmt.Sprintf("%s/%s", cfg.KubernetesRolePrefix, cfg.WorkerLabelEnvVar)
 	cfg.ControlPlaneLabel = fmt.Sprintf("%s/%s", cfg.KubernetesRolePrefix, cfg.ControlPlaneLabel)
 	cfg.WorkerLabelMap = map[string]string{cfg.WorkerLabel: ""}
 	cfg.ControlPlaneLabelMap = map[string]string{cfg.ControlPlaneLabel: ""}
-
-	return nil
 }
 
 func deployReportDir(dirName string) error {
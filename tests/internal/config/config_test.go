@@ -1 +1,42 @@
 package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetJunitReportPath(t *testing.T) {
+	testCases := []struct {
+		name     string
+		attempt  int
+		expected string
+	}{
+		{
+			name:     "first attempt",
+			expected: "/reports/sriov_suite_test_junit.xml",
+		},
+		{
+			name:     "retry",
+			attempt:  2,
+			expected: "/reports/sriov_suite_test_attempt2_junit.xml",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg := GeneralConfig{ReportsDirAbsPath: "/reports", ReportAttempt: testCase.attempt}
+
+			assert.Equal(t, testCase.expected, cfg.GetJunitReportPath("/tests/sriov/sriov_suite_test.go"))
+		})
+	}
+}
+
+func TestDefaultConfigIsValid(t *testing.T) {
+	t.Setenv(EnvConfigFile, "")
+
+	var cfg GeneralConfig
+
+	assert.Nil(t, Load(&cfg, PathToDefaultParamsFile))
+	assert.Equal(t, "openshift-sriov-network-operator", cfg.SriovOperatorNamespace)
+}
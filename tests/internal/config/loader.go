@@ -0,0 +1,399 @@
+package config
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// EnvConfigFile is the environment variable holding the path to an optional user override file. The same file is
+	// applied on top of every suite default.yaml, so a single file may contain overrides for all suites.
+	EnvConfigFile = "ECO_CONFIG_FILE"
+	// EnvPrintConfig is the environment variable that, when set to true, logs the effective config after loading.
+	EnvPrintConfig = "ECO_PRINT_CONFIG"
+	// RedactedValue replaces the value of secret fields when printing the effective config.
+	RedactedValue = "<redacted>"
+)
+
+// Layer is the source a config value was loaded from. Layers are applied in the order default, override, env.
+type Layer string
+
+const (
+	// LayerDefault is the default.yaml file shipped next to the config package.
+	LayerDefault Layer = "default"
+	// LayerOverride is the user override file referenced by ECO_CONFIG_FILE.
+	LayerOverride Layer = "override"
+	// LayerEnv is the set of ECO_* environment variables.
+	LayerEnv Layer = "env"
+)
+
+var (
+	// ErrInvalidTarget is returned when the config passed to Load is not a non-nil pointer to a struct.
+	ErrInvalidTarget = errors.New("config must be a non-nil pointer to a struct")
+
+	// Matches field names that hold credentials and should never be printed.
+	secretFieldName = regexp.MustCompile(`(?i)(pass|password|token|secret)$`)
+)
+
+// LayerError is returned when one of the config layers cannot be read.
+type LayerError struct {
+	Layer  Layer
+	Source string
+	Err    error
+}
+
+// Error returns the string representation of the LayerError.
+func (layerError *LayerError) Error() string {
+	return fmt.Sprintf("failed to load %s config layer from %s: %v", layerError.Layer, layerError.Source, layerError.Err)
+}
+
+// Unwrap returns the underlying error.
+func (layerError *LayerError) Unwrap() error {
+	return layerError.Err
+}
+
+// MissingFieldError describes a field tagged with `validate:"required"` that is still empty after all layers have
+// been applied.
+type MissingFieldError struct {
+	Field   string
+	YAMLKey string
+	EnvVar  string
+}
+
+// Error returns the string representation of the MissingFieldError, including where the value may be provided.
+func (missingError *MissingFieldError) Error() string {
+	var sources []string
+
+	if missingError.YAMLKey != "" {
+		sources = append(sources, fmt.Sprintf("yaml key %q", missingError.YAMLKey))
+	}
+
+	if missingError.EnvVar != "" {
+		sources = append(sources, fmt.Sprintf("env var %s", missingError.EnvVar))
+	}
+
+	if len(sources) == 0 {
+		return fmt.Sprintf("required field %s is not set", missingError.Field)
+	}
+
+	return fmt.Sprintf(
+		"required field %s is not set, provide it using %s", missingError.Field, strings.Join(sources, " or "))
+}
+
+// ValidationError aggregates every MissingFieldError found while validating a config.
+type ValidationError struct {
+	Missing []*MissingFieldError
+}
+
+// Error returns the string representation of the ValidationError.
+func (validationError *ValidationError) Error() string {
+	messages := make([]string, 0, len(validationError.Missing))
+
+	for _, missing := range validationError.Missing {
+		messages = append(messages, missing.Error())
+	}
+
+	return fmt.Sprintf("config validation failed: %s", strings.Join(messages, "; "))
+}
+
+// Unwrap returns the individual MissingFieldErrors so they can be matched using errors.As.
+func (validationError *ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(validationError.Missing))
+
+	for _, missing := range validationError.Missing {
+		errs = append(errs, missing)
+	}
+
+	return errs
+}
+
+// Load populates config, which must be a pointer to a struct, by applying the defaultFile, the optional override
+// file from ECO_CONFIG_FILE, and environment variables in that order. Once merged, the config is validated and, if
+// ECO_PRINT_CONFIG is true, the effective config is logged with secrets redacted.
+func Load(config any, defaultFile string) error {
+	return LoadWithEnvPrefix(config, defaultFile, "")
+}
+
+// LoadWithEnvPrefix is the same as Load except that envPrefix is passed to envconfig when reading the environment
+// variables layer.
+func LoadWithEnvPrefix(config any, defaultFile, envPrefix string) error {
+	if err := checkTarget(config); err != nil {
+		return err
+	}
+
+	if err := decodeFile(config, defaultFile); err != nil {
+		return &LayerError{Layer: LayerDefault, Source: defaultFile, Err: err}
+	}
+
+	if overrideFile := os.Getenv(EnvConfigFile); overrideFile != "" {
+		if err := decodeFile(config, overrideFile); err != nil {
+			return &LayerError{Layer: LayerOverride, Source: overrideFile, Err: err}
+		}
+	}
+
+	if err := envconfig.Process(envPrefix, config); err != nil {
+		return &LayerError{Layer: LayerEnv, Source: "environment", Err: err}
+	}
+
+	if err := Validate(config); err != nil {
+		return err
+	}
+
+	if os.Getenv(EnvPrintConfig) == "true" {
+		effectiveConfig, err := EffectiveYAML(config)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Effective %T config:\n%s", config, effectiveConfig)
+	}
+
+	return nil
+}
+
+// Validate checks that every field tagged with `validate:"required"` is set, recursing into embedded structs. It
+// returns a *ValidationError listing all the missing fields or nil if none are missing.
+func Validate(config any) error {
+	if err := checkTarget(config); err != nil {
+		return err
+	}
+
+	var missing []*MissingFieldError
+
+	walkFields(reflect.ValueOf(config).Elem(), func(field reflect.StructField, value reflect.Value) {
+		if field.Tag.Get("validate") != "required" || !value.IsZero() {
+			return
+		}
+
+		missing = append(missing, &MissingFieldError{
+			Field:   field.Name,
+			YAMLKey: yamlKey(field),
+			EnvVar:  field.Tag.Get("envconfig"),
+		})
+	})
+
+	if len(missing) > 0 {
+		return &ValidationError{Missing: missing}
+	}
+
+	return nil
+}
+
+// EffectiveYAML returns the config as YAML, including only fields that can be set from a file or the environment.
+// Fields from embedded structs are flattened into the same document. Fields tagged with `secret:"true"` or whose
+// names look like credentials, as well as map entries whose keys look like credentials, are replaced with
+// RedactedValue when set, including inside nested structs, slices, and maps.
+func EffectiveYAML(config any) (string, error) {
+	if err := checkTarget(config); err != nil {
+		return "", err
+	}
+
+	var document yaml.MapSlice
+
+	walkFields(reflect.ValueOf(config).Elem(), func(field reflect.StructField, value reflect.Value) {
+		key := yamlKey(field)
+		if key == "" {
+			key = field.Tag.Get("envconfig")
+		}
+
+		if key == "" {
+			return
+		}
+
+		document = append(document, yaml.MapItem{Key: key, Value: printableValue(field, value)})
+	})
+
+	output, err := yaml.Marshal(document)
+	if err != nil {
+		return "", err
+	}
+
+	return string(output), nil
+}
+
+// checkTarget returns ErrInvalidTarget if config is not a non-nil pointer to a struct.
+func checkTarget(config any) error {
+	value := reflect.ValueOf(config)
+
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+
+	return nil
+}
+
+// decodeFile decodes the YAML file at path on top of the existing values in config. A file without any values, such
+// as an empty one or one with only comments, does not override anything.
+func decodeFile(config any, path string) error {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	// Decoding a null document would reset config to its zero value, discarding the previous layers.
+	var document any
+
+	err = yaml.Unmarshal(contents, &document)
+	if err != nil || document == nil {
+		return err
+	}
+
+	return yaml.Unmarshal(contents, config)
+}
+
+// walkFields calls visit for every exported, non-embedded field of structValue. Embedded structs and non-nil
+// embedded struct pointers are walked recursively as though their fields belonged to structValue.
+func walkFields(structValue reflect.Value, visit func(reflect.StructField, reflect.Value)) {
+	structType := structValue.Type()
+
+	for index := range structType.NumField() {
+		field := structType.Field(index)
+		value := structValue.Field(index)
+
+		if field.Anonymous {
+			if value.Kind() == reflect.Pointer {
+				if value.IsNil() {
+					continue
+				}
+
+				value = value.Elem()
+			}
+
+			if value.Kind() == reflect.Struct {
+				walkFields(value, visit)
+			}
+
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		visit(field, value)
+	}
+}
+
+// yamlKey returns the name of the yaml key for field or an empty string if it has none.
+func yamlKey(field reflect.StructField) string {
+	key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if key == "-" {
+		return ""
+	}
+
+	return key
+}
+
+// printableValue returns the value of field to be used when printing the config, redacting it if it is a secret.
+func printableValue(field reflect.StructField, value reflect.Value) any {
+	isSecret := field.Tag.Get("secret") == "true" || secretFieldName.MatchString(field.Name)
+	if isSecret && !value.IsZero() {
+		return RedactedValue
+	}
+
+	return redactValue(value)
+}
+
+// redactValue returns value to be used when printing the config. Nested structs, pointers, slices, and maps are
+// walked recursively so that secret fields and map entries with secret keys are redacted at any depth. Nested structs
+// are returned as a yaml.MapSlice keyed the same way yaml.Marshal would key them.
+func redactValue(value reflect.Value) any {
+	if !value.IsValid() || !value.CanInterface() {
+		return nil
+	}
+
+	if duration, ok := value.Interface().(time.Duration); ok {
+		return duration.String()
+	}
+
+	// Types with their own encoding, such as time.Time, are printed as is.
+	switch value.Interface().(type) {
+	case yaml.Marshaler, encoding.TextMarshaler:
+		return value.Interface()
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return value.Interface()
+		}
+
+		return redactValue(value.Elem())
+	case reflect.Struct:
+		return redactStruct(value)
+	case reflect.Slice, reflect.Array:
+		if (value.Kind() == reflect.Slice && value.IsNil()) || value.Type().Elem().Kind() == reflect.Uint8 {
+			return value.Interface()
+		}
+
+		items := make([]any, 0, value.Len())
+
+		for index := range value.Len() {
+			items = append(items, redactValue(value.Index(index)))
+		}
+
+		return items
+	case reflect.Map:
+		if value.IsNil() {
+			return value.Interface()
+		}
+
+		return redactMap(value)
+	default:
+		return value.Interface()
+	}
+}
+
+// redactStruct returns the exported fields of structValue as a yaml.MapSlice, redacting secret fields.
+func redactStruct(structValue reflect.Value) yaml.MapSlice {
+	document := yaml.MapSlice{}
+
+	walkFields(structValue, func(field reflect.StructField, value reflect.Value) {
+		key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+
+		switch key {
+		case "-":
+			return
+		case "":
+			key = strings.ToLower(field.Name)
+		}
+
+		document = append(document, yaml.MapItem{Key: key, Value: printableValue(field, value)})
+	})
+
+	return document
+}
+
+// redactMap returns mapValue as a yaml.MapSlice sorted by key, redacting the entries whose keys look like credentials.
+func redactMap(mapValue reflect.Value) yaml.MapSlice {
+	keys := mapValue.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+
+	document := make(yaml.MapSlice, 0, len(keys))
+
+	for _, key := range keys {
+		value := mapValue.MapIndex(key)
+
+		var printable any = RedactedValue
+		if !secretFieldName.MatchString(fmt.Sprint(key.Interface())) || value.IsZero() {
+			printable = redactValue(value)
+		}
+
+		document = append(document, yaml.MapItem{Key: key.Interface(), Value: printable})
+	}
+
+	return document
+}
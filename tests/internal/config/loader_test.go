@@ -0,0 +1,283 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// EmbeddedTestConfig is exported so envconfig is able to set its fields when embedded.
+type EmbeddedTestConfig struct {
+	Namespace string `yaml:"namespace" envconfig:"ECO_TEST_NAMESPACE" validate:"required"`
+}
+
+type testConfig struct {
+	EmbeddedTestConfig `yaml:",inline"`
+	Image              string        `yaml:"image" envconfig:"ECO_TEST_IMAGE"`
+	Timeout            time.Duration `yaml:"timeout" envconfig:"ECO_TEST_TIMEOUT"`
+	Hosts              []string      `yaml:"hosts" envconfig:"ECO_TEST_HOSTS"`
+	Password           string        `envconfig:"ECO_TEST_PASSWORD"`
+	APIKey             string        `envconfig:"ECO_TEST_API_KEY" secret:"true"`
+	Kubeconf           string        `envconfig:"ECO_TEST_KUBECONFIG" validate:"required"`
+	Computed           string
+}
+
+func TestLoad(t *testing.T) {
+	testCases := []struct {
+		defaultYAML   string
+		overrideYAML  string
+		useOverride   bool
+		env           map[string]string
+		expectedImage string
+		expectedHosts []string
+		expectedNS    string
+		expectedError error
+	}{
+		{
+			defaultYAML:   "image: default\nnamespace: default-ns\nhosts: [a]\n",
+			env:           map[string]string{"ECO_TEST_KUBECONFIG": "/kubeconfig"},
+			expectedImage: "default",
+			expectedHosts: []string{"a"},
+			expectedNS:    "default-ns",
+		},
+		{
+			defaultYAML:   "image: default\nnamespace: default-ns\nhosts: [a]\n",
+			overrideYAML:  "image: override\nunrelated_key: ignored\n",
+			useOverride:   true,
+			env:           map[string]string{"ECO_TEST_KUBECONFIG": "/kubeconfig"},
+			expectedImage: "override",
+			expectedHosts: []string{"a"},
+			expectedNS:    "default-ns",
+		},
+		{
+			defaultYAML:  "image: default\nnamespace: default-ns\n",
+			overrideYAML: "image: override\n",
+			useOverride:  true,
+			env: map[string]string{
+				"ECO_TEST_KUBECONFIG": "/kubeconfig",
+				"ECO_TEST_IMAGE":      "env",
+				"ECO_TEST_HOSTS":      "b,c",
+			},
+			expectedImage: "env",
+			expectedHosts: []string{"b", "c"},
+			expectedNS:    "default-ns",
+		},
+		{
+			// An empty override file is allowed and should not change anything.
+			defaultYAML:   "image: default\nnamespace: default-ns\n",
+			useOverride:   true,
+			env:           map[string]string{"ECO_TEST_KUBECONFIG": "/kubeconfig", "ECO_TEST_NAMESPACE": "env-ns"},
+			expectedImage: "default",
+			expectedNS:    "env-ns",
+		},
+		{
+			// An override file with only comments, like the default.yaml of many suites, should not change anything.
+			defaultYAML:   "image: default\nnamespace: default-ns\n",
+			overrideYAML:  "---\n# Only comments.\n...\n",
+			useOverride:   true,
+			env:           map[string]string{"ECO_TEST_KUBECONFIG": "/kubeconfig"},
+			expectedImage: "default",
+			expectedNS:    "default-ns",
+		},
+		{
+			defaultYAML: "image: default\n",
+			expectedError: &ValidationError{Missing: []*MissingFieldError{
+				{Field: "Namespace", YAMLKey: "namespace", EnvVar: "ECO_TEST_NAMESPACE"},
+				{Field: "Kubeconf", EnvVar: "ECO_TEST_KUBECONFIG"},
+			}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		tempDir := t.TempDir()
+		defaultFile := writeTestFile(t, tempDir, "default.yaml", testCase.defaultYAML)
+
+		t.Setenv(EnvConfigFile, "")
+
+		if testCase.useOverride {
+			t.Setenv(EnvConfigFile, writeTestFile(t, tempDir, "override.yaml", testCase.overrideYAML))
+		}
+
+		for _, name := range []string{"ECO_TEST_KUBECONFIG", "ECO_TEST_IMAGE", "ECO_TEST_HOSTS", "ECO_TEST_NAMESPACE"} {
+			t.Setenv(name, "")
+			assert.NoError(t, os.Unsetenv(name))
+		}
+
+		for name, value := range testCase.env {
+			t.Setenv(name, value)
+		}
+
+		config := testConfig{}
+		err := Load(&config, defaultFile)
+
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testCase.expectedImage, config.Image)
+			assert.Equal(t, testCase.expectedNS, config.Namespace)
+
+			if testCase.expectedHosts != nil {
+				assert.Equal(t, testCase.expectedHosts, config.Hosts)
+			}
+		}
+	}
+}
+
+func TestLoadLayerErrors(t *testing.T) {
+	tempDir := t.TempDir()
+	defaultFile := writeTestFile(t, tempDir, "default.yaml", "namespace: ns\n")
+	invalidFile := writeTestFile(t, tempDir, "invalid.yaml", "namespace: [unterminated\n")
+	missingFile := filepath.Join(tempDir, "missing.yaml")
+
+	testCases := []struct {
+		defaultFile   string
+		overrideFile  string
+		expectedLayer Layer
+	}{
+		{defaultFile: missingFile, expectedLayer: LayerDefault},
+		{defaultFile: invalidFile, expectedLayer: LayerDefault},
+		{defaultFile: defaultFile, overrideFile: missingFile, expectedLayer: LayerOverride},
+		{defaultFile: defaultFile, overrideFile: invalidFile, expectedLayer: LayerOverride},
+	}
+
+	for _, testCase := range testCases {
+		t.Setenv(EnvConfigFile, testCase.overrideFile)
+
+		err := Load(&EmbeddedTestConfig{}, testCase.defaultFile)
+
+		var layerError *LayerError
+
+		assert.True(t, errors.As(err, &layerError))
+		assert.Equal(t, testCase.expectedLayer, layerError.Layer)
+	}
+
+	assert.Equal(t, ErrInvalidTarget, Load(EmbeddedTestConfig{}, defaultFile))
+}
+
+func TestValidationErrorUnwrap(t *testing.T) {
+	err := Validate(&testConfig{})
+
+	var missingError *MissingFieldError
+
+	assert.True(t, errors.As(err, &missingError))
+	assert.Equal(t, "Namespace", missingError.Field)
+	assert.Equal(t,
+		"required field Namespace is not set, provide it using yaml key \"namespace\" or env var ECO_TEST_NAMESPACE",
+		missingError.Error())
+}
+
+func TestEffectiveYAML(t *testing.T) {
+	config := testConfig{
+		EmbeddedTestConfig: EmbeddedTestConfig{Namespace: "ns"},
+		Image:              "image",
+		Timeout:            time.Minute,
+		Password:           "hunter2",
+		APIKey:             "key",
+		Computed:           "not printed",
+	}
+
+	output, err := EffectiveYAML(&config)
+	assert.Nil(t, err)
+	assert.Equal(t, `namespace: ns
+image: image
+timeout: 1m0s
+hosts: []
+ECO_TEST_PASSWORD: <redacted>
+ECO_TEST_API_KEY: <redacted>
+ECO_TEST_KUBECONFIG: ""
+`, output)
+}
+
+func TestEffectiveYAMLNested(t *testing.T) {
+	type bmcDetails struct {
+		Username string `yaml:"username"`
+		Password string `yaml:"password"`
+	}
+
+	type switchConfig struct {
+		Host       string
+		SwitchPass string      `yaml:"switchPass"`
+		Client     *bmcDetails `yaml:"-"`
+	}
+
+	type nestedTestConfig struct {
+		Switch   *switchConfig            `yaml:"switch"`
+		Nodes    map[string]bmcDetails    `yaml:"nodes"`
+		Spokes   []*bmcDetails            `yaml:"spokes"`
+		Extra    map[string]string        `yaml:"extra"`
+		Timeouts map[string]time.Duration `yaml:"timeouts"`
+	}
+
+	testCases := []struct {
+		name     string
+		config   nestedTestConfig
+		expected string
+	}{
+		{
+			name:   "empty",
+			config: nestedTestConfig{},
+			expected: `switch: null
+nodes: {}
+spokes: []
+extra: {}
+timeouts: {}
+`,
+		},
+		{
+			name: "nested secrets",
+			config: nestedTestConfig{
+				Switch: &switchConfig{Host: "switch", SwitchPass: "hunter2", Client: &bmcDetails{Password: "hidden"}},
+				Nodes: map[string]bmcDetails{
+					"worker-1": {Username: "root", Password: "hunter2"},
+					"worker-0": {Username: "root"},
+				},
+				Spokes:   []*bmcDetails{{Username: "admin", Password: "hunter2"}, nil},
+				Extra:    map[string]string{"user": "admin", "token": "hunter2", "secret": ""},
+				Timeouts: map[string]time.Duration{"bmc": time.Minute},
+			},
+			expected: `switch:
+  host: switch
+  switchPass: <redacted>
+nodes:
+  worker-0:
+    username: root
+    password: ""
+  worker-1:
+    username: root
+    password: <redacted>
+spokes:
+- username: admin
+  password: <redacted>
+- null
+extra:
+  secret: ""
+  token: <redacted>
+  user: admin
+timeouts:
+  bmc: 1m0s
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			output, err := EffectiveYAML(&testCase.config)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, output)
+		})
+	}
+}
+
+func writeTestFile(t *testing.T, dir, name, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	err := os.WriteFile(path, []byte(contents), 0600)
+	assert.NoError(t, err)
+
+	return path
+}
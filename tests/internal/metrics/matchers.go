@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/types"
+)
+
+// HaveMetric succeeds if the actual *Metrics, or metrics text that parses successfully, has a sample with the full
+// name whose labels include labels and whose value satisfies value. Labels not in labels are ignored, so their order
+// and any extra labels do not matter. The value may be nil to match any value, a float64 or int to match exactly, or
+// a Gomega matcher such as BeNumerically(">", 0). For example:
+//
+//	Expect(metrics).To(HaveMetric("openshift_ptp_clock_state",
+//		Labels{"iface": "CLOCK_REALTIME", "process": "phc2sys"}, 1))
+func HaveMetric(name string, labels Labels, value any) types.GomegaMatcher {
+	return &metricMatcher{name: name, labels: labels, value: value}
+}
+
+type metricMatcher struct {
+	name   string
+	labels Labels
+	value  any
+}
+
+// Match returns whether any sample matches the name, labels, and value.
+func (matcher *metricMatcher) Match(actual any) (bool, error) {
+	metrics, err := toMetrics(actual)
+	if err != nil {
+		return false, err
+	}
+
+	for _, sample := range metrics.Samples(matcher.name, matcher.labels) {
+		matched, err := matcher.matchValue(sample.Value)
+		if err != nil {
+			return false, err
+		}
+
+		if matched {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// FailureMessage returns the expected metric and every sample with the same name.
+func (matcher *metricMatcher) FailureMessage(actual any) string {
+	return fmt.Sprintf("Expected metric\n\t%s\nbut found\n%s", matcher.describe(), samplesNamed(actual, matcher.name))
+}
+
+// NegatedFailureMessage returns the metric that should not be present and every sample with the same name.
+func (matcher *metricMatcher) NegatedFailureMessage(actual any) string {
+	return fmt.Sprintf("Expected no metric\n\t%s\nbut found\n%s", matcher.describe(), samplesNamed(actual, matcher.name))
+}
+
+// matchValue returns whether value satisfies the expected value of the matcher.
+func (matcher *metricMatcher) matchValue(value float64) (bool, error) {
+	switch expected := matcher.value.(type) {
+	case nil:
+		return true, nil
+	case float64:
+		return value == expected, nil
+	case int:
+		return value == float64(expected), nil
+	case types.GomegaMatcher:
+		return expected.Match(value)
+	default:
+		return false, fmt.Errorf("HaveMetric expects a nil, float64, int, or Gomega matcher value, got %T", matcher.value)
+	}
+}
+
+// describe returns the name, labels, and value the matcher expects.
+func (matcher *metricMatcher) describe() string {
+	switch expected := matcher.value.(type) {
+	case nil:
+		return fmt.Sprintf("%s%s with any value", matcher.name, matcher.labels)
+	case types.GomegaMatcher:
+		return fmt.Sprintf("%s%s with value matching %T", matcher.name, matcher.labels, expected)
+	default:
+		return fmt.Sprintf("%s%s %v", matcher.name, matcher.labels, expected)
+	}
+}
+
+// samplesNamed returns every sample in actual with name, one per line, for failure messages.
+func samplesNamed(actual any, name string) string {
+	metrics, err := toMetrics(actual)
+	if err != nil {
+		return "\t" + err.Error()
+	}
+
+	samples := metrics.Samples(name, nil)
+	if len(samples) == 0 {
+		return fmt.Sprintf("\tno samples named %s", name)
+	}
+
+	var lines []string
+	for _, sample := range samples {
+		lines = append(lines, "\t"+sample.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func toMetrics(actual any) (*Metrics, error) {
+	switch typed := actual.(type) {
+	case *Metrics:
+		if typed == nil {
+			return nil, fmt.Errorf("metrics matchers expect a non-nil *Metrics")
+		}
+
+		return typed, nil
+	case string:
+		return Parse(typed)
+	case []byte:
+		return Parse(string(typed))
+	default:
+		return nil, fmt.Errorf("metrics matchers expect a *Metrics, string, or []byte, got %T", actual)
+	}
+}
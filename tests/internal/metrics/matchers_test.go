@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHaveMetric(t *testing.T) {
+	metrics, err := Parse(ptpMetrics)
+	assert.Nil(t, err)
+
+	realtime := Labels{"iface": "CLOCK_REALTIME", "process": "phc2sys"}
+
+	testCases := []struct {
+		name          string
+		actual        any
+		metricName    string
+		labels        Labels
+		value         any
+		expectedMatch bool
+		expectedError bool
+	}{
+		{
+			name:          "exact float value",
+			actual:        metrics,
+			metricName:    "openshift_ptp_clock_state",
+			labels:        realtime,
+			value:         1.0,
+			expectedMatch: true,
+		},
+		{
+			name:          "int value from text",
+			actual:        ptpMetrics,
+			metricName:    "openshift_ptp_clock_state",
+			labels:        realtime,
+			value:         1,
+			expectedMatch: true,
+		},
+		{
+			name:          "wrong value",
+			actual:        metrics,
+			metricName:    "openshift_ptp_clock_state",
+			labels:        realtime,
+			value:         2,
+			expectedMatch: false,
+		},
+		{
+			name:          "any value with any labels",
+			actual:        []byte(ptpMetrics),
+			metricName:    "openshift_ptp_offset_ns_count",
+			expectedMatch: true,
+		},
+		{
+			name:          "gomega matcher value",
+			actual:        metrics,
+			metricName:    "openshift_ptp_clock_state",
+			labels:        Labels{"node": "sno.example.com"},
+			value:         gomega.BeNumerically(">", 1),
+			expectedMatch: true,
+		},
+		{
+			name:          "missing label",
+			actual:        metrics,
+			metricName:    "openshift_ptp_clock_state",
+			labels:        Labels{"iface": "ens2f0"},
+			expectedMatch: false,
+		},
+		{
+			name:          "invalid value type",
+			actual:        metrics,
+			metricName:    "openshift_ptp_clock_state",
+			value:         "1",
+			expectedError: true,
+		},
+		{
+			name:          "invalid actual type",
+			actual:        42,
+			metricName:    "openshift_ptp_clock_state",
+			expectedError: true,
+		},
+		{
+			name:          "invalid metrics text",
+			actual:        "metric one",
+			metricName:    "metric",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			matched, err := HaveMetric(testCase.metricName, testCase.labels, testCase.value).Match(testCase.actual)
+			if testCase.expectedError {
+				assert.NotNil(t, err)
+
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedMatch, matched)
+		})
+	}
+}
+
+func TestHaveMetricFailureMessage(t *testing.T) {
+	matcher := HaveMetric("openshift_ptp_clock_state", Labels{"process": "phc2sys"}, 2)
+
+	assert.Equal(t, `Expected metric
+	openshift_ptp_clock_state{process="phc2sys"} 2
+but found
+	openshift_ptp_clock_state{iface="CLOCK_REALTIME",node="sno.example.com",process="phc2sys"} 1
+	openshift_ptp_clock_state{iface="ens1f0",node="sno.example.com",process="ptp4l"} 2`,
+		matcher.FailureMessage(ptpMetrics))
+
+	matcher = HaveMetric("missing_metric", nil, nil)
+	assert.Equal(t, "Expected no metric\n\tmissing_metric{} with any value\nbut found\n\tno samples named missing_metric",
+		matcher.NegatedFailureMessage(ptpMetrics))
+}
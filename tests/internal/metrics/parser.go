@@ -0,0 +1,429 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MetricType is the type of a metric family as declared in its TYPE line.
+type MetricType string
+
+const (
+	// TypeCounter is the type of counters.
+	TypeCounter MetricType = "counter"
+	// TypeGauge is the type of gauges.
+	TypeGauge MetricType = "gauge"
+	// TypeHistogram is the type of histograms.
+	TypeHistogram MetricType = "histogram"
+	// TypeGaugeHistogram is the type of gauge histograms, which only exist in OpenMetrics.
+	TypeGaugeHistogram MetricType = "gaugehistogram"
+	// TypeSummary is the type of summaries.
+	TypeSummary MetricType = "summary"
+	// TypeInfo is the type of info metrics, which only exist in OpenMetrics.
+	TypeInfo MetricType = "info"
+	// TypeStateSet is the type of state sets, which only exist in OpenMetrics.
+	TypeStateSet MetricType = "stateset"
+	// TypeUntyped is the type of metrics without a TYPE line or with an untyped or unknown type.
+	TypeUntyped MetricType = "untyped"
+)
+
+// suffixes are the suffixes samples may have beyond the name of their family, depending on its type.
+var suffixes = map[MetricType][]string{
+	TypeCounter:        {"_total", "_created"},
+	TypeHistogram:      {"_bucket", "_sum", "_count", "_created"},
+	TypeGaugeHistogram: {"_bucket", "_gsum", "_gcount"},
+	TypeSummary:        {"_sum", "_count", "_created"},
+	TypeInfo:           {"_info"},
+}
+
+// Labels is the set of labels on a sample.
+type Labels map[string]string
+
+// String returns the labels in the exposition format, sorted by name.
+func (labels Labels) String() string {
+	var pairs []string
+
+	for _, name := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", name, labels[name]))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// Matches returns whether labels has every label in subset with the same value. Labels not in subset are ignored, so
+// an empty subset matches any labels.
+func (labels Labels) Matches(subset Labels) bool {
+	for name, value := range subset {
+		actual, ok := labels[name]
+		if !ok || actual != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Sample is a single line of a metrics exposition.
+type Sample struct {
+	// Name is the full name of the sample, including any suffix such as _bucket or _total.
+	Name   string
+	Labels Labels
+	Value  float64
+	// Timestamp is the timestamp of the sample as written, if present. It is milliseconds in the Prometheus text
+	// format and seconds in OpenMetrics.
+	Timestamp *float64
+}
+
+// String returns the sample in the exposition format, without its timestamp.
+func (sample Sample) String() string {
+	return fmt.Sprintf("%s%s %s", sample.Name, sample.Labels, strconv.FormatFloat(sample.Value, 'g', -1, 64))
+}
+
+// Family is a metric family: the samples that share a HELP and TYPE.
+type Family struct {
+	Name    string
+	Help    string
+	Type    MetricType
+	Samples []Sample
+}
+
+// Metrics is the parsed contents of a metrics endpoint.
+type Metrics struct {
+	// Families is every metric family in the order they first appeared.
+	Families []*Family
+}
+
+// Parse parses metrics in either the Prometheus text format or OpenMetrics. Samples without a HELP or TYPE line get
+// their own untyped family.
+func Parse(text string) (*Metrics, error) {
+	parser := &parser{metrics: &Metrics{}, families: make(map[string]*Family)}
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" {
+			continue
+		}
+
+		if line == "# EOF" {
+			break
+		}
+
+		var err error
+
+		if strings.HasPrefix(line, "#") {
+			err = parser.parseComment(line)
+		} else {
+			err = parser.parseSample(line)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse metrics line %d %q: %w", lineNumber, line, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read metrics: %w", err)
+	}
+
+	return parser.metrics, nil
+}
+
+// Family returns the metric family with the provided name, or nil if there is none.
+func (metrics *Metrics) Family(name string) *Family {
+	for _, family := range metrics.Families {
+		if family.Name == name {
+			return family
+		}
+	}
+
+	return nil
+}
+
+// Samples returns every sample with the provided full name whose labels match labels, as in Labels.Matches.
+func (metrics *Metrics) Samples(name string, labels Labels) []Sample {
+	var samples []Sample
+
+	for _, family := range metrics.Families {
+		for _, sample := range family.Samples {
+			if sample.Name == name && sample.Labels.Matches(labels) {
+				samples = append(samples, sample)
+			}
+		}
+	}
+
+	return samples
+}
+
+// Value returns the value of the only sample with the provided full name whose labels match labels. It returns an
+// error if there is not exactly one such sample.
+func (metrics *Metrics) Value(name string, labels Labels) (float64, error) {
+	samples := metrics.Samples(name, labels)
+
+	switch len(samples) {
+	case 0:
+		return 0, fmt.Errorf("no samples found for metric %s%s", name, labels)
+	case 1:
+		return samples[0].Value, nil
+	default:
+		return 0, fmt.Errorf("found %d samples for metric %s%s, expected exactly one", len(samples), name, labels)
+	}
+}
+
+// Names returns the full name of every sample, without duplicates, in the order they first appeared.
+func (metrics *Metrics) Names() []string {
+	var names []string
+
+	for _, family := range metrics.Families {
+		for _, sample := range family.Samples {
+			if !slices.Contains(names, sample.Name) {
+				names = append(names, sample.Name)
+			}
+		}
+	}
+
+	return names
+}
+
+// parser holds the state while parsing metrics.
+type parser struct {
+	metrics  *Metrics
+	families map[string]*Family
+	// current is the family of the most recent HELP or TYPE line. Samples are only assigned to it if their name
+	// matches, otherwise they get their own family.
+	current *Family
+}
+
+// family returns the family with name, creating it if necessary.
+func (parser *parser) family(name string) *Family {
+	if family, ok := parser.families[name]; ok {
+		return family
+	}
+
+	family := &Family{Name: name, Type: TypeUntyped}
+	parser.families[name] = family
+	parser.metrics.Families = append(parser.metrics.Families, family)
+
+	return family
+}
+
+// parseComment parses HELP and TYPE lines. All other comments, including UNIT lines, are ignored.
+func (parser *parser) parseComment(line string) error {
+	fields := strings.SplitN(strings.TrimSpace(strings.TrimPrefix(line, "#")), " ", 3)
+	if len(fields) < 2 || (fields[0] != "HELP" && fields[0] != "TYPE") {
+		return nil
+	}
+
+	family := parser.family(fields[1])
+	parser.current = family
+
+	value := ""
+	if len(fields) == 3 {
+		value = strings.TrimSpace(fields[2])
+	}
+
+	if fields[0] == "HELP" {
+		family.Help = unescape(value, false)
+
+		return nil
+	}
+
+	switch metricType := MetricType(strings.ToLower(value)); metricType {
+	case TypeCounter, TypeGauge, TypeHistogram, TypeGaugeHistogram, TypeSummary, TypeInfo, TypeStateSet:
+		family.Type = metricType
+	case TypeUntyped, "unknown":
+		family.Type = TypeUntyped
+	default:
+		return fmt.Errorf("unknown metric type %q", value)
+	}
+
+	return nil
+}
+
+// parseSample parses a sample line and adds it to its family.
+func (parser *parser) parseSample(line string) error {
+	sample := Sample{Labels: Labels{}}
+
+	nameEnd := strings.IndexAny(line, "{ \t")
+	if nameEnd <= 0 {
+		return fmt.Errorf("missing value")
+	}
+
+	sample.Name = line[:nameEnd]
+	rest := line[nameEnd:]
+
+	if strings.HasPrefix(rest, "{") {
+		var err error
+
+		rest, err = parseLabels(rest, sample.Labels)
+		if err != nil {
+			return err
+		}
+	}
+
+	// OpenMetrics exemplars come after the value and timestamp, separated by a #.
+	if exemplarStart := strings.Index(rest, " # "); exemplarStart >= 0 {
+		rest = rest[:exemplarStart]
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return fmt.Errorf("expected a value and optional timestamp but found %d fields", len(fields))
+	}
+
+	value, err := parseFloat(fields[0])
+	if err != nil {
+		return fmt.Errorf("failed to parse value: %w", err)
+	}
+
+	sample.Value = value
+
+	if len(fields) == 2 {
+		timestamp, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse timestamp: %w", err)
+		}
+
+		sample.Timestamp = &timestamp
+	}
+
+	family := parser.familyForSample(sample.Name)
+	family.Samples = append(family.Samples, sample)
+
+	return nil
+}
+
+// familyForSample returns the family a sample with the provided name belongs to: the current family if the name is
+// the family name or the family name with a suffix allowed by its type, otherwise a family for the name.
+func (parser *parser) familyForSample(name string) *Family {
+	if parser.current != nil {
+		if name == parser.current.Name {
+			return parser.current
+		}
+
+		for _, suffix := range suffixes[parser.current.Type] {
+			if name == parser.current.Name+suffix {
+				return parser.current
+			}
+		}
+	}
+
+	family := parser.family(name)
+	parser.current = family
+
+	return family
+}
+
+// parseLabels parses the labels at the start of text, which must begin with {, into labels. It returns the text after
+// the closing }.
+func parseLabels(text string, labels Labels) (string, error) {
+	position := 1
+
+	for {
+		for position < len(text) && (text[position] == ' ' || text[position] == ',') {
+			position++
+		}
+
+		if position >= len(text) {
+			return "", fmt.Errorf("unterminated labels")
+		}
+
+		if text[position] == '}' {
+			return text[position+1:], nil
+		}
+
+		equals := strings.IndexByte(text[position:], '=')
+		if equals < 0 {
+			return "", fmt.Errorf("label without value")
+		}
+
+		name := strings.TrimSpace(text[position : position+equals])
+		position += equals + 1
+
+		if name == "" {
+			return "", fmt.Errorf("label without name")
+		}
+
+		if position >= len(text) || text[position] != '"' {
+			return "", fmt.Errorf("value of label %s is not quoted", name)
+		}
+
+		end, err := findClosingQuote(text, position)
+		if err != nil {
+			return "", fmt.Errorf("value of label %s: %w", name, err)
+		}
+
+		labels[name] = unescape(text[position+1:end], true)
+		position = end + 1
+	}
+}
+
+// findClosingQuote returns the index of the unescaped quote closing the quote at start.
+func findClosingQuote(text string, start int) (int, error) {
+	for index := start + 1; index < len(text); index++ {
+		switch text[index] {
+		case '\\':
+			index++
+		case '"':
+			return index, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unterminated quote")
+}
+
+// unescape replaces the escape sequences allowed in HELP text and, if quoted is true, label values.
+func unescape(text string, quoted bool) string {
+	if !strings.Contains(text, `\`) {
+		return text
+	}
+
+	var builder strings.Builder
+
+	for index := 0; index < len(text); index++ {
+		if text[index] != '\\' || index+1 == len(text) {
+			builder.WriteByte(text[index])
+
+			continue
+		}
+
+		index++
+
+		switch next := text[index]; {
+		case next == 'n':
+			builder.WriteByte('\n')
+		case next == '\\':
+			builder.WriteByte('\\')
+		case next == '"' && quoted:
+			builder.WriteByte('"')
+		default:
+			builder.WriteByte('\\')
+			builder.WriteByte(next)
+		}
+	}
+
+	return builder.String()
+}
+
+// parseFloat parses a sample value, including the special values used by Prometheus.
+func parseFloat(text string) (float64, error) {
+	switch text {
+	case "+Inf", "Inf":
+		return math.Inf(1), nil
+	case "-Inf":
+		return math.Inf(-1), nil
+	case "NaN":
+		return math.NaN(), nil
+	default:
+		return strconv.ParseFloat(text, 64)
+	}
+}
@@ -0,0 +1,171 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const ptpMetrics = `# HELP openshift_ptp_clock_state 0 = FREERUN, 1 = LOCKED, 2 = HOLDOVER
+# TYPE openshift_ptp_clock_state gauge
+openshift_ptp_clock_state{iface="CLOCK_REALTIME",node="sno.example.com",process="phc2sys"} 1
+openshift_ptp_clock_state{process="ptp4l",node="sno.example.com",iface="ens1f0"} 2
+# HELP openshift_ptp_offset_ns Offset from the master in nanoseconds.
+# TYPE openshift_ptp_offset_ns histogram
+openshift_ptp_offset_ns_bucket{le="10"} 5
+openshift_ptp_offset_ns_bucket{le="+Inf"} 7
+openshift_ptp_offset_ns_sum -12.5
+openshift_ptp_offset_ns_count 7
+untyped_metric 3 1700000000000
+infinite_metric -Inf
+`
+
+const openMetrics = `# TYPE frr_bgp_peer_updates counter
+# UNIT frr_bgp_peer_updates updates
+# HELP frr_bgp_peer_updates Number of updates with an \"escaped\" \\ help\ntext.
+frr_bgp_peer_updates_total{peer="10.0.0.1",vrf="default"} 4.0 # {trace_id="abc"} 1.0 1700000000.123
+frr_bgp_peer_updates_created{peer="10.0.0.1",vrf="default"} 1700000000.0
+escaped{path="C:\\dir",quote="say \"hi\"",newline="a\nb",}  NaN
+# EOF
+after_eof 1
+`
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name             string
+		text             string
+		expectedFamilies []string
+		expectedError    bool
+	}{
+		{
+			name: "prometheus text",
+			text: ptpMetrics,
+			expectedFamilies: []string{
+				"openshift_ptp_clock_state", "openshift_ptp_offset_ns", "untyped_metric", "infinite_metric"},
+		},
+		{
+			name:             "openmetrics",
+			text:             openMetrics,
+			expectedFamilies: []string{"frr_bgp_peer_updates", "escaped"},
+		},
+		{
+			name:             "empty",
+			text:             "\n# just a comment\n",
+			expectedFamilies: nil,
+		},
+		{
+			name:          "missing value",
+			text:          "no_value{label=\"value\"}\n",
+			expectedError: true,
+		},
+		{
+			name:          "unquoted label",
+			text:          "metric{label=value} 1\n",
+			expectedError: true,
+		},
+		{
+			name:          "unterminated labels",
+			text:          "metric{label=\"value\" 1\n",
+			expectedError: true,
+		},
+		{
+			name:          "invalid value",
+			text:          "metric one\n",
+			expectedError: true,
+		},
+		{
+			name:          "unknown type",
+			text:          "# TYPE metric thermometer\n",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			metrics, err := Parse(testCase.text)
+			if testCase.expectedError {
+				assert.NotNil(t, err)
+
+				return
+			}
+
+			assert.Nil(t, err)
+
+			var names []string
+			for _, family := range metrics.Families {
+				names = append(names, family.Name)
+			}
+
+			assert.Equal(t, testCase.expectedFamilies, names)
+		})
+	}
+}
+
+func TestParseSamples(t *testing.T) {
+	metrics, err := Parse(ptpMetrics)
+	assert.Nil(t, err)
+
+	clockState := metrics.Family("openshift_ptp_clock_state")
+	assert.NotNil(t, clockState)
+	assert.Equal(t, TypeGauge, clockState.Type)
+	assert.Equal(t, "0 = FREERUN, 1 = LOCKED, 2 = HOLDOVER", clockState.Help)
+	assert.Len(t, clockState.Samples, 2)
+
+	offset := metrics.Family("openshift_ptp_offset_ns")
+	assert.Equal(t, TypeHistogram, offset.Type)
+	assert.Len(t, offset.Samples, 4)
+	assert.Equal(t, Labels{"le": "+Inf"}, offset.Samples[1].Labels)
+	assert.Equal(t, 7.0, offset.Samples[1].Value)
+	assert.Equal(t, -12.5, offset.Samples[2].Value)
+
+	untyped := metrics.Family("untyped_metric")
+	assert.Equal(t, TypeUntyped, untyped.Type)
+	assert.Equal(t, 1700000000000.0, *untyped.Samples[0].Timestamp)
+	assert.True(t, math.IsInf(metrics.Family("infinite_metric").Samples[0].Value, -1))
+
+	// Label order in the exposition does not matter.
+	value, err := metrics.Value("openshift_ptp_clock_state", Labels{"process": "ptp4l", "iface": "ens1f0"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2.0, value)
+
+	_, err = metrics.Value("openshift_ptp_clock_state", Labels{"node": "sno.example.com"})
+	assert.NotNil(t, err)
+
+	_, err = metrics.Value("openshift_ptp_clock_state", Labels{"process": "ts2phc"})
+	assert.NotNil(t, err)
+
+	assert.Equal(t, []string{
+		"openshift_ptp_clock_state",
+		"openshift_ptp_offset_ns_bucket",
+		"openshift_ptp_offset_ns_sum",
+		"openshift_ptp_offset_ns_count",
+		"untyped_metric",
+		"infinite_metric",
+	}, metrics.Names())
+
+	metrics, err = Parse(openMetrics)
+	assert.Nil(t, err)
+
+	updates := metrics.Family("frr_bgp_peer_updates")
+	assert.Equal(t, TypeCounter, updates.Type)
+	assert.Equal(t, `Number of updates with an \"escaped\" \ help`+"\ntext.", updates.Help)
+	assert.Len(t, updates.Samples, 2)
+	assert.Equal(t, 4.0, updates.Samples[0].Value)
+	assert.Nil(t, updates.Samples[0].Timestamp)
+
+	escaped := metrics.Family("escaped").Samples[0]
+	assert.Equal(t, Labels{"path": `C:\dir`, "quote": `say "hi"`, "newline": "a\nb"}, escaped.Labels)
+	assert.True(t, math.IsNaN(escaped.Value))
+	assert.Nil(t, metrics.Family("after_eof"))
+}
+
+func TestLabels(t *testing.T) {
+	labels := Labels{"process": "phc2sys", "iface": "CLOCK_REALTIME"}
+
+	assert.Equal(t, `{iface="CLOCK_REALTIME",process="phc2sys"}`, labels.String())
+	assert.True(t, labels.Matches(nil))
+	assert.True(t, labels.Matches(Labels{"iface": "CLOCK_REALTIME"}))
+	assert.False(t, labels.Matches(Labels{"iface": "ens1f0"}))
+	assert.False(t, labels.Matches(Labels{"node": "sno.example.com"}))
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+)
+
+// ScrapePod gets the metrics at url by running curl in a container of podBuilder and parses them. Since the command
+// runs inside the pod, url is usually on localhost, such as http://localhost:9091/metrics. If containerName is not
+// provided, the first container of the pod is used.
+func ScrapePod(podBuilder *pod.Builder, url string, containerName ...string) (*Metrics, error) {
+	if podBuilder == nil {
+		return nil, fmt.Errorf("cannot scrape metrics from nil pod")
+	}
+
+	glog.V(90).Infof("Scraping metrics at %s from pod %s in namespace %s",
+		url, podBuilder.Definition.Name, podBuilder.Definition.Namespace)
+
+	output, err := podBuilder.ExecCommand([]string{"curl", "-s", url}, containerName...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape metrics at %s from pod %s: %w: %s",
+			url, podBuilder.Definition.Name, err, output.String())
+	}
+
+	if output.Len() == 0 {
+		return nil, fmt.Errorf("failed to scrape metrics at %s from pod %s: empty response", url, podBuilder.Definition.Name)
+	}
+
+	return Parse(output.String())
+}
+
+// ScrapeService gets the metrics at path on port of a service through the API server proxy and parses them. The
+// scheme may be empty, in which case http is used. Unlike ScrapePod, this does not require curl in any container.
+func ScrapeService(apiClient *clients.Settings, scheme, namespace, name, port, path string) (*Metrics, error) {
+	if apiClient == nil {
+		return nil, fmt.Errorf("cannot scrape metrics using nil apiClient")
+	}
+
+	glog.V(90).Infof("Scraping metrics at %s on port %s of service %s in namespace %s", path, port, name, namespace)
+
+	output, err := apiClient.K8sClient.CoreV1().Services(namespace).
+		ProxyGet(scheme, name, port, path, nil).
+		DoRaw(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape metrics at %s from service %s in namespace %s: %w",
+			path, name, namespace, err)
+	}
+
+	return Parse(string(output))
+}
@@ -0,0 +1,130 @@
+package pcap
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+)
+
+// DefaultPath is the file in the pod that captures are written to when Options.Path is empty.
+const DefaultPath = "/tmp/capture.pcap"
+
+// Options are the options of a tcpdump capture in a pod. The capture stops after Duration or Count packets,
+// whichever comes first. If both are zero, it runs until tcpdump is stopped, which is only useful as the command of a
+// container whose capture is read later with ReadFile.
+type Options struct {
+	// Interface is the interface to capture on, such as net1.
+	Interface string
+	// Duration is how long to capture for, rounded up to whole seconds.
+	Duration time.Duration
+	// Count is the number of packets after which to stop capturing.
+	Count int
+	// SnapLength is the number of bytes captured of each packet. Headers fit in 128 bytes, which keeps long captures
+	// small. Defaults to the tcpdump default of the whole packet.
+	SnapLength int
+	// Filter is a tcpdump filter expression, such as icmp or vlan 100.
+	Filter string
+	// Path is the file the capture is written to. Defaults to DefaultPath.
+	Path string
+}
+
+// Command returns the shell command that runs tcpdump with options, writing the capture to Path.
+func (options Options) Command() string {
+	args := []string{"tcpdump", "-i", options.Interface, "-U", "-w", options.path()}
+
+	if options.SnapLength > 0 {
+		args = append(args, "-s", fmt.Sprint(options.SnapLength))
+	}
+
+	if options.Count > 0 {
+		args = append(args, "-c", fmt.Sprint(options.Count))
+	}
+
+	if options.Filter != "" {
+		args = append(args, shellQuote(options.Filter))
+	}
+
+	if options.Duration > 0 {
+		args = append([]string{"timeout", "-s", "INT", fmt.Sprint(int(math.Ceil(options.Duration.Seconds())))}, args...)
+	}
+
+	return strings.Join(args, " ")
+}
+
+// ContainerCommand returns the command of a container that captures with options.
+func (options Options) ContainerCommand() []string {
+	return []string{"bash", "-c", options.Command()}
+}
+
+// Capture runs tcpdump with options in podBuilder, waits for the capture to stop, and returns the captured frames.
+// Options must set Duration or Count so the capture stops. If containerName is not provided, the first container of
+// the pod is used.
+func Capture(podBuilder *pod.Builder, options Options, containerName ...string) ([]Frame, error) {
+	if podBuilder == nil {
+		return nil, fmt.Errorf("cannot capture packets in nil pod")
+	}
+
+	if options.Interface == "" {
+		return nil, fmt.Errorf("cannot capture packets without an interface")
+	}
+
+	if options.Duration <= 0 && options.Count <= 0 {
+		return nil, fmt.Errorf("cannot capture packets without a duration or count to stop the capture")
+	}
+
+	glog.V(90).Infof("Capturing packets on interface %s of pod %s in namespace %s with command %s",
+		options.Interface, podBuilder.Definition.Name, podBuilder.Definition.Namespace, options.Command())
+
+	command := fmt.Sprintf("rm -f %[1]s; %[2]s; base64 -w 0 %[1]s", options.path(), options.Command())
+
+	return readBase64(podBuilder, command, containerName...)
+}
+
+// ReadFile copies the pcap file at path from podBuilder and returns its frames. It can read a capture that is still
+// being written, such as one started by ContainerCommand. If containerName is not provided, the first container of
+// the pod is used.
+func ReadFile(podBuilder *pod.Builder, path string, containerName ...string) ([]Frame, error) {
+	if podBuilder == nil {
+		return nil, fmt.Errorf("cannot read packet capture from nil pod")
+	}
+
+	glog.V(90).Infof("Reading packet capture %s from pod %s in namespace %s",
+		path, podBuilder.Definition.Name, podBuilder.Definition.Namespace)
+
+	return readBase64(podBuilder, "base64 -w 0 "+path, containerName...)
+}
+
+// readBase64 runs command in podBuilder, which must print a pcap file encoded as base64, and returns its frames.
+func readBase64(podBuilder *pod.Builder, command string, containerName ...string) ([]Frame, error) {
+	output, err := podBuilder.ExecCommand([]string{"bash", "-c", command}, containerName...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read packet capture from pod %s: %w: %s",
+			podBuilder.Definition.Name, err, output.String())
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(output.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode packet capture from pod %s: %w", podBuilder.Definition.Name, err)
+	}
+
+	return Read(data)
+}
+
+// path returns the file the capture is written to.
+func (options Options) path() string {
+	if options.Path == "" {
+		return DefaultPath
+	}
+
+	return options.Path
+}
+
+// shellQuote returns value quoted for bash so it is passed as a single argument.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,50 @@
+package pcap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionsCommand(t *testing.T) {
+	testCases := []struct {
+		name     string
+		options  Options
+		expected string
+	}{
+		{
+			name:     "until stopped",
+			options:  Options{Interface: "net1"},
+			expected: "tcpdump -i net1 -U -w /tmp/capture.pcap",
+		},
+		{
+			name: "bounded with filter",
+			options: Options{
+				Interface:  "net2",
+				Duration:   1500 * time.Millisecond,
+				Count:      10,
+				SnapLength: 128,
+				Filter:     "dst host 239.100.100.250 and not port 'x'",
+				Path:       "/tmp/net2.pcap",
+			},
+			expected: "timeout -s INT 2 tcpdump -i net2 -U -w /tmp/net2.pcap -s 128 -c 10 " +
+				`'dst host 239.100.100.250 and not port '\''x'\'''`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.options.Command())
+			assert.Equal(t, []string{"bash", "-c", testCase.expected}, testCase.options.ContainerCommand())
+		})
+	}
+}
+
+func TestCaptureValidation(t *testing.T) {
+	_, err := Capture(nil, Options{Interface: "net1", Count: 1})
+	assert.EqualError(t, err, "cannot capture packets in nil pod")
+
+	_, err = ReadFile(nil, DefaultPath)
+	assert.EqualError(t, err, "cannot read packet capture from nil pod")
+}
@@ -0,0 +1,273 @@
+package pcap
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	// TPIDDot1Q is the tag protocol identifier of an 802.1Q VLAN tag, also used for QinQ customer tags.
+	TPIDDot1Q uint16 = 0x8100
+	// TPIDDot1AD is the tag protocol identifier of an 802.1ad service VLAN tag.
+	TPIDDot1AD uint16 = 0x88a8
+	// TPIDQinQ is the pre-standard tag protocol identifier some switches use for QinQ service tags.
+	TPIDQinQ uint16 = 0x9100
+
+	// EtherTypeIPv4 is the EtherType of IPv4 packets.
+	EtherTypeIPv4 uint16 = 0x0800
+	// EtherTypeARP is the EtherType of ARP packets.
+	EtherTypeARP uint16 = 0x0806
+	// EtherTypeIPv6 is the EtherType of IPv6 packets.
+	EtherTypeIPv6 uint16 = 0x86dd
+
+	// ProtocolICMP is the IP protocol number of ICMP.
+	ProtocolICMP uint8 = 1
+	// ProtocolTCP is the IP protocol number of TCP.
+	ProtocolTCP uint8 = 6
+	// ProtocolUDP is the IP protocol number of UDP.
+	ProtocolUDP uint8 = 17
+	// ProtocolICMPv6 is the IP protocol number of ICMPv6.
+	ProtocolICMPv6 uint8 = 58
+)
+
+type (
+	// Frame is a decoded Ethernet frame. Layers that are not present or that are cut short by the capture snapshot
+	// length are nil.
+	Frame struct {
+		// Timestamp is when the frame was captured.
+		Timestamp time.Time
+		// Length is the length of the frame on the wire, which may be more than len(Data).
+		Length int
+		// Data is the captured bytes of the frame.
+		Data []byte
+
+		DstMAC net.HardwareAddr
+		SrcMAC net.HardwareAddr
+		// Tags are the VLAN tags of the frame, outermost first.
+		Tags []VLANTag
+		// EtherType is the EtherType of the payload after the VLAN tags.
+		EtherType uint16
+
+		IP   *IPHeader
+		ICMP *ICMPHeader
+		UDP  *PortHeader
+		TCP  *TCPHeader
+	}
+
+	// VLANTag is an 802.1Q or 802.1ad VLAN tag.
+	VLANTag struct {
+		TPID     uint16
+		Priority uint8
+		DEI      bool
+		VLAN     uint16
+	}
+
+	// IPHeader is the IPv4 or IPv6 header of a frame. Protocol is the transport protocol after any IPv6 extension
+	// headers and TTL is the hop limit for IPv6.
+	IPHeader struct {
+		Version  int
+		Src      net.IP
+		Dst      net.IP
+		Protocol uint8
+		TTL      uint8
+	}
+
+	// ICMPHeader is the ICMP or ICMPv6 header of a frame.
+	ICMPHeader struct {
+		Type uint8
+		Code uint8
+	}
+
+	// PortHeader is the UDP header of a frame or the ports of a TCP header.
+	PortHeader struct {
+		SrcPort uint16
+		DstPort uint16
+	}
+
+	// TCPHeader is the TCP header of a frame. Flags are the low eight flag bits, such as 0x02 for SYN.
+	TCPHeader struct {
+		PortHeader
+		Seq   uint32
+		Flags uint8
+	}
+)
+
+// isTPID returns whether etherType is the tag protocol identifier of a VLAN tag.
+func isTPID(etherType uint16) bool {
+	return etherType == TPIDDot1Q || etherType == TPIDDot1AD || etherType == TPIDQinQ
+}
+
+// DecodeFrame decodes the Ethernet, VLAN, IP, and transport headers of data. It decodes as many layers as data
+// contains and never fails, so a short frame only has the layers that fit.
+func DecodeFrame(data []byte) Frame {
+	frame := Frame{Length: len(data), Data: data}
+	if len(data) < 14 {
+		return frame
+	}
+
+	frame.DstMAC = net.HardwareAddr(data[0:6])
+	frame.SrcMAC = net.HardwareAddr(data[6:12])
+	frame.EtherType = binary.BigEndian.Uint16(data[12:])
+	payload := data[14:]
+
+	for isTPID(frame.EtherType) && len(payload) >= 4 {
+		tci := binary.BigEndian.Uint16(payload)
+		frame.Tags = append(frame.Tags, VLANTag{
+			TPID:     frame.EtherType,
+			Priority: uint8(tci >> 13),
+			DEI:      tci&0x1000 != 0,
+			VLAN:     tci & 0x0fff,
+		})
+		frame.EtherType = binary.BigEndian.Uint16(payload[2:])
+		payload = payload[4:]
+	}
+
+	switch frame.EtherType {
+	case EtherTypeIPv4:
+		frame.IP, payload = decodeIPv4(payload)
+	case EtherTypeIPv6:
+		frame.IP, payload = decodeIPv6(payload)
+	}
+
+	if frame.IP != nil {
+		frame.decodeTransport(payload)
+	}
+
+	return frame
+}
+
+// decodeTransport decodes the ICMP, UDP, or TCP header at the start of payload.
+func (frame *Frame) decodeTransport(payload []byte) {
+	switch frame.IP.Protocol {
+	case ProtocolICMP, ProtocolICMPv6:
+		if len(payload) >= 2 {
+			frame.ICMP = &ICMPHeader{Type: payload[0], Code: payload[1]}
+		}
+	case ProtocolUDP:
+		if len(payload) >= 8 {
+			frame.UDP = &PortHeader{
+				SrcPort: binary.BigEndian.Uint16(payload),
+				DstPort: binary.BigEndian.Uint16(payload[2:]),
+			}
+		}
+	case ProtocolTCP:
+		if len(payload) >= 20 {
+			frame.TCP = &TCPHeader{
+				PortHeader: PortHeader{
+					SrcPort: binary.BigEndian.Uint16(payload),
+					DstPort: binary.BigEndian.Uint16(payload[2:]),
+				},
+				Seq:   binary.BigEndian.Uint32(payload[4:]),
+				Flags: payload[13],
+			}
+		}
+	}
+}
+
+// decodeIPv4 decodes the IPv4 header at the start of data and returns it with the payload after it. The header is
+// nil if data is too short.
+func decodeIPv4(data []byte) (*IPHeader, []byte) {
+	if len(data) < 20 || data[0]>>4 != 4 {
+		return nil, nil
+	}
+
+	headerLength := int(data[0]&0x0f) * 4
+	if headerLength < 20 || len(data) < headerLength {
+		return nil, nil
+	}
+
+	return &IPHeader{
+		Version:  4,
+		Src:      net.IP(data[12:16]),
+		Dst:      net.IP(data[16:20]),
+		Protocol: data[9],
+		TTL:      data[8],
+	}, data[headerLength:]
+}
+
+// decodeIPv6 decodes the IPv6 header and any hop-by-hop, routing, fragment, and destination options extension
+// headers at the start of data and returns it with the payload after them. The header is nil if data is too short.
+func decodeIPv6(data []byte) (*IPHeader, []byte) {
+	if len(data) < 40 || data[0]>>4 != 6 {
+		return nil, nil
+	}
+
+	header := &IPHeader{
+		Version:  6,
+		Src:      net.IP(data[8:24]),
+		Dst:      net.IP(data[24:40]),
+		Protocol: data[6],
+		TTL:      data[7],
+	}
+	payload := data[40:]
+
+	for isIPv6ExtensionHeader(header.Protocol) && len(payload) >= 8 {
+		extensionLength := 8
+		if header.Protocol != 44 {
+			extensionLength = (int(payload[1]) + 1) * 8
+		}
+
+		if len(payload) < extensionLength {
+			return header, nil
+		}
+
+		header.Protocol = payload[0]
+		payload = payload[extensionLength:]
+	}
+
+	return header, payload
+}
+
+// isIPv6ExtensionHeader returns whether protocol is an IPv6 extension header that DecodeFrame skips.
+func isIPv6ExtensionHeader(protocol uint8) bool {
+	return protocol == 0 || protocol == 43 || protocol == 44 || protocol == 60
+}
+
+// String returns a summary of the frame similar to tcpdump -e, such as
+// 60:00:00:00:00:01 > 60:00:00:00:00:02, 802.1ad vlan 10, 802.1Q vlan 100, IPv4 192.168.100.1 > 192.168.100.2, TCP
+// 40000 > 4444.
+func (frame Frame) String() string {
+	if frame.SrcMAC == nil {
+		return fmt.Sprintf("truncated frame of %d bytes", len(frame.Data))
+	}
+
+	parts := []string{fmt.Sprintf("%s > %s", frame.SrcMAC, frame.DstMAC)}
+
+	for _, tag := range frame.Tags {
+		parts = append(parts, tag.String())
+	}
+
+	if frame.IP == nil {
+		return strings.Join(append(parts, fmt.Sprintf("ethertype 0x%04x", frame.EtherType)), ", ")
+	}
+
+	parts = append(parts, fmt.Sprintf("IPv%d %s > %s", frame.IP.Version, frame.IP.Src, frame.IP.Dst))
+
+	switch {
+	case frame.ICMP != nil:
+		parts = append(parts, fmt.Sprintf("ICMP type %d code %d", frame.ICMP.Type, frame.ICMP.Code))
+	case frame.UDP != nil:
+		parts = append(parts, fmt.Sprintf("UDP %d > %d", frame.UDP.SrcPort, frame.UDP.DstPort))
+	case frame.TCP != nil:
+		parts = append(parts, fmt.Sprintf("TCP %d > %d", frame.TCP.SrcPort, frame.TCP.DstPort))
+	default:
+		parts = append(parts, fmt.Sprintf("protocol %d", frame.IP.Protocol))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// String returns the kind of tag and its VLAN, such as 802.1Q vlan 100.
+func (tag VLANTag) String() string {
+	switch tag.TPID {
+	case TPIDDot1Q:
+		return fmt.Sprintf("802.1Q vlan %d", tag.VLAN)
+	case TPIDDot1AD:
+		return fmt.Sprintf("802.1ad vlan %d", tag.VLAN)
+	default:
+		return fmt.Sprintf("tpid 0x%04x vlan %d", tag.TPID, tag.VLAN)
+	}
+}
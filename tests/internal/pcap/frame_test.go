@@ -0,0 +1,109 @@
+package pcap
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeFrame(t *testing.T) {
+	tcpHeader := portHeader(40000, 4444, 20)
+	tcpHeader[13] = 0x02
+
+	testCases := []struct {
+		name           string
+		data           []byte
+		expectedTags   []VLANTag
+		expectedType   uint16
+		expectedIP     *IPHeader
+		expectedICMP   *ICMPHeader
+		expectedUDP    *PortHeader
+		expectedTCP    *TCPHeader
+		expectedString string
+	}{
+		{
+			name: "untagged ipv4 icmp",
+			data: ethernetFrame(EtherTypeIPv4,
+				ipv4Packet("192.168.100.1", "192.168.100.2", ProtocolICMP, []byte{8, 0})),
+			expectedType: EtherTypeIPv4,
+			expectedIP: &IPHeader{
+				Version:  4,
+				Src:      net.ParseIP("192.168.100.1").To4(),
+				Dst:      net.ParseIP("192.168.100.2").To4(),
+				Protocol: ProtocolICMP,
+				TTL:      64,
+			},
+			expectedICMP: &ICMPHeader{Type: 8},
+			expectedString: "60:00:00:00:00:01 > 60:00:00:00:00:02, IPv4 192.168.100.1 > 192.168.100.2, " +
+				"ICMP type 8 code 0",
+		},
+		{
+			name: "double tagged ipv4 tcp",
+			data: ethernetFrame(EtherTypeIPv4, ipv4Packet("192.168.100.1", "192.168.100.2", ProtocolTCP, tcpHeader),
+				VLANTag{TPID: TPIDDot1AD, VLAN: 10, Priority: 5}, VLANTag{TPID: TPIDDot1Q, VLAN: 100}),
+			expectedTags: []VLANTag{{TPID: TPIDDot1AD, VLAN: 10, Priority: 5}, {TPID: TPIDDot1Q, VLAN: 100}},
+			expectedType: EtherTypeIPv4,
+			expectedIP: &IPHeader{
+				Version:  4,
+				Src:      net.ParseIP("192.168.100.1").To4(),
+				Dst:      net.ParseIP("192.168.100.2").To4(),
+				Protocol: ProtocolTCP,
+				TTL:      64,
+			},
+			expectedTCP: &TCPHeader{PortHeader: PortHeader{SrcPort: 40000, DstPort: 4444}, Flags: 0x02},
+			expectedString: "60:00:00:00:00:01 > 60:00:00:00:00:02, 802.1ad vlan 10, 802.1Q vlan 100, " +
+				"IPv4 192.168.100.1 > 192.168.100.2, TCP 40000 > 4444",
+		},
+		{
+			name: "tagged ipv6 udp after extension header",
+			data: ethernetFrame(EtherTypeIPv6, ipv6Packet("2001:100::1", "ff05:5::5", 0,
+				append([]byte{ProtocolUDP, 0, 0, 0, 0, 0, 0, 0}, portHeader(5000, 6000, 8)...)),
+				VLANTag{TPID: TPIDQinQ, VLAN: 200}),
+			expectedTags: []VLANTag{{TPID: TPIDQinQ, VLAN: 200}},
+			expectedType: EtherTypeIPv6,
+			expectedIP: &IPHeader{
+				Version:  6,
+				Src:      net.ParseIP("2001:100::1"),
+				Dst:      net.ParseIP("ff05:5::5"),
+				Protocol: ProtocolUDP,
+				TTL:      255,
+			},
+			expectedUDP: &PortHeader{SrcPort: 5000, DstPort: 6000},
+			expectedString: "60:00:00:00:00:01 > 60:00:00:00:00:02, tpid 0x9100 vlan 200, " +
+				"IPv6 2001:100::1 > ff05:5::5, UDP 5000 > 6000",
+		},
+		{
+			name:           "arp",
+			data:           ethernetFrame(EtherTypeARP, make([]byte, 28)),
+			expectedType:   EtherTypeARP,
+			expectedString: "60:00:00:00:00:01 > 60:00:00:00:00:02, ethertype 0x0806",
+		},
+		{
+			name: "ipv4 cut short by snapshot length",
+			data: ethernetFrame(EtherTypeIPv4,
+				ipv4Packet("192.168.100.1", "192.168.100.2", ProtocolTCP, nil)[:10]),
+			expectedType:   EtherTypeIPv4,
+			expectedString: "60:00:00:00:00:01 > 60:00:00:00:00:02, ethertype 0x0800",
+		},
+		{
+			name:           "shorter than ethernet header",
+			data:           make([]byte, 10),
+			expectedString: "truncated frame of 10 bytes",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			frame := DecodeFrame(testCase.data)
+
+			assert.Equal(t, testCase.expectedTags, frame.Tags)
+			assert.Equal(t, testCase.expectedType, frame.EtherType)
+			assert.Equal(t, testCase.expectedIP, frame.IP)
+			assert.Equal(t, testCase.expectedICMP, frame.ICMP)
+			assert.Equal(t, testCase.expectedUDP, frame.UDP)
+			assert.Equal(t, testCase.expectedTCP, frame.TCP)
+			assert.Equal(t, testCase.expectedString, frame.String())
+		})
+	}
+}
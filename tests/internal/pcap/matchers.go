@@ -0,0 +1,257 @@
+package pcap
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/onsi/gomega/types"
+)
+
+// maxFramesInMessage is the number of captured frames listed in failure messages.
+const maxFramesInMessage = 20
+
+// FrameCondition is a condition on a single frame used by ContainFrame and Filter.
+type FrameCondition struct {
+	description string
+	match       func(frame Frame) bool
+}
+
+// ContainFrame succeeds if the actual []Frame, or pcap data as []byte, has a frame that satisfies all conditions.
+// For example, to check that traffic is double tagged with service VLAN 100 and customer VLAN 200:
+//
+//	Expect(frames).To(ContainFrame(WithOuterTag(TPIDDot1AD, 100), WithInnerTag(TPIDDot1Q, 200)))
+func ContainFrame(conditions ...FrameCondition) types.GomegaMatcher {
+	return &frameMatcher{conditions: conditions}
+}
+
+// Filter returns the frames that satisfy all conditions.
+func Filter(frames []Frame, conditions ...FrameCondition) []Frame {
+	var matching []Frame
+
+	for _, frame := range frames {
+		if matchAll(frame, conditions) {
+			matching = append(matching, frame)
+		}
+	}
+
+	return matching
+}
+
+// WithOuterTag requires the outermost VLAN tag of the frame to have tpid and vlan.
+func WithOuterTag(tpid, vlan uint16) FrameCondition {
+	return withTag(0, "outer", tpid, vlan)
+}
+
+// WithInnerTag requires the second VLAN tag of the frame to have tpid and vlan.
+func WithInnerTag(tpid, vlan uint16) FrameCondition {
+	return withTag(1, "inner", tpid, vlan)
+}
+
+// WithOuterTPID requires the outermost VLAN tag of the frame to have tpid with any VLAN.
+func WithOuterTPID(tpid uint16) FrameCondition {
+	return FrameCondition{
+		description: fmt.Sprintf("outer tag with tpid 0x%04x", tpid),
+		match: func(frame Frame) bool {
+			return len(frame.Tags) > 0 && frame.Tags[0].TPID == tpid
+		},
+	}
+}
+
+// WithInnerVLAN requires the second VLAN tag of the frame to have vlan with any TPID.
+func WithInnerVLAN(vlan uint16) FrameCondition {
+	return FrameCondition{
+		description: fmt.Sprintf("inner tag with vlan %d", vlan),
+		match: func(frame Frame) bool {
+			return len(frame.Tags) > 1 && frame.Tags[1].VLAN == vlan
+		},
+	}
+}
+
+// WithoutVLANTags requires the frame to be untagged.
+func WithoutVLANTags() FrameCondition {
+	return FrameCondition{
+		description: "no vlan tags",
+		match:       func(frame Frame) bool { return len(frame.Tags) == 0 },
+	}
+}
+
+// WithSrcMAC requires the frame to have the source MAC address mac.
+func WithSrcMAC(mac string) FrameCondition {
+	expected := normalizeMAC(mac)
+
+	return FrameCondition{
+		description: "source mac " + expected,
+		match:       func(frame Frame) bool { return frame.SrcMAC.String() == expected },
+	}
+}
+
+// WithDstMAC requires the frame to have the destination MAC address mac.
+func WithDstMAC(mac string) FrameCondition {
+	expected := normalizeMAC(mac)
+
+	return FrameCondition{
+		description: "destination mac " + expected,
+		match:       func(frame Frame) bool { return frame.DstMAC.String() == expected },
+	}
+}
+
+// WithMulticastDst requires the frame to have a multicast or broadcast destination MAC address.
+func WithMulticastDst() FrameCondition {
+	return FrameCondition{
+		description: "multicast destination mac",
+		match:       func(frame Frame) bool { return len(frame.DstMAC) > 0 && frame.DstMAC[0]&0x01 != 0 },
+	}
+}
+
+// WithEtherType requires the payload after the VLAN tags of the frame to have etherType.
+func WithEtherType(etherType uint16) FrameCondition {
+	return FrameCondition{
+		description: fmt.Sprintf("ethertype 0x%04x", etherType),
+		match:       func(frame Frame) bool { return frame.EtherType == etherType },
+	}
+}
+
+// WithSrcIP requires the frame to have the IPv4 or IPv6 source address ip.
+func WithSrcIP(ip string) FrameCondition {
+	expected := net.ParseIP(ip)
+
+	return FrameCondition{
+		description: "source ip " + ip,
+		match:       func(frame Frame) bool { return frame.IP != nil && frame.IP.Src.Equal(expected) },
+	}
+}
+
+// WithDstIP requires the frame to have the IPv4 or IPv6 destination address ip.
+func WithDstIP(ip string) FrameCondition {
+	expected := net.ParseIP(ip)
+
+	return FrameCondition{
+		description: "destination ip " + ip,
+		match:       func(frame Frame) bool { return frame.IP != nil && frame.IP.Dst.Equal(expected) },
+	}
+}
+
+// WithIPProtocol requires the frame to have an IP header with the transport protocol, such as ProtocolUDP.
+func WithIPProtocol(protocol uint8) FrameCondition {
+	return FrameCondition{
+		description: fmt.Sprintf("ip protocol %d", protocol),
+		match:       func(frame Frame) bool { return frame.IP != nil && frame.IP.Protocol == protocol },
+	}
+}
+
+// WithDstPort requires the frame to have a UDP or TCP header with the destination port.
+func WithDstPort(port uint16) FrameCondition {
+	return FrameCondition{
+		description: fmt.Sprintf("destination port %d", port),
+		match: func(frame Frame) bool {
+			return (frame.UDP != nil && frame.UDP.DstPort == port) || (frame.TCP != nil && frame.TCP.DstPort == port)
+		},
+	}
+}
+
+type frameMatcher struct {
+	conditions []FrameCondition
+}
+
+// Match returns whether any frame satisfies all conditions.
+func (matcher *frameMatcher) Match(actual any) (bool, error) {
+	frames, err := toFrames(actual)
+	if err != nil {
+		return false, err
+	}
+
+	return len(Filter(frames, matcher.conditions...)) > 0, nil
+}
+
+// FailureMessage returns the expected frame and the captured frames.
+func (matcher *frameMatcher) FailureMessage(actual any) string {
+	frames, _ := toFrames(actual)
+
+	return fmt.Sprintf("Expected a frame with\n\t%s\nbut captured\n%s", matcher.describe(), describeFrames(frames))
+}
+
+// NegatedFailureMessage returns the frame that should not be present and the captured frames that have it.
+func (matcher *frameMatcher) NegatedFailureMessage(actual any) string {
+	frames, _ := toFrames(actual)
+
+	return fmt.Sprintf("Expected no frame with\n\t%s\nbut captured\n%s",
+		matcher.describe(), describeFrames(Filter(frames, matcher.conditions...)))
+}
+
+// describe returns the conditions of the matcher.
+func (matcher *frameMatcher) describe() string {
+	if len(matcher.conditions) == 0 {
+		return "any contents"
+	}
+
+	descriptions := make([]string, 0, len(matcher.conditions))
+	for _, condition := range matcher.conditions {
+		descriptions = append(descriptions, condition.description)
+	}
+
+	return strings.Join(descriptions, ", ")
+}
+
+// describeFrames returns up to maxFramesInMessage frames, one per line, for failure messages.
+func describeFrames(frames []Frame) string {
+	if len(frames) == 0 {
+		return "\tno frames"
+	}
+
+	var lines []string
+
+	for index, frame := range frames {
+		if index == maxFramesInMessage {
+			lines = append(lines, fmt.Sprintf("\t... and %d more", len(frames)-maxFramesInMessage))
+
+			break
+		}
+
+		lines = append(lines, "\t"+frame.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// withTag returns a condition requiring the VLAN tag at index to have tpid and vlan.
+func withTag(index int, name string, tpid, vlan uint16) FrameCondition {
+	return FrameCondition{
+		description: fmt.Sprintf("%s tag with tpid 0x%04x and vlan %d", name, tpid, vlan),
+		match: func(frame Frame) bool {
+			return len(frame.Tags) > index && frame.Tags[index].TPID == tpid && frame.Tags[index].VLAN == vlan
+		},
+	}
+}
+
+// matchAll returns whether frame satisfies all conditions.
+func matchAll(frame Frame, conditions []FrameCondition) bool {
+	for _, condition := range conditions {
+		if !condition.match(frame) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// normalizeMAC returns mac in the format of net.HardwareAddr.String, or mac unchanged if it does not parse.
+func normalizeMAC(mac string) string {
+	hardwareAddr, err := net.ParseMAC(mac)
+	if err != nil {
+		return mac
+	}
+
+	return hardwareAddr.String()
+}
+
+func toFrames(actual any) ([]Frame, error) {
+	switch typed := actual.(type) {
+	case []Frame:
+		return typed, nil
+	case []byte:
+		return Read(typed)
+	default:
+		return nil, fmt.Errorf("frame matchers expect a []Frame or pcap []byte, got %T", actual)
+	}
+}
@@ -0,0 +1,131 @@
+package pcap
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainFrame(t *testing.T) {
+	doubleTagged := ethernetFrame(EtherTypeIPv4,
+		ipv4Packet("192.168.100.1", "192.168.100.2", ProtocolUDP, portHeader(5000, 4444, 8)),
+		VLANTag{TPID: TPIDDot1AD, VLAN: 10}, VLANTag{TPID: TPIDDot1Q, VLAN: 100})
+	multicast := ethernetFrame(EtherTypeIPv4,
+		ipv4Packet("192.168.100.20", "239.100.100.250", ProtocolICMP, []byte{8, 0}))
+	copy(multicast, mustParseMAC("01:00:5e:64:64:fa"))
+
+	data := pcapFile(binary.LittleEndian, false, time.Unix(0, 0), doubleTagged, multicast)
+	frames, err := Read(data)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		name          string
+		actual        any
+		conditions    []FrameCondition
+		expectedMatch bool
+		expectedError string
+	}{
+		{
+			name:          "outer and inner tags",
+			actual:        frames,
+			conditions:    []FrameCondition{WithOuterTag(TPIDDot1AD, 10), WithInnerTag(TPIDDot1Q, 100)},
+			expectedMatch: true,
+		},
+		{
+			name:          "inner tag with wrong tpid",
+			actual:        frames,
+			conditions:    []FrameCondition{WithOuterTag(TPIDDot1AD, 10), WithInnerTag(TPIDDot1AD, 100)},
+			expectedMatch: false,
+		},
+		{
+			name:          "outer tpid and inner vlan from pcap data",
+			actual:        data,
+			conditions:    []FrameCondition{WithOuterTPID(TPIDDot1AD), WithInnerVLAN(100)},
+			expectedMatch: true,
+		},
+		{
+			name:          "conditions must hold for the same frame",
+			actual:        frames,
+			conditions:    []FrameCondition{WithOuterTPID(TPIDDot1AD), WithMulticastDst()},
+			expectedMatch: false,
+		},
+		{
+			name:   "untagged multicast icmp",
+			actual: frames,
+			conditions: []FrameCondition{
+				WithoutVLANTags(), WithMulticastDst(), WithDstMAC("01:00:5E:64:64:FA"), WithSrcMAC("60:00:00:00:00:01"),
+				WithEtherType(EtherTypeIPv4), WithSrcIP("192.168.100.20"), WithDstIP("239.100.100.250"),
+				WithIPProtocol(ProtocolICMP),
+			},
+			expectedMatch: true,
+		},
+		{
+			name:          "destination port",
+			actual:        frames,
+			conditions:    []FrameCondition{WithDstPort(4444)},
+			expectedMatch: true,
+		},
+		{
+			name:          "any frame",
+			actual:        frames,
+			expectedMatch: true,
+		},
+		{
+			name:          "no frames",
+			actual:        []Frame{},
+			expectedMatch: false,
+		},
+		{
+			name:          "unsupported actual",
+			actual:        "frames",
+			expectedError: "frame matchers expect a []Frame or pcap []byte, got string",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			matched, err := ContainFrame(testCase.conditions...).Match(testCase.actual)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedMatch, matched)
+		})
+	}
+}
+
+func TestContainFrameMessages(t *testing.T) {
+	frames := []Frame{DecodeFrame(ethernetFrame(EtherTypeARP, make([]byte, 28),
+		VLANTag{TPID: TPIDDot1Q, VLAN: 100}))}
+	matcher := ContainFrame(WithOuterTag(TPIDDot1AD, 100), WithInnerVLAN(200))
+
+	assert.Equal(t, "Expected a frame with\n"+
+		"\touter tag with tpid 0x88a8 and vlan 100, inner tag with vlan 200\n"+
+		"but captured\n"+
+		"\t60:00:00:00:00:01 > 60:00:00:00:00:02, 802.1Q vlan 100, ethertype 0x0806", matcher.FailureMessage(frames))
+
+	matcher = ContainFrame(WithOuterTPID(TPIDDot1Q))
+	assert.Equal(t, "Expected no frame with\n"+
+		"\touter tag with tpid 0x8100\n"+
+		"but captured\n"+
+		"\t60:00:00:00:00:01 > 60:00:00:00:00:02, 802.1Q vlan 100, ethertype 0x0806",
+		matcher.NegatedFailureMessage(frames))
+}
+
+func TestFilter(t *testing.T) {
+	frames := make([]Frame, 0, maxFramesInMessage+5)
+	for vlan := range uint16(maxFramesInMessage + 5) {
+		frames = append(frames, DecodeFrame(ethernetFrame(EtherTypeARP, nil, VLANTag{TPID: TPIDDot1Q, VLAN: vlan})))
+	}
+
+	assert.Len(t, Filter(frames, WithOuterTPID(TPIDDot1Q)), maxFramesInMessage+5)
+	assert.Len(t, Filter(frames, WithOuterTag(TPIDDot1Q, 3)), 1)
+	assert.Empty(t, Filter(frames, WithoutVLANTags()))
+	assert.Contains(t, describeFrames(frames), "\t... and 5 more")
+}
@@ -0,0 +1,75 @@
+package pcap
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+const (
+	magicMicroseconds  = 0xa1b2c3d4
+	magicNanoseconds   = 0xa1b23c4d
+	magicPcapNG        = 0x0a0d0d0a
+	globalHeaderLength = 24
+	recordHeaderLength = 16
+	linkTypeEthernet   = 1
+)
+
+// Read decodes the frames of a pcap file with the Ethernet link type, such as one written by tcpdump -w. Either byte
+// order and both microsecond and nanosecond timestamps are supported. A record cut short at the end of data, which
+// happens when reading a capture that is still being written, is ignored.
+func Read(data []byte) ([]Frame, error) {
+	if len(data) < globalHeaderLength {
+		return nil, fmt.Errorf("pcap data has %d bytes, shorter than the %d byte file header",
+			len(data), globalHeaderLength)
+	}
+
+	var (
+		byteOrder   binary.ByteOrder
+		nanoseconds bool
+	)
+
+	switch magic := binary.LittleEndian.Uint32(data); {
+	case magic == magicMicroseconds || magic == magicNanoseconds:
+		byteOrder, nanoseconds = binary.LittleEndian, magic == magicNanoseconds
+	case binary.BigEndian.Uint32(data) == magicMicroseconds || binary.BigEndian.Uint32(data) == magicNanoseconds:
+		byteOrder, nanoseconds = binary.BigEndian, binary.BigEndian.Uint32(data) == magicNanoseconds
+	case magic == magicPcapNG:
+		return nil, fmt.Errorf("pcapng files are not supported, capture with tcpdump -w which writes pcap")
+	default:
+		return nil, fmt.Errorf("unknown pcap magic number 0x%08x", magic)
+	}
+
+	if linkType := byteOrder.Uint32(data[20:]) & 0xffff; linkType != linkTypeEthernet {
+		return nil, fmt.Errorf("unsupported pcap link type %d, only Ethernet (%d) is supported",
+			linkType, linkTypeEthernet)
+	}
+
+	var frames []Frame
+
+	for offset := globalHeaderLength; offset+recordHeaderLength <= len(data); {
+		header := data[offset : offset+recordHeaderLength]
+		seconds := int64(byteOrder.Uint32(header))
+		fraction := int64(byteOrder.Uint32(header[4:]))
+		capturedLength := int(byteOrder.Uint32(header[8:]))
+		originalLength := int(byteOrder.Uint32(header[12:]))
+
+		offset += recordHeaderLength
+		if capturedLength > len(data)-offset {
+			break
+		}
+
+		if !nanoseconds {
+			fraction *= int64(time.Microsecond)
+		}
+
+		frame := DecodeFrame(data[offset : offset+capturedLength])
+		frame.Timestamp = time.Unix(seconds, fraction).UTC()
+		frame.Length = originalLength
+		frames = append(frames, frame)
+
+		offset += capturedLength
+	}
+
+	return frames, nil
+}
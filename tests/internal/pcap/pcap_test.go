@@ -0,0 +1,180 @@
+package pcap
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testSrcMAC = mustParseMAC("60:00:00:00:00:01")
+	testDstMAC = mustParseMAC("60:00:00:00:00:02")
+)
+
+func TestRead(t *testing.T) {
+	frame := ethernetFrame(EtherTypeARP, make([]byte, 28))
+	timestamp := time.Date(2024, 5, 1, 10, 0, 0, 123456000, time.UTC)
+
+	testCases := []struct {
+		name           string
+		data           []byte
+		expectedFrames int
+		expectedError  string
+	}{
+		{
+			name:           "little endian microseconds",
+			data:           pcapFile(binary.LittleEndian, false, timestamp, frame, frame),
+			expectedFrames: 2,
+		},
+		{
+			name:           "big endian nanoseconds",
+			data:           pcapFile(binary.BigEndian, true, timestamp, frame),
+			expectedFrames: 1,
+		},
+		{
+			name:           "truncated last record",
+			data:           pcapFile(binary.LittleEndian, false, timestamp, frame, frame)[:24+16+len(frame)+20],
+			expectedFrames: 1,
+		},
+		{
+			name:           "no records",
+			data:           pcapFile(binary.LittleEndian, false, timestamp),
+			expectedFrames: 0,
+		},
+		{
+			name:          "short header",
+			data:          []byte{0xd4, 0xc3, 0xb2, 0xa1},
+			expectedError: "pcap data has 4 bytes, shorter than the 24 byte file header",
+		},
+		{
+			name:          "pcapng",
+			data:          append([]byte{0x0a, 0x0d, 0x0d, 0x0a}, make([]byte, 20)...),
+			expectedError: "pcapng files are not supported, capture with tcpdump -w which writes pcap",
+		},
+		{
+			name:          "unknown magic",
+			data:          make([]byte, 24),
+			expectedError: "unknown pcap magic number 0x00000000",
+		},
+		{
+			name: "linux cooked capture",
+			data: func() []byte {
+				data := pcapFile(binary.LittleEndian, false, timestamp)
+				binary.LittleEndian.PutUint32(data[20:], 113)
+
+				return data
+			}(),
+			expectedError: "unsupported pcap link type 113, only Ethernet (1) is supported",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			frames, err := Read(testCase.data)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Len(t, frames, testCase.expectedFrames)
+
+			for _, frame := range frames {
+				assert.Equal(t, timestamp, frame.Timestamp)
+				assert.Equal(t, len(frame.Data), frame.Length)
+				assert.Equal(t, EtherTypeARP, frame.EtherType)
+			}
+		})
+	}
+}
+
+// pcapFile returns a pcap file with the Ethernet link type containing frames, all captured at timestamp.
+func pcapFile(byteOrder binary.ByteOrder, nanoseconds bool, timestamp time.Time, frames ...[]byte) []byte {
+	magic := uint32(magicMicroseconds)
+	fraction := uint32(timestamp.Nanosecond() / 1000)
+
+	if nanoseconds {
+		magic = magicNanoseconds
+		fraction = uint32(timestamp.Nanosecond())
+	}
+
+	data := make([]byte, globalHeaderLength)
+	byteOrder.PutUint32(data, magic)
+	byteOrder.PutUint16(data[4:], 2)
+	byteOrder.PutUint16(data[6:], 4)
+	byteOrder.PutUint32(data[16:], 262144)
+	byteOrder.PutUint32(data[20:], linkTypeEthernet)
+
+	for _, frame := range frames {
+		header := make([]byte, recordHeaderLength)
+		byteOrder.PutUint32(header, uint32(timestamp.Unix()))
+		byteOrder.PutUint32(header[4:], fraction)
+		byteOrder.PutUint32(header[8:], uint32(len(frame)))
+		byteOrder.PutUint32(header[12:], uint32(len(frame)))
+		data = append(append(data, header...), frame...)
+	}
+
+	return data
+}
+
+// ethernetFrame returns an Ethernet frame from testSrcMAC to testDstMAC with the payload and VLAN tags, outermost
+// first.
+func ethernetFrame(etherType uint16, payload []byte, tags ...VLANTag) []byte {
+	frame := append(append([]byte{}, testDstMAC...), testSrcMAC...)
+
+	for _, tag := range tags {
+		frame = binary.BigEndian.AppendUint16(frame, tag.TPID)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(tag.Priority)<<13|tag.VLAN)
+	}
+
+	frame = binary.BigEndian.AppendUint16(frame, etherType)
+
+	return append(frame, payload...)
+}
+
+// ipv4Packet returns an IPv4 packet from src to dst with the protocol and transport header.
+func ipv4Packet(src, dst string, protocol uint8, transport []byte) []byte {
+	header := make([]byte, 20)
+	header[0] = 0x45
+	header[8] = 64
+	header[9] = protocol
+	copy(header[12:], net.ParseIP(src).To4())
+	copy(header[16:], net.ParseIP(dst).To4())
+
+	return append(header, transport...)
+}
+
+// ipv6Packet returns an IPv6 packet from src to dst whose first header after the fixed header is nextHeader.
+func ipv6Packet(src, dst string, nextHeader uint8, payload []byte) []byte {
+	header := make([]byte, 40)
+	header[0] = 0x60
+	header[6] = nextHeader
+	header[7] = 255
+	copy(header[8:], net.ParseIP(src).To16())
+	copy(header[24:], net.ParseIP(dst).To16())
+
+	return append(header, payload...)
+}
+
+// portHeader returns a UDP header, or the start of a TCP header when length is 20, with the ports.
+func portHeader(srcPort, dstPort uint16, length int) []byte {
+	header := make([]byte, length)
+	binary.BigEndian.PutUint16(header, srcPort)
+	binary.BigEndian.PutUint16(header[2:], dstPort)
+
+	return header
+}
+
+func mustParseMAC(mac string) net.HardwareAddr {
+	hardwareAddr, err := net.ParseMAC(mac)
+	if err != nil {
+		panic(err)
+	}
+
+	return hardwareAddr
+}
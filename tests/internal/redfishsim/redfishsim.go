@@ -0,0 +1,563 @@
+// Package redfishsim provides an in-process Redfish BMC simulator that eco-goinfra's bmc.BMC can connect to. It
+// simulates the systems, power state, power control, and chassis power readings used by the power and reboot helpers
+// along with configurable latency and failure injection so their wait and retry logic can be unit tested.
+package redfishsim
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
+)
+
+// Endpoint identifies a group of Redfish API routes for failure injection and request counting.
+type Endpoint string
+
+const (
+	// EndpointServiceRoot is the service root that every connection starts with.
+	EndpointServiceRoot Endpoint = "ServiceRoot"
+	// EndpointSessions is creating and deleting sessions.
+	EndpointSessions Endpoint = "Sessions"
+	// EndpointSystems is getting the systems collection and individual systems, including their power state.
+	EndpointSystems Endpoint = "Systems"
+	// EndpointReset is the ComputerSystem.Reset action used for power control.
+	EndpointReset Endpoint = "Reset"
+	// EndpointChassis is getting the chassis collection and individual chassis.
+	EndpointChassis Endpoint = "Chassis"
+	// EndpointPower is getting the power readings of a chassis.
+	EndpointPower Endpoint = "Power"
+)
+
+// Power states reported by the simulator. Systems only report the transitional states while a transition delay is
+// configured.
+const (
+	PowerStateOn          = "On"
+	PowerStateOff         = "Off"
+	PowerStatePoweringOn  = "PoweringOn"
+	PowerStatePoweringOff = "PoweringOff"
+)
+
+const (
+	// DefaultUsername is the username accepted by the simulator unless changed using WithCredentials.
+	DefaultUsername = "admin"
+	// DefaultPassword is the password accepted by the simulator unless changed using WithCredentials.
+	DefaultPassword = "password"
+	// DefaultPowerWatts is the power consumed by a powered on system unless changed using SetPowerWatts.
+	DefaultPowerWatts = 250
+	// DefaultTimeout is the Redfish timeout used for BMC clients returned by the simulator.
+	DefaultTimeout = 5 * time.Second
+)
+
+// supportedResetTypes are the reset types advertised by every system.
+var supportedResetTypes = []string{
+	"On", "ForceOff", "GracefulShutdown", "GracefulRestart", "ForceRestart", "PowerCycle",
+}
+
+// system is the simulated state of a single computer system.
+type system struct {
+	powerState string
+	// targetState and transitionEnd are set while the system is transitioning between power states.
+	targetState   string
+	transitionEnd time.Time
+	resets        []string
+}
+
+// failure is an injected failure for an endpoint.
+type failure struct {
+	status    int
+	remaining int
+}
+
+// Simulator is a Redfish BMC served over TLS on a random local port. Create one using New and stop it using Close.
+// All methods are safe for concurrent use.
+type Simulator struct {
+	server *httptest.Server
+
+	mutex             sync.Mutex
+	username          string
+	password          string
+	systems           []*system
+	powerWatts        float64
+	latency           time.Duration
+	transitionDelay   time.Duration
+	failures          map[Endpoint]*failure
+	requests          map[Endpoint]int
+	sessions          map[string]bool
+	unsupportedResets map[string]bool
+}
+
+// Option configures a Simulator when it is created.
+type Option func(*Simulator)
+
+// WithSystems sets the number of systems, which defaults to one. Every system starts powered on.
+func WithSystems(count int) Option {
+	return func(simulator *Simulator) {
+		simulator.systems = nil
+
+		for range count {
+			simulator.systems = append(simulator.systems, &system{powerState: PowerStateOn})
+		}
+	}
+}
+
+// WithCredentials sets the username and password required to create a session.
+func WithCredentials(username, password string) Option {
+	return func(simulator *Simulator) {
+		simulator.username = username
+		simulator.password = password
+	}
+}
+
+// WithUnsupportedResetTypes removes the provided reset types from the allowable values of every system so requesting
+// them fails.
+func WithUnsupportedResetTypes(resetTypes ...string) Option {
+	return func(simulator *Simulator) {
+		for _, resetType := range resetTypes {
+			simulator.unsupportedResets[resetType] = true
+		}
+	}
+}
+
+// New starts a simulator with one powered on system and a single chassis. The simulator must be closed using Close.
+func New(options ...Option) *Simulator {
+	simulator := &Simulator{
+		username:          DefaultUsername,
+		password:          DefaultPassword,
+		systems:           []*system{{powerState: PowerStateOn}},
+		powerWatts:        DefaultPowerWatts,
+		failures:          make(map[Endpoint]*failure),
+		requests:          make(map[Endpoint]int),
+		sessions:          make(map[string]bool),
+		unsupportedResets: make(map[string]bool),
+	}
+
+	for _, option := range options {
+		option(simulator)
+	}
+
+	simulator.server = httptest.NewTLSServer(simulator.routes())
+
+	return simulator
+}
+
+// Close stops the simulator.
+func (simulator *Simulator) Close() {
+	simulator.server.Close()
+}
+
+// Host returns the address of the simulator without a scheme, as expected by bmc.New.
+func (simulator *Simulator) Host() string {
+	return strings.TrimPrefix(simulator.server.URL, "https://")
+}
+
+// BMC returns a bmc.BMC connected to the simulator using its credentials and DefaultTimeout.
+func (simulator *Simulator) BMC() *bmc.BMC {
+	simulator.mutex.Lock()
+	defer simulator.mutex.Unlock()
+
+	return bmc.New(simulator.Host()).
+		WithRedfishUser(simulator.username, simulator.password).
+		WithRedfishTimeout(DefaultTimeout)
+}
+
+// SetPowerState immediately sets the power state of the system at index, canceling any ongoing transition.
+func (simulator *Simulator) SetPowerState(index int, powerState string) {
+	simulator.mutex.Lock()
+	defer simulator.mutex.Unlock()
+
+	sys := simulator.systems[index]
+	sys.powerState = powerState
+	sys.targetState = ""
+}
+
+// PowerState returns the power state of the system at index as it would be reported by the API.
+func (simulator *Simulator) PowerState(index int) string {
+	simulator.mutex.Lock()
+	defer simulator.mutex.Unlock()
+
+	return simulator.systems[index].currentPowerState(time.Now())
+}
+
+// Resets returns every reset type successfully requested for the system at index, in order.
+func (simulator *Simulator) Resets(index int) []string {
+	simulator.mutex.Lock()
+	defer simulator.mutex.Unlock()
+
+	return append([]string{}, simulator.systems[index].resets...)
+}
+
+// SetPowerWatts sets the power reported by the chassis while the first system is not off.
+func (simulator *Simulator) SetPowerWatts(watts float64) {
+	simulator.mutex.Lock()
+	defer simulator.mutex.Unlock()
+
+	simulator.powerWatts = watts
+}
+
+// SetLatency sets how long the simulator waits before responding to every request.
+func (simulator *Simulator) SetLatency(latency time.Duration) {
+	simulator.mutex.Lock()
+	defer simulator.mutex.Unlock()
+
+	simulator.latency = latency
+}
+
+// SetTransitionDelay sets how long systems take to reach their new power state after a reset. During the transition,
+// systems report PoweringOn or PoweringOff.
+func (simulator *Simulator) SetTransitionDelay(delay time.Duration) {
+	simulator.mutex.Lock()
+	defer simulator.mutex.Unlock()
+
+	simulator.transitionDelay = delay
+}
+
+// FailNext makes the next count requests to endpoint fail with status. A count less than zero fails every request
+// until the failure is cleared using FailNext with a count of zero.
+func (simulator *Simulator) FailNext(endpoint Endpoint, count, status int) {
+	simulator.mutex.Lock()
+	defer simulator.mutex.Unlock()
+
+	if count == 0 {
+		delete(simulator.failures, endpoint)
+
+		return
+	}
+
+	simulator.failures[endpoint] = &failure{status: status, remaining: count}
+}
+
+// Requests returns the number of requests received for endpoint, including failed ones.
+func (simulator *Simulator) Requests(endpoint Endpoint) int {
+	simulator.mutex.Lock()
+	defer simulator.mutex.Unlock()
+
+	return simulator.requests[endpoint]
+}
+
+// currentPowerState returns the power state of the system at now, completing its transition if it has ended.
+func (sys *system) currentPowerState(now time.Time) string {
+	if sys.targetState == "" {
+		return sys.powerState
+	}
+
+	if !now.Before(sys.transitionEnd) {
+		sys.powerState = sys.targetState
+		sys.targetState = ""
+
+		return sys.powerState
+	}
+
+	if sys.targetState == PowerStateOff {
+		return PowerStatePoweringOff
+	}
+
+	return PowerStatePoweringOn
+}
+
+// routes returns the handler for every route of the simulated Redfish API.
+func (simulator *Simulator) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /redfish/v1/{$}", simulator.handle(EndpointServiceRoot, false, simulator.getServiceRoot))
+	mux.HandleFunc("POST /redfish/v1/SessionService/Sessions",
+		simulator.handle(EndpointSessions, false, simulator.createSession))
+	mux.HandleFunc("DELETE /redfish/v1/SessionService/Sessions/{id}",
+		simulator.handle(EndpointSessions, true, simulator.deleteSession))
+	mux.HandleFunc("GET /redfish/v1/Systems", simulator.handle(EndpointSystems, true, simulator.getSystems))
+	mux.HandleFunc("GET /redfish/v1/Systems/{id}", simulator.handle(EndpointSystems, true, simulator.getSystem))
+	mux.HandleFunc("POST /redfish/v1/Systems/{id}/Actions/ComputerSystem.Reset",
+		simulator.handle(EndpointReset, true, simulator.resetSystem))
+	mux.HandleFunc("GET /redfish/v1/Chassis", simulator.handle(EndpointChassis, true, simulator.getChassisCollection))
+	mux.HandleFunc("GET /redfish/v1/Chassis/{id}", simulator.handle(EndpointChassis, true, simulator.getChassis))
+	mux.HandleFunc("GET /redfish/v1/Chassis/{id}/Power", simulator.handle(EndpointPower, true, simulator.getPower))
+
+	return mux
+}
+
+// handle wraps handler with the latency, request counting, failure injection, and, if authenticated is true, session
+// checks shared by every route. The handler is called with the mutex held.
+func (simulator *Simulator) handle(
+	endpoint Endpoint, authenticated bool, handler func(http.ResponseWriter, *http.Request)) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		simulator.mutex.Lock()
+		latency := simulator.latency
+		simulator.mutex.Unlock()
+
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-request.Context().Done():
+				return
+			}
+		}
+
+		simulator.mutex.Lock()
+		defer simulator.mutex.Unlock()
+
+		simulator.requests[endpoint]++
+
+		if injected, ok := simulator.failures[endpoint]; ok {
+			if injected.remaining > 0 {
+				injected.remaining--
+
+				if injected.remaining == 0 {
+					delete(simulator.failures, endpoint)
+				}
+			}
+
+			writeError(writer, injected.status, fmt.Sprintf("injected failure for %s", endpoint))
+
+			return
+		}
+
+		if authenticated && !simulator.sessions[request.Header.Get("X-Auth-Token")] {
+			writeError(writer, http.StatusUnauthorized, "missing or invalid X-Auth-Token")
+
+			return
+		}
+
+		handler(writer, request)
+	}
+}
+
+func (simulator *Simulator) getServiceRoot(writer http.ResponseWriter, _ *http.Request) {
+	writeJSON(writer, http.StatusOK, map[string]any{
+		"@odata.id":      "/redfish/v1/",
+		"Id":             "RootService",
+		"Name":           "Redfish Simulator",
+		"RedfishVersion": "1.6.0",
+		"Systems":        link("/redfish/v1/Systems"),
+		"Chassis":        link("/redfish/v1/Chassis"),
+		"SessionService": link("/redfish/v1/SessionService"),
+		"Links": map[string]any{
+			"Sessions": link("/redfish/v1/SessionService/Sessions"),
+		},
+	})
+}
+
+func (simulator *Simulator) createSession(writer http.ResponseWriter, request *http.Request) {
+	var credentials struct {
+		UserName string
+		Password string
+	}
+
+	err := json.NewDecoder(request.Body).Decode(&credentials)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, fmt.Sprintf("invalid session request: %v", err))
+
+		return
+	}
+
+	if credentials.UserName != simulator.username || credentials.Password != simulator.password {
+		writeError(writer, http.StatusUnauthorized, "invalid credentials")
+
+		return
+	}
+
+	token := newToken()
+	simulator.sessions[token] = true
+
+	location := "/redfish/v1/SessionService/Sessions/" + token
+	writer.Header().Set("X-Auth-Token", token)
+	writer.Header().Set("Location", location)
+	writeJSON(writer, http.StatusCreated, map[string]any{"@odata.id": location, "Id": token})
+}
+
+func (simulator *Simulator) deleteSession(writer http.ResponseWriter, request *http.Request) {
+	delete(simulator.sessions, request.PathValue("id"))
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (simulator *Simulator) getSystems(writer http.ResponseWriter, _ *http.Request) {
+	var members []map[string]any
+	for index := range simulator.systems {
+		members = append(members, link(systemPath(index)))
+	}
+
+	writeJSON(writer, http.StatusOK, map[string]any{
+		"@odata.id":           "/redfish/v1/Systems",
+		"Members":             members,
+		"Members@odata.count": len(members),
+	})
+}
+
+func (simulator *Simulator) getSystem(writer http.ResponseWriter, request *http.Request) {
+	index, ok := simulator.systemIndex(writer, request)
+	if !ok {
+		return
+	}
+
+	var resetTypes []string
+
+	for _, resetType := range supportedResetTypes {
+		if !simulator.unsupportedResets[resetType] {
+			resetTypes = append(resetTypes, resetType)
+		}
+	}
+
+	writeJSON(writer, http.StatusOK, map[string]any{
+		"@odata.id":    systemPath(index),
+		"Id":           strconv.Itoa(index + 1),
+		"Name":         fmt.Sprintf("System %d", index+1),
+		"Manufacturer": "Redfish Simulator",
+		"PowerState":   simulator.systems[index].currentPowerState(time.Now()),
+		"Actions": map[string]any{
+			"#ComputerSystem.Reset": map[string]any{
+				"target":                            systemPath(index) + "/Actions/ComputerSystem.Reset",
+				"ResetType@Redfish.AllowableValues": resetTypes,
+			},
+		},
+	})
+}
+
+func (simulator *Simulator) resetSystem(writer http.ResponseWriter, request *http.Request) {
+	index, ok := simulator.systemIndex(writer, request)
+	if !ok {
+		return
+	}
+
+	var action struct {
+		ResetType string
+	}
+
+	err := json.NewDecoder(request.Body).Decode(&action)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, fmt.Sprintf("invalid reset request: %v", err))
+
+		return
+	}
+
+	var targetState string
+
+	switch action.ResetType {
+	case "On", "GracefulRestart", "ForceRestart", "PowerCycle":
+		targetState = PowerStateOn
+	case "ForceOff", "GracefulShutdown":
+		targetState = PowerStateOff
+	default:
+		writeError(writer, http.StatusBadRequest, fmt.Sprintf("unknown reset type %q", action.ResetType))
+
+		return
+	}
+
+	if simulator.unsupportedResets[action.ResetType] {
+		writeError(writer, http.StatusBadRequest, fmt.Sprintf("unsupported reset type %q", action.ResetType))
+
+		return
+	}
+
+	sys := simulator.systems[index]
+	sys.resets = append(sys.resets, action.ResetType)
+
+	if simulator.transitionDelay > 0 {
+		sys.targetState = targetState
+		sys.transitionEnd = time.Now().Add(simulator.transitionDelay)
+	} else {
+		sys.powerState = targetState
+		sys.targetState = ""
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (simulator *Simulator) getChassisCollection(writer http.ResponseWriter, _ *http.Request) {
+	writeJSON(writer, http.StatusOK, map[string]any{
+		"@odata.id":           "/redfish/v1/Chassis",
+		"Members":             []map[string]any{link("/redfish/v1/Chassis/1")},
+		"Members@odata.count": 1,
+	})
+}
+
+func (simulator *Simulator) getChassis(writer http.ResponseWriter, request *http.Request) {
+	if request.PathValue("id") != "1" {
+		writeError(writer, http.StatusNotFound, "chassis not found")
+
+		return
+	}
+
+	writeJSON(writer, http.StatusOK, map[string]any{
+		"@odata.id": "/redfish/v1/Chassis/1",
+		"Id":        "1",
+		"Name":      "Chassis 1",
+		"Power":     link("/redfish/v1/Chassis/1/Power"),
+	})
+}
+
+func (simulator *Simulator) getPower(writer http.ResponseWriter, request *http.Request) {
+	if request.PathValue("id") != "1" {
+		writeError(writer, http.StatusNotFound, "chassis not found")
+
+		return
+	}
+
+	watts := simulator.powerWatts
+	if simulator.systems[0].currentPowerState(time.Now()) == PowerStateOff {
+		watts = 0
+	}
+
+	writeJSON(writer, http.StatusOK, map[string]any{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Id":        "Power",
+		"PowerControl": []map[string]any{{
+			"@odata.id":          "/redfish/v1/Chassis/1/Power#/PowerControl/0",
+			"MemberId":           "0",
+			"PowerConsumedWatts": watts,
+		}},
+	})
+}
+
+// systemIndex returns the index of the system in the request path, writing a not found error if there is none.
+func (simulator *Simulator) systemIndex(writer http.ResponseWriter, request *http.Request) (int, bool) {
+	id, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil || id < 1 || id > len(simulator.systems) {
+		writeError(writer, http.StatusNotFound, fmt.Sprintf("system %q not found", request.PathValue("id")))
+
+		return 0, false
+	}
+
+	return id - 1, true
+}
+
+// systemPath returns the path of the system at index. System IDs start at 1.
+func systemPath(index int) string {
+	return fmt.Sprintf("/redfish/v1/Systems/%d", index+1)
+}
+
+// link returns a Redfish reference to path.
+func link(path string) map[string]any {
+	return map[string]any{"@odata.id": path}
+}
+
+// newToken returns a random session token.
+func newToken() string {
+	token := make([]byte, 16)
+	_, _ = rand.Read(token)
+
+	return hex.EncodeToString(token)
+}
+
+// writeJSON writes body as JSON with the provided status.
+func writeJSON(writer http.ResponseWriter, status int, body any) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+
+	_ = json.NewEncoder(writer).Encode(body)
+}
+
+// writeError writes a Redfish error response with the provided status and message.
+func writeError(writer http.ResponseWriter, status int, message string) {
+	writeJSON(writer, status, map[string]any{
+		"error": map[string]any{
+			"code":    "Base.1.0.GeneralError",
+			"message": message,
+		},
+	})
+}
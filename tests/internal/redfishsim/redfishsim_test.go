@@ -0,0 +1,123 @@
+package redfishsim
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPowerControl(t *testing.T) {
+	simulator := New()
+	defer simulator.Close()
+
+	bmcClient := simulator.BMC()
+
+	powerState, err := bmcClient.SystemPowerState()
+	assert.Nil(t, err)
+	assert.Equal(t, PowerStateOn, powerState)
+
+	power, err := bmcClient.PowerUsage()
+	assert.Nil(t, err)
+	assert.Equal(t, float32(DefaultPowerWatts), power)
+
+	err = bmcClient.SystemPowerOff()
+	assert.Nil(t, err)
+	assert.Equal(t, PowerStateOff, simulator.PowerState(0))
+
+	power, err = bmcClient.PowerUsage()
+	assert.Nil(t, err)
+	assert.Equal(t, float32(0), power)
+
+	err = bmcClient.SystemPowerOn()
+	assert.Nil(t, err)
+	assert.Equal(t, PowerStateOn, simulator.PowerState(0))
+	assert.Equal(t, []string{"ForceOff", "On"}, simulator.Resets(0))
+}
+
+func TestTransitionDelay(t *testing.T) {
+	simulator := New()
+	defer simulator.Close()
+
+	simulator.SetTransitionDelay(200 * time.Millisecond)
+
+	bmcClient := simulator.BMC()
+
+	err := bmcClient.SystemPowerOff()
+	assert.Nil(t, err)
+	assert.Equal(t, PowerStatePoweringOff, simulator.PowerState(0))
+
+	time.Sleep(250 * time.Millisecond)
+	assert.Equal(t, PowerStateOff, simulator.PowerState(0))
+}
+
+func TestFailNext(t *testing.T) {
+	testCases := []struct {
+		endpoint      Endpoint
+		count         int
+		call          func(*bmc.BMC) error
+		expectedError bool
+		// expectedRequests is the number of requests to endpoint after calling call twice.
+		expectedRequests int
+	}{
+		{
+			endpoint:         EndpointPower,
+			count:            1,
+			call:             func(bmcClient *bmc.BMC) error { _, err := bmcClient.PowerUsage(); return err },
+			expectedError:    true,
+			expectedRequests: 2,
+		},
+		{
+			endpoint:         EndpointReset,
+			count:            1,
+			call:             (*bmc.BMC).SystemPowerOff,
+			expectedError:    true,
+			expectedRequests: 2,
+		},
+		{
+			endpoint:         EndpointSessions,
+			count:            1,
+			call:             func(bmcClient *bmc.BMC) error { _, err := bmcClient.SystemPowerState(); return err },
+			expectedError:    true,
+			expectedRequests: 3,
+		},
+		{
+			endpoint:         EndpointPower,
+			count:            0,
+			call:             func(bmcClient *bmc.BMC) error { _, err := bmcClient.PowerUsage(); return err },
+			expectedError:    false,
+			expectedRequests: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		simulator := New()
+		simulator.FailNext(testCase.endpoint, testCase.count, http.StatusInternalServerError)
+
+		err := testCase.call(simulator.BMC())
+		assert.Equal(t, testCase.expectedError, err != nil, "endpoint: %s", testCase.endpoint)
+
+		// Injected failures only apply to the next count requests so the second call should always succeed. A failed
+		// session request means the first call never gets to delete its session.
+		err = testCase.call(simulator.BMC())
+		assert.Nil(t, err, "endpoint: %s", testCase.endpoint)
+		assert.Equal(t, testCase.expectedRequests, simulator.Requests(testCase.endpoint), "endpoint: %s", testCase.endpoint)
+
+		simulator.Close()
+	}
+}
+
+func TestCredentialsAndLatency(t *testing.T) {
+	simulator := New(WithCredentials("root", "calvin"))
+	defer simulator.Close()
+
+	_, err := bmc.New(simulator.Host()).WithRedfishUser("root", "wrong").SystemPowerState()
+	assert.NotNil(t, err)
+
+	simulator.SetLatency(500 * time.Millisecond)
+
+	_, err = simulator.BMC().WithRedfishTimeout(100 * time.Millisecond).SystemPowerState()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,272 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+)
+
+const (
+	// ManifestFileName is the name of the file describing every artifact captured for a failed spec.
+	ManifestFileName = "manifest.json"
+)
+
+// Collector gathers artifacts for a failed spec. Each registered collector writes into its own subdirectory of the
+// spec dump directory, named after the collector, unless it implements SpecDirCollector.
+type Collector interface {
+	// Name returns the unique name of the collector. It is used as the name of the directory the collector writes to.
+	Name() string
+	// Collect writes the artifacts for the failed spec into outputDir, which already exists, and returns a
+	// description of each artifact. Artifact paths must be relative to outputDir.
+	Collect(report types.SpecReport, outputDir string) ([]Artifact, error)
+}
+
+// SpecDirCollector is implemented by collectors that write directly into the spec dump directory rather than into a
+// subdirectory named after them.
+type SpecDirCollector interface {
+	Collector
+	// WritesToSpecDir returns whether the collector writes directly into the spec dump directory.
+	WritesToSpecDir() bool
+}
+
+// Artifact describes a single file captured by a collector.
+type Artifact struct {
+	// Collector is the name of the collector that captured the artifact. It is set by the registry.
+	Collector string `json:"collector"`
+	// Path is the path of the artifact relative to the spec dump directory.
+	Path string `json:"path"`
+	// Description is an optional human readable description of the artifact.
+	Description string `json:"description,omitempty"`
+}
+
+// CollectorError records a collector that failed while gathering artifacts.
+type CollectorError struct {
+	Collector string `json:"collector"`
+	Error     string `json:"error"`
+}
+
+// Manifest describes a failed spec and every artifact captured for it. It is saved as manifest.json in the spec dump
+// directory.
+type Manifest struct {
+	Suite     string           `json:"suite"`
+	Spec      string           `json:"spec"`
+	State     string           `json:"state"`
+	Labels    []string         `json:"labels,omitempty"`
+	Location  string           `json:"location,omitempty"`
+	Failure   string           `json:"failure,omitempty"`
+	StartTime time.Time        `json:"startTime"`
+	EndTime   time.Time        `json:"endTime"`
+	Artifacts []Artifact       `json:"artifacts"`
+	Errors    []CollectorError `json:"errors,omitempty"`
+}
+
+// Registry holds the collectors declared by a suite. It is meant to be created once per suite and used in
+// JustAfterEach.
+type Registry struct {
+	collectors []Collector
+}
+
+// NewRegistry returns a new Registry with the provided collectors.
+func NewRegistry(collectors ...Collector) *Registry {
+	return &Registry{collectors: collectors}
+}
+
+// Register adds collectors to the registry and returns it for chaining.
+func (registry *Registry) Register(collectors ...Collector) *Registry {
+	registry.collectors = append(registry.collectors, collectors...)
+
+	return registry
+}
+
+// Collectors returns the collectors in the registry in the order they were registered.
+func (registry *Registry) Collectors() []Collector {
+	return registry.collectors
+}
+
+// ReportIfFailed runs every collector if the spec failed and dumping failed tests is enabled. Artifacts are written
+// under the failed test report location for testSuite, in a directory named after the spec, along with a
+// manifest.json describing them. Collector failures are logged and recorded in the manifest rather than failing.
+func (registry *Registry) ReportIfFailed(report types.SpecReport, testSuite string) {
+	if !types.SpecStateFailureStates.Is(report.State) {
+		return
+	}
+
+	dumpDir := GeneralConfig.GetDumpFailedTestReportLocation(testSuite)
+
+	if dumpDir != "" {
+		specDir := filepath.Join(dumpDir, SpecDirName(report))
+
+		err := registry.collect(report, testSuite, specDir)
+		if err != nil {
+			glog.Errorf("Failed to collect artifacts for spec %s: %v", report.FullText(), err)
+		}
+	}
+
+	err := removeFile(pathToPodExecLogs)
+	if err != nil {
+		glog.Errorf("Failed to remove pod exec logs: %v", err)
+	}
+}
+
+// SpecDirName returns the name of the directory used for the artifacts of the spec in report. It matches the
+// directory name k8sreporter historically used so existing tooling keeps finding the dumps.
+func SpecDirName(report types.SpecReport) string {
+	return strings.NewReplacer(" ", "_", "/", "-").Replace(report.FullText())
+}
+
+// collect runs every collector and saves the manifest in specDir.
+func (registry *Registry) collect(report types.SpecReport, testSuite, specDir string) error {
+	err := os.MkdirAll(specDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	manifest := newManifest(report, testSuite)
+
+	for _, collector := range registry.collectors {
+		artifacts, err := runCollector(collector, report, specDir)
+		manifest.Artifacts = append(manifest.Artifacts, artifacts...)
+
+		if err != nil {
+			glog.Errorf("Collector %s failed for spec %s: %v", collector.Name(), report.FullText(), err)
+
+			manifest.Errors = append(manifest.Errors, CollectorError{Collector: collector.Name(), Error: err.Error()})
+		}
+	}
+
+	podExecLogs, err := movePodExecLogs(specDir)
+	if err != nil {
+		manifest.Errors = append(manifest.Errors, CollectorError{Collector: "pod-exec-logs", Error: err.Error()})
+	} else if podExecLogs != nil {
+		manifest.Artifacts = append(manifest.Artifacts, *podExecLogs)
+	}
+
+	return writeManifest(manifest, filepath.Join(specDir, ManifestFileName))
+}
+
+// runCollector runs a single collector in its own directory under specDir and returns its artifacts with paths
+// relative to specDir. Collectors implementing SpecDirCollector may write into specDir itself.
+func runCollector(collector Collector, report types.SpecReport, specDir string) ([]Artifact, error) {
+	outputDir := specDir
+	pathPrefix := ""
+
+	if specDirCollector, ok := collector.(SpecDirCollector); !ok || !specDirCollector.WritesToSpecDir() {
+		outputDir = filepath.Join(specDir, collector.Name())
+		pathPrefix = collector.Name()
+
+		err := os.MkdirAll(outputDir, 0755)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	artifacts, err := collector.Collect(report, outputDir)
+
+	for index := range artifacts {
+		artifacts[index].Collector = collector.Name()
+		artifacts[index].Path = filepath.Join(pathPrefix, artifacts[index].Path)
+	}
+
+	return artifacts, err
+}
+
+// movePodExecLogs moves the pod exec logs into specDir, returning the resulting artifact or nil if there were no logs.
+func movePodExecLogs(specDir string) (*Artifact, error) {
+	if _, err := os.Stat(pathToPodExecLogs); err != nil {
+		return nil, nil
+	}
+
+	fileName := filepath.Base(pathToPodExecLogs)
+
+	err := moveFile(pathToPodExecLogs, filepath.Join(specDir, fileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to move pod exec logs %s to report folder: %w", pathToPodExecLogs, err)
+	}
+
+	return &Artifact{Collector: "pod-exec-logs", Path: fileName, Description: "Commands executed in pods"}, nil
+}
+
+func newManifest(report types.SpecReport, testSuite string) *Manifest {
+	manifest := &Manifest{
+		Suite:     strings.TrimSuffix(filepath.Base(testSuite), filepath.Ext(testSuite)),
+		Spec:      report.FullText(),
+		State:     report.State.String(),
+		Labels:    report.Labels(),
+		StartTime: report.StartTime,
+		EndTime:   report.EndTime,
+		Artifacts: []Artifact{},
+	}
+
+	if report.Failure.Message != "" {
+		manifest.Failure = report.Failure.Message
+		manifest.Location = report.Failure.Location.String()
+	}
+
+	return manifest
+}
+
+func writeManifest(manifest *Manifest, manifestPath string) error {
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(manifestPath, manifestBytes, 0644)
+}
+
+// artifactsInDir returns an artifact for every regular file under dir, with paths relative to dir. It is useful for
+// collectors that delegate writing to another library and do not know the files created ahead of time.
+func artifactsInDir(dir string) ([]Artifact, error) {
+	var artifacts []Artifact
+
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		artifacts = append(artifacts, Artifact{Path: relativePath})
+
+		return nil
+	})
+
+	return artifacts, err
+}
+
+// newArtifactsInDir returns an artifact for every regular file under dir that is not in existingArtifacts.
+func newArtifactsInDir(dir string, existingArtifacts []Artifact) ([]Artifact, error) {
+	artifacts, err := artifactsInDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	existingPaths := make(map[string]bool, len(existingArtifacts))
+	for _, artifact := range existingArtifacts {
+		existingPaths[artifact.Path] = true
+	}
+
+	var newArtifacts []Artifact
+
+	for _, artifact := range artifacts {
+		if !existingPaths[artifact.Path] {
+			newArtifacts = append(newArtifacts, artifact)
+		}
+	}
+
+	return newArtifacts, nil
+}
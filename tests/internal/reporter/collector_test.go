@@ -0,0 +1,146 @@
+package reporter
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeCollector struct {
+	name    string
+	files   []string
+	err     error
+	specDir bool
+}
+
+func (collector *fakeCollector) Name() string {
+	return collector.name
+}
+
+func (collector *fakeCollector) WritesToSpecDir() bool {
+	return collector.specDir
+}
+
+func (collector *fakeCollector) Collect(_ types.SpecReport, outputDir string) ([]Artifact, error) {
+	var artifacts []Artifact
+
+	for _, file := range collector.files {
+		err := writeArtifactFile(outputDir, file, []byte(file))
+		if err != nil {
+			return nil, err
+		}
+
+		artifacts = append(artifacts, Artifact{Path: file})
+	}
+
+	return artifacts, collector.err
+}
+
+func TestRegistryCollect(t *testing.T) {
+	testCases := []struct {
+		collectors        []Collector
+		expectedArtifacts []Artifact
+		expectedErrors    []CollectorError
+	}{
+		{
+			collectors:        nil,
+			expectedArtifacts: []Artifact{},
+		},
+		{
+			collectors: []Collector{
+				&fakeCollector{name: "first", files: []string{"a.log", "nested/b.log"}},
+				&fakeCollector{name: "second", files: []string{"c.json"}},
+			},
+			expectedArtifacts: []Artifact{
+				{Collector: "first", Path: "first/a.log"},
+				{Collector: "first", Path: "first/nested/b.log"},
+				{Collector: "second", Path: "second/c.json"},
+			},
+		},
+		{
+			collectors: []Collector{
+				&fakeCollector{name: "partial", files: []string{"a.log"}, err: errors.New("node unreachable")},
+				&fakeCollector{name: "ok", files: []string{"b.log"}},
+			},
+			expectedArtifacts: []Artifact{
+				{Collector: "partial", Path: "partial/a.log"},
+				{Collector: "ok", Path: "ok/b.log"},
+			},
+			expectedErrors: []CollectorError{{Collector: "partial", Error: "node unreachable"}},
+		},
+		{
+			collectors: []Collector{
+				&fakeCollector{name: "crs", files: []string{"pods.log", "nodes/worker-0.log"}, specDir: true},
+				&fakeCollector{name: "events", files: []string{"events.json"}},
+			},
+			expectedArtifacts: []Artifact{
+				{Collector: "crs", Path: "pods.log"},
+				{Collector: "crs", Path: "nodes/worker-0.log"},
+				{Collector: "events", Path: "events/events.json"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		specDir := filepath.Join(t.TempDir(), "spec")
+		report := types.SpecReport{
+			State:                   types.SpecStateFailed,
+			LeafNodeText:            "fails",
+			ContainerHierarchyTexts: []string{"suite"},
+			Failure:                 types.Failure{Message: "expected failure"},
+		}
+
+		err := NewRegistry(testCase.collectors...).collect(report, "/path/to/sriov_suite_test.go", specDir)
+		assert.Nil(t, err)
+
+		manifestBytes, err := os.ReadFile(filepath.Join(specDir, ManifestFileName))
+		assert.Nil(t, err)
+
+		var manifest Manifest
+
+		err = json.Unmarshal(manifestBytes, &manifest)
+		assert.Nil(t, err)
+
+		assert.Equal(t, "sriov_suite_test", manifest.Suite)
+		assert.Equal(t, "suite fails", manifest.Spec)
+		assert.Equal(t, "failed", manifest.State)
+		assert.Equal(t, "expected failure", manifest.Failure)
+		assert.Equal(t, testCase.expectedArtifacts, manifest.Artifacts)
+		assert.Equal(t, testCase.expectedErrors, manifest.Errors)
+
+		for _, artifact := range manifest.Artifacts {
+			assert.FileExists(t, filepath.Join(specDir, artifact.Path))
+		}
+	}
+}
+
+func TestSpecDirName(t *testing.T) {
+	report := types.SpecReport{
+		ContainerHierarchyTexts: []string{"SR-IOV", "with a/b"},
+		LeafNodeText:            "works",
+	}
+
+	assert.Equal(t, "SR-IOV_with_a-b_works", SpecDirName(report))
+}
+
+func TestNewArtifactsInDir(t *testing.T) {
+	dir := t.TempDir()
+
+	err := writeArtifactFile(dir, "events/events.json", []byte("{}"))
+	assert.Nil(t, err)
+
+	existingArtifacts, err := artifactsInDir(dir)
+	assert.Nil(t, err)
+
+	err = writeArtifactFile(dir, "pods.log", []byte("pods"))
+	assert.Nil(t, err)
+
+	artifacts, err := newArtifactsInDir(dir, existingArtifacts)
+	assert.Nil(t, err)
+	assert.Equal(t, []Artifact{{Path: "pods.log"}}, artifacts)
+}
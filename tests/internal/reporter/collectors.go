@@ -0,0 +1,335 @@
+package reporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/openshift-kni/k8sreporter"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clusteroperator"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clusterversion"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/events"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/mco"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+// CRDumper is a Collector that dumps the requested CRs, pods, nodes, and logs using k8sreporter.
+type CRDumper struct {
+	kubeconfig string
+	namespaces map[string]string
+	cRDs       []k8sreporter.CRData
+}
+
+// NewCRDumper returns a CRDumper for the cluster at kubeconfig. An empty kubeconfig uses the KUBECONFIG environment
+// variable.
+func NewCRDumper(kubeconfig string, namespaces map[string]string, cRDs []k8sreporter.CRData) *CRDumper {
+	return &CRDumper{kubeconfig: kubeconfig, namespaces: namespaces, cRDs: cRDs}
+}
+
+// Name returns the name of the collector.
+func (dumper *CRDumper) Name() string {
+	return "crs"
+}
+
+// WritesToSpecDir returns true so the CRs are dumped directly into the spec dump directory, which is the layout
+// k8sreporter has always used and existing tooling expects.
+func (dumper *CRDumper) WritesToSpecDir() bool {
+	return true
+}
+
+// Collect dumps the CRs into outputDir. Only the files created by the dump are returned since outputDir may already
+// contain the artifacts of other collectors.
+func (dumper *CRDumper) Collect(report types.SpecReport, outputDir string) ([]Artifact, error) {
+	k8sReporter, err := newReporter(outputDir, dumper.kubeconfig, dumper.namespaces, setReporterSchemes, dumper.cRDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create k8sreporter: %w", err)
+	}
+
+	existingArtifacts, err := artifactsInDir(outputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	k8sReporter.Dump(report.RunTime, "")
+
+	return newArtifactsInDir(outputDir, existingArtifacts)
+}
+
+// PodLogCollector is a Collector that saves the tail of the logs of every container in the provided namespaces.
+type PodLogCollector struct {
+	apiClient  *clients.Settings
+	namespaces map[string]string
+	tailLines  int64
+}
+
+// NewPodLogCollector returns a PodLogCollector that saves the last tailLines lines of each container log. If
+// tailLines is not positive, the full logs are saved.
+func NewPodLogCollector(apiClient *clients.Settings, namespaces map[string]string, tailLines int64) *PodLogCollector {
+	return &PodLogCollector{apiClient: apiClient, namespaces: namespaces, tailLines: tailLines}
+}
+
+// Name returns the name of the collector.
+func (collector *PodLogCollector) Name() string {
+	return "pod-logs"
+}
+
+// Collect saves the container logs into outputDir, one directory per namespace and one file per container.
+func (collector *PodLogCollector) Collect(_ types.SpecReport, outputDir string) ([]Artifact, error) {
+	if collector.apiClient == nil {
+		return nil, fmt.Errorf("cannot collect pod logs from nil apiClient")
+	}
+
+	var (
+		artifacts []Artifact
+		errs      []error
+	)
+
+	for _, namespace := range sortedKeys(collector.namespaces) {
+		pods, err := pod.List(collector.apiClient, namespace)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err))
+
+			continue
+		}
+
+		for _, podBuilder := range pods {
+			for _, container := range podBuilder.Object.Spec.Containers {
+				options := &corev1.PodLogOptions{Container: container.Name}
+				if collector.tailLines > 0 {
+					options.TailLines = ptr.To(collector.tailLines)
+				}
+
+				logs, err := podBuilder.GetLogsWithOptions(options)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("failed to get logs for container %s of pod %s/%s: %w",
+						container.Name, namespace, podBuilder.Object.Name, err))
+
+					continue
+				}
+
+				fileName := filepath.Join(namespace, fmt.Sprintf("%s_%s.log", podBuilder.Object.Name, container.Name))
+
+				err = writeArtifactFile(outputDir, fileName, logs)
+				if err != nil {
+					errs = append(errs, err)
+
+					continue
+				}
+
+				artifacts = append(artifacts, Artifact{
+					Path: fileName,
+					Description: fmt.Sprintf(
+						"Logs of container %s in pod %s/%s", container.Name, namespace, podBuilder.Object.Name),
+				})
+			}
+		}
+	}
+
+	return artifacts, errors.Join(errs...)
+}
+
+// EventCollector is a Collector that saves the events in the provided namespaces.
+type EventCollector struct {
+	apiClient  *clients.Settings
+	namespaces map[string]string
+}
+
+// NewEventCollector returns an EventCollector for the provided namespaces.
+func NewEventCollector(apiClient *clients.Settings, namespaces map[string]string) *EventCollector {
+	return &EventCollector{apiClient: apiClient, namespaces: namespaces}
+}
+
+// Name returns the name of the collector.
+func (collector *EventCollector) Name() string {
+	return "events"
+}
+
+// Collect saves the events of each namespace as a JSON file in outputDir.
+func (collector *EventCollector) Collect(_ types.SpecReport, outputDir string) ([]Artifact, error) {
+	if collector.apiClient == nil {
+		return nil, fmt.Errorf("cannot collect events from nil apiClient")
+	}
+
+	var (
+		artifacts []Artifact
+		errs      []error
+	)
+
+	for _, namespace := range sortedKeys(collector.namespaces) {
+		eventBuilders, err := events.List(collector.apiClient, namespace)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list events in namespace %s: %w", namespace, err))
+
+			continue
+		}
+
+		var namespaceEvents []*corev1.Event
+		for _, eventBuilder := range eventBuilders {
+			namespaceEvents = append(namespaceEvents, eventBuilder.Object)
+		}
+
+		fileName := namespace + ".json"
+
+		err = writeJSONArtifact(outputDir, fileName, namespaceEvents)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		artifacts = append(artifacts, Artifact{Path: fileName, Description: "Events in namespace " + namespace})
+	}
+
+	return artifacts, errors.Join(errs...)
+}
+
+// MustGatherLiteCollector is a Collector that saves a small, cluster-wide subset of what must-gather collects: the
+// clusterversion, clusteroperators, nodes, and machineconfigpools.
+type MustGatherLiteCollector struct {
+	apiClient *clients.Settings
+}
+
+// NewMustGatherLiteCollector returns a MustGatherLiteCollector for the cluster accessible through apiClient.
+func NewMustGatherLiteCollector(apiClient *clients.Settings) *MustGatherLiteCollector {
+	return &MustGatherLiteCollector{apiClient: apiClient}
+}
+
+// Name returns the name of the collector.
+func (collector *MustGatherLiteCollector) Name() string {
+	return "must-gather-lite"
+}
+
+// Collect saves each resource type as a JSON file in outputDir.
+func (collector *MustGatherLiteCollector) Collect(_ types.SpecReport, outputDir string) ([]Artifact, error) {
+	if collector.apiClient == nil {
+		return nil, fmt.Errorf("cannot collect must-gather-lite from nil apiClient")
+	}
+
+	gatherers := []struct {
+		fileName string
+		gather   func() (any, error)
+	}{
+		{fileName: "clusterversion.json", gather: collector.gatherClusterVersion},
+		{fileName: "clusteroperators.json", gather: collector.gatherClusterOperators},
+		{fileName: "nodes.json", gather: collector.gatherNodes},
+		{fileName: "machineconfigpools.json", gather: collector.gatherMCPs},
+	}
+
+	var (
+		artifacts []Artifact
+		errs      []error
+	)
+
+	for _, gatherer := range gatherers {
+		resources, err := gatherer.gather()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to gather %s: %w", gatherer.fileName, err))
+
+			continue
+		}
+
+		err = writeJSONArtifact(outputDir, gatherer.fileName, resources)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		artifacts = append(artifacts, Artifact{Path: gatherer.fileName})
+	}
+
+	return artifacts, errors.Join(errs...)
+}
+
+func (collector *MustGatherLiteCollector) gatherClusterVersion() (any, error) {
+	clusterVersion, err := clusterversion.Pull(collector.apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterVersion.Object, nil
+}
+
+func (collector *MustGatherLiteCollector) gatherClusterOperators() (any, error) {
+	clusterOperators, err := clusteroperator.List(collector.apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []any
+	for _, clusterOperator := range clusterOperators {
+		objects = append(objects, clusterOperator.Object)
+	}
+
+	return objects, nil
+}
+
+func (collector *MustGatherLiteCollector) gatherNodes() (any, error) {
+	nodeBuilders, err := nodes.List(collector.apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []any
+	for _, nodeBuilder := range nodeBuilders {
+		objects = append(objects, nodeBuilder.Object)
+	}
+
+	return objects, nil
+}
+
+func (collector *MustGatherLiteCollector) gatherMCPs() (any, error) {
+	mcps, err := mco.ListMCP(collector.apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []any
+	for _, mcp := range mcps {
+		objects = append(objects, mcp.Object)
+	}
+
+	return objects, nil
+}
+
+// writeArtifactFile writes contents to fileName relative to outputDir, creating any missing directories.
+func writeArtifactFile(outputDir, fileName string, contents []byte) error {
+	filePath := filepath.Join(outputDir, fileName)
+
+	err := os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filePath, contents, 0644)
+}
+
+// writeJSONArtifact marshals object as indented JSON and writes it to fileName relative to outputDir.
+func writeJSONArtifact(outputDir, fileName string, object any) error {
+	contents, err := json.MarshalIndent(object, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", fileName, err)
+	}
+
+	return writeArtifactFile(outputDir, fileName, contents)
+}
+
+// sortedKeys returns the keys of a namespace map in sorted order so collection is deterministic.
+func sortedKeys(namespaces map[string]string) []string {
+	keys := make([]string, 0, len(namespaces))
+	for key := range namespaces {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
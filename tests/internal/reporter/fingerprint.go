@@ -0,0 +1,70 @@
+package reporter
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/fingerprint"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+)
+
+// SaveClusterFingerprint captures the fingerprint of the cluster of apiClient and saves it to the reports directory. It
+// is meant to be called from ReportBeforeSuite so the fingerprint describes the cluster as the suite found it. Errors
+// are logged without failing the suite.
+func SaveClusterFingerprint(apiClient *clients.Settings) {
+	if GeneralConfig == nil {
+		glog.V(100).Infof("Skipping cluster fingerprint since the general config is not loaded")
+
+		return
+	}
+
+	clusterFingerprint, err := cluster.GetClusterFingerprint(apiClient)
+	if err != nil {
+		glog.Errorf("Failed to capture cluster fingerprint: %v", err)
+
+		return
+	}
+
+	err = os.MkdirAll(GeneralConfig.ReportsDirAbsPath, 0755)
+	if err != nil {
+		glog.Errorf("Failed to create reports directory %s: %v", GeneralConfig.ReportsDirAbsPath, err)
+
+		return
+	}
+
+	err = clusterFingerprint.Save(filepath.Join(GeneralConfig.ReportsDirAbsPath, fingerprint.FileName))
+	if err != nil {
+		glog.Errorf("Failed to save cluster fingerprint: %v", err)
+	}
+}
+
+// AddFingerprintProperties embeds the key fields of the fingerprint saved by SaveClusterFingerprint as properties of
+// the JUnit or reportxml report at reportPath. Since ginkgo only writes the JUnit report once RunSpecs returns, it must
+// be called after RunSpecs for JUnit reports and after reportxml.Create in ReportAfterSuite for reportxml reports.
+// Nothing is done if reportPath is empty, the general config is not loaded, or no fingerprint was saved.
+func AddFingerprintProperties(reportPath string) {
+	if reportPath == "" {
+		return
+	}
+
+	if GeneralConfig == nil {
+		glog.V(100).Infof("Not adding cluster fingerprint to %s since the general config is not loaded", reportPath)
+
+		return
+	}
+
+	clusterFingerprint, err := fingerprint.Load(filepath.Join(GeneralConfig.ReportsDirAbsPath, fingerprint.FileName))
+	if err != nil {
+		glog.V(100).Infof("Not adding cluster fingerprint to %s: %v", reportPath, err)
+
+		return
+	}
+
+	err = fingerprint.AddReportProperties(reportPath, clusterFingerprint.Properties())
+	if err != nil {
+		glog.Errorf("Failed to add cluster fingerprint to %s: %v", reportPath, err)
+	}
+}
@@ -5,18 +5,21 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"path"
-	"strings"
+	"sync"
 
-	"github.com/golang/glog"
 	"github.com/onsi/ginkgo/v2/types"
 	"github.com/openshift-kni/k8sreporter"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 var (
 	pathToPodExecLogs = "/tmp/pod_exec_logs.log"
+
+	// clusterClients holds the clients created by clusterClient, keyed by kubeconfig.
+	clusterClients      = map[string]*clients.Settings{}
+	clusterClientsMutex sync.Mutex
 )
 
 func newReporter(
@@ -57,7 +60,7 @@ func ReportIfFailed(
 }
 
 // ReportIfFailedOnCluster dumps the requested cluster CRs on the cluster specified by kubeconfig if TC is failed to the
-// given directory.
+// given directory. The events and container logs in nSpaces since the spec started are saved as well.
 func ReportIfFailedOnCluster(
 	kubeconfig string,
 	report types.SpecReport,
@@ -68,32 +71,32 @@ func ReportIfFailedOnCluster(
 		return
 	}
 
-	dumpDir := GeneralConfig.GetDumpFailedTestReportLocation(testSuite)
-
-	if dumpDir != "" {
-		reporter, err := newReporter(dumpDir, kubeconfig, nSpaces, setReporterSchemes, cRDs)
-
-		if err != nil {
-			glog.Fatalf("Failed to create log reporter due to %s", err)
-		}
-
-		tcReportFolderName := strings.ReplaceAll(report.FullText(), " ", "_")
-		reporter.Dump(report.RunTime, tcReportFolderName)
+	NewRegistry(
+		NewCRDumper(kubeconfig, nSpaces, cRDs),
+		NewSpecWindowCollector(clusterClient(kubeconfig), nSpaces),
+	).ReportIfFailed(report, testSuite)
+}
 
-		_, podExecLogsFName := path.Split(pathToPodExecLogs)
+// clusterClient returns the client for the cluster at kubeconfig, or APIClient if kubeconfig is empty. Clients are
+// created the first time they are needed and reused for every later failed spec on the same cluster.
+func clusterClient(kubeconfig string) *clients.Settings {
+	if kubeconfig == "" {
+		return APIClient
+	}
 
-		err = moveFile(
-			pathToPodExecLogs, path.Join(GeneralConfig.ReportsDirAbsPath, tcReportFolderName, podExecLogsFName))
+	clusterClientsMutex.Lock()
+	defer clusterClientsMutex.Unlock()
 
-		if err != nil {
-			glog.Fatalf("Failed to move pod exec logs %s to report folder: %s", pathToPodExecLogs, err)
-		}
+	if apiClient, ok := clusterClients[kubeconfig]; ok {
+		return apiClient
 	}
 
-	err := removeFile(pathToPodExecLogs)
-	if err != nil {
-		glog.Fatalf(err.Error())
+	apiClient := clients.New(kubeconfig)
+	if apiClient != nil {
+		clusterClients[kubeconfig] = apiClient
 	}
+
+	return apiClient
 }
 
 func moveFile(sourcePath, destPath string) error {
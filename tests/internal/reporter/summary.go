@@ -0,0 +1,47 @@
+package reporter
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/results"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+)
+
+// WriteRunSummary regenerates the run summary in the reports directory from every report already there plus the
+// current suite report. It is meant to be called from ReportAfterSuite, where the JUnit report of the current suite
+// has not been written yet, so that the summary covers every suite run so far. Nothing is written if reports are
+// disabled or the general config was not loaded, and errors are logged without failing the suite.
+func WriteRunSummary(report types.Report, testSuite string) {
+	if GeneralConfig == nil {
+		glog.V(100).Infof("Skipping run summary for %s since the general config is not loaded", testSuite)
+
+		return
+	}
+
+	if !GeneralConfig.EnableReport {
+		return
+	}
+
+	reportsDir := GeneralConfig.ReportsDirAbsPath
+	suiteKey := strings.TrimSuffix(filepath.Base(testSuite), filepath.Ext(testSuite))
+
+	summary, err := results.Load(reportsDir)
+	if err != nil {
+		glog.V(100).Infof("Starting a new run summary in %s: %v", reportsDir, err)
+
+		summary = results.NewRunSummary(reportsDir, nil, nil)
+	}
+
+	suite := results.SuiteFromGinkgoReport(suiteKey, report)
+	suite.Attempt = GeneralConfig.ReportAttempt
+
+	summary = summary.ReplaceSuite(suite)
+
+	err = summary.WriteFiles(reportsDir)
+	if err != nil {
+		glog.Errorf("Failed to write run summary to %s: %v", reportsDir, err)
+	}
+}
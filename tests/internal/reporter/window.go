@@ -0,0 +1,328 @@
+package reporter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/events"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// TimelineFileName is the name of the JSON lines file containing the events and logs since the spec started.
+	TimelineFileName = "timeline.jsonl"
+
+	// RecordKindEvent is the kind of TimelineRecord created from a Kubernetes event.
+	RecordKindEvent = "event"
+	// RecordKindLog is the kind of TimelineRecord created from a container log line.
+	RecordKindLog = "log"
+
+	// maxLogLineSize is the longest container log line LogRecords is able to parse.
+	maxLogLineSize = 4 * 1024 * 1024
+)
+
+// TimelineRecord is a single line of the spec timeline. It is either an event or a container log line.
+type TimelineRecord struct {
+	Time      time.Time `json:"time"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	// Object is the kind and name of the object involved in an event.
+	Object string `json:"object,omitempty"`
+	// Reason, Type, and Count are only set for events.
+	Reason string `json:"reason,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Count  int32  `json:"count,omitempty"`
+	// Pod, Container, and Previous are only set for logs. Previous is true when the line comes from the previous
+	// instance of a restarted container.
+	Pod       string `json:"pod,omitempty"`
+	Container string `json:"container,omitempty"`
+	Previous  bool   `json:"previous,omitempty"`
+	Message   string `json:"message"`
+}
+
+// SpecWindowCollector is a Collector that gathers all events and container logs, including those of previous
+// container instances, in the provided namespaces from the time the spec started. They are saved as a single
+// time-sorted JSON lines file so the history leading to the failure is preserved.
+type SpecWindowCollector struct {
+	apiClient  *clients.Settings
+	namespaces map[string]string
+}
+
+// NewSpecWindowCollector returns a SpecWindowCollector for the provided namespaces.
+func NewSpecWindowCollector(apiClient *clients.Settings, namespaces map[string]string) *SpecWindowCollector {
+	return &SpecWindowCollector{apiClient: apiClient, namespaces: namespaces}
+}
+
+// Name returns the name of the collector.
+func (collector *SpecWindowCollector) Name() string {
+	return "spec-window"
+}
+
+// Collect saves the events and logs since the spec started to outputDir.
+func (collector *SpecWindowCollector) Collect(report types.SpecReport, outputDir string) ([]Artifact, error) {
+	if collector.apiClient == nil {
+		return nil, fmt.Errorf("cannot collect spec window from nil apiClient")
+	}
+
+	since := SpecStartTime(report)
+
+	var (
+		records []TimelineRecord
+		errs    []error
+	)
+
+	for _, namespace := range sortedKeys(collector.namespaces) {
+		eventRecords, err := collector.collectEvents(namespace, since)
+		records = append(records, eventRecords...)
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		logRecords, err := collector.collectLogs(namespace, since)
+		records = append(records, logRecords...)
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	SortTimeline(records)
+
+	err := writeTimeline(filepath.Join(outputDir, TimelineFileName), records)
+	if err != nil {
+		return nil, errors.Join(append(errs, err)...)
+	}
+
+	artifact := Artifact{
+		Path:        TimelineFileName,
+		Description: fmt.Sprintf("Events and container logs since %s", since.Format(time.RFC3339)),
+	}
+
+	return []Artifact{artifact}, errors.Join(errs...)
+}
+
+// SpecStartTime returns the time the spec in report started. If the report has no start time, it is estimated from
+// the run time.
+func SpecStartTime(report types.SpecReport) time.Time {
+	if !report.StartTime.IsZero() {
+		return report.StartTime
+	}
+
+	return time.Now().Add(-report.RunTime)
+}
+
+// SortTimeline sorts records by time, keeping the original order for records with the same time.
+func SortTimeline(records []TimelineRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time.Before(records[j].Time)
+	})
+}
+
+func (collector *SpecWindowCollector) collectEvents(namespace string, since time.Time) ([]TimelineRecord, error) {
+	eventBuilders, err := events.List(collector.apiClient, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events in namespace %s: %w", namespace, err)
+	}
+
+	var eventObjects []*corev1.Event
+	for _, eventBuilder := range eventBuilders {
+		eventObjects = append(eventObjects, eventBuilder.Object)
+	}
+
+	return EventRecordsSince(eventObjects, since), nil
+}
+
+func (collector *SpecWindowCollector) collectLogs(namespace string, since time.Time) ([]TimelineRecord, error) {
+	pods, err := pod.List(collector.apiClient, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err)
+	}
+
+	var (
+		records []TimelineRecord
+		errs    []error
+	)
+
+	for _, podBuilder := range pods {
+		for _, status := range containerStatuses(podBuilder.Object) {
+			logRecords, err := collectContainerLogs(podBuilder, status.Name, since, false)
+			records = append(records, logRecords...)
+
+			if err != nil {
+				errs = append(errs, err)
+			}
+
+			if status.RestartCount == 0 {
+				continue
+			}
+
+			logRecords, err = collectContainerLogs(podBuilder, status.Name, since, true)
+			records = append(records, logRecords...)
+
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return records, errors.Join(errs...)
+}
+
+func collectContainerLogs(
+	podBuilder *pod.Builder, container string, since time.Time, previous bool) ([]TimelineRecord, error) {
+	sinceTime := metav1.NewTime(since)
+
+	logs, err := podBuilder.GetLogsWithOptions(&corev1.PodLogOptions{
+		Container:  container,
+		Previous:   previous,
+		SinceTime:  &sinceTime,
+		Timestamps: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs for container %s of pod %s/%s (previous=%t): %w",
+			container, podBuilder.Object.Namespace, podBuilder.Object.Name, previous, err)
+	}
+
+	template := TimelineRecord{
+		Kind:      RecordKindLog,
+		Namespace: podBuilder.Object.Namespace,
+		Pod:       podBuilder.Object.Name,
+		Container: container,
+		Previous:  previous,
+	}
+
+	records, err := LogRecords(logs, template, since)
+	if err != nil {
+		return records, fmt.Errorf("failed to parse logs for container %s of pod %s/%s (previous=%t): %w",
+			container, podBuilder.Object.Namespace, podBuilder.Object.Name, previous, err)
+	}
+
+	return records, nil
+}
+
+// containerStatuses returns the statuses of both init and regular containers in the pod.
+func containerStatuses(podObject *corev1.Pod) []corev1.ContainerStatus {
+	var statuses []corev1.ContainerStatus
+
+	statuses = append(statuses, podObject.Status.InitContainerStatuses...)
+	statuses = append(statuses, podObject.Status.ContainerStatuses...)
+
+	return statuses
+}
+
+// EventRecordsSince converts the events last seen at or after since into TimelineRecords.
+func EventRecordsSince(eventObjects []*corev1.Event, since time.Time) []TimelineRecord {
+	var records []TimelineRecord
+
+	for _, event := range eventObjects {
+		eventTime := lastEventTime(event)
+		if eventTime.Before(since) {
+			continue
+		}
+
+		records = append(records, TimelineRecord{
+			Time:      eventTime,
+			Kind:      RecordKindEvent,
+			Namespace: event.Namespace,
+			Object:    fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
+			Reason:    event.Reason,
+			Type:      event.Type,
+			Count:     event.Count,
+			Message:   event.Message,
+		})
+	}
+
+	return records
+}
+
+// lastEventTime returns the most recent time an event was observed, falling back through the different time fields
+// since not all event sources populate all of them.
+func lastEventTime(event *corev1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// LogRecords parses logs retrieved with timestamps into TimelineRecords based on template. Lines without a valid
+// timestamp are assumed to continue the previous line and use its time. Lines before since are dropped. If the logs
+// cannot be fully read, such as when a line is longer than 4 MiB, the records parsed so far are returned with an error.
+func LogRecords(logs []byte, template TimelineRecord, since time.Time) ([]TimelineRecord, error) {
+	var (
+		records  []TimelineRecord
+		lastTime = since
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(logs))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLogLineSize)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		message := line
+
+		if timestamp, rest, found := strings.Cut(line, " "); found {
+			if lineTime, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+				lastTime = lineTime
+				message = rest
+			}
+		}
+
+		if lastTime.Before(since) {
+			continue
+		}
+
+		record := template
+		record.Time = lastTime
+		record.Message = message
+		records = append(records, record)
+	}
+
+	return records, scanner.Err()
+}
+
+// writeTimeline saves records to fileName as JSON lines.
+func writeTimeline(fileName string, records []TimelineRecord) error {
+	timelineFile, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+
+	defer timelineFile.Close()
+
+	writer := bufio.NewWriter(timelineFile)
+	encoder := json.NewEncoder(writer)
+
+	for _, record := range records {
+		err = encoder.Encode(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,139 @@
+package reporter
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var windowStart = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestLogRecords(t *testing.T) {
+	template := TimelineRecord{Kind: RecordKindLog, Namespace: "ns", Pod: "pod", Container: "ctr"}
+
+	testCases := []struct {
+		logs             string
+		expectedTimes    []time.Time
+		expectedMessages []string
+		expectedError    error
+	}{
+		{
+			logs: "2024-05-01T12:00:01.5Z first line\n" +
+				"2024-05-01T12:00:02Z second line\n",
+			expectedTimes:    []time.Time{windowStart.Add(1500 * time.Millisecond), windowStart.Add(2 * time.Second)},
+			expectedMessages: []string{"first line", "second line"},
+		},
+		{
+			// Lines without timestamps continue the previous line.
+			logs:             "2024-05-01T12:00:03Z panic: oops\ngoroutine 1 [running]:\n\n",
+			expectedTimes:    []time.Time{windowStart.Add(3 * time.Second), windowStart.Add(3 * time.Second)},
+			expectedMessages: []string{"panic: oops", "goroutine 1 [running]:"},
+		},
+		{
+			// Lines from before the window are dropped along with their continuations.
+			logs:             "2024-05-01T11:59:59Z too early\ncontinued\n2024-05-01T12:00:00Z on time\n",
+			expectedTimes:    []time.Time{windowStart},
+			expectedMessages: []string{"on time"},
+		},
+		{
+			logs: "",
+		},
+		{
+			// Lines longer than the default bufio.Scanner limit are still parsed.
+			logs:             "2024-05-01T12:00:04Z " + strings.Repeat("a", 2*bufio.MaxScanTokenSize) + "\n",
+			expectedTimes:    []time.Time{windowStart.Add(4 * time.Second)},
+			expectedMessages: []string{strings.Repeat("a", 2*bufio.MaxScanTokenSize)},
+		},
+		{
+			logs: "2024-05-01T12:00:05Z before\n2024-05-01T12:00:06Z " + strings.Repeat("a", maxLogLineSize) +
+				"\nafter\n",
+			expectedTimes:    []time.Time{windowStart.Add(5 * time.Second)},
+			expectedMessages: []string{"before"},
+			expectedError:    bufio.ErrTooLong,
+		},
+	}
+
+	for _, testCase := range testCases {
+		records, err := LogRecords([]byte(testCase.logs), template, windowStart)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, len(testCase.expectedMessages), len(records))
+
+		for index, record := range records {
+			assert.Equal(t, testCase.expectedTimes[index], record.Time)
+			assert.Equal(t, testCase.expectedMessages[index], record.Message)
+			assert.Equal(t, "ctr", record.Container)
+			assert.Equal(t, RecordKindLog, record.Kind)
+		}
+	}
+}
+
+func TestEventRecordsSince(t *testing.T) {
+	eventObjects := []*corev1.Event{
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "old", Namespace: "ns"},
+			LastTimestamp:  metav1.NewTime(windowStart.Add(-time.Minute)),
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "old"},
+		},
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "repeated", Namespace: "ns"},
+			FirstTimestamp: metav1.NewTime(windowStart.Add(-time.Hour)),
+			LastTimestamp:  metav1.NewTime(windowStart.Add(time.Minute)),
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "repeated"},
+			Reason:         "BackOff",
+			Type:           corev1.EventTypeWarning,
+			Count:          4,
+			Message:        "Back-off restarting failed container",
+		},
+		{
+			ObjectMeta:     metav1.ObjectMeta{Name: "new", Namespace: "ns"},
+			EventTime:      metav1.NewMicroTime(windowStart.Add(time.Second)),
+			InvolvedObject: corev1.ObjectReference{Kind: "Node", Name: "worker-0"},
+			Reason:         "NodeNotReady",
+		},
+	}
+
+	records := EventRecordsSince(eventObjects, windowStart)
+	assert.Equal(t, 2, len(records))
+	assert.Equal(t, TimelineRecord{
+		Time:      windowStart.Add(time.Minute),
+		Kind:      RecordKindEvent,
+		Namespace: "ns",
+		Object:    "Pod/repeated",
+		Reason:    "BackOff",
+		Type:      corev1.EventTypeWarning,
+		Count:     4,
+		Message:   "Back-off restarting failed container",
+	}, records[0])
+	assert.Equal(t, "Node/worker-0", records[1].Object)
+}
+
+func TestSortTimeline(t *testing.T) {
+	records := []TimelineRecord{
+		{Time: windowStart.Add(2 * time.Second), Message: "c"},
+		{Time: windowStart, Message: "a"},
+		{Time: windowStart.Add(time.Second), Message: "b1"},
+		{Time: windowStart.Add(time.Second), Message: "b2"},
+	}
+
+	SortTimeline(records)
+
+	var messages []string
+	for _, record := range records {
+		messages = append(messages, record.Message)
+	}
+
+	assert.Equal(t, []string{"a", "b1", "b2", "c"}, messages)
+}
+
+func TestSpecStartTime(t *testing.T) {
+	assert.Equal(t, windowStart, SpecStartTime(types.SpecReport{StartTime: windowStart}))
+
+	estimated := SpecStartTime(types.SpecReport{RunTime: time.Hour})
+	assert.WithinDuration(t, time.Now().Add(-time.Hour), estimated, time.Minute)
+}
@@ -2,16 +2,18 @@ package systemreporter
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	re "regexp"
-	"strings"
 
 	"github.com/golang/glog"
 	"github.com/onsi/ginkgo/v2/types"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/reporter"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -26,75 +28,98 @@ var (
 	leadAndTrailUnderscores = re.MustCompile(`^_|_$`)
 )
 
-// ReportIfFailedFromClient dumps the requested command output
-// from nodes pulled from specified apiClient if test case fails.
-func ReportIfFailedFromClient(
-	report types.SpecReport, testSuite string, commands []string, apiClient *clients.Settings) {
-	if types.SpecStateFailureStates.Is(report.State) {
-		if apiClient == nil {
-			glog.Errorf("cannot gather system report from nil apiClient")
+// NodeCommandCollector is a reporter.Collector that saves the output of commands executed on every node accessible
+// from an apiClient.
+type NodeCommandCollector struct {
+	apiClient *clients.Settings
+	commands  []string
+}
 
-			return
-		}
+// NewNodeCommandCollector returns a NodeCommandCollector running commands on nodes through apiClient.
+func NewNodeCommandCollector(apiClient *clients.Settings, commands ...string) *NodeCommandCollector {
+	return &NodeCommandCollector{apiClient: apiClient, commands: commands}
+}
 
-		dumpDir := GeneralConfig.GetDumpFailedTestReportLocation(testSuite)
+// Name returns the name of the collector.
+func (collector *NodeCommandCollector) Name() string {
+	return "system"
+}
 
-		tcReportFolderName := strings.ReplaceAll(report.FullText(), " ", "_")
+// Collect executes the commands on the nodes and saves their output into outputDir.
+func (collector *NodeCommandCollector) Collect(_ types.SpecReport, outputDir string) ([]reporter.Artifact, error) {
+	return gatherThroughKubeClient(collector.commands, outputDir, collector.apiClient)
+}
 
-		systemFolder := fmt.Sprintf("%s/%s/system", dumpDir, tcReportFolderName)
+// SSHNodeCommandCollector is a reporter.Collector that saves the output of commands executed on a list of nodes
+// through SSH.
+type SSHNodeCommandCollector struct {
+	sshKeyPath string
+	nodes      []string
+	commands   []string
+}
 
-		err := os.MkdirAll(systemFolder, 0755)
-		if err != nil {
-			glog.Errorf("failed creating dir for system info: %s", err)
+// NewSSHNodeCommandCollector returns an SSHNodeCommandCollector running commands on nodes using the private key at
+// sshKeyPath.
+func NewSSHNodeCommandCollector(sshKeyPath string, nodes []string, commands ...string) *SSHNodeCommandCollector {
+	return &SSHNodeCommandCollector{sshKeyPath: sshKeyPath, nodes: nodes, commands: commands}
+}
 
-			return
-		}
+// Name returns the name of the collector. It matches NodeCommandCollector so the output is saved to the same system
+// folder regardless of how the nodes are reached.
+func (collector *SSHNodeCommandCollector) Name() string {
+	return "system"
+}
 
-		GatherInfoThroughKubeClient(commands, systemFolder, apiClient)
-	}
+// Collect executes the commands on the nodes and saves their output into outputDir.
+func (collector *SSHNodeCommandCollector) Collect(_ types.SpecReport, outputDir string) ([]reporter.Artifact, error) {
+	return gatherThroughSSH(collector.commands, outputDir, collector.sshKeyPath, collector.nodes)
+}
+
+// ReportIfFailedFromClient dumps the requested command output
+// from nodes pulled from specified apiClient if test case fails.
+func ReportIfFailedFromClient(
+	report types.SpecReport, testSuite string, commands []string, apiClient *clients.Settings) {
+	reporter.NewRegistry(NewNodeCommandCollector(apiClient, commands...)).ReportIfFailed(report, testSuite)
 }
 
 // ReportIfFailedFromNodeList dumps the requested command output from specified nodes through SSH if test case fails.
 func ReportIfFailedFromNodeList(report types.SpecReport, testSuite string, commands []string, nodes []string) {
-	if types.SpecStateFailureStates.Is(report.State) {
-		dumpDir := GeneralConfig.GetDumpFailedTestReportLocation(testSuite)
-
-		tcReportFolderName := strings.ReplaceAll(report.FullText(), " ", "_")
-
-		systemFolder := fmt.Sprintf("%s/%s/system", dumpDir, tcReportFolderName)
-
-		err := os.MkdirAll(systemFolder, 0755)
-		if err != nil {
-			glog.Errorf("failed to create directory for storing system info %s", err)
-
-			return
-		}
-
-		GatherInfoThroughSSH(commands, systemFolder, GeneralConfig.SSHKeyPath, nodes)
-	}
+	reporter.NewRegistry(NewSSHNodeCommandCollector(GeneralConfig.SSHKeyPath, nodes, commands...)).
+		ReportIfFailed(report, testSuite)
 }
 
 // GatherInfoThroughSSH gathers command output from specified nodes
 // and writes output to specified directory.
 func GatherInfoThroughSSH(commands []string, outputdir string, sshKeyPath string, nodes []string) {
-	if sshKeyPath == "" {
-		glog.Errorf("cannot gather system information without providing ssh key path")
+	_, err := gatherThroughSSH(commands, outputdir, sshKeyPath, nodes)
+	if err != nil {
+		glog.Errorf("failed to gather system information through SSH: %s", err)
+	}
+}
+
+// GatherInfoThroughKubeClient gathers command output from nodes accessible from APIClient
+// and writes output to specified directory.
+func GatherInfoThroughKubeClient(commands []string, outputdir string, apiClient *clients.Settings) {
+	_, err := gatherThroughKubeClient(commands, outputdir, apiClient)
+	if err != nil {
+		glog.Errorf("failed to gather system information through kube client: %s", err)
+	}
+}
 
-		return
+func gatherThroughSSH(
+	commands []string, outputdir string, sshKeyPath string, nodes []string) ([]reporter.Artifact, error) {
+	if sshKeyPath == "" {
+		return nil, fmt.Errorf("cannot gather system information without providing ssh key path")
 	}
 
 	privateKey, err := os.ReadFile(sshKeyPath)
 	if err != nil {
-		glog.Errorf("failed to read private ssh key from system: %s", err)
-
-		return
+		return nil, fmt.Errorf("failed to read private ssh key from system: %w", err)
 	}
 
 	signer, err := ssh.ParsePrivateKey(privateKey)
 	if err != nil {
-		glog.Errorf("failed to parse private ssh key: %s", err)
-
-		return
+		return nil, fmt.Errorf("failed to parse private ssh key: %w", err)
 	}
 
 	config := ssh.ClientConfig{
@@ -105,70 +130,118 @@ func GatherInfoThroughSSH(commands []string, outputdir string, sshKeyPath string
 		},
 	}
 
+	var (
+		artifacts []reporter.Artifact
+		errs      []error
+	)
+
 	for _, node := range nodes {
-		client, err := ssh.Dial("tcp", fmt.Sprintf("%s:22", node), &config)
-		if err != nil {
-			glog.Errorf("failed to establish SSH connection to %s: %s", node, err)
+		nodeArtifacts, err := gatherFromSSHNode(&config, node, commands, outputdir)
+		artifacts = append(artifacts, nodeArtifacts...)
 
-			continue
+		if err != nil {
+			errs = append(errs, err)
 		}
+	}
 
-		defer client.Close()
+	return artifacts, errors.Join(errs...)
+}
 
-		for _, command := range commands {
-			var output bytes.Buffer
+func gatherFromSSHNode(
+	config *ssh.ClientConfig, node string, commands []string, outputdir string) ([]reporter.Artifact, error) {
+	client, err := ssh.Dial("tcp", fmt.Sprintf("%s:22", node), config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to establish SSH connection to %s: %w", node, err)
+	}
 
-			var stderr bytes.Buffer
+	defer client.Close()
 
-			session, err := client.NewSession()
-			if err != nil {
-				glog.Errorf("failed to create SSH session on %s: %s", node, err)
+	var (
+		artifacts []reporter.Artifact
+		errs      []error
+	)
 
-				break
-			}
+	for _, command := range commands {
+		var output bytes.Buffer
 
-			defer session.Close()
+		var stderr bytes.Buffer
 
-			session.Stdout = &output
-			session.Stderr = &stderr
+		session, err := client.NewSession()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to create SSH session on %s: %w", node, err))
 
-			err = session.Run(command)
-			if err == nil {
-				err = os.WriteFile(outputdir+"/"+node+"_"+fileNameFromCommand(command), output.Bytes(), 0650)
-				if err != nil {
-					glog.Errorf("error writing to file: %s", err)
-				}
-			} else {
-				glog.Errorf("error executing command '%s' on %s: %s", command, node, stderr.String())
-			}
+			break
+		}
+
+		session.Stdout = &output
+		session.Stderr = &stderr
+
+		err = session.Run(command)
+		_ = session.Close()
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error executing command '%s' on %s: %s", command, node, stderr.String()))
+
+			continue
 		}
+
+		artifact, err := writeCommandOutput(outputdir, node, command, output.Bytes())
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		artifacts = append(artifacts, artifact)
 	}
+
+	return artifacts, errors.Join(errs...)
 }
 
-// GatherInfoThroughKubeClient gathers command output from nodes accessible from APIClient
-// and writes output to specified directory.
-func GatherInfoThroughKubeClient(commands []string, outputdir string, apiClient *clients.Settings) {
+func gatherThroughKubeClient(
+	commands []string, outputdir string, apiClient *clients.Settings) ([]reporter.Artifact, error) {
 	if apiClient == nil {
-		glog.Errorf("cannot gather system information from nil APIClient")
-
-		return
+		return nil, fmt.Errorf("cannot gather system information from nil APIClient")
 	}
 
+	var (
+		artifacts []reporter.Artifact
+		errs      []error
+	)
+
 	for _, command := range commands {
 		output, err := cluster.ExecCmdWithStdout(apiClient, command)
 		if err != nil {
-			glog.Errorf("error occurred while executing command: %s", err)
+			errs = append(errs, fmt.Errorf("error occurred while executing command '%s': %w", command, err))
 
 			continue
 		}
 
 		for node, results := range output {
-			err = os.WriteFile(outputdir+"/"+node+"_"+fileNameFromCommand(command), []byte(results), 0650)
+			artifact, err := writeCommandOutput(outputdir, node, command, []byte(results))
 			if err != nil {
-				glog.Errorf("error writing to file: %s", err)
+				errs = append(errs, err)
+
+				continue
 			}
+
+			artifacts = append(artifacts, artifact)
 		}
 	}
+
+	return artifacts, errors.Join(errs...)
+}
+
+// writeCommandOutput saves the output of command on node to outputdir and returns the artifact describing it.
+func writeCommandOutput(outputdir, node, command string, output []byte) (reporter.Artifact, error) {
+	fileName := node + "_" + fileNameFromCommand(command)
+
+	err := os.WriteFile(filepath.Join(outputdir, fileName), output, 0650)
+	if err != nil {
+		return reporter.Artifact{}, fmt.Errorf("error writing to file: %w", err)
+	}
+
+	return reporter.Artifact{Path: fileName, Description: fmt.Sprintf("Output of '%s' on node %s", command, node)}, nil
 }
 
 func fileNameFromCommand(command string) string {
@@ -1,15 +1,14 @@
 package cnfconfig
 
 import (
-	"os"
 	"path/filepath"
 	"runtime"
 
 	"github.com/golang/glog"
 	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/cnf/internal/cnfparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/internal/ibuconfig"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -47,16 +46,9 @@ func NewCNFConfig() *CNFConfig {
 	baseDir := filepath.Dir(filename)
 	configFile := filepath.Join(baseDir, PathToDefaultIbuCnfParamsFile)
 
-	err := readFile(&cnfConfig, configFile)
+	err := config.Load(&cnfConfig, configFile)
 	if err != nil {
-		glog.V(cnfparams.CNFLogLevel).Infof("Error reading config file %s", configFile)
-
-		return nil
-	}
-
-	err = readEnv(&cnfConfig)
-	if err != nil {
-		glog.V(cnfparams.CNFLogLevel).Infof("Error reading environment variables")
+		glog.V(cnfparams.CNFLogLevel).Infof("Error loading config file %s: %v", configFile, err)
 
 		return nil
 	}
@@ -68,26 +60,3 @@ func NewCNFConfig() *CNFConfig {
 
 	return &cnfConfig
 }
-
-func readFile(cnfConfig *CNFConfig, configFile string) error {
-	openedConfigFile, err := os.Open(configFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedConfigFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedConfigFile)
-
-	err = decoder.Decode(&cnfConfig)
-
-	return err
-}
-
-func readEnv(cnfConfig *CNFConfig) error {
-	err := envconfig.Process("", cnfConfig)
-
-	return err
-}
@@ -2,13 +2,10 @@ package rhwaconfig
 
 import (
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -31,50 +28,12 @@ func NewRHWAConfig() *RHWAConfig {
 	_, filename, _, _ := runtime.Caller(0)
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultRhwaParamsFile)
-	err := readFile(&rhwaConf, confFile)
-
+	err := config.Load(&rhwaConf, confFile)
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
-
-		return nil
-	}
-
-	err = readEnv(&rhwaConf)
-
-	if err != nil {
-		log.Print("Error to read environment variables")
+		log.Printf("Error to load config file %s: %v", confFile, err)
 
 		return nil
 	}
 
 	return &rhwaConf
 }
-
-func readFile(rhwaConfig *RHWAConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-	err = decoder.Decode(&rhwaConfig)
-
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(rhwaConfig *RHWAConfig) error {
-	err := envconfig.Process("", rhwaConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
@@ -2,17 +2,15 @@ package config
 
 import (
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 	"time"
 
 	"github.com/golang/glog"
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
+	ecoconfig "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/diskencryption/tsparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/systemtestsconfig"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -42,18 +40,9 @@ func NewDiskEncryptionConfig() *DiskEncrptionConfig {
 	_, filename, _, _ := runtime.Caller(0)
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultDiskEncryptionParamsFile)
-	err := readFile(&diskEncryptionConf, confFile)
-
+	err := ecoconfig.Load(&diskEncryptionConf, confFile)
 	if err != nil {
-		log.Printf("Error reading config file %s", confFile)
-
-		return nil
-	}
-
-	err = readEnv(&diskEncryptionConf)
-
-	if err != nil {
-		log.Print("Error reading environment variables")
+		log.Printf("Error to load config file %s: %v", confFile, err)
 
 		return nil
 	}
@@ -74,32 +63,3 @@ func NewDiskEncryptionConfig() *DiskEncrptionConfig {
 
 	return &diskEncryptionConf
 }
-
-func readFile(diskEncrptionConfig *DiskEncrptionConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-	err = decoder.Decode(&diskEncrptionConfig)
-
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(diskEncrptionConfig *DiskEncrptionConfig) error {
-	err := envconfig.Process("", diskEncrptionConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
@@ -3,12 +3,12 @@ package ptp
 import (
 	"bytes"
 	"fmt"
-	"regexp"
 	"strings"
 	"time"
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/metrics"
 )
 
 const (
@@ -77,9 +77,6 @@ func isPtpClockSync(apiClient *clients.Settings) (bool, error) {
 		return false, fmt.Errorf("failed to get PTP pod list, %w", err)
 	}
 
-	ptpSyncPattern := `openshift_ptp_clock_state{iface="CLOCK_REALTIME",node=".*",process="phc2sys"} 1`
-	ptpRe := regexp.MustCompile(ptpSyncPattern)
-
 	for _, pod := range podList {
 		if strings.Contains(pod.Object.Name, ptpLinuxPod) {
 			const maxRetries = 6
@@ -111,7 +108,15 @@ func isPtpClockSync(apiClient *clients.Settings) (bool, error) {
 				return false, fmt.Errorf("failed to check PTP sync status, empty response after %d retries", maxRetries)
 			}
 
-			if !ptpRe.MatchString(cmd.String()) {
+			ptpMetrics, err := metrics.Parse(cmd.String())
+			if err != nil {
+				return false, fmt.Errorf("failed to parse PTP metrics, %w", err)
+			}
+
+			// Matching on labels rather than the whole line means the order of the labels does not matter.
+			clockState, err := ptpMetrics.Value("openshift_ptp_clock_state",
+				metrics.Labels{"iface": "CLOCK_REALTIME", "process": "phc2sys"})
+			if err != nil || clockState != 1 {
 				return false, fmt.Errorf("PTP not in sync, %s", cmd.String())
 			}
 
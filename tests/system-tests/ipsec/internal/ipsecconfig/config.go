@@ -2,13 +2,11 @@ package ipsecconfig
 
 import (
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 
-	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/systemtestsconfig"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -49,50 +47,12 @@ func NewIpsecConfig() *IpsecConfig {
 	_, filename, _, _ := runtime.Caller(0)
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultIpsecParamsFile)
-	err := readFile(&ipsecConf, confFile)
-
+	err := config.Load(&ipsecConf, confFile)
 	if err != nil {
-		log.Printf("Error reading config file %s", confFile)
-
-		return nil
-	}
-
-	err = readEnv(&ipsecConf)
-
-	if err != nil {
-		log.Print("Error reading environment variables")
+		log.Printf("Error to load config file %s: %v", confFile, err)
 
 		return nil
 	}
 
 	return &ipsecConf
 }
-
-func readFile(ipsecConfig *IpsecConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-	err = decoder.Decode(&ipsecConfig)
-
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(ipsecConfig *IpsecConfig) error {
-	err := envconfig.Process("", ipsecConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
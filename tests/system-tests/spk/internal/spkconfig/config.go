@@ -8,9 +8,8 @@ import (
 	"runtime"
 	"strings"
 
-	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/systemtestsconfig"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -21,7 +20,7 @@ const (
 // SPKConfig type keeps SPK configuration.
 type SPKConfig struct {
 	*systemtestsconfig.SystemTestsConfig
-	Namespace         string `yaml:"spk_workload_ns" envconfig:"ECO_SYSTEM_SPK_WORKLOAD_NS"`
+	Namespace         string `yaml:"spk_workload_ns" envconfig:"ECO_SYSTEM_SPK_WORKLOAD_NS" validate:"required"`
 	IngressTCPIPv4URL string `yaml:"spk_ingress_tcp_ipv4_url" envconfig:"ECO_SYSTEM_SPK_INGRESS_TCP_IPV4_URL"`
 	IngressUDPIPv4URL string `yaml:"spk_ingress_udp_ipv4_url" envconfig:"ECO_SYSTEM_SPK_INGRESS_UDP_IPV4_URL"`
 	IngressTCPIPv6URL string `yaml:"spk_ingress_tcp_ipv6_url" envconfig:"ECO_SYSTEM_SPK_INGRESS_TCP_IPV6_URL"`
@@ -105,50 +104,12 @@ func NewSPKConfig() *SPKConfig {
 
 	log.Printf("Open config file %s", confFile)
 
-	err := readFile(&spkConf, confFile)
-
-	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
-
-		return nil
-	}
-
-	err = readEnv(&spkConf)
-
+	err := config.Load(&spkConf, confFile)
 	if err != nil {
-		log.Print("Error to read environment variables")
+		log.Printf("Error to load config file %s: %v", confFile, err)
 
 		return nil
 	}
 
 	return &spkConf
 }
-
-func readFile(spkConfig *SPKConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-	err = decoder.Decode(&spkConfig)
-
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(spkConfig *SPKConfig) error {
-	err := envconfig.Process("", spkConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
@@ -3,15 +3,13 @@ package vcoreconfig
 import (
 	"fmt"
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/systemtestsconfig"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -22,7 +20,7 @@ const (
 // VCoreConfig type keeps vCore configuration.
 type VCoreConfig struct {
 	*systemtestsconfig.SystemTestsConfig
-	Namespace                   string `yaml:"vcore_default_ns" envconfig:"ECO_SYSTEM_VCORE_NS"`
+	Namespace                   string `yaml:"vcore_default_ns" envconfig:"ECO_SYSTEM_VCORE_NS" validate:"required"`
 	OdfMCPName                  string `yaml:"odf_mcp" envconfig:"ECO_SYSTEM_VCORE_ODF_MCP"`
 	VCorePpMCPName              string `yaml:"vcore_pp_mcp" envconfig:"ECO_SYSTEM_VCORE_PP_MCP"`
 	VCoreCpMCPName              string `yaml:"vcore_cp_mcp" envconfig:"ECO_SYSTEM_VCORE_CP_MCP"`
@@ -36,7 +34,7 @@ type VCoreConfig struct {
 	RegistryRepository          string `yaml:"registry_repository" envconfig:"ECO_SYSTEM_VCORE_REGISTRY_REPOSITORY"`
 	CPUIsolated                 string `yaml:"cpu_isolated" envconfig:"ECO_SYSTEM_VCORE_CPU_ISOLATED"`
 	CPUReserved                 string `yaml:"cpu_reserved" envconfig:"ECO_SYSTEM_VCORE_CPU_RESERVED"`
-	KubeconfigPath              string `yaml:"kubeconfig_path" envconfig:"ECO_SYSTEM_VCORE_KUBECONFIG"`
+	KubeconfigPath              string `yaml:"kubeconfig_path" envconfig:"ECO_SYSTEM_VCORE_KUBECONFIG" validate:"required"`
 	OdfLabel                    string
 	VCorePpLabel                string
 	VCoreCpLabel                string
@@ -60,51 +58,20 @@ func NewVCoreConfig() *VCoreConfig {
 	_, filename, _, _ := runtime.Caller(0)
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultVCoreParamsFile)
-	err := readFile(&vcoreConf, confFile)
-
+	err := config.Load(&vcoreConf, confFile)
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
+		log.Printf("Error to load config file %s: %v", confFile, err)
 
 		return nil
 	}
 
-	err = readEnv(&vcoreConf)
-
-	if err != nil {
-		log.Print("Error to read environment variables")
-
-		return nil
-	}
+	setLabels(&vcoreConf)
 
 	return &vcoreConf
 }
 
-func readFile(vcoreConfig *VCoreConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-	err = decoder.Decode(&vcoreConfig)
-
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(vcoreConfig *VCoreConfig) error {
-	err := envconfig.Process("", vcoreConfig)
-	if err != nil {
-		return err
-	}
-
+// setLabels builds the label selectors from the configured labels.
+func setLabels(vcoreConfig *VCoreConfig) {
 	vcoreConfig.OdfLabel = fmt.Sprintf("%s/%s", vcoreConfig.KubernetesRolePrefix, vcoreConfig.OdfMCPName)
 	vcoreConfig.VCorePpLabel = fmt.Sprintf("%s/%s", vcoreConfig.KubernetesRolePrefix, vcoreConfig.VCorePpMCPName)
 	vcoreConfig.VCoreCpLabel = fmt.Sprintf("%s/%s", vcoreConfig.KubernetesRolePrefix, vcoreConfig.VCoreCpMCPName)
@@ -116,6 +83,4 @@ func readEnv(vcoreConfig *VCoreConfig) error {
 	vcoreConfig.OdfLabelMap = map[string]string{vcoreConfig.OdfLabel: ""}
 	vcoreConfig.VCorePpLabelMap = map[string]string{vcoreConfig.VCorePpLabel: ""}
 	vcoreConfig.VCoreCpLabelMap = map[string]string{vcoreConfig.VCoreCpLabel: ""}
-
-	return nil
 }